@@ -48,6 +48,7 @@ func Load() *Config {
 			"User-Agent",
 			"x-access-token",
 			"x-id-token",
+			"x-locale",
 			"Access-Control-Allow-Credentials",
 			"Access-Control-Allow-Origin",
 			"Authorization",
@@ -5,7 +5,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"runtime"
+	"slices"
+	"strings"
 
 	"github.com/go-sql-driver/mysql"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
@@ -15,8 +18,7 @@ import (
 )
 
 const (
-	domain   = "owner"
-	localeJa = "ja-JP"
+	domain = "owner"
 )
 
 type ErrorType string
@@ -31,6 +33,8 @@ var ErrorTypes = struct {
 	PreconditionFailedError ErrorType
 	UnknownError            ErrorType
 	CanceledError           ErrorType
+	DeadlineExceededError   ErrorType
+	ResourceExhaustedError  ErrorType
 }{
 	AlreadyExistedError:     "ALREADY_EXISTED_ERROR",
 	AuthNError:              "AUTH_N_ERROR",
@@ -41,6 +45,24 @@ var ErrorTypes = struct {
 	PreconditionFailedError: "PRECONDITIONAL_FAILED_ERROR",
 	UnknownError:            "UNKNOWN_ERROR",
 	CanceledError:           "CANCELED_ERROR",
+	DeadlineExceededError:   "DEADLINE_EXCEEDED_ERROR",
+	ResourceExhaustedError:  "RESOURCE_EXHAUSTED_ERROR",
+}
+
+// errorTypes are the values of ErrorTypes, which GRPCErrToAppError accepts
+// from the ErrorInfo of a status.
+var errorTypes = []ErrorType{
+	ErrorTypes.AlreadyExistedError,
+	ErrorTypes.AuthNError,
+	ErrorTypes.AuthZError,
+	ErrorTypes.InternalError,
+	ErrorTypes.NotFoundError,
+	ErrorTypes.ParameterError,
+	ErrorTypes.PreconditionFailedError,
+	ErrorTypes.UnknownError,
+	ErrorTypes.CanceledError,
+	ErrorTypes.DeadlineExceededError,
+	ErrorTypes.ResourceExhaustedError,
 }
 
 type Metadata struct {
@@ -75,10 +97,6 @@ func WithExecutedPathMetadata() Metadata {
 	return Metadata{key: "Path", value: fmt.Sprintf("%s:%d", filename, line)}
 }
 
-type LocalizedMessage struct {
-	JaMessage string
-}
-
 type ErrorElement struct {
 	Type     ErrorType
 	Msg      string
@@ -108,23 +126,42 @@ func (e ErrorElement) toError() string {
 }
 
 type AppError struct {
-	Elem    ErrorElement
-	JaError string
+	Elem ErrorElement
+	// Messages is the message shown to users, by locale.
+	Messages LocalizedMessage
+	// PrimaryLocale is the locale of the message a server picked for the
+	// request the error was received in, tried after the locales asked for.
+	PrimaryLocale string
 }
 
+// NewAppError creates an error with the default messages of errtype when
+// localizedMessage is nil.
 func NewAppError(
 	errtype ErrorType,
 	msg string,
 	err error,
-	localizedMessage *LocalizedMessage,
+	localizedMessage LocalizedMessage,
 	mds ...Metadata,
 ) AppError {
-	jaErrorMessage := ""
-	if localizedMessage != nil {
-		jaErrorMessage = localizedMessage.JaMessage
+	if localizedMessage == nil {
+		localizedMessage = defaultMessages[errtype]
 	}
 	elem := NewErrorElement(errtype, msg, err, mds...)
-	return AppError{Elem: elem, JaError: jaErrorMessage}
+	return AppError{Elem: elem, Messages: localizedMessage}
+}
+
+// LocalizedMessage returns the message of the first of locales the error has,
+// see LocalizedMessage.Lookup, with its parameters replaced by the metadata
+// of the error.
+func (e AppError) LocalizedMessage(locales ...string) (locale string, message string, ok bool) {
+	if e.PrimaryLocale != "" {
+		locales = append(append([]string{}, locales...), e.PrimaryLocale)
+	}
+	locale, template, ok := e.Messages.Lookup(locales...)
+	if !ok {
+		return "", "", false
+	}
+	return locale, formatMessage(template, e.Elem.Metadata), true
 }
 
 func (e AppError) Error() string {
@@ -138,79 +175,64 @@ func (e AppError) Unwrap() error {
 func NewAlreadyExistsError(
 	msg string,
 	err error,
-	localizedMessage *LocalizedMessage,
+	localizedMessage LocalizedMessage,
 	mds ...Metadata,
 ) AppError {
-	LMessage := &LocalizedMessage{JaMessage: AlreadyExistsJaMessage}
-	if localizedMessage != nil {
-		LMessage = localizedMessage
-	}
-	return NewAppError(ErrorTypes.AlreadyExistedError, msg, err, LMessage, mds...)
+	return NewAppError(ErrorTypes.AlreadyExistedError, msg, err, localizedMessage, mds...)
 }
 
 func NewAuthNError(
 	msg string,
 	err error,
-	localizedMessage *LocalizedMessage,
+	localizedMessage LocalizedMessage,
 	mds ...Metadata,
 ) AppError {
-	LMessage := &LocalizedMessage{JaMessage: AuthNJaMessage}
-	if localizedMessage != nil {
-		LMessage = localizedMessage
-	}
-	return NewAppError(ErrorTypes.AuthNError, msg, err, LMessage, mds...)
+	return NewAppError(ErrorTypes.AuthNError, msg, err, localizedMessage, mds...)
 }
 
 func NewAuthZError(
 	msg string,
 	err error,
-	localizedMessage *LocalizedMessage,
+	localizedMessage LocalizedMessage,
 	mds ...Metadata,
 ) AppError {
-	LMessage := &LocalizedMessage{JaMessage: AuthZJaMessage}
-	if localizedMessage != nil {
-		LMessage = localizedMessage
-	}
-	return NewAppError(ErrorTypes.AuthZError, msg, err, LMessage, mds...)
+	return NewAppError(ErrorTypes.AuthZError, msg, err, localizedMessage, mds...)
 }
 
 func NewNotFoundError(
 	msg string,
 	err error,
-	localizedMessage *LocalizedMessage,
+	localizedMessage LocalizedMessage,
 	mds ...Metadata,
 ) AppError {
-	LMessage := &LocalizedMessage{JaMessage: NotFoundJaMessage}
-	if localizedMessage != nil {
-		LMessage = localizedMessage
-	}
-	return NewAppError(ErrorTypes.NotFoundError, msg, err, LMessage, mds...)
+	return NewAppError(ErrorTypes.NotFoundError, msg, err, localizedMessage, mds...)
 }
 
 func NewParameterError(
 	msg string,
 	err error,
-	localizedMessage *LocalizedMessage,
+	localizedMessage LocalizedMessage,
 	mds ...Metadata,
 ) AppError {
-	LMessage := &LocalizedMessage{JaMessage: PreconditionFailedJaMessage}
-	if localizedMessage != nil {
-		LMessage = localizedMessage
-	}
-	return NewAppError(ErrorTypes.ParameterError, msg, err, LMessage, mds...)
+	return NewAppError(ErrorTypes.ParameterError, msg, err, localizedMessage, mds...)
 }
 
 func NewPreconditionFailedError(
 	msg string,
 	err error,
-	localizedMessage *LocalizedMessage,
+	localizedMessage LocalizedMessage,
 	mds ...Metadata,
 ) AppError {
-	LMessage := &LocalizedMessage{JaMessage: InvalidRequestJaMessage}
-	if localizedMessage != nil {
-		LMessage = localizedMessage
-	}
-	return NewAppError(ErrorTypes.PreconditionFailedError, msg, err, LMessage, mds...)
+	return NewAppError(ErrorTypes.PreconditionFailedError, msg, err, localizedMessage, mds...)
+}
+
+func NewResourceExhaustedError(
+	msg string,
+	err error,
+	localizedMessage LocalizedMessage,
+	mds ...Metadata,
+) AppError {
+	return NewAppError(ErrorTypes.ResourceExhaustedError, msg, err, localizedMessage, mds...)
 }
 
 func NewInternalError(
@@ -223,6 +245,9 @@ func NewInternalError(
 	if errors.Is(err, context.Canceled) {
 		errType = ErrorTypes.CanceledError
 	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		errType = ErrorTypes.DeadlineExceededError
+	}
 
 	return NewAppError(errType, msg, err, nil, mds...)
 }
@@ -243,6 +268,14 @@ func NewCanceledError(
 	return NewAppError(ErrorTypes.CanceledError, msg, err, nil, mds...)
 }
 
+func NewDeadlineExceededError(
+	msg string,
+	err error,
+	mds ...Metadata,
+) AppError {
+	return NewAppError(ErrorTypes.DeadlineExceededError, msg, err, nil, mds...)
+}
+
 func ToGRPCCode(err error) codes.Code {
 	var appError AppError
 	if errors.As(err, &appError) {
@@ -263,6 +296,10 @@ func ToGRPCCode(err error) codes.Code {
 			return codes.Internal
 		case ErrorTypes.CanceledError:
 			return codes.Canceled
+		case ErrorTypes.DeadlineExceededError:
+			return codes.DeadlineExceeded
+		case ErrorTypes.ResourceExhaustedError:
+			return codes.ResourceExhausted
 		}
 	}
 
@@ -273,6 +310,10 @@ func IsCanceledError(err error) bool {
 	return ToGRPCCode(err) == codes.Canceled
 }
 
+func IsDeadlineExceededError(err error) bool {
+	return ToGRPCCode(err) == codes.DeadlineExceeded
+}
+
 func IsNotFoundErr(err error) bool {
 	if err == nil {
 		return false
@@ -289,7 +330,18 @@ func IsNotFoundErr(err error) bool {
 // GRPCStatus implement the interface { GRPCStatus() *Status } of package grpc/status,
 // so that gRPC server can use struct AppError directly when response error
 // ref: https://github.com/grpc/grpc-go/blob/v1.65.0/status/status.go#L88-L91
+//
+// Its localized message is in the default locale, UnaryServerInterceptor
+// picks the locale of the request instead.
 func (e AppError) GRPCStatus() *status.Status {
+	return e.LocalizedGRPCStatus()
+}
+
+// LocalizedGRPCStatus is GRPCStatus with the localized message in the first
+// of locales the error has. The messages in the other locales follow it, so
+// that a client can rebuild every message of the error; clients showing a
+// single one show the first.
+func (e AppError) LocalizedGRPCStatus(locales ...string) *status.Status {
 	stt := status.New(ToGRPCCode(e), e.Elem.Msg)
 	errDetails := []protoiface.MessageV1{
 		&errdetails.ErrorInfo{
@@ -298,11 +350,20 @@ func (e AppError) GRPCStatus() *status.Status {
 			Metadata: e.Elem.Metadata,
 		},
 	}
-	if e.JaError != "" {
+	if primary, message, ok := e.LocalizedMessage(locales...); ok {
 		errDetails = append(errDetails, &errdetails.LocalizedMessage{
-			Locale:  localeJa,
-			Message: e.JaError,
+			Locale:  primary,
+			Message: message,
 		})
+		for _, locale := range slices.Sorted(maps.Keys(e.Messages)) {
+			if locale == primary {
+				continue
+			}
+			errDetails = append(errDetails, &errdetails.LocalizedMessage{
+				Locale:  locale,
+				Message: formatMessage(e.Messages[locale], e.Elem.Metadata),
+			})
+		}
 	}
 
 	stt, err := stt.WithDetails(errDetails...)
@@ -313,30 +374,106 @@ func (e AppError) GRPCStatus() *status.Status {
 	return stt
 }
 
-// nolint:exhaustive
+// GRPCErrToAppError rebuilds the AppError of a gRPC error from the details
+// GRPCStatus attaches: its type, metadata and localized messages, the first
+// of which is the primary one. A status
+// without them gets the type of its code. The cause of the AppError is err,
+// joined with context.Canceled or context.DeadlineExceeded when the call was
+// canceled or timed out.
 func GRPCErrToAppError(err error) AppError {
-	grpcError, ok := status.FromError(err)
+	var appErr AppError
+	if errors.As(err, &appErr) {
+		return appErr
+	}
 
-	if !ok {
+	// The status of a wrapped error, unlike the one of status.FromError, has
+	// the message of the server.
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &grpcErr) {
+		switch {
+		case errors.Is(err, context.Canceled):
+			return NewCanceledError("canceled", err)
+		case errors.Is(err, context.DeadlineExceeded):
+			return NewDeadlineExceededError("deadline exceeded", err)
+		}
 		return NewUnknownError("unknown error", err)
 	}
+	grpcError := grpcErr.GRPCStatus()
+
+	errType := errorTypeOfCode(grpcError.Code())
+	var md map[string]string
+	var messages LocalizedMessage
+	var primaryLocale string
+	for _, detail := range grpcError.Details() {
+		switch detail := detail.(type) {
+		case *errdetails.ErrorInfo:
+			if detail.GetDomain() != domain {
+				continue
+			}
+			// The reason is AppError.Error(), which starts with the type.
+			reasonType, _, _ := strings.Cut(detail.GetReason(), ":")
+			if slices.Contains(errorTypes, ErrorType(reasonType)) {
+				errType = ErrorType(reasonType)
+			}
+			md = maps.Clone(detail.GetMetadata())
+			// The error had no localized message if the status has none.
+			if messages == nil {
+				messages = LocalizedMessage{}
+			}
+		case *errdetails.LocalizedMessage:
+			if messages == nil {
+				messages = LocalizedMessage{}
+			}
+			if primaryLocale == "" {
+				primaryLocale = detail.GetLocale()
+			}
+			messages[detail.GetLocale()] = detail.GetMessage()
+		}
+	}
+
+	cause := err
 	switch grpcError.Code() {
+	case codes.Canceled:
+		cause = fmt.Errorf("%w: %w", context.Canceled, err)
+	case codes.DeadlineExceeded:
+		cause = fmt.Errorf("%w: %w", context.DeadlineExceeded, err)
+	}
+
+	appErr = NewAppError(errType, grpcError.Message(), cause, messages)
+	appErr.PrimaryLocale = primaryLocale
+	if md != nil {
+		appErr.Elem.Metadata = md
+	}
+	return appErr
+}
+
+// errorTypeOfCode is the ErrorType of a gRPC code, the reverse of ToGRPCCode.
+//
+// nolint:exhaustive
+func errorTypeOfCode(code codes.Code) ErrorType {
+	switch code {
 	case codes.Unauthenticated:
-		return NewAuthNError(grpcError.Message(), err, nil)
+		return ErrorTypes.AuthNError
 	case codes.PermissionDenied:
-		return NewAuthZError(grpcError.Message(), err, nil)
+		return ErrorTypes.AuthZError
 	case codes.FailedPrecondition:
-		return NewPreconditionFailedError(grpcError.Message(), err, nil)
+		return ErrorTypes.PreconditionFailedError
 	case codes.InvalidArgument:
-		return NewParameterError(grpcError.Message(), err, nil)
+		return ErrorTypes.ParameterError
 	case codes.NotFound:
-		return NewNotFoundError(grpcError.Message(), err, nil)
+		return ErrorTypes.NotFoundError
 	case codes.AlreadyExists:
-		return NewAlreadyExistsError(grpcError.Message(), err, nil)
+		return ErrorTypes.AlreadyExistedError
+	case codes.ResourceExhausted:
+		return ErrorTypes.ResourceExhaustedError
 	case codes.Internal:
-		return NewInternalError(grpcError.Message(), err)
+		return ErrorTypes.InternalError
+	case codes.Canceled:
+		return ErrorTypes.CanceledError
+	case codes.DeadlineExceeded:
+		return ErrorTypes.DeadlineExceededError
 	default:
-		return NewUnknownError(grpcError.Message(), err)
+		return ErrorTypes.UnknownError
 	}
 }
 
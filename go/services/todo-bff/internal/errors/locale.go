@@ -0,0 +1,263 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	LocaleEn = "en-US"
+	LocaleJa = "ja-JP"
+	LocaleVi = "vi-VN"
+
+	// DefaultLocale is the locale of the messages when the request asks for
+	// none of the locales of a message.
+	DefaultLocale = LocaleJa
+
+	// LocaleMetadataKey is the gRPC metadata a client sets to choose the
+	// locale, taking precedence over Accept-Language.
+	LocaleMetadataKey = "x-locale"
+)
+
+// acceptLanguageMetadataKeys are the metadata the Accept-Language header
+// reaches the service in, sent by a gRPC client or forwarded by grpc-gateway.
+var acceptLanguageMetadataKeys = []string{"accept-language", "grpcgateway-accept-language"}
+
+// fallbackLocales are tried after the locales of the request.
+var fallbackLocales = []string{DefaultLocale, LocaleEn}
+
+// messageParameterPattern matches the {key} parameters of a message template.
+var messageParameterPattern = regexp.MustCompile(`\{(\w+)\}`)
+
+// LocalizedMessage is a message shown to users, by locale. A message may be a
+// template whose {key} parameters are the metadata of the error.
+type LocalizedMessage map[string]string
+
+// Lookup returns the message of the first of locales it has, then of the
+// fallback locales, then its first message by locale. For every locale, a
+// message of the same locale comes first, then one of the same language: "vi"
+// and "vi-VN" both match "vi-VN".
+func (m LocalizedMessage) Lookup(locales ...string) (locale string, message string, ok bool) {
+	if len(m) == 0 {
+		return "", "", false
+	}
+
+	// The locales of m are sorted for the lookup to be the same every time.
+	haves := make([]string, 0, len(m))
+	for have := range m {
+		haves = append(haves, have)
+	}
+	sort.Strings(haves)
+
+	for _, want := range append(append([]string{}, locales...), fallbackLocales...) {
+		for _, have := range haves {
+			if strings.EqualFold(have, want) {
+				return have, m[have], true
+			}
+		}
+		for _, have := range haves {
+			if strings.EqualFold(language(have), language(want)) {
+				return have, m[have], true
+			}
+		}
+	}
+
+	return haves[0], m[haves[0]], true
+}
+
+func language(locale string) string {
+	lang, _, _ := strings.Cut(locale, "-")
+	return lang
+}
+
+// formatMessage replaces the {key} parameters of template with the metadata
+// of the same key, leaving the others as they are.
+func formatMessage(template string, md map[string]string) string {
+	return messageParameterPattern.ReplaceAllStringFunc(template, func(parameter string) string {
+		if value, ok := md[parameter[1:len(parameter)-1]]; ok {
+			return value
+		}
+		return parameter
+	})
+}
+
+// ParseAcceptLanguage returns the locales of an Accept-Language header, most
+// preferred first. Locales with a zero quality and the wildcard are left out.
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		locale  string
+		quality float64
+	}
+
+	var parsed []weighted
+	for _, part := range strings.Split(header, ",") {
+		locale, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		locale = strings.TrimSpace(locale)
+		if locale == "" || locale == "*" {
+			continue
+		}
+
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			v, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = v
+		}
+		if quality <= 0 {
+			continue
+		}
+		parsed = append(parsed, weighted{locale: locale, quality: quality})
+	}
+
+	sort.SliceStable(parsed, func(i, j int) bool {
+		return parsed[i].quality > parsed[j].quality
+	})
+
+	locales := make([]string, 0, len(parsed))
+	for _, w := range parsed {
+		locales = append(locales, w.locale)
+	}
+	return locales
+}
+
+// LocalesFromIncomingContext returns the locales the incoming gRPC request
+// asks for, most preferred first: the one of LocaleMetadataKey, then the ones
+// of Accept-Language.
+func LocalesFromIncomingContext(ctx context.Context) []string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	var locales []string
+	for _, locale := range md.Get(LocaleMetadataKey) {
+		if locale = strings.TrimSpace(locale); locale != "" {
+			locales = append(locales, locale)
+		}
+	}
+	for _, key := range acceptLanguageMetadataKeys {
+		for _, header := range md.Get(key) {
+			locales = append(locales, ParseAcceptLanguage(header)...)
+		}
+	}
+	return locales
+}
+
+// UnaryServerInterceptor returns the AppError of the handlers with their
+// message in the locale of the request, see LocalesFromIncomingContext.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		return resp, localizeError(ctx, err)
+	}
+}
+
+// StreamServerInterceptor is the UnaryServerInterceptor of streams.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return localizeError(ss.Context(), handler(srv, ss))
+	}
+}
+
+func localizeError(ctx context.Context, err error) error {
+	var appErr AppError
+	if !errors.As(err, &appErr) {
+		return err
+	}
+	return appErr.LocalizedGRPCStatus(LocalesFromIncomingContext(ctx)...).Err()
+}
+
+// requestLocale is the locale a request to the BFF asks for, forwarded to the
+// services it calls.
+type requestLocale struct {
+	locale         string
+	acceptLanguage string
+}
+
+type requestLocaleKey struct{}
+
+// LocaleMiddleware keeps the X-Locale and Accept-Language headers of the
+// request in its context, for UnaryClientInterceptor and
+// StreamClientInterceptor to forward them.
+func LocaleMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		locale := requestLocale{
+			locale:         strings.TrimSpace(r.Header.Get(LocaleMetadataKey)),
+			acceptLanguage: r.Header.Get("Accept-Language"),
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestLocaleKey{}, locale)))
+	})
+}
+
+// LocalesFromContext returns the locales the request kept by LocaleMiddleware
+// asks for, most preferred first, as LocalesFromIncomingContext does.
+func LocalesFromContext(ctx context.Context) []string {
+	locale, _ := ctx.Value(requestLocaleKey{}).(requestLocale)
+
+	var locales []string
+	if locale.locale != "" {
+		locales = append(locales, locale.locale)
+	}
+	return append(locales, ParseAcceptLanguage(locale.acceptLanguage)...)
+}
+
+// withOutgoingLocale adds the locale of the request kept by LocaleMiddleware
+// to the metadata of a call, so that the service localizes its errors the
+// same way.
+func withOutgoingLocale(ctx context.Context) context.Context {
+	locale, ok := ctx.Value(requestLocaleKey{}).(requestLocale)
+	if !ok {
+		return ctx
+	}
+
+	var kv []string
+	if locale.locale != "" {
+		kv = append(kv, LocaleMetadataKey, locale.locale)
+	}
+	if locale.acceptLanguage != "" {
+		kv = append(kv, "accept-language", locale.acceptLanguage)
+	}
+	if len(kv) == 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, kv...)
+}
+
+// UnaryClientInterceptor forwards the locale of the request to the services
+// the BFF calls, see LocaleMiddleware.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		return invoker(withOutgoingLocale(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor is the UnaryClientInterceptor of streams.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		return streamer(withOutgoingLocale(ctx), desc, cc, method, opts...)
+	}
+}
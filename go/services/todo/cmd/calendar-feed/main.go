@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/config"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/cache"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/calendarfeed"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/datastore"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/interchange"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/usecase"
+)
+
+func main() {
+	dbCfg, err := config.LoadDBConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	feedCfg, err := config.LoadCalendarFeedConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	cacheCfg, err := config.LoadCacheConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	todoConn, closeDB, err := datastore.NewTodoSQLHandler(dbCfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer closeDB()
+
+	cacheBackend, closeCache, err := cache.NewSharedBackend(cacheCfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer closeCache()
+
+	todoReader, todoWriter := datastore.NewTodoReader(), datastore.NewTodoWriter()
+	if cacheBackend != nil {
+		todoReader = cache.NewTodoReader(todoReader, cacheBackend, cacheCfg.CacheTTL)
+		todoWriter = cache.NewTodoWriter(todoWriter, cacheBackend)
+	}
+
+	interchangeUsecase := usecase.NewTodoInterchangeUsecase(
+		datastore.NewConnectionBinder(todoConn),
+		todoReader,
+		todoWriter,
+		interchange.NewCodecs(),
+	)
+	signer := calendarfeed.NewTokenSigner(feedCfg.CalendarFeedSecret, feedCfg.CalendarFeedBaseURL)
+
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%d", feedCfg.CalendarFeedPort),
+		Handler:           calendarfeed.NewHandler(interchangeUsecase, signer),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("calendar feed listening on %s", server.Addr)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal(err)
+	}
+}
@@ -1,23 +1,66 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"errors"
+	"flag"
 	"fmt"
+	"io/fs"
 	"log"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strconv"
-
-	"github.com/phamquanandpad/training-project/services/todo/internal/config"
+	"text/tabwriter"
 
 	"github.com/golang-migrate/migrate/v4"
 	mysqlDriver "github.com/golang-migrate/migrate/v4/database/mysql"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
 
 	_ "github.com/go-sql-driver/mysql"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/database/migrations"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/config"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/migrationlint"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/schemadrift"
 )
 
+const usage = `usage:
+  migrate up      [-dry-run]
+  migrate down    [-dry-run]
+  migrate steps   [-dry-run] N
+  migrate goto    [-dry-run] VERSION
+  migrate force   VERSION
+  migrate version
+  migrate status
+  migrate create  [-dir DIR] NAME
+  migrate drift   [-sqlite] [FILE...]
+  migrate lint    [-dir DIR] [-baseline VERSION]
+
+-dry-run prints the SQL that would run instead of running it. The
+migrations are built into the command; create writes new ones to DIR,
+database/migrations by default. drift applies the migrations and each schema
+file to scratch databases and fails when their schemas differ; FILE defaults
+to the test and docker schema files and the SQLite one, or to the SQLite one
+with -sqlite. SQLite files are compared without types and checks. lint checks the migrations, or the ones
+of DIR, for destructive or locking statements and needs no database; the
+findings of the migrations up to VERSION, applied before the linter, are
+reported without failing.`
+
+var migrationNamePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// schemaFiles are the schema files kept apart from the migrations.
+var schemaFiles = []string{
+	filepath.Join("database", "test", "sqls", "import", "create_tables.sql"),
+	filepath.Join("database", "docker", "sqls", "import", "create_tables.sql"),
+}
+
+// sqliteSchemaFiles are the SQLite translations of the migrations.
+var sqliteSchemaFiles = []string{
+	filepath.Join("database", "sqlite", "create_tables.sql"),
+}
+
 func buildDSN(c config.DBConfig) string {
 	return fmt.Sprintf(
 		"%s:%s@tcp(%s:%s)/%s?multiStatements=true&parseTime=true",
@@ -26,69 +69,417 @@ func buildDSN(c config.DBConfig) string {
 }
 
 func main() {
-	cfg, err := config.LoadDBConfig()
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	dsn := buildDSN(*cfg)
+	log.SetFlags(0)
 
-	db, err := sql.Open("mysql", dsn)
-	if err != nil {
-		log.Fatal(err)
+	action := "up"
+	var args []string
+	if len(os.Args) > 1 {
+		action = os.Args[1]
+		args = os.Args[2:]
 	}
-	defer db.Close()
 
-	driver, err := mysqlDriver.WithInstance(db, &mysqlDriver.Config{})
+	var err error
+	switch action {
+	case "up", "down", "steps", "goto":
+		err = runMigrate(action, args)
+	case "force":
+		err = runForce(args)
+	case "version":
+		err = runVersion()
+	case "status":
+		err = runStatus()
+	case "create":
+		err = runCreate(args)
+	case "drift":
+		err = runDrift(args)
+	case "lint":
+		err = runLint(args)
+	default:
+		log.Fatal(usage)
+	}
 	if err != nil {
 		log.Fatal(err)
 	}
+}
 
-	wd, _ := os.Getwd()
-	fmt.Println(wd)
+func runMigrate(action string, args []string) error {
+	flags := flag.NewFlagSet(action, flag.ExitOnError)
+	dryRun := flags.Bool("dry-run", false, "print the SQL that would run instead of running it")
+	positional := parseArgs(flags, args)
 
-	path := filepath.Join(wd, "database", "migrations")
+	list, err := migrations.List(migrations.FS)
+	if err != nil {
+		return err
+	}
 
-	m, err := migrate.NewWithDatabaseInstance(
-		"file://"+path,
-		"mysql",
-		driver,
-	)
+	m, err := newMigrate()
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
 	defer m.Close()
 
-	action := "up"
-	steps := 0
-
-	if len(os.Args) > 1 {
-		action = os.Args[1]
+	from, dirty, err := currentVersion(m)
+	if err != nil {
+		return err
 	}
-	if len(os.Args) > 2 {
-		steps, _ = strconv.Atoi(os.Args[2])
+	if dirty {
+		return fmt.Errorf("database is dirty at version %d: fix it by hand, then run force %d", from, from)
 	}
 
+	var to uint
 	switch action {
 	case "up":
-		err = m.Up()
+		to = migrations.Latest(list)
 	case "down":
-		err = m.Down()
+		to = 0
 	case "steps":
-		err = m.Steps(steps)
-	case "force":
-		err = m.Force(steps)
-	case "version":
-		v, dirty, _ := m.Version()
-		fmt.Println("version:", v, "dirty:", dirty)
-		return
-	default:
-		log.Fatalf("unknown command: %s", action)
+		n, err := intArg(positional)
+		if err != nil {
+			return err
+		}
+		if to, err = migrations.StepVersion(list, from, n); err != nil {
+			return err
+		}
+	case "goto":
+		n, err := intArg(positional)
+		if err != nil {
+			return err
+		}
+		to = uint(n)
+		if n < 0 || (to != 0 && !hasVersion(list, to)) {
+			return fmt.Errorf("version %d is not a migration", n)
+		}
+	}
+
+	if *dryRun {
+		return printPlan(list, from, to)
 	}
 
+	if to == 0 {
+		err = m.Down()
+	} else {
+		err = m.Migrate(to)
+	}
 	if err != nil && !errors.Is(err, migrate.ErrNoChange) {
-		log.Fatal(err)
+		return err
+	}
+
+	fmt.Printf("migrated from version %d to %d\n", from, to)
+	return nil
+}
+
+func runForce(args []string) error {
+	version, err := intArg(args)
+	if err != nil {
+		return err
+	}
+
+	m, err := newMigrate()
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	return m.Force(version)
+}
+
+func runVersion() error {
+	m, err := newMigrate()
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	version, dirty, err := currentVersion(m)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("version:", version, "dirty:", dirty)
+	return nil
+}
+
+// runStatus lists the migrations with whether they are applied.
+func runStatus() error {
+	list, err := migrations.List(migrations.FS)
+	if err != nil {
+		return err
+	}
+
+	m, err := newMigrate()
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	version, dirty, err := currentVersion(m)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS")
+	for _, migration := range list {
+		status := "pending"
+		switch {
+		case migration.Version == version && dirty:
+			status = "dirty"
+		case migration.Version <= version:
+			status = "applied"
+		}
+		fmt.Fprintf(w, "%06d\t%s\t%s\n", migration.Version, migration.Name, status)
+	}
+	return w.Flush()
+}
+
+// runCreate writes the empty files of a migration numbered after the last one
+// of the directory.
+func runCreate(args []string) error {
+	flags := flag.NewFlagSet("create", flag.ExitOnError)
+	dir := flags.String("dir", filepath.Join("database", "migrations"), "directory of the migrations")
+	positional := parseArgs(flags, args)
+
+	if len(positional) != 1 || !migrationNamePattern.MatchString(positional[0]) {
+		return errors.New("create needs one NAME made of lowercase letters, digits and underscores")
+	}
+
+	list, err := migrations.List(os.DirFS(*dir))
+	if err != nil {
+		return err
+	}
+
+	base := fmt.Sprintf("%06d_%s", migrations.Latest(list)+1, positional[0])
+	for _, direction := range []string{"up", "down"} {
+		path := filepath.Join(*dir, base+"."+direction+".sql")
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err != nil {
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+		fmt.Println(path)
+	}
+
+	return nil
+}
+
+// runDrift reports the objects of the schema files that differ from the
+// schema the migrations build.
+func runDrift(args []string) error {
+	flags := flag.NewFlagSet("drift", flag.ExitOnError)
+	isSQLite := flags.Bool("sqlite", false, "FILE are SQLite schema files")
+	_ = flags.Parse(args)
+
+	mysqlFiles, sqliteFiles := flags.Args(), []string(nil)
+	if *isSQLite {
+		mysqlFiles, sqliteFiles = nil, flags.Args()
+	}
+	if flags.NArg() == 0 {
+		if !*isSQLite {
+			mysqlFiles = schemaFiles
+		}
+		sqliteFiles = sqliteSchemaFiles
+	}
+
+	mysqlContents, err := readFiles(mysqlFiles)
+	if err != nil {
+		return err
+	}
+	sqliteContents, err := readFiles(sqliteFiles)
+	if err != nil {
+		return err
+	}
+
+	db, cfg, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	checker := schemadrift.NewChecker(db, cfg.DBName)
+	drifts := map[string][]schemadrift.Drift{}
+	if len(mysqlContents) > 0 {
+		if drifts, err = checker.Check(context.Background(), mysqlContents); err != nil {
+			return err
+		}
+	}
+	if len(sqliteContents) > 0 {
+		sqliteDrifts, err := checker.CheckSQLite(context.Background(), sqliteContents)
+		if err != nil {
+			return err
+		}
+		for file, diff := range sqliteDrifts {
+			drifts[file] = diff
+		}
 	}
 
-	fmt.Println("Done")
+	drifted := 0
+	for _, file := range append(append([]string{}, mysqlFiles...), sqliteFiles...) {
+		for _, drift := range drifts[file] {
+			fmt.Printf("%s: %s\n", file, drift)
+			drifted++
+		}
+	}
+	if drifted > 0 {
+		return fmt.Errorf("%d objects of the schema files drifted from the migrations", drifted)
+	}
+
+	fmt.Println("no drift")
+	return nil
+}
+
+func readFiles(files []string) (map[string]string, error) {
+	contents := make(map[string]string, len(files))
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		contents[file] = string(content)
+	}
+	return contents, nil
+}
+
+// runLint lints the migrations and fails when a rule is broken by a migration
+// after the baseline.
+func runLint(args []string) error {
+	cfg := migrationlint.DefaultConfig()
+
+	flags := flag.NewFlagSet("lint", flag.ExitOnError)
+	dir := flags.String("dir", "", "directory of the migrations, the built-in ones by default")
+	baseline := flags.Uint("baseline", cfg.Baseline, "last migration applied before the linter, whose findings do not fail")
+	_ = flags.Parse(args)
+
+	var fsys fs.FS = migrations.FS
+	if *dir != "" {
+		fsys = os.DirFS(*dir)
+	}
+	cfg.Baseline = *baseline
+
+	findings, err := migrationlint.Lint(fsys, cfg)
+	if err != nil {
+		return err
+	}
+	for _, finding := range findings {
+		fmt.Println(finding)
+	}
+	if failures := migrationlint.Failures(findings); len(failures) > 0 {
+		return fmt.Errorf("lint failed with %d findings: fix them, or add a lint:ignore comment saying why they are safe", len(failures))
+	}
+
+	if len(findings) == 0 {
+		fmt.Println("no findings")
+	}
+	return nil
+}
+
+// openDB connects to the database of the configuration, which must be MySQL.
+func openDB() (*sql.DB, *config.DBConfig, error) {
+	cfg, err := config.LoadDBConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	if cfg.DBDialect != config.DBDialectMySQL {
+		return nil, nil, fmt.Errorf("migrations are for mysql, the %s schema is created when the service starts", cfg.DBDialect)
+	}
+
+	db, err := sql.Open("mysql", buildDSN(*cfg))
+	if err != nil {
+		return nil, nil, err
+	}
+	return db, cfg, nil
+}
+
+func newMigrate() (*migrate.Migrate, error) {
+	db, _, err := openDB()
+	if err != nil {
+		return nil, err
+	}
+
+	driver, err := mysqlDriver.WithInstance(db, &mysqlDriver.Config{})
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	source, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		_ = driver.Close()
+		return nil, err
+	}
+
+	m, err := migrate.NewWithInstance("iofs", source, "mysql", driver)
+	if err != nil {
+		_ = source.Close()
+		_ = driver.Close()
+		return nil, err
+	}
+	return m, nil
+}
+
+// currentVersion returns 0 for a database without any migration.
+func currentVersion(m *migrate.Migrate) (uint, bool, error) {
+	version, dirty, err := m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("read version: %w", err)
+	}
+	return version, dirty, nil
+}
+
+// printPlan prints the files that would run, in order.
+func printPlan(list []*migrations.Migration, from, to uint) error {
+	steps := migrations.Plan(list, from, to)
+	if len(steps) == 0 {
+		fmt.Printf("-- version %d, no change\n", from)
+		return nil
+	}
+
+	for _, step := range steps {
+		content, err := fs.ReadFile(migrations.FS, step.File)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("-- %s\n%s\n", step.File, content)
+	}
+	fmt.Printf("-- version %d to %d\n", from, to)
+	return nil
+}
+
+// parseArgs parses the flags of the command, before and after its positional
+// arguments, and returns the positional ones.
+func parseArgs(flags *flag.FlagSet, args []string) []string {
+	var positional []string
+	for {
+		_ = flags.Parse(args)
+		args = flags.Args()
+		if len(args) == 0 {
+			return positional
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func intArg(args []string) (int, error) {
+	if len(args) != 1 {
+		return 0, errors.New(usage)
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", args[0])
+	}
+	return n, nil
+}
+
+func hasVersion(list []*migrations.Migration, version uint) bool {
+	for _, m := range list {
+		if m.Version == version {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/config"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/datastore"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/publisher"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/worker"
+)
+
+func main() {
+	dbCfg, err := config.LoadDBConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	leaderCfg, err := config.LoadLeaderConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	relayCfg, err := config.LoadRelayConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	todoConn, closeDB, err := datastore.NewTodoSQLHandler(dbCfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer closeDB()
+
+	pub, closePublisher, err := newPublisher(ctx, relayCfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer closePublisher()
+
+	binder := datastore.NewConnectionBinder(todoConn)
+	relay := worker.NewOutboxRelay(
+		binder,
+		datastore.NewOutboxReader(),
+		datastore.NewOutboxWriter(),
+		pub,
+		worker.OutboxRelayConfig{
+			PollInterval: relayCfg.OutboxPollInterval,
+			BatchSize:    relayCfg.OutboxBatchSize,
+			BaseBackoff:  relayCfg.OutboxBaseBackoff,
+			MaxBackoff:   relayCfg.OutboxMaxBackoff,
+			MaxAttempts:  relayCfg.OutboxMaxAttempts,
+		},
+	)
+
+	log.Printf("outbox relay started, publisher = %s", relayCfg.OutboxPublisher)
+	// Only one replica runs the outbox relay at a time.
+	leader := worker.NewLeader(
+		binder,
+		datastore.NewLeaseWriter(),
+		worker.LeaderConfig{
+			Name:          "outbox-relay",
+			Holder:        leaderCfg.LeaderHolder,
+			TTL:           leaderCfg.LeaderLeaseTTL,
+			RetryInterval: leaderCfg.LeaderRetryInterval,
+		},
+	)
+	err = leader.Run(ctx, func(ctx context.Context, lease *todo.Lease) error {
+		return relay.Run(ctx, lease)
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func newPublisher(ctx context.Context, cfg *config.RelayConfig) (gateway.Publisher, func(), error) {
+	switch cfg.OutboxPublisher {
+	case config.OutboxPublisherMemory:
+		broker := publisher.NewInMemoryBroker()
+		events := broker.Subscribe(ctx, cfg.OutboxBatchSize)
+		go func() {
+			for event := range events {
+				log.Printf("event %d: %s %s", event.ID, event.EventType, event.Payload)
+			}
+		}()
+		return broker, func() {}, nil
+	default:
+		return publisher.NewNDJSONFilePublisher(cfg.OutboxNDJSONPath)
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/config"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/cache"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/datastore"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/worker"
+)
+
+func main() {
+	dbCfg, err := config.LoadDBConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	leaderCfg, err := config.LoadLeaderConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	rebalanceCfg, err := config.LoadRebalanceConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	cacheCfg, err := config.LoadCacheConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	todoConn, closeDB, err := datastore.NewTodoSQLHandler(dbCfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer closeDB()
+
+	cacheBackend, closeCache, err := cache.NewSharedBackend(cacheCfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer closeCache()
+
+	// Rebalancing moves todos, so the reads cached by other processes are
+	// invalidated.
+	positionWriter := datastore.NewTodoPositionWriter()
+	if cacheBackend != nil {
+		positionWriter = cache.NewTodoPositionWriter(positionWriter, cacheBackend)
+	}
+
+	binder := datastore.NewConnectionBinder(todoConn)
+	rebalancer := worker.NewPositionRebalancer(
+		binder,
+		datastore.NewTodoPositionReader(),
+		positionWriter,
+		worker.PositionRebalancerConfig{
+			Interval:  rebalanceCfg.PositionRebalanceInterval,
+			BatchSize: rebalanceCfg.PositionRebalanceBatchSize,
+		},
+	)
+
+	log.Printf("position rebalancer started, interval = %s", rebalanceCfg.PositionRebalanceInterval)
+	// Only one replica runs the position rebalancer at a time.
+	leader := worker.NewLeader(
+		binder,
+		datastore.NewLeaseWriter(),
+		worker.LeaderConfig{
+			Name:          "position-rebalancer",
+			Holder:        leaderCfg.LeaderHolder,
+			TTL:           leaderCfg.LeaderLeaseTTL,
+			RetryInterval: leaderCfg.LeaderRetryInterval,
+		},
+	)
+	err = leader.Run(ctx, func(ctx context.Context, _ *todo.Lease) error {
+		return rebalancer.Run(ctx)
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+}
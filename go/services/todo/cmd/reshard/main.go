@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/config"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/cache"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/datastore"
+)
+
+const usage = `usage:
+  reshard move -user ID -to SHARD [-settle DURATION] [-delete-source]
+  reshard pin  [-batch-size N]
+
+Shards are "primary" and the names of DB_SHARDS.`
+
+func main() {
+	log.SetFlags(0)
+
+	if len(os.Args) < 2 {
+		log.Fatal(usage)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var err error
+	switch os.Args[1] {
+	case "move":
+		err = runMove(ctx, os.Args[2:])
+	case "pin":
+		err = runPin(ctx, os.Args[2:])
+	default:
+		log.Fatal(usage)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func runMove(ctx context.Context, args []string) error {
+	dbCfg, err := config.LoadDBConfig()
+	if err != nil {
+		return err
+	}
+
+	cacheCfg, err := config.LoadCacheConfig()
+	if err != nil {
+		return err
+	}
+
+	fs := flag.NewFlagSet("move", flag.ExitOnError)
+	userID := fs.Int64("user", 0, "user to move")
+	to := fs.String("to", "", "shard the user is moved to")
+	settle := fs.Duration(
+		"settle",
+		2*dbCfg.DBShardMapRefreshInterval,
+		"how long the service takes to see a change of the shard map",
+	)
+	deleteSource := fs.Bool("delete-source", false, "delete the rows of the user from their former shard")
+	_ = fs.Parse(args)
+
+	mover, closeDB, err := newUserMover(dbCfg, datastore.UserMoverConfig{
+		Settle:       *settle,
+		DeleteSource: *deleteSource,
+	})
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	cacheBackend, closeCache, err := cache.NewSharedBackend(cacheCfg)
+	if err != nil {
+		return err
+	}
+	defer closeCache()
+
+	// The reads cached by the service may come from the former shard, or
+	// from a replica of the new one lagging behind the copy. A failed move
+	// may have been flipped already, so they are invalidated either way.
+	if cacheBackend != nil {
+		defer cache.InvalidateUser(ctx, cacheBackend, todo.UserID(*userID))
+	}
+
+	start := time.Now()
+	report, err := mover.MoveUser(ctx, todo.UserID(*userID), *to)
+	if err != nil {
+		return err
+	}
+	if report.From == report.To {
+		log.Printf("user %d is already on shard %q", *userID, report.To)
+		return nil
+	}
+
+	log.Printf(
+		"moved user %d from shard %q to %q with %d todos in %s",
+		*userID, report.From, report.To, report.Todos, time.Since(start).Round(time.Millisecond),
+	)
+	return nil
+}
+
+func runPin(ctx context.Context, args []string) error {
+	dbCfg, err := config.LoadDBConfig()
+	if err != nil {
+		return err
+	}
+
+	fs := flag.NewFlagSet("pin", flag.ExitOnError)
+	batchSize := fs.Int("batch-size", 500, "users pinned per statement")
+	_ = fs.Parse(args)
+
+	mover, closeDB, err := newUserMover(dbCfg, datastore.UserMoverConfig{BatchSize: *batchSize})
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	pinned, err := mover.PinUsers(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("pinned %d users to their shard", pinned)
+	return nil
+}
+
+func newUserMover(dbCfg *config.DBConfig, cfg datastore.UserMoverConfig) (*datastore.UserMover, func(), error) {
+	todoConn, closeDB, err := datastore.NewTodoSQLHandler(dbCfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return datastore.NewUserMover(todoConn, cfg), closeDB, nil
+}
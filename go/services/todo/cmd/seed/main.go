@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/config"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/datastore"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/seed"
+)
+
+const usage = `usage:
+  seed fixtures [-reset [-yes]] [-dir DIR]
+  seed generate [-reset [-yes]] [-users N] [-todos M] [-seed N] [-now TIME] [-history DURATION]
+  seed reset [-yes]
+
+-reset empties every table but schema_migrations, erased_users, leases and
+user_shards first, in foreign key order. It refuses to empty a MySQL database
+whose name or host is not marked as a development one unless -yes is passed.`
+
+// defaultNow is the default -now, fixed so that the same -seed always
+// generates the same data.
+const defaultNow = "2026-01-01T00:00:00Z"
+
+func main() {
+	log.SetFlags(0)
+
+	if len(os.Args) < 2 {
+		log.Fatal(usage)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var err error
+	switch os.Args[1] {
+	case "fixtures":
+		err = runFixtures(ctx, os.Args[2:])
+	case "generate":
+		err = runGenerate(ctx, os.Args[2:])
+	case "reset":
+		err = runReset(ctx, os.Args[2:])
+	default:
+		log.Fatal(usage)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func runFixtures(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("fixtures", flag.ExitOnError)
+	resetFirst := fs.Bool("reset", false, "empty the tables first")
+	yes := fs.Bool("yes", false, "reset a database not marked as a development one")
+	dir := fs.String("dir", "testdata/todo_fixtures", "directory of the fixture files")
+	_ = fs.Parse(args)
+
+	return withSeeder(*yes, func(seeder *datastore.Seeder) error {
+		if *resetFirst {
+			if err := reset(ctx, seeder); err != nil {
+				return err
+			}
+		}
+
+		if err := seeder.LoadFixtures(*dir); err != nil {
+			return err
+		}
+
+		log.Printf("loaded the fixtures of %s", *dir)
+		return nil
+	})
+}
+
+func runGenerate(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("generate", flag.ExitOnError)
+	resetFirst := fs.Bool("reset", false, "empty the tables first")
+	yes := fs.Bool("yes", false, "reset a database not marked as a development one")
+	users := fs.Int("users", 10, "users generated")
+	todos := fs.Int("todos", 20, "todos generated per user")
+	seedValue := fs.Int64("seed", 1, "seed of the random generator, the same seed and -now generate the same data")
+	nowValue := fs.String("now", defaultNow, "RFC 3339 time the data is generated at, every time is before it")
+	history := fs.Duration("history", 90*24*time.Hour, "how far back the times of the data go")
+	_ = fs.Parse(args)
+
+	now, err := time.Parse(time.RFC3339, *nowValue)
+	if err != nil {
+		return fmt.Errorf("parse -now: %w", err)
+	}
+
+	data := seed.Generate(seed.Config{
+		Users:        *users,
+		TodosPerUser: *todos,
+		Seed:         *seedValue,
+		Now:          now,
+		History:      *history,
+	})
+
+	return withSeeder(*yes, func(seeder *datastore.Seeder) error {
+		if *resetFirst {
+			if err := reset(ctx, seeder); err != nil {
+				return err
+			}
+		}
+
+		if err := seeder.Insert(ctx, data); err != nil {
+			return err
+		}
+
+		log.Printf("inserted %d users with %d todos each", *users, *todos)
+		return nil
+	})
+}
+
+func runReset(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("reset", flag.ExitOnError)
+	yes := fs.Bool("yes", false, "reset a database not marked as a development one")
+	_ = fs.Parse(args)
+
+	return withSeeder(*yes, func(seeder *datastore.Seeder) error {
+		return reset(ctx, seeder)
+	})
+}
+
+func reset(ctx context.Context, seeder *datastore.Seeder) error {
+	tableNames, err := seeder.Reset(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("emptied %s", strings.Join(tableNames, ", "))
+	return nil
+}
+
+// withSeeder runs f with a seeder of the configured database, which resets a
+// database not marked as a development one only when confirmReset is set.
+func withSeeder(confirmReset bool, f func(seeder *datastore.Seeder) error) error {
+	dbCfg, err := config.LoadDBConfig()
+	if err != nil {
+		return err
+	}
+
+	todoConn, closeDB, err := datastore.NewTodoSQLHandler(dbCfg)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	seeder, err := datastore.NewSeeder(todoConn, datastore.SeederConfig{
+		DBName:       dbCfg.DBName,
+		DBHost:       dbCfg.DBHost,
+		ConfirmReset: confirmReset,
+	})
+	if err != nil {
+		return err
+	}
+
+	return f(seeder)
+}
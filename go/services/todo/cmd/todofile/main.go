@@ -0,0 +1,241 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/config"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/cache"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/datastore"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/interchange"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/usecase"
+)
+
+const usage = `usage:
+  todofile export  -user ID -format FORMAT [-out FILE]
+  todofile import  -user ID -format FORMAT [-in FILE] [-dry-run] [-update-existing]
+  todofile convert -from FORMAT -to FORMAT [-in FILE] [-out FILE]
+
+formats: csv, jsonl, ics, todotxt, md`
+
+func main() {
+	log.SetFlags(0)
+
+	if len(os.Args) < 2 {
+		log.Fatal(usage)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "export":
+		err = runExport(os.Args[2:])
+	case "import":
+		err = runImport(os.Args[2:])
+	case "convert":
+		err = runConvert(os.Args[2:])
+	default:
+		log.Fatal(usage)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func runExport(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	userID := fs.Int64("user", 0, "user whose todos are exported")
+	format := fs.String("format", "", "file format")
+	out := fs.String("out", "", "output file (default stdout)")
+	_ = fs.Parse(args)
+
+	interchangeUsecase, closeDB, err := newInterchangeUsecase()
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	w, closeOut, err := openOutput(*out)
+	if err != nil {
+		return err
+	}
+	defer closeOut()
+
+	return interchangeUsecase.ExportTodos(context.Background(), todo.UserID(*userID), todo.FileFormat(*format), w)
+}
+
+func runImport(args []string) error {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	userID := fs.Int64("user", 0, "user the todos are imported for")
+	format := fs.String("format", "", "file format")
+	in := fs.String("in", "", "input file (default stdin)")
+	dryRun := fs.Bool("dry-run", false, "validate the file without writing")
+	updateExisting := fs.Bool("update-existing", false, "update todos that already have the external ID of a row")
+	_ = fs.Parse(args)
+
+	interchangeUsecase, closeDB, err := newInterchangeUsecase()
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	r, closeIn, err := openInput(*in)
+	if err != nil {
+		return err
+	}
+	defer closeIn()
+
+	report, err := interchangeUsecase.ImportTodos(
+		context.Background(),
+		todo.UserID(*userID),
+		todo.FileFormat(*format),
+		r,
+		todo.ImportOptions{DryRun: *dryRun, UpdateExisting: *updateExisting},
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, rowErr := range report.Errors {
+		log.Print(rowErr.Error())
+	}
+	log.Printf(
+		"created: %d, updated: %d, skipped: %d, errors: %d, dry run: %t",
+		report.Created, report.Updated, report.Skipped, len(report.Errors), report.DryRun,
+	)
+
+	return nil
+}
+
+// runConvert converts a file between formats without a database, for example
+// a todo.txt file into a Markdown checklist. Rows with errors are reported and
+// left out.
+func runConvert(args []string) error {
+	fs := flag.NewFlagSet("convert", flag.ExitOnError)
+	from := fs.String("from", "", "input file format")
+	to := fs.String("to", "", "output file format")
+	in := fs.String("in", "", "input file (default stdin)")
+	out := fs.String("out", "", "output file (default stdout)")
+	_ = fs.Parse(args)
+
+	codecs := interchange.NewCodecs()
+	decoder, ok := codecs[todo.FileFormat(*from)]
+	if !ok {
+		return fmt.Errorf("unsupported input format %q", *from)
+	}
+	encoder, ok := codecs[todo.FileFormat(*to)]
+	if !ok {
+		return fmt.Errorf("unsupported output format %q", *to)
+	}
+
+	r, closeIn, err := openInput(*in)
+	if err != nil {
+		return err
+	}
+	defer closeIn()
+
+	rows, rowErrors, err := decoder.Decode(r)
+	if err != nil {
+		return err
+	}
+
+	todos := make([]*todo.Todo, 0, len(rows))
+	for _, row := range rows {
+		if errs := row.Validate(); len(errs) > 0 {
+			rowErrors = append(rowErrors, errs...)
+			continue
+		}
+		t := &todo.Todo{
+			ExternalID:  row.ExternalID,
+			Task:        row.Task,
+			Description: row.Description,
+			Status:      row.Status,
+			DueAt:       row.DueAt,
+			CompletedAt: row.CompletedAt,
+		}
+		if row.CreatedAt != nil {
+			t.CreatedAt = *row.CreatedAt
+		}
+		todos = append(todos, t)
+	}
+	for _, rowErr := range rowErrors {
+		log.Print(rowErr.Error())
+	}
+
+	w, closeOut, err := openOutput(*out)
+	if err != nil {
+		return err
+	}
+	defer closeOut()
+
+	return encoder.Encode(w, todos)
+}
+
+func newInterchangeUsecase() (usecase.TodoInterchangeUsecase, func(), error) {
+	dbCfg, err := config.LoadDBConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cacheCfg, err := config.LoadCacheConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	todoConn, closeDB, err := datastore.NewTodoSQLHandler(dbCfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cacheBackend, closeCache, err := cache.NewSharedBackend(cacheCfg)
+	if err != nil {
+		closeDB()
+		return nil, nil, err
+	}
+
+	// Imports write todos, so the reads cached by the service are
+	// invalidated.
+	todoWriter := datastore.NewTodoWriter()
+	if cacheBackend != nil {
+		todoWriter = cache.NewTodoWriter(todoWriter, cacheBackend)
+	}
+
+	interchangeUsecase := usecase.NewTodoInterchangeUsecase(
+		datastore.NewConnectionBinder(todoConn),
+		datastore.NewTodoReader(),
+		todoWriter,
+		interchange.NewCodecs(),
+	)
+	return interchangeUsecase, func() {
+		closeCache()
+		closeDB()
+	}, nil
+}
+
+func openInput(path string) (io.Reader, func(), error) {
+	if path == "" {
+		return os.Stdin, func() {}, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, func() { _ = f.Close() }, nil
+}
+
+func openOutput(path string) (io.Writer, func(), error) {
+	if path == "" {
+		return os.Stdout, func() {}, nil
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, func() { _ = f.Close() }, nil
+}
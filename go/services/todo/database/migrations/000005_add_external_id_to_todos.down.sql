@@ -0,0 +1,3 @@
+ALTER TABLE todos DROP INDEX ui_todos_user_id_external_id, ALGORITHM=INPLACE, LOCK=NONE;
+
+ALTER TABLE todos DROP COLUMN external_id, ALGORITHM=INSTANT;
@@ -0,0 +1,3 @@
+ALTER TABLE todos ADD COLUMN external_id VARCHAR(255) NULL AFTER user_id, ALGORITHM=INSTANT;
+
+ALTER TABLE todos ADD UNIQUE INDEX ui_todos_user_id_external_id (user_id, external_id), ALGORITHM=INPLACE, LOCK=NONE;
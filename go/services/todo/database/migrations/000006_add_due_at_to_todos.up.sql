@@ -0,0 +1 @@
+ALTER TABLE todos ADD COLUMN due_at DATETIME NULL AFTER status, ALGORITHM=INSTANT;
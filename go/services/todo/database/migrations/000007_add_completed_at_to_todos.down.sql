@@ -0,0 +1,3 @@
+DROP INDEX idx_todos_user_id_completed_at ON todos ALGORITHM=INPLACE LOCK=NONE;
+DROP INDEX idx_todos_user_id_created_at ON todos ALGORITHM=INPLACE LOCK=NONE;
+ALTER TABLE todos DROP COLUMN completed_at, ALGORITHM=INSTANT;
@@ -0,0 +1,4 @@
+ALTER TABLE todos ADD COLUMN completed_at DATETIME NULL AFTER due_at, ALGORITHM=INSTANT;
+UPDATE todos SET completed_at = updated_at WHERE status = 2;
+CREATE INDEX idx_todos_user_id_created_at ON todos (user_id, created_at) ALGORITHM=INPLACE LOCK=NONE;
+CREATE INDEX idx_todos_user_id_completed_at ON todos (user_id, completed_at) ALGORITHM=INPLACE LOCK=NONE;
@@ -0,0 +1,2 @@
+DROP INDEX idx_todos_user_id_position ON todos ALGORITHM=INPLACE LOCK=NONE;
+ALTER TABLE todos DROP COLUMN position, ALGORITHM=INSTANT;
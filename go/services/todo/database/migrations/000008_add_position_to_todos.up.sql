@@ -0,0 +1,14 @@
+ALTER TABLE todos ADD COLUMN position VARCHAR(255) CHARACTER SET ascii COLLATE ascii_bin NOT NULL DEFAULT '' AFTER status, ALGORITHM=INSTANT;
+
+-- Rank the existing todos of every user in the order they were created, as
+-- base-36 fractions without trailing zeros. updated_at is kept as is.
+UPDATE todos t
+JOIN (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY created_at ASC, id ASC) AS n
+    FROM todos
+) ranked ON ranked.id = t.id
+SET
+    t.position = TRIM(TRAILING '0' FROM LPAD(LOWER(CONV(ranked.n * 1000, 10, 36)), 8, '0')),
+    t.updated_at = t.updated_at;
+
+CREATE INDEX idx_todos_user_id_position ON todos (user_id, position) ALGORITHM=INPLACE LOCK=NONE;
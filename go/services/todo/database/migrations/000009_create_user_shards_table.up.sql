@@ -0,0 +1,6 @@
+CREATE TABLE user_shards (
+    user_id BIGINT UNSIGNED PRIMARY KEY,
+    shard VARCHAR(64) NOT NULL,
+    read_only BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
@@ -0,0 +1,4 @@
+CREATE TABLE erased_users (
+    user_id BIGINT UNSIGNED PRIMARY KEY,
+    erased_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
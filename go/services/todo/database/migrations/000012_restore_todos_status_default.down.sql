@@ -0,0 +1 @@
+ALTER TABLE todos ALTER COLUMN status DROP DEFAULT, ALGORITHM=INSTANT;
@@ -0,0 +1,2 @@
+-- 000003 replaced the status column without the default of 000002, pending.
+ALTER TABLE todos ALTER COLUMN status SET DEFAULT 0, ALGORITHM=INSTANT;
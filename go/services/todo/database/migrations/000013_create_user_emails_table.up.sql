@@ -0,0 +1,11 @@
+CREATE TABLE user_emails (
+    email VARCHAR(255) PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+
+    INDEX idx_user_emails_user_id (user_id)
+);
+
+-- Only the primary of a sharded database uses the table, and the users
+-- created before sharding was configured are there.
+INSERT INTO user_emails (email, user_id)
+SELECT email, id FROM users WHERE email IS NOT NULL;
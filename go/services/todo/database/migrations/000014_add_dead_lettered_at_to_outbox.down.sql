@@ -0,0 +1,4 @@
+DROP INDEX idx_outbox_user_id_aggregate_type_id ON outbox ALGORITHM=INPLACE LOCK=NONE;
+CREATE INDEX idx_outbox_published_at ON outbox (published_at) ALGORITHM=INPLACE LOCK=NONE;
+DROP INDEX idx_outbox_published_at_next_attempt_at ON outbox ALGORITHM=INPLACE LOCK=NONE;
+ALTER TABLE outbox DROP COLUMN dead_lettered_at, ALGORITHM=INSTANT;
@@ -0,0 +1,7 @@
+ALTER TABLE outbox ADD COLUMN dead_lettered_at DATETIME NULL, ALGORITHM=INSTANT;
+
+-- The relay lists the events due for an attempt, the shard mover the events
+-- of a user.
+CREATE INDEX idx_outbox_published_at_next_attempt_at ON outbox (published_at, next_attempt_at) ALGORITHM=INPLACE LOCK=NONE;
+DROP INDEX idx_outbox_published_at ON outbox ALGORITHM=INPLACE LOCK=NONE;
+CREATE INDEX idx_outbox_user_id_aggregate_type_id ON outbox (user_id, aggregate_type, id) ALGORITHM=INPLACE LOCK=NONE;
@@ -0,0 +1,150 @@
+// Package migrations holds the MySQL migrations of the todo database,
+// embedded so that the migrate command runs from any directory.
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// FS holds the migration files, named VERSION_NAME.up.sql and
+// VERSION_NAME.down.sql.
+//
+//go:embed *.sql
+var FS embed.FS
+
+var fileNamePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version uint
+	Name    string
+	// Up and Down are the names of the files in FS.
+	Up   string
+	Down string
+}
+
+// Step is a migration file to run, up or down.
+type Step struct {
+	Version uint
+	File    string
+}
+
+// List returns the migrations of fsys in version order. Every migration must
+// have both files, and every file a version of its own.
+func List(fsys fs.FS) ([]*Migration, error) {
+	list, err := Scan(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range list {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s: up or down file is missing", m.Version, m.Name)
+		}
+	}
+
+	return list, nil
+}
+
+// Scan returns the migrations of fsys in version order, like List, but keeps
+// the migrations with a missing file: their Up or Down is empty.
+func Scan(fsys fs.FS) ([]*Migration, error) {
+	files, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[uint]*Migration{}
+	for _, file := range files {
+		match := fileNamePattern.FindStringSubmatch(file)
+		if match == nil {
+			return nil, fmt.Errorf("migration %s: name is not VERSION_NAME.up.sql or VERSION_NAME.down.sql", file)
+		}
+		version, err := strconv.ParseUint(match[1], 10, 0)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: %w", file, err)
+		}
+
+		m, ok := byVersion[uint(version)]
+		if !ok {
+			m = &Migration{Version: uint(version), Name: match[2]}
+			byVersion[uint(version)] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %s: version %d is also used by %s", file, version, m.Name)
+		}
+		if match[3] == "up" {
+			m.Up = file
+		} else {
+			m.Down = file
+		}
+	}
+
+	list := make([]*Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		list = append(list, m)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Version < list[j].Version
+	})
+
+	return list, nil
+}
+
+// Plan returns the files that migrate the database from version from to
+// version to, in the order they run. Version 0 is the database without any
+// migration.
+func Plan(list []*Migration, from, to uint) []Step {
+	var steps []Step
+	if to >= from {
+		for _, m := range list {
+			if m.Version > from && m.Version <= to {
+				steps = append(steps, Step{Version: m.Version, File: m.Up})
+			}
+		}
+		return steps
+	}
+
+	for i := len(list) - 1; i >= 0; i-- {
+		m := list[i]
+		if m.Version > to && m.Version <= from {
+			steps = append(steps, Step{Version: m.Version, File: m.Down})
+		}
+	}
+	return steps
+}
+
+// StepVersion returns the version n migrations away from version from: after
+// it when n is positive, before it when n is negative.
+func StepVersion(list []*Migration, from uint, n int) (uint, error) {
+	// Index 0 is the database without any migration.
+	versions := []uint{0}
+	current := 0
+	for _, m := range list {
+		versions = append(versions, m.Version)
+		if m.Version == from {
+			current = len(versions) - 1
+		}
+	}
+	if from != 0 && current == 0 {
+		return 0, fmt.Errorf("version %d is not a migration", from)
+	}
+
+	target := current + n
+	if target < 0 || target >= len(versions) {
+		return 0, fmt.Errorf("cannot move %d migrations from version %d", n, from)
+	}
+	return versions[target], nil
+}
+
+// Latest returns the version of the last migration, 0 without migrations.
+func Latest(list []*Migration) uint {
+	if len(list) == 0 {
+		return 0
+	}
+	return list[len(list)-1].Version
+}
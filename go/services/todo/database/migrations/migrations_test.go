@@ -0,0 +1,153 @@
+package migrations_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/database/migrations"
+)
+
+func TestList(t *testing.T) {
+	t.Parallel()
+
+	list, err := migrations.List(migrations.FS)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	for i, m := range list {
+		if m.Version != uint(i+1) {
+			t.Errorf("migration %d has version %d, want %d", i, m.Version, i+1)
+		}
+	}
+}
+
+func TestList_RejectsAMissingDownFile(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"000001_create_todos_table.up.sql":   {},
+		"000001_create_todos_table.down.sql": {},
+		"000002_add_index.up.sql":            {},
+	}
+	if _, err := migrations.List(fsys); err == nil {
+		t.Fatal("List() error = nil, want an error for the missing down file")
+	}
+}
+
+func TestScan_KeepsAMigrationWithAMissingFile(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"000001_create_todos_table.up.sql":   {},
+		"000001_create_todos_table.down.sql": {},
+		"000002_add_index.up.sql":            {},
+	}
+	list, err := migrations.Scan(fsys)
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+
+	expected := []*migrations.Migration{
+		{Version: 1, Name: "create_todos_table", Up: "000001_create_todos_table.up.sql", Down: "000001_create_todos_table.down.sql"},
+		{Version: 2, Name: "add_index", Up: "000002_add_index.up.sql"},
+	}
+	if diff := cmp.Diff(list, expected); diff != "" {
+		t.Fatalf("Scan() mismatch (-actual +expected):\n%s", diff)
+	}
+}
+
+func TestPlan(t *testing.T) {
+	type testcase struct {
+		from     uint
+		to       uint
+		expected []migrations.Step
+	}
+
+	t.Parallel()
+
+	list := []*migrations.Migration{
+		{Version: 1, Name: "a", Up: "1.up.sql", Down: "1.down.sql"},
+		{Version: 2, Name: "b", Up: "2.up.sql", Down: "2.down.sql"},
+		{Version: 3, Name: "c", Up: "3.up.sql", Down: "3.down.sql"},
+	}
+
+	testTables := map[string]testcase{
+		"Up from an empty database": {
+			from: 0,
+			to:   3,
+			expected: []migrations.Step{
+				{Version: 1, File: "1.up.sql"},
+				{Version: 2, File: "2.up.sql"},
+				{Version: 3, File: "3.up.sql"},
+			},
+		},
+		"Up to a version": {
+			from:     1,
+			to:       2,
+			expected: []migrations.Step{{Version: 2, File: "2.up.sql"}},
+		},
+		"Down to a version": {
+			from: 3,
+			to:   1,
+			expected: []migrations.Step{
+				{Version: 3, File: "3.down.sql"},
+				{Version: 2, File: "2.down.sql"},
+			},
+		},
+		"Nothing at the same version": {
+			from:     2,
+			to:       2,
+			expected: nil,
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			actual := migrations.Plan(list, tt.from, tt.to)
+			if diff := cmp.Diff(actual, tt.expected); diff != "" {
+				t.Fatalf("mismatch (-actual +expected):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestStepVersion(t *testing.T) {
+	type testcase struct {
+		from     uint
+		n        int
+		expected uint
+		wantErr  bool
+	}
+
+	t.Parallel()
+
+	list := []*migrations.Migration{{Version: 1}, {Version: 2}, {Version: 5}}
+
+	testTables := map[string]testcase{
+		"Forward from an empty database": {from: 0, n: 2, expected: 2},
+		"Forward over a gap":             {from: 2, n: 1, expected: 5},
+		"Back to an empty database":      {from: 2, n: -2, expected: 0},
+		"Past the last migration":        {from: 5, n: 1, wantErr: true},
+		"From an unknown version":        {from: 3, n: 1, wantErr: true},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := migrations.StepVersion(list, tt.from, tt.n)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("StepVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if actual != tt.expected {
+				t.Fatalf("StepVersion() = %d, want %d", actual, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+-- SQLite translation of database/migrations, for local development and tests.
+-- Keep it in step with the migrations: datetimes are stored as text in the
+-- driver's "2006-01-02 15:04:05.999999999-07:00" layout and compared as text.
+
+CREATE TABLE IF NOT EXISTS users (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    username VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NULL,
+    password VARCHAR(255) NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ui_users_email ON users (email);
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users (deleted_at);
+
+CREATE TABLE IF NOT EXISTS todos (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    external_id VARCHAR(255) NULL,
+    task VARCHAR(255) NOT NULL,
+    description TEXT NULL,
+    status INTEGER NOT NULL DEFAULT 0,
+    position VARCHAR(255) NOT NULL DEFAULT '',
+    due_at DATETIME NULL,
+    completed_at DATETIME NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME NULL,
+
+    CONSTRAINT check_todos_status CHECK (status IN (0, 1, 2)),
+
+    CONSTRAINT fk_todos_user
+        FOREIGN KEY (user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_todos_user_id ON todos (user_id);
+CREATE INDEX IF NOT EXISTS idx_todos_deleted_at ON todos (deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS ui_todos_user_id_external_id ON todos (user_id, external_id);
+CREATE INDEX IF NOT EXISTS idx_todos_user_id_created_at ON todos (user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_todos_user_id_completed_at ON todos (user_id, completed_at);
+CREATE INDEX IF NOT EXISTS idx_todos_user_id_position ON todos (user_id, position);
+
+CREATE TABLE IF NOT EXISTS outbox (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    aggregate_type VARCHAR(50) NOT NULL,
+    aggregate_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    payload BLOB NOT NULL, -- JSON, kept as written so that it reads back as bytes
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NULL,
+    occurred_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    next_attempt_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    published_at DATETIME NULL,
+    dead_lettered_at DATETIME NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_published_at_next_attempt_at ON outbox (published_at, next_attempt_at);
+CREATE INDEX IF NOT EXISTS idx_outbox_aggregate ON outbox (aggregate_type, aggregate_id);
+CREATE INDEX IF NOT EXISTS idx_outbox_user_id_aggregate_type_id ON outbox (user_id, aggregate_type, id);
+
+CREATE TABLE IF NOT EXISTS user_shards (
+    user_id INTEGER PRIMARY KEY,
+    shard VARCHAR(64) NOT NULL,
+    read_only BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS erased_users (
+    user_id INTEGER PRIMARY KEY,
+    erased_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS leases (
+    name VARCHAR(64) PRIMARY KEY,
+    holder VARCHAR(255) NOT NULL,
+    token INTEGER NOT NULL,
+    expires_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS user_emails (
+    email VARCHAR(255) PRIMARY KEY,
+    user_id INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_emails_user_id ON user_emails (user_id);
@@ -0,0 +1,10 @@
+// Package sqlite holds the SQLite schema of the todo database.
+package sqlite
+
+import _ "embed"
+
+// Schema creates the tables that do not exist yet, so it can be applied on
+// every start.
+//
+//go:embed create_tables.sql
+var Schema string
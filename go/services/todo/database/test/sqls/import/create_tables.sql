@@ -14,15 +14,23 @@ CREATE TABLE users (
 CREATE TABLE todos (
     id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
     user_id BIGINT UNSIGNED NOT NULL,
+    external_id VARCHAR(255) NULL,
     task VARCHAR(255) NOT NULL,
     description TEXT NULL,
     status TINYINT UNSIGNED NOT NULL DEFAULT 0,
+    position VARCHAR(255) CHARACTER SET ascii COLLATE ascii_bin NOT NULL DEFAULT '',
+    due_at DATETIME NULL,
+    completed_at DATETIME NULL,
     created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     deleted_at DATETIME NULL,
 
     INDEX idx_todos_user_id (user_id),
     INDEX idx_todos_deleted_at (deleted_at),
+    UNIQUE INDEX ui_todos_user_id_external_id (user_id, external_id),
+    INDEX idx_todos_user_id_created_at (user_id, created_at),
+    INDEX idx_todos_user_id_completed_at (user_id, completed_at),
+    INDEX idx_todos_user_id_position (user_id, position),
 
     CONSTRAINT check_todos_status CHECK (status IN (0, 1, 2)),
 
@@ -31,3 +39,49 @@ CREATE TABLE todos (
         REFERENCES users(id)
         ON DELETE CASCADE
 );
+
+CREATE TABLE outbox (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    aggregate_type VARCHAR(50) NOT NULL,
+    aggregate_id BIGINT UNSIGNED NOT NULL,
+    user_id BIGINT UNSIGNED NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    payload JSON NOT NULL,
+    attempts INT UNSIGNED NOT NULL DEFAULT 0,
+    last_error TEXT NULL,
+    occurred_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    next_attempt_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    published_at DATETIME NULL,
+    dead_lettered_at DATETIME NULL,
+
+    INDEX idx_outbox_published_at_next_attempt_at (published_at, next_attempt_at),
+    INDEX idx_outbox_aggregate (aggregate_type, aggregate_id),
+    INDEX idx_outbox_user_id_aggregate_type_id (user_id, aggregate_type, id)
+);
+
+CREATE TABLE user_shards (
+    user_id BIGINT UNSIGNED PRIMARY KEY,
+    shard VARCHAR(64) NOT NULL,
+    read_only BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
+
+CREATE TABLE erased_users (
+    user_id BIGINT UNSIGNED PRIMARY KEY,
+    erased_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE leases (
+    name VARCHAR(64) PRIMARY KEY,
+    holder VARCHAR(255) NOT NULL,
+    token BIGINT UNSIGNED NOT NULL,
+    expires_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
+
+CREATE TABLE user_emails (
+    email VARCHAR(255) PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+
+    INDEX idx_user_emails_user_id (user_id)
+);
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+type CacheBackend string
+
+const (
+	CacheBackendNone   CacheBackend = "none"
+	CacheBackendMemory CacheBackend = "memory"
+	CacheBackendRedis  CacheBackend = "redis"
+)
+
+type CacheConfig struct {
+	CacheBackend CacheBackend  `default:"none" split_words:"true"`
+	CacheTTL     time.Duration `default:"30s" envconfig:"CACHE_TTL"`
+	// CacheLRUSize is the number of reads kept by the memory backend.
+	CacheLRUSize int `default:"10000" envconfig:"CACHE_LRU_SIZE"`
+
+	CacheRedisAddr     string        `split_words:"true"`
+	CacheRedisPassword string        `split_words:"true"`
+	CacheRedisDB       int           `envconfig:"CACHE_REDIS_DB"`
+	CacheRedisTimeout  time.Duration `default:"500ms" split_words:"true"`
+}
+
+func LoadCacheConfig() (*CacheConfig, error) {
+	var c CacheConfig
+	err := envconfig.Process("", &c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load cache config: %w", err)
+	}
+
+	switch c.CacheBackend {
+	case CacheBackendNone, CacheBackendMemory:
+	case CacheBackendRedis:
+		if c.CacheRedisAddr == "" {
+			return nil, fmt.Errorf("failed to load cache config: CACHE_REDIS_ADDR is required by the %s backend", c.CacheBackend)
+		}
+	default:
+		return nil, fmt.Errorf("failed to load cache config: unsupported CACHE_BACKEND %q", c.CacheBackend)
+	}
+
+	return &c, nil
+}
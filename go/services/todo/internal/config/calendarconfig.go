@@ -0,0 +1,23 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+type CalendarFeedConfig struct {
+	CalendarFeedPort    int    `default:"5008" split_words:"true"`
+	CalendarFeedBaseURL string `default:"http://localhost:5008" envconfig:"CALENDAR_FEED_BASE_URL"`
+	CalendarFeedSecret  string `required:"true" split_words:"true"`
+}
+
+func LoadCalendarFeedConfig() (*CalendarFeedConfig, error) {
+	var c CalendarFeedConfig
+	err := envconfig.Process("", &c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load calendar feed config: %w", err)
+	}
+
+	return &c, nil
+}
@@ -2,16 +2,86 @@ package config
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/kelseyhightower/envconfig"
 )
 
+type DBDialect string
+
+const (
+	DBDialectMySQL  DBDialect = "mysql"
+	DBDialectSQLite DBDialect = "sqlite"
+)
+
+type DBReplicaPolicy string
+
+const (
+	DBReplicaPolicyRandom     DBReplicaPolicy = "random"
+	DBReplicaPolicyRoundRobin DBReplicaPolicy = "round_robin"
+)
+
+// DBShard is a shard of the todo data, parsed from name=host:port.
+type DBShard struct {
+	Name string
+	Addr string
+}
+
+func (s *DBShard) Decode(value string) error {
+	name, addr, ok := strings.Cut(value, "=")
+	if !ok || name == "" || addr == "" {
+		return fmt.Errorf("invalid shard %q, want name=host:port", value)
+	}
+	s.Name = name
+	s.Addr = addr
+	return nil
+}
+
 type DBConfig struct {
-	DBHost string `required:"true" split_words:"true"`
-	DBPort int    `required:"true" split_words:"true"`
-	DBUser string `required:"true" split_words:"true"`
-	DBPass string `required:"true" split_words:"true"`
-	DBName string `required:"true" split_words:"true"`
+	DBDialect DBDialect `default:"mysql" split_words:"true"`
+	// DBSQLitePath is the database file of the sqlite dialect, created with
+	// its schema when missing.
+	DBSQLitePath string `default:"todo.db" envconfig:"DB_SQLITE_PATH"`
+
+	// The connection settings are required by the mysql dialect only.
+	DBHost string `split_words:"true"`
+	DBPort int    `split_words:"true"`
+	DBUser string `split_words:"true"`
+	DBPass string `split_words:"true"`
+	DBName string `split_words:"true"`
+
+	DBMaxOpenConns    int           `default:"25" split_words:"true"`
+	DBMaxIdleConns    int           `default:"25" split_words:"true"`
+	DBConnMaxLifetime time.Duration `default:"5m" split_words:"true"`
+	DBConnMaxIdleTime time.Duration `default:"1m" split_words:"true"`
+	DBDialTimeout     time.Duration `default:"5s" split_words:"true"`
+	DBReadTimeout     time.Duration `default:"30s" split_words:"true"`
+	DBWriteTimeout    time.Duration `default:"30s" split_words:"true"`
+	// DBConnectTimeout bounds how long startup waits for the primary to
+	// accept connections.
+	DBConnectTimeout time.Duration `default:"30s" split_words:"true"`
+
+	// DBSlowQueryThreshold is the latency from which statements are logged,
+	// without their arguments. Zero turns the log off.
+	DBSlowQueryThreshold time.Duration `default:"200ms" split_words:"true"`
+
+	// DBReplicaHosts are the host:port addresses of the read replicas. They
+	// share the user, password and database name of the primary.
+	DBReplicaHosts         []string        `split_words:"true"`
+	DBReplicaPolicy        DBReplicaPolicy `default:"random" split_words:"true"`
+	DBReplicaCheckInterval time.Duration   `default:"5s" split_words:"true"`
+	DBReplicaPingTimeout   time.Duration   `default:"1s" split_words:"true"`
+
+	// DBShards are the shards besides the primary, which is the shard named
+	// "primary" and keeps the shard map. They share the user, password and
+	// database name of the primary and have no replicas.
+	DBShards []DBShard `split_words:"true"`
+	// DBShardMapRefreshInterval is how often the shard map is reloaded from
+	// the primary, so how long a moved user may still be routed to its former
+	// shard.
+	DBShardMapRefreshInterval time.Duration `default:"10s" split_words:"true"`
+	DBShardVirtualNodes       int           `default:"64" split_words:"true"`
 }
 
 func LoadDBConfig() (*DBConfig, error) {
@@ -21,5 +91,29 @@ func LoadDBConfig() (*DBConfig, error) {
 		return nil, fmt.Errorf("failed to load db config: %w", err)
 	}
 
+	switch c.DBDialect {
+	case DBDialectMySQL:
+		if c.DBHost == "" || c.DBPort == 0 || c.DBUser == "" || c.DBPass == "" || c.DBName == "" {
+			return nil, fmt.Errorf(
+				"failed to load db config: DB_HOST, DB_PORT, DB_USER, DB_PASS and DB_NAME are required by the %s dialect",
+				c.DBDialect,
+			)
+		}
+	case DBDialectSQLite:
+		if len(c.DBShards) > 0 {
+			return nil, fmt.Errorf("failed to load db config: DB_SHARDS is not supported by the %s dialect", c.DBDialect)
+		}
+	default:
+		return nil, fmt.Errorf("failed to load db config: unsupported DB_DIALECT %q", c.DBDialect)
+	}
+
+	names := map[string]struct{}{"primary": {}}
+	for _, shard := range c.DBShards {
+		if _, ok := names[shard.Name]; ok {
+			return nil, fmt.Errorf("failed to load db config: duplicated shard %q in DB_SHARDS", shard.Name)
+		}
+		names[shard.Name] = struct{}{}
+	}
+
 	return &c, nil
 }
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+type LeaderConfig struct {
+	// LeaderLeaseTTL is how long a job keeps running on a process that stopped
+	// renewing its lease, and how long the other processes wait before taking
+	// over from it.
+	LeaderLeaseTTL      time.Duration `default:"15s" envconfig:"LEADER_LEASE_TTL"`
+	LeaderRetryInterval time.Duration `default:"5s" split_words:"true"`
+	// LeaderHolder names this process in the leases; the host name and the
+	// process ID by default.
+	LeaderHolder string `split_words:"true"`
+}
+
+func LoadLeaderConfig() (*LeaderConfig, error) {
+	var c LeaderConfig
+	err := envconfig.Process("", &c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load leader config: %w", err)
+	}
+
+	if c.LeaderLeaseTTL <= 0 || c.LeaderRetryInterval <= 0 {
+		return nil, errors.New("failed to load leader config: LEADER_LEASE_TTL and LEADER_RETRY_INTERVAL must be positive")
+	}
+
+	return &c, nil
+}
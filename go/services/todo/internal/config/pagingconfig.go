@@ -0,0 +1,23 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+type PagingConfig struct {
+	// PageTokenSecret signs the page tokens returned to clients. Rotating it
+	// invalidates every issued token.
+	PageTokenSecret string `required:"true" split_words:"true"`
+}
+
+func LoadPagingConfig() (*PagingConfig, error) {
+	var c PagingConfig
+	err := envconfig.Process("", &c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load paging config: %w", err)
+	}
+
+	return &c, nil
+}
@@ -0,0 +1,23 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+type RebalanceConfig struct {
+	PositionRebalanceInterval  time.Duration `default:"10m" split_words:"true"`
+	PositionRebalanceBatchSize int           `default:"100" split_words:"true"`
+}
+
+func LoadRebalanceConfig() (*RebalanceConfig, error) {
+	var c RebalanceConfig
+	err := envconfig.Process("", &c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load rebalance config: %w", err)
+	}
+
+	return &c, nil
+}
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+type OutboxPublisherType string
+
+const (
+	OutboxPublisherMemory OutboxPublisherType = "memory"
+	OutboxPublisherNDJSON OutboxPublisherType = "ndjson"
+)
+
+type RelayConfig struct {
+	OutboxPublisher    OutboxPublisherType `default:"ndjson" split_words:"true"`
+	OutboxNDJSONPath   string              `default:"outbox.ndjson" envconfig:"OUTBOX_NDJSON_PATH"`
+	OutboxPollInterval time.Duration       `default:"1s" split_words:"true"`
+	OutboxBatchSize    int                 `default:"100" split_words:"true"`
+	OutboxBaseBackoff  time.Duration       `default:"1s" split_words:"true"`
+	OutboxMaxBackoff   time.Duration       `default:"5m" split_words:"true"`
+	OutboxMaxAttempts  int                 `default:"20" split_words:"true"`
+}
+
+func LoadRelayConfig() (*RelayConfig, error) {
+	var c RelayConfig
+	err := envconfig.Process("", &c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load relay config: %w", err)
+	}
+
+	return &c, nil
+}
@@ -0,0 +1,36 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+type WatchConfig struct {
+	WatchMaxStreamsPerUser int           `default:"5" split_words:"true"`
+	WatchBufferSize        int           `default:"64" split_words:"true"`
+	WatchHeartbeatInterval time.Duration `default:"15s" split_words:"true"`
+	// WatchReplayBatchSize is the number of events read at a time when a
+	// stream resumes from its last event.
+	WatchReplayBatchSize int `default:"100" split_words:"true"`
+	// WatchReplayOverlap is the number of event IDs before the last event of a
+	// stream replayed again when it resumes.
+	WatchReplayOverlap int `default:"100" split_words:"true"`
+	// WatchTailInterval is how often the outbox is read for the events of the
+	// streams of this process.
+	WatchTailInterval time.Duration `default:"500ms" split_words:"true"`
+	// WatchTailOverlap is the number of event IDs read again behind the last
+	// event seen, for the events committed out of order.
+	WatchTailOverlap int `default:"1000" split_words:"true"`
+}
+
+func LoadWatchConfig() (*WatchConfig, error) {
+	var c WatchConfig
+	err := envconfig.Process("", &c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load watch config: %w", err)
+	}
+
+	return &c, nil
+}
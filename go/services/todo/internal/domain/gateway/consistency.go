@@ -0,0 +1,16 @@
+package gateway
+
+import "context"
+
+type primaryReadsKey struct{}
+
+// WithPrimaryReads makes the queries gateways read from the primary database
+// instead of a replica, for reads that must not miss a recent write.
+func WithPrimaryReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryReadsKey{}, true)
+}
+
+func PrimaryReadsRequired(ctx context.Context) bool {
+	required, _ := ctx.Value(primaryReadsKey{}).(bool)
+	return required
+}
@@ -2,6 +2,8 @@ package gateway
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
 )
@@ -10,21 +12,165 @@ type Binder interface {
 	Bind(context.Context) context.Context
 }
 
+// ShardBinder binds each shard of the database in turn, for the jobs that go
+// through the rows of every user, such as the outbox relay.
+type ShardBinder interface {
+	Binder
+	// Shards returns the names of the shards, a single one when the database
+	// is not sharded.
+	Shards() []string
+	// BindShard binds the database of the shard. The rows kept on the primary
+	// only, such as the leases, are still read there.
+	BindShard(ctx context.Context, shard string) context.Context
+}
+
 type TodoQueriesGateway interface {
 	GetTodo(ctx context.Context, todoID todo.TodoID, userID todo.UserID) (*todo.Todo, error)
-	ListTodos(ctx context.Context, userID todo.UserID) ([]*todo.Todo, int, error)
+	ListTodos(ctx context.Context, userID todo.UserID, sortingType todo.SortingType) ([]*todo.Todo, int, error)
+	ListTodosByExternalIDs(ctx context.Context, userID todo.UserID, externalIDs []string) ([]*todo.Todo, error)
+	ListTodosByIDs(ctx context.Context, userID todo.UserID, todoIDs []todo.TodoID) ([]*todo.Todo, error)
+}
+
+type TodoStatsQueriesGateway interface {
+	CountTodosByStatus(ctx context.Context, userID todo.UserID, from, to time.Time) (map[todo.TodoStatus]int, error)
+	GetAverageCompletionTime(ctx context.Context, userID todo.UserID, from, to time.Time) (*time.Duration, error)
+	// ListTodoActivity returns one page of the activity series, the one after or
+	// before param.Paging.Token.
+	ListTodoActivity(ctx context.Context, param todo.TodoStatsParam) (*todo.Page[*todo.TodoActivityBucket], error)
 }
 
 type TodoCommandsGateway interface {
 	CreateTodo(ctx context.Context, newTodo todo.NewTodo) (*todo.Todo, error)
 	UpdateTodo(ctx context.Context, todoID todo.TodoID, userID todo.UserID, updateTodo todo.UpdateTodo) (*todo.Todo, error)
 	SoftDeleteTodo(ctx context.Context, todoID todo.TodoID, userID todo.UserID) error
+	MoveTodo(ctx context.Context, todoID todo.TodoID, userID todo.UserID, move todo.MoveTodo) (*todo.Todo, error)
+}
+
+type TodoPositionQueriesGateway interface {
+	// ListUserIDsWithLongPositions returns the users with a todo whose position
+	// is at least length characters long.
+	ListUserIDsWithLongPositions(ctx context.Context, length int, limit int) ([]todo.UserID, error)
+}
+
+type TodoPositionCommandsGateway interface {
+	RebalancePositions(ctx context.Context, userID todo.UserID) error
 }
 
 type UserQueriesGateway interface {
 	GetUser(ctx context.Context, userID int64) (*todo.User, error)
 }
 
+type UserWithTodosQueriesGateway interface {
+	// GetUserWithTodos returns the user with the page of their todos after or
+	// before paging.Token, or nil when the user does not exist.
+	GetUserWithTodos(ctx context.Context, userID todo.UserID, paging todo.CursorPagingParam) (*todo.UserWithTodos, error)
+}
+
 type UserCommandsGateway interface {
 	CreateUser(ctx context.Context, newUser todo.NewUser) (*todo.User, error)
+	UpdateUser(ctx context.Context, userID todo.UserID, updateUser todo.UpdateUser) (*todo.User, error)
+	SoftDeleteUser(ctx context.Context, userID todo.UserID) error
+}
+
+type OutboxQueriesGateway interface {
+	// ListPendingEvents returns the events due at now that are neither
+	// published nor dead-lettered, in the order they were recorded, leaving
+	// out those recorded after an event of the same aggregate still waiting
+	// for its next attempt.
+	ListPendingEvents(ctx context.Context, now time.Time, limit int) ([]*todo.Event, error)
+	ListUserEventsAfter(
+		ctx context.Context,
+		userID todo.UserID,
+		aggregateType todo.AggregateType,
+		afterID todo.EventID,
+		limit int,
+	) ([]*todo.Event, error)
+	// ListEventsAfter returns the events of every user recorded after
+	// afterID, whether they have been published yet or not.
+	ListEventsAfter(ctx context.Context, afterID todo.EventID, limit int) ([]*todo.Event, error)
+	// GetLastEventID returns the ID of the last event recorded, 0 when there
+	// is none.
+	GetLastEventID(ctx context.Context) (todo.EventID, error)
+}
+
+// OutboxCommandsGateway writes are fenced by the lease of the relay: they
+// fail with todo.ErrLeaseLost, writing nothing, once the lease was acquired
+// again.
+type OutboxCommandsGateway interface {
+	MarkEventPublished(ctx context.Context, lease *todo.Lease, eventID todo.EventID) error
+	MarkEventFailed(ctx context.Context, lease *todo.Lease, eventID todo.EventID, nextAttemptAt time.Time, cause error) error
+	// MarkEventDeadLettered records the last failed attempt of an event the
+	// relay gives up on. The event no longer holds back its aggregate.
+	MarkEventDeadLettered(ctx context.Context, lease *todo.Lease, eventID todo.EventID, cause error) error
+}
+
+// LeaseCommandsGateway keeps the leases of the jobs that must not run on
+// several processes at once.
+type LeaseCommandsGateway interface {
+	// AcquireLease returns nil when the lease is held by another holder.
+	AcquireLease(ctx context.Context, name, holder string, ttl time.Duration) (*todo.Lease, error)
+	// RenewLease fails with todo.ErrLeaseLost once the lease expired or was
+	// acquired again.
+	RenewLease(ctx context.Context, lease *todo.Lease, ttl time.Duration) (*todo.Lease, error)
+	// ReleaseLease lets another holder acquire the lease right away. Releasing a
+	// lost lease does nothing.
+	ReleaseLease(ctx context.Context, lease *todo.Lease) error
+}
+
+type Publisher interface {
+	Publish(ctx context.Context, event *todo.Event) error
+}
+
+// UserDataSink receives the data of a user as it is read: the user first,
+// then their todos and then their events, both in pages.
+type UserDataSink interface {
+	WriteUser(user *todo.User) error
+	WriteTodos(todos []*todo.Todo) error
+	WriteEvents(events []*todo.Event) error
+}
+
+type UserDataQueriesGateway interface {
+	// ReadUserData writes everything kept about the user, deleted or not, to
+	// sink in pages of up to pageSize rows. It returns false without writing
+	// anything when the user does not exist.
+	ReadUserData(ctx context.Context, userID todo.UserID, pageSize int, sink UserDataSink) (bool, error)
+}
+
+type UserErasureCommandsGateway interface {
+	// EraseUser deletes the user, deleted or not, with their todos and events,
+	// records a UserErased event and a tombstone that keeps the ID from being
+	// created again. It returns nil when the user does not exist.
+	EraseUser(ctx context.Context, userID todo.UserID) (*todo.ErasedUser, error)
+}
+
+type TodoEncoder interface {
+	Encode(w io.Writer, todos []*todo.Todo) error
+}
+
+// TodoDecoder reads todos from an imported file. Rows that cannot be parsed are
+// reported as row errors; the returned error is only for unreadable files.
+type TodoDecoder interface {
+	Decode(r io.Reader) ([]*todo.ImportRow, []todo.ImportRowError, error)
+}
+
+type TodoCodec interface {
+	TodoEncoder
+	TodoDecoder
+}
+
+// UserDataEncoder writes the data of a user as a bundle they can keep.
+type UserDataEncoder interface {
+	// NewWriter returns a writer encoding the data to w as it is written.
+	NewWriter(w io.Writer) UserDataWriter
+}
+
+// UserDataWriter is a UserDataSink whose Close ends the bundle. Close writes
+// nothing when no user was written.
+type UserDataWriter interface {
+	UserDataSink
+	Close() error
+}
+
+type DBStatusQueriesGateway interface {
+	ListDBPoolStatuses(ctx context.Context) ([]*todo.DBPoolStatus, error)
 }
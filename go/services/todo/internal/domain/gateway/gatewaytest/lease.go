@@ -0,0 +1,124 @@
+package gatewaytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+// LeaseGateways are the lease gateways under test.
+type LeaseGateways struct {
+	Binder gateway.Binder
+	Writer gateway.LeaseCommandsGateway
+}
+
+// RunLeaseGatewaysSuite runs every test case against new gateways, created by
+// newGateways for each of them.
+func RunLeaseGatewaysSuite(t *testing.T, newGateways func(t *testing.T) LeaseGateways) {
+	t.Helper()
+
+	type testcase struct {
+		run func(t *testing.T, ctx context.Context, g LeaseGateways)
+	}
+
+	const ttl = time.Minute
+
+	acquire := func(t *testing.T, ctx context.Context, g LeaseGateways, holder string) *todo.Lease {
+		t.Helper()
+
+		lease, err := g.Writer.AcquireLease(ctx, "job", holder, ttl)
+		if err != nil {
+			t.Fatalf("AcquireLease(%q) error = %v", holder, err)
+		}
+		return lease
+	}
+
+	testTables := map[string]testcase{
+		"Acquire a free lease": {
+			run: func(t *testing.T, ctx context.Context, g LeaseGateways) {
+				lease := acquire(t, ctx, g, "a")
+				if lease == nil || lease.Name != "job" || lease.Holder != "a" || lease.Token <= 0 {
+					t.Fatalf("AcquireLease() = %+v, want the lease of a", lease)
+				}
+				if !lease.ExpiresAt.After(time.Now()) {
+					t.Errorf("AcquireLease() expires at %v, want a time to come", lease.ExpiresAt)
+				}
+			},
+		},
+		"Acquire a held lease returns nil": {
+			run: func(t *testing.T, ctx context.Context, g LeaseGateways) {
+				acquire(t, ctx, g, "a")
+				if lease := acquire(t, ctx, g, "b"); lease != nil {
+					t.Errorf("AcquireLease() = %+v, want nil", lease)
+				}
+			},
+		},
+		"Acquire a released lease increases the token": {
+			run: func(t *testing.T, ctx context.Context, g LeaseGateways) {
+				first := acquire(t, ctx, g, "a")
+				if err := g.Writer.ReleaseLease(ctx, first); err != nil {
+					t.Fatalf("ReleaseLease() error = %v", err)
+				}
+
+				second := acquire(t, ctx, g, "b")
+				if second == nil || second.Holder != "b" || second.Token <= first.Token {
+					t.Errorf("AcquireLease() = %+v, want the lease of b with a token greater than %d", second, first.Token)
+				}
+			},
+		},
+		"Renew a lease keeps its token": {
+			run: func(t *testing.T, ctx context.Context, g LeaseGateways) {
+				lease := acquire(t, ctx, g, "a")
+
+				renewed, err := g.Writer.RenewLease(ctx, lease, 2*ttl)
+				if err != nil {
+					t.Fatalf("RenewLease() error = %v", err)
+				}
+				if renewed.Token != lease.Token || !renewed.ExpiresAt.After(lease.ExpiresAt) {
+					t.Errorf("RenewLease() = %+v, want token %d expiring after %v", renewed, lease.Token, lease.ExpiresAt)
+				}
+			},
+		},
+		"Renew a lease acquired by another holder fails": {
+			run: func(t *testing.T, ctx context.Context, g LeaseGateways) {
+				lost := acquire(t, ctx, g, "a")
+				if err := g.Writer.ReleaseLease(ctx, lost); err != nil {
+					t.Fatalf("ReleaseLease() error = %v", err)
+				}
+				acquire(t, ctx, g, "b")
+
+				if _, err := g.Writer.RenewLease(ctx, lost, ttl); !errors.Is(err, todo.ErrLeaseLost) {
+					t.Errorf("RenewLease() error = %v, want ErrLeaseLost", err)
+				}
+			},
+		},
+		"Release a lost lease keeps the new holder": {
+			run: func(t *testing.T, ctx context.Context, g LeaseGateways) {
+				lost := acquire(t, ctx, g, "a")
+				if err := g.Writer.ReleaseLease(ctx, lost); err != nil {
+					t.Fatalf("ReleaseLease() error = %v", err)
+				}
+				acquire(t, ctx, g, "b")
+
+				if err := g.Writer.ReleaseLease(ctx, lost); err != nil {
+					t.Fatalf("ReleaseLease() error = %v", err)
+				}
+				if lease := acquire(t, ctx, g, "c"); lease != nil {
+					t.Errorf("AcquireLease() = %+v, want nil while b holds the lease", lease)
+				}
+			},
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			g := newGateways(t)
+			tt.run(t, g.Binder.Bind(context.Background()), g)
+		})
+	}
+}
@@ -0,0 +1,386 @@
+// Package gatewaytest holds conformance suites that every implementation of
+// the gateways has to pass, so that fakes used by use-case tests behave like
+// the datastore.
+package gatewaytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/phamquanandpad/training-project/go/pkg/cast"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+// TodoGateways are the todo gateways under test, with two users that exist and
+// have no todos yet.
+type TodoGateways struct {
+	Binder gateway.Binder
+	Reader gateway.TodoQueriesGateway
+	Writer gateway.TodoCommandsGateway
+
+	UserID      todo.UserID
+	OtherUserID todo.UserID
+}
+
+// ignoreTimes leaves out the times set by the implementation.
+var ignoreTimes = cmpopts.IgnoreFields(todo.Todo{}, "CompletedAt", "CreatedAt", "UpdatedAt", "DeletedAt")
+
+// RunTodoGatewaysSuite runs every test case against new gateways, created by
+// newGateways for each of them.
+func RunTodoGatewaysSuite(t *testing.T, newGateways func(t *testing.T) TodoGateways) {
+	t.Helper()
+
+	type testcase struct {
+		run func(t *testing.T, ctx context.Context, g TodoGateways)
+	}
+
+	testTables := map[string]testcase{
+		"Create Todo then get it": {
+			run: func(t *testing.T, ctx context.Context, g TodoGateways) {
+				created := mustCreateTodo(t, ctx, g, todo.NewTodo{
+					UserID:      g.UserID,
+					ExternalID:  cast.Ptr("ext-1"),
+					Task:        "task 1",
+					Description: cast.Ptr("description 1"),
+					Status:      todo.InProcess,
+				})
+
+				expected := &todo.Todo{
+					ID:          created.ID,
+					UserID:      g.UserID,
+					ExternalID:  cast.Ptr("ext-1"),
+					Task:        "task 1",
+					Description: cast.Ptr("description 1"),
+					Status:      todo.InProcess,
+					Position:    created.Position,
+				}
+				if created.Position == "" {
+					t.Errorf("CreateTodo() position is empty")
+				}
+				if diff := cmp.Diff(created, expected, ignoreTimes); diff != "" {
+					t.Errorf("CreateTodo() mismatch (-actual +expected):\n%s", diff)
+				}
+
+				got, err := g.Reader.GetTodo(ctx, created.ID, g.UserID)
+				if err != nil {
+					t.Fatalf("GetTodo() error = %v", err)
+				}
+				if diff := cmp.Diff(got, expected, ignoreTimes); diff != "" {
+					t.Errorf("GetTodo() mismatch (-actual +expected):\n%s", diff)
+				}
+			},
+		},
+		"Create Todo as done sets its completion time": {
+			run: func(t *testing.T, ctx context.Context, g TodoGateways) {
+				created := mustCreateTodo(t, ctx, g, todo.NewTodo{UserID: g.UserID, Task: "task", Status: todo.Done})
+				if created.CompletedAt == nil {
+					t.Errorf("CreateTodo() CompletedAt = nil, want it set")
+				}
+			},
+		},
+		"Create Todo with a used external ID returns todo.ErrDuplicateKey": {
+			run: func(t *testing.T, ctx context.Context, g TodoGateways) {
+				mustCreateTodo(t, ctx, g, todo.NewTodo{UserID: g.UserID, ExternalID: cast.Ptr("ext-1"), Task: "task 1"})
+
+				_, err := g.Writer.CreateTodo(ctx, todo.NewTodo{UserID: g.UserID, ExternalID: cast.Ptr("ext-1"), Task: "task 2"})
+				if !errors.Is(err, todo.ErrDuplicateKey) {
+					t.Errorf("CreateTodo() error = %v, want %v", err, todo.ErrDuplicateKey)
+				}
+
+				// External IDs are unique per user.
+				mustCreateTodo(t, ctx, g, todo.NewTodo{UserID: g.OtherUserID, ExternalID: cast.Ptr("ext-1"), Task: "task 3"})
+			},
+		},
+		"Get Todo returns nil when not found or of another user": {
+			run: func(t *testing.T, ctx context.Context, g TodoGateways) {
+				created := mustCreateTodo(t, ctx, g, todo.NewTodo{UserID: g.UserID, Task: "task"})
+
+				for name, args := range map[string]struct {
+					todoID todo.TodoID
+					userID todo.UserID
+				}{
+					"unknown todo": {todoID: created.ID + 1000, userID: g.UserID},
+					"another user": {todoID: created.ID, userID: g.OtherUserID},
+				} {
+					got, err := g.Reader.GetTodo(ctx, args.todoID, args.userID)
+					if err != nil || got != nil {
+						t.Errorf("GetTodo() of %s = %v, %v, want nil, nil", name, got, err)
+					}
+				}
+			},
+		},
+		"List Todos of the user in each order": {
+			run: func(t *testing.T, ctx context.Context, g TodoGateways) {
+				first := mustCreateTodo(t, ctx, g, todo.NewTodo{UserID: g.UserID, Task: "task 1"})
+				second := mustCreateTodo(t, ctx, g, todo.NewTodo{UserID: g.UserID, Task: "task 2"})
+				mustCreateTodo(t, ctx, g, todo.NewTodo{UserID: g.OtherUserID, Task: "task 3"})
+
+				for sortingType, expected := range map[todo.SortingType][]todo.TodoID{
+					todo.SortingTypes.ID:       {first.ID, second.ID},
+					todo.SortingTypes.Position: {first.ID, second.ID},
+				} {
+					todos, total, err := g.Reader.ListTodos(ctx, g.UserID, sortingType)
+					if err != nil {
+						t.Fatalf("ListTodos() error = %v", err)
+					}
+					if total != len(expected) {
+						t.Errorf("ListTodos() total = %d, want %d", total, len(expected))
+					}
+					if diff := cmp.Diff(todoIDs(todos), expected); diff != "" {
+						t.Errorf("ListTodos(%s) mismatch (-actual +expected):\n%s", sortingType, diff)
+					}
+				}
+			},
+		},
+		"Update Todo": {
+			run: func(t *testing.T, ctx context.Context, g TodoGateways) {
+				created := mustCreateTodo(t, ctx, g, todo.NewTodo{UserID: g.UserID, Task: "task", Description: cast.Ptr("description")})
+
+				updated, err := g.Writer.UpdateTodo(ctx, created.ID, g.UserID, todo.UpdateTodo{
+					Task:   cast.Ptr("updated task"),
+					Status: cast.Ptr(todo.Done),
+				})
+				if err != nil {
+					t.Fatalf("UpdateTodo() error = %v", err)
+				}
+
+				expected := &todo.Todo{
+					ID:          created.ID,
+					UserID:      g.UserID,
+					Task:        "updated task",
+					Description: cast.Ptr("description"),
+					Status:      todo.Done,
+					Position:    created.Position,
+				}
+				if diff := cmp.Diff(updated, expected, ignoreTimes); diff != "" {
+					t.Errorf("UpdateTodo() mismatch (-actual +expected):\n%s", diff)
+				}
+				if updated.CompletedAt == nil {
+					t.Errorf("UpdateTodo() CompletedAt = nil, want it set")
+				}
+
+				got, err := g.Reader.GetTodo(ctx, created.ID, g.UserID)
+				if err != nil {
+					t.Fatalf("GetTodo() error = %v", err)
+				}
+				if diff := cmp.Diff(got, expected, ignoreTimes); diff != "" {
+					t.Errorf("GetTodo() after update mismatch (-actual +expected):\n%s", diff)
+				}
+			},
+		},
+		"Update Todo returns nil when not found or of another user": {
+			run: func(t *testing.T, ctx context.Context, g TodoGateways) {
+				created := mustCreateTodo(t, ctx, g, todo.NewTodo{UserID: g.UserID, Task: "task"})
+
+				updated, err := g.Writer.UpdateTodo(ctx, created.ID, g.OtherUserID, todo.UpdateTodo{Task: cast.Ptr("updated task")})
+				if err != nil || updated != nil {
+					t.Errorf("UpdateTodo() of another user = %v, %v, want nil, nil", updated, err)
+				}
+
+				updated, err = g.Writer.UpdateTodo(ctx, created.ID+1000, g.UserID, todo.UpdateTodo{Task: cast.Ptr("updated task")})
+				if err != nil || updated != nil {
+					t.Errorf("UpdateTodo() of unknown todo = %v, %v, want nil, nil", updated, err)
+				}
+
+				got, err := g.Reader.GetTodo(ctx, created.ID, g.UserID)
+				if err != nil {
+					t.Fatalf("GetTodo() error = %v", err)
+				}
+				if got.Task != "task" {
+					t.Errorf("GetTodo() task = %q, want it unchanged", got.Task)
+				}
+			},
+		},
+		"Soft delete Todo hides it but keeps its external ID": {
+			run: func(t *testing.T, ctx context.Context, g TodoGateways) {
+				created := mustCreateTodo(t, ctx, g, todo.NewTodo{UserID: g.UserID, ExternalID: cast.Ptr("ext-1"), Task: "task"})
+
+				if err := g.Writer.SoftDeleteTodo(ctx, created.ID, g.UserID); err != nil {
+					t.Fatalf("SoftDeleteTodo() error = %v", err)
+				}
+
+				got, err := g.Reader.GetTodo(ctx, created.ID, g.UserID)
+				if err != nil || got != nil {
+					t.Errorf("GetTodo() of deleted todo = %v, %v, want nil, nil", got, err)
+				}
+
+				todos, total, err := g.Reader.ListTodos(ctx, g.UserID, todo.SortingTypes.ID)
+				if err != nil || total != 0 || len(todos) != 0 {
+					t.Errorf("ListTodos() after delete = %d todos, %v, want none", total, err)
+				}
+
+				updated, err := g.Writer.UpdateTodo(ctx, created.ID, g.UserID, todo.UpdateTodo{Task: cast.Ptr("updated task")})
+				if err != nil || updated != nil {
+					t.Errorf("UpdateTodo() of deleted todo = %v, %v, want nil, nil", updated, err)
+				}
+
+				byExternalID, err := g.Reader.ListTodosByExternalIDs(ctx, g.UserID, []string{"ext-1"})
+				if err != nil {
+					t.Fatalf("ListTodosByExternalIDs() error = %v", err)
+				}
+				if len(byExternalID) != 1 || !byExternalID[0].IsDeleted() {
+					t.Errorf("ListTodosByExternalIDs() = %v, want the deleted todo", byExternalID)
+				}
+			},
+		},
+		"Soft delete Todo does nothing when not found or of another user": {
+			run: func(t *testing.T, ctx context.Context, g TodoGateways) {
+				created := mustCreateTodo(t, ctx, g, todo.NewTodo{UserID: g.UserID, Task: "task"})
+
+				if err := g.Writer.SoftDeleteTodo(ctx, created.ID, g.OtherUserID); err != nil {
+					t.Errorf("SoftDeleteTodo() of another user error = %v", err)
+				}
+				if err := g.Writer.SoftDeleteTodo(ctx, created.ID+1000, g.UserID); err != nil {
+					t.Errorf("SoftDeleteTodo() of unknown todo error = %v", err)
+				}
+
+				got, err := g.Reader.GetTodo(ctx, created.ID, g.UserID)
+				if err != nil || got == nil {
+					t.Errorf("GetTodo() = %v, %v, want the todo kept", got, err)
+				}
+			},
+		},
+		"List Todos by external IDs of the user": {
+			run: func(t *testing.T, ctx context.Context, g TodoGateways) {
+				first := mustCreateTodo(t, ctx, g, todo.NewTodo{UserID: g.UserID, ExternalID: cast.Ptr("ext-1"), Task: "task 1"})
+				second := mustCreateTodo(t, ctx, g, todo.NewTodo{UserID: g.UserID, ExternalID: cast.Ptr("ext-2"), Task: "task 2"})
+				mustCreateTodo(t, ctx, g, todo.NewTodo{UserID: g.UserID, Task: "task 3"})
+				mustCreateTodo(t, ctx, g, todo.NewTodo{UserID: g.OtherUserID, ExternalID: cast.Ptr("ext-1"), Task: "task 4"})
+
+				todos, err := g.Reader.ListTodosByExternalIDs(ctx, g.UserID, []string{"ext-2", "ext-1", "ext-9"})
+				if err != nil {
+					t.Fatalf("ListTodosByExternalIDs() error = %v", err)
+				}
+				if diff := cmp.Diff(todoIDs(todos), []todo.TodoID{first.ID, second.ID}); diff != "" {
+					t.Errorf("ListTodosByExternalIDs() mismatch (-actual +expected):\n%s", diff)
+				}
+
+				todos, err = g.Reader.ListTodosByExternalIDs(ctx, g.UserID, nil)
+				if err != nil || len(todos) != 0 {
+					t.Errorf("ListTodosByExternalIDs() of no IDs = %v, %v, want none", todos, err)
+				}
+			},
+		},
+		"List Todos by IDs of the user": {
+			run: func(t *testing.T, ctx context.Context, g TodoGateways) {
+				first := mustCreateTodo(t, ctx, g, todo.NewTodo{UserID: g.UserID, Task: "task 1"})
+				second := mustCreateTodo(t, ctx, g, todo.NewTodo{UserID: g.UserID, Task: "task 2"})
+				mustCreateTodo(t, ctx, g, todo.NewTodo{UserID: g.UserID, Task: "task 3"})
+				other := mustCreateTodo(t, ctx, g, todo.NewTodo{UserID: g.OtherUserID, Task: "task 4"})
+
+				if err := g.Writer.SoftDeleteTodo(ctx, second.ID, g.UserID); err != nil {
+					t.Fatalf("SoftDeleteTodo() error = %v", err)
+				}
+
+				todos, err := g.Reader.ListTodosByIDs(ctx, g.UserID, []todo.TodoID{second.ID, first.ID, other.ID, first.ID})
+				if err != nil {
+					t.Fatalf("ListTodosByIDs() error = %v", err)
+				}
+				if diff := cmp.Diff(todoIDs(todos), []todo.TodoID{first.ID, second.ID}); diff != "" {
+					t.Errorf("ListTodosByIDs() mismatch (-actual +expected):\n%s", diff)
+				}
+
+				todos, err = g.Reader.ListTodosByIDs(ctx, g.UserID, nil)
+				if err != nil || len(todos) != 0 {
+					t.Errorf("ListTodosByIDs() of no IDs = %v, %v, want none", todos, err)
+				}
+			},
+		},
+		"Move Todo before and after another one": {
+			run: func(t *testing.T, ctx context.Context, g TodoGateways) {
+				first := mustCreateTodo(t, ctx, g, todo.NewTodo{UserID: g.UserID, Task: "task 1"})
+				second := mustCreateTodo(t, ctx, g, todo.NewTodo{UserID: g.UserID, Task: "task 2"})
+				third := mustCreateTodo(t, ctx, g, todo.NewTodo{UserID: g.UserID, Task: "task 3"})
+
+				for _, step := range []struct {
+					todoID   todo.TodoID
+					move     todo.MoveTodo
+					expected []todo.TodoID
+				}{
+					{
+						todoID:   third.ID,
+						move:     todo.MoveTodo{BeforeID: cast.Ptr(first.ID)},
+						expected: []todo.TodoID{third.ID, first.ID, second.ID},
+					},
+					{
+						todoID:   third.ID,
+						move:     todo.MoveTodo{AfterID: cast.Ptr(first.ID)},
+						expected: []todo.TodoID{first.ID, third.ID, second.ID},
+					},
+					{
+						todoID:   first.ID,
+						move:     todo.MoveTodo{AfterID: cast.Ptr(second.ID)},
+						expected: []todo.TodoID{third.ID, second.ID, first.ID},
+					},
+				} {
+					moved, err := g.Writer.MoveTodo(ctx, step.todoID, g.UserID, step.move)
+					if err != nil {
+						t.Fatalf("MoveTodo() error = %v", err)
+					}
+					if moved == nil || moved.ID != step.todoID {
+						t.Fatalf("MoveTodo() = %v, want todo %d", moved, step.todoID)
+					}
+
+					todos, _, err := g.Reader.ListTodos(ctx, g.UserID, todo.SortingTypes.Position)
+					if err != nil {
+						t.Fatalf("ListTodos() error = %v", err)
+					}
+					if diff := cmp.Diff(todoIDs(todos), step.expected); diff != "" {
+						t.Errorf("ListTodos() after MoveTodo() mismatch (-actual +expected):\n%s", diff)
+					}
+				}
+			},
+		},
+		"Move Todo returns nil when either todo is not found or of another user": {
+			run: func(t *testing.T, ctx context.Context, g TodoGateways) {
+				created := mustCreateTodo(t, ctx, g, todo.NewTodo{UserID: g.UserID, Task: "task 1"})
+				other := mustCreateTodo(t, ctx, g, todo.NewTodo{UserID: g.OtherUserID, Task: "task 2"})
+
+				for name, args := range map[string]struct {
+					todoID todo.TodoID
+					move   todo.MoveTodo
+				}{
+					"unknown todo":           {todoID: created.ID + 1000, move: todo.MoveTodo{BeforeID: cast.Ptr(created.ID)}},
+					"unknown target":         {todoID: created.ID, move: todo.MoveTodo{BeforeID: cast.Ptr(created.ID + 1000)}},
+					"target of another user": {todoID: created.ID, move: todo.MoveTodo{AfterID: cast.Ptr(other.ID)}},
+				} {
+					moved, err := g.Writer.MoveTodo(ctx, args.todoID, g.UserID, args.move)
+					if err != nil || moved != nil {
+						t.Errorf("MoveTodo() of %s = %v, %v, want nil, nil", name, moved, err)
+					}
+				}
+			},
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			g := newGateways(t)
+			tt.run(t, g.Binder.Bind(context.Background()), g)
+		})
+	}
+}
+
+func mustCreateTodo(t *testing.T, ctx context.Context, g TodoGateways, newTodo todo.NewTodo) *todo.Todo {
+	t.Helper()
+
+	created, err := g.Writer.CreateTodo(ctx, newTodo)
+	if err != nil {
+		t.Fatalf("CreateTodo() error = %v", err)
+	}
+	return created
+}
+
+func todoIDs(todos []*todo.Todo) []todo.TodoID {
+	ids := make([]todo.TodoID, 0, len(todos))
+	for _, t := range todos {
+		ids = append(ids, t.ID)
+	}
+	return ids
+}
@@ -0,0 +1,284 @@
+package gatewaytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/phamquanandpad/training-project/go/pkg/cast"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+// UserGateways are the user gateways under test. UnusedUserID and the next ID
+// are not used by any user yet.
+type UserGateways struct {
+	Binder     gateway.Binder
+	Reader     gateway.UserQueriesGateway
+	Writer     gateway.UserCommandsGateway
+	DataReader gateway.UserDataQueriesGateway
+	Eraser     gateway.UserErasureCommandsGateway
+
+	UnusedUserID todo.UserID
+}
+
+// RunUserGatewaysSuite runs every test case against new gateways, created by
+// newGateways for each of them.
+func RunUserGatewaysSuite(t *testing.T, newGateways func(t *testing.T) UserGateways) {
+	t.Helper()
+
+	type testcase struct {
+		run func(t *testing.T, ctx context.Context, g UserGateways)
+	}
+
+	ignoreTimes := cmpopts.IgnoreFields(todo.User{}, "CreatedAt", "UpdatedAt", "DeletedAt")
+
+	testTables := map[string]testcase{
+		"Create User then get it": {
+			run: func(t *testing.T, ctx context.Context, g UserGateways) {
+				created, err := g.Writer.CreateUser(ctx, todo.NewUser{
+					ID:       g.UnusedUserID,
+					Username: "new user",
+					Email:    cast.Ptr("new.user@example.com"),
+				})
+				if err != nil {
+					t.Fatalf("CreateUser() error = %v", err)
+				}
+
+				expected := &todo.User{
+					ID:       g.UnusedUserID,
+					Username: "new user",
+					Email:    cast.Ptr("new.user@example.com"),
+				}
+				if diff := cmp.Diff(created, expected, ignoreTimes); diff != "" {
+					t.Errorf("CreateUser() mismatch (-actual +expected):\n%s", diff)
+				}
+
+				got, err := g.Reader.GetUser(ctx, int64(g.UnusedUserID))
+				if err != nil {
+					t.Fatalf("GetUser() error = %v", err)
+				}
+				if diff := cmp.Diff(got, expected, ignoreTimes); diff != "" {
+					t.Errorf("GetUser() mismatch (-actual +expected):\n%s", diff)
+				}
+			},
+		},
+		"Create User with a used ID or email returns todo.ErrDuplicateKey": {
+			run: func(t *testing.T, ctx context.Context, g UserGateways) {
+				_, err := g.Writer.CreateUser(ctx, todo.NewUser{
+					ID:       g.UnusedUserID,
+					Username: "new user",
+					Email:    cast.Ptr("new.user@example.com"),
+				})
+				if err != nil {
+					t.Fatalf("CreateUser() error = %v", err)
+				}
+
+				for name, newUser := range map[string]todo.NewUser{
+					"used ID":    {ID: g.UnusedUserID, Username: "other user"},
+					"used email": {ID: g.UnusedUserID + 1, Username: "other user", Email: cast.Ptr("new.user@example.com")},
+				} {
+					_, err := g.Writer.CreateUser(ctx, newUser)
+					if !errors.Is(err, todo.ErrDuplicateKey) {
+						t.Errorf("CreateUser() with %s error = %v, want %v", name, err, todo.ErrDuplicateKey)
+					}
+				}
+			},
+		},
+		"Update User changes the fields that are set": {
+			run: func(t *testing.T, ctx context.Context, g UserGateways) {
+				_, err := g.Writer.CreateUser(ctx, todo.NewUser{
+					ID:       g.UnusedUserID,
+					Username: "new user",
+					Email:    cast.Ptr("new.user@example.com"),
+				})
+				if err != nil {
+					t.Fatalf("CreateUser() error = %v", err)
+				}
+
+				updated, err := g.Writer.UpdateUser(ctx, g.UnusedUserID, todo.UpdateUser{
+					Email: cast.Ptr("renamed.user@example.com"),
+				})
+				if err != nil {
+					t.Fatalf("UpdateUser() error = %v", err)
+				}
+
+				expected := &todo.User{
+					ID:       g.UnusedUserID,
+					Username: "new user",
+					Email:    cast.Ptr("renamed.user@example.com"),
+				}
+				if diff := cmp.Diff(updated, expected, ignoreTimes); diff != "" {
+					t.Errorf("UpdateUser() mismatch (-actual +expected):\n%s", diff)
+				}
+
+				got, err := g.Reader.GetUser(ctx, int64(g.UnusedUserID))
+				if err != nil {
+					t.Fatalf("GetUser() error = %v", err)
+				}
+				if diff := cmp.Diff(got, expected, ignoreTimes); diff != "" {
+					t.Errorf("GetUser() mismatch (-actual +expected):\n%s", diff)
+				}
+			},
+		},
+		"Update User with the email of another user returns todo.ErrDuplicateKey": {
+			run: func(t *testing.T, ctx context.Context, g UserGateways) {
+				for i, email := range []string{"first.user@example.com", "second.user@example.com"} {
+					_, err := g.Writer.CreateUser(ctx, todo.NewUser{
+						ID:       g.UnusedUserID + todo.UserID(i),
+						Username: "new user",
+						Email:    cast.Ptr(email),
+					})
+					if err != nil {
+						t.Fatalf("CreateUser() error = %v", err)
+					}
+				}
+
+				_, err := g.Writer.UpdateUser(ctx, g.UnusedUserID+1, todo.UpdateUser{
+					Email: cast.Ptr("first.user@example.com"),
+				})
+				if !errors.Is(err, todo.ErrDuplicateKey) {
+					t.Errorf("UpdateUser() error = %v, want %v", err, todo.ErrDuplicateKey)
+				}
+			},
+		},
+		"Update User returns nil when not found": {
+			run: func(t *testing.T, ctx context.Context, g UserGateways) {
+				got, err := g.Writer.UpdateUser(ctx, g.UnusedUserID, todo.UpdateUser{Username: cast.Ptr("renamed")})
+				if err != nil || got != nil {
+					t.Errorf("UpdateUser() = %v, %v, want nil, nil", got, err)
+				}
+			},
+		},
+		"Soft delete User hides it but keeps its email used": {
+			run: func(t *testing.T, ctx context.Context, g UserGateways) {
+				_, err := g.Writer.CreateUser(ctx, todo.NewUser{
+					ID:       g.UnusedUserID,
+					Username: "new user",
+					Email:    cast.Ptr("new.user@example.com"),
+				})
+				if err != nil {
+					t.Fatalf("CreateUser() error = %v", err)
+				}
+
+				if err := g.Writer.SoftDeleteUser(ctx, g.UnusedUserID); err != nil {
+					t.Fatalf("SoftDeleteUser() error = %v", err)
+				}
+				if err := g.Writer.SoftDeleteUser(ctx, g.UnusedUserID); err != nil {
+					t.Fatalf("SoftDeleteUser() again error = %v", err)
+				}
+
+				got, err := g.Reader.GetUser(ctx, int64(g.UnusedUserID))
+				if err != nil || got != nil {
+					t.Errorf("GetUser() = %v, %v, want nil, nil", got, err)
+				}
+				updated, err := g.Writer.UpdateUser(ctx, g.UnusedUserID, todo.UpdateUser{Username: cast.Ptr("renamed")})
+				if err != nil || updated != nil {
+					t.Errorf("UpdateUser() = %v, %v, want nil, nil", updated, err)
+				}
+
+				_, err = g.Writer.CreateUser(ctx, todo.NewUser{
+					ID:       g.UnusedUserID + 1,
+					Username: "other user",
+					Email:    cast.Ptr("new.user@example.com"),
+				})
+				if !errors.Is(err, todo.ErrDuplicateKey) {
+					t.Errorf("CreateUser() error = %v, want %v", err, todo.ErrDuplicateKey)
+				}
+			},
+		},
+		"Get User Data includes a deleted user": {
+			run: func(t *testing.T, ctx context.Context, g UserGateways) {
+				_, err := g.Writer.CreateUser(ctx, todo.NewUser{
+					ID:       g.UnusedUserID,
+					Username: "new user",
+				})
+				if err != nil {
+					t.Fatalf("CreateUser() error = %v", err)
+				}
+				if err := g.Writer.SoftDeleteUser(ctx, g.UnusedUserID); err != nil {
+					t.Fatalf("SoftDeleteUser() error = %v", err)
+				}
+
+				data := &todo.UserData{}
+				found, err := g.DataReader.ReadUserData(ctx, g.UnusedUserID, 10, data)
+				if err != nil {
+					t.Fatalf("ReadUserData() error = %v", err)
+				}
+				if !found || data.User == nil || !data.User.IsDeleted() || len(data.Todos) != 0 {
+					t.Errorf("ReadUserData() = %v, %+v, want the deleted user without todos", found, data)
+				}
+
+				data = &todo.UserData{}
+				found, err = g.DataReader.ReadUserData(ctx, g.UnusedUserID+1, 10, data)
+				if err != nil || found || data.User != nil {
+					t.Errorf("ReadUserData() of an unknown user = %v, %+v, %v, want false and nothing written", found, data, err)
+				}
+			},
+		},
+		"Erase User removes it and keeps its ID from being created again": {
+			run: func(t *testing.T, ctx context.Context, g UserGateways) {
+				_, err := g.Writer.CreateUser(ctx, todo.NewUser{
+					ID:       g.UnusedUserID,
+					Username: "new user",
+					Email:    cast.Ptr("new.user@example.com"),
+				})
+				if err != nil {
+					t.Fatalf("CreateUser() error = %v", err)
+				}
+
+				erased, err := g.Eraser.EraseUser(ctx, g.UnusedUserID)
+				if err != nil {
+					t.Fatalf("EraseUser() error = %v", err)
+				}
+				if erased == nil || erased.UserID != g.UnusedUserID || erased.ErasedAt.IsZero() {
+					t.Fatalf("EraseUser() = %+v, want the tombstone of the user", erased)
+				}
+
+				found, err := g.DataReader.ReadUserData(ctx, g.UnusedUserID, 10, &todo.UserData{})
+				if err != nil || found {
+					t.Errorf("ReadUserData() = %v, %v, want false, nil", found, err)
+				}
+
+				_, err = g.Writer.CreateUser(ctx, todo.NewUser{ID: g.UnusedUserID, Username: "new user"})
+				if !errors.Is(err, todo.ErrUserErased) {
+					t.Errorf("CreateUser() error = %v, want todo.ErrUserErased", err)
+				}
+
+				// The email is free again.
+				_, err = g.Writer.CreateUser(ctx, todo.NewUser{
+					ID:       g.UnusedUserID + 1,
+					Username: "other user",
+					Email:    cast.Ptr("new.user@example.com"),
+				})
+				if err != nil {
+					t.Errorf("CreateUser() with the email of the erased user error = %v", err)
+				}
+
+				erased, err = g.Eraser.EraseUser(ctx, g.UnusedUserID)
+				if err != nil || erased != nil {
+					t.Errorf("EraseUser() again = %v, %v, want nil, nil", erased, err)
+				}
+			},
+		},
+		"Get User returns nil when not found": {
+			run: func(t *testing.T, ctx context.Context, g UserGateways) {
+				got, err := g.Reader.GetUser(ctx, int64(g.UnusedUserID))
+				if err != nil || got != nil {
+					t.Errorf("GetUser() = %v, %v, want nil, nil", got, err)
+				}
+			},
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			g := newGateways(t)
+			tt.run(t, g.Binder.Bind(context.Background()), g)
+		})
+	}
+}
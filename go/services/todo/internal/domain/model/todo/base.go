@@ -1,6 +1,10 @@
 package todo
 
-import "time"
+import "errors"
+
+// ErrDuplicateKey is returned by the writes that would break a unique key,
+// such as a used external ID of a todo or a used email of a user.
+var ErrDuplicateKey = errors.New("duplicate key")
 
 type SortingOrder string
 type SortingType string
@@ -17,6 +21,16 @@ func (so *SortingOrder) String() string {
 	return string(*so)
 }
 
+// IsValid reports whether so is ASC or DESC. Orders are written into ORDER BY
+// clauses, so any other value must be rejected.
+func (so SortingOrder) IsValid() bool {
+	switch so {
+	case SortingOrders.Asc, SortingOrders.Desc:
+		return true
+	}
+	return false
+}
+
 var SortingTypes = struct {
 	CreatedAt   SortingType
 	UpdatedAt   SortingType
@@ -24,6 +38,7 @@ var SortingTypes = struct {
 	ID          SortingType
 	Body        SortingType
 	Status      SortingType
+	Position    SortingType
 }{
 	CreatedAt:   "created_at",
 	UpdatedAt:   "updated_at",
@@ -31,6 +46,7 @@ var SortingTypes = struct {
 	ID:          "id",
 	Body:        "body",
 	Status:      "status",
+	Position:    "position",
 }
 
 func (st *SortingType) String() string {
@@ -50,11 +66,5 @@ var ModelTypes = struct {
 type CursorPagingParam struct {
 	Token        *string      `json:"token"`
 	Size         int          `json:"size" validate:"gt=0"`
-	HasCursor    bool         `json:"has_cursor"`
 	SortingOrder SortingOrder `json:"-"`
-	LastDataAt   *time.Time   `json:"-"`
-}
-
-type CursorPagingTime struct {
-	CursorPaging time.Time
 }
@@ -0,0 +1,21 @@
+package todo
+
+import "time"
+
+// DBPoolStatus reports a database connection pool of the service: the primary
+// or one of the read replicas.
+type DBPoolStatus struct {
+	Name               string
+	Healthy            bool
+	MaxOpenConnections int
+	OpenConnections    int
+	InUse              int
+	Idle               int
+	// WaitCount and WaitDuration add up the waits for a free connection since
+	// the pool was opened.
+	WaitCount         int64
+	WaitDuration      time.Duration
+	MaxIdleClosed     int64
+	MaxIdleTimeClosed int64
+	MaxLifetimeClosed int64
+}
@@ -0,0 +1,213 @@
+package todo
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type EventID int64
+
+func NewEventID(id int64) *EventID {
+	eventID := EventID(id)
+	return &eventID
+}
+
+type EventType string
+
+var EventTypes = struct {
+	TodoCreated EventType
+	TodoUpdated EventType
+	TodoDeleted EventType
+	UserCreated EventType
+	UserUpdated EventType
+	UserDeleted EventType
+	UserErased  EventType
+}{
+	TodoCreated: "TodoCreated",
+	TodoUpdated: "TodoUpdated",
+	TodoDeleted: "TodoDeleted",
+	UserCreated: "UserCreated",
+	UserUpdated: "UserUpdated",
+	UserDeleted: "UserDeleted",
+	UserErased:  "UserErased",
+}
+
+type AggregateType string
+
+var AggregateTypes = struct {
+	Todo AggregateType
+	User AggregateType
+}{
+	Todo: "todo",
+	User: "user",
+}
+
+// Event is a domain event stored in the outbox table. It is written in the same
+// transaction as the change it describes and published later by the relay.
+type Event struct {
+	ID            EventID         `json:"id"`
+	AggregateType AggregateType   `json:"aggregate_type"`
+	AggregateID   int64           `json:"aggregate_id"`
+	UserID        UserID          `json:"user_id"`
+	EventType     EventType       `json:"event_type"`
+	Payload       json.RawMessage `json:"payload"`
+	Attempts      int             `json:"-"`
+	LastError     *string         `json:"-"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	NextAttemptAt time.Time       `json:"-"`
+	PublishedAt   *time.Time      `json:"-"`
+	// DeadLetteredAt is set once the relay gave up publishing the event.
+	DeadLetteredAt *time.Time `json:"-"`
+}
+
+type TodoEventPayload struct {
+	ID          TodoID     `json:"id"`
+	UserID      UserID     `json:"user_id"`
+	ExternalID  *string    `json:"external_id"`
+	Task        string     `json:"task"`
+	Description *string    `json:"description"`
+	Status      TodoStatus `json:"status"`
+	Position    string     `json:"position"`
+	DueAt       *time.Time `json:"due_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	DeletedAt   *time.Time `json:"deleted_at"`
+}
+
+type UserEventPayload struct {
+	ID        UserID     `json:"id"`
+	Username  string     `json:"username"`
+	Email     *string    `json:"email"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at"`
+}
+
+// UserErasedEventPayload has no personal data: subscribers erase what they
+// keep about the user when they receive it.
+type UserErasedEventPayload struct {
+	ID       UserID    `json:"id"`
+	ErasedAt time.Time `json:"erased_at"`
+}
+
+func NewTodoEvent(eventType EventType, t *Todo) (*Event, error) {
+	if t == nil {
+		return nil, fmt.Errorf("NewTodoEvent: todo is nil")
+	}
+
+	payload, err := json.Marshal(TodoEventPayload{
+		ID:          t.ID,
+		UserID:      t.UserID,
+		ExternalID:  t.ExternalID,
+		Task:        t.Task,
+		Description: t.Description,
+		Status:      t.Status,
+		Position:    t.Position,
+		DueAt:       t.DueAt,
+		CompletedAt: t.CompletedAt,
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+		DeletedAt:   t.DeletedAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("NewTodoEvent: marshal payload: %w", err)
+	}
+
+	return newEvent(AggregateTypes.Todo, t.ID.Int64(), t.UserID, eventType, payload), nil
+}
+
+func NewUserEvent(eventType EventType, u *User) (*Event, error) {
+	if u == nil {
+		return nil, fmt.Errorf("NewUserEvent: user is nil")
+	}
+
+	payload, err := json.Marshal(UserEventPayload{
+		ID:        u.ID,
+		Username:  u.Username,
+		Email:     u.Email,
+		CreatedAt: u.CreatedAt,
+		UpdatedAt: u.UpdatedAt,
+		DeletedAt: u.DeletedAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("NewUserEvent: marshal payload: %w", err)
+	}
+
+	return newEvent(AggregateTypes.User, u.ID.Int64(), u.ID, eventType, payload), nil
+}
+
+func NewUserErasedEvent(erased *ErasedUser) (*Event, error) {
+	if erased == nil {
+		return nil, fmt.Errorf("NewUserErasedEvent: erased user is nil")
+	}
+
+	payload, err := json.Marshal(UserErasedEventPayload{
+		ID:       erased.UserID,
+		ErasedAt: erased.ErasedAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("NewUserErasedEvent: marshal payload: %w", err)
+	}
+
+	return newEvent(AggregateTypes.User, erased.UserID.Int64(), erased.UserID, EventTypes.UserErased, payload), nil
+}
+
+func newEvent(
+	aggregateType AggregateType,
+	aggregateID int64,
+	userID UserID,
+	eventType EventType,
+	payload json.RawMessage,
+) *Event {
+	now := time.Now()
+	return &Event{
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		UserID:        userID,
+		EventType:     eventType,
+		Payload:       payload,
+		OccurredAt:    now,
+		NextAttemptAt: now,
+	}
+}
+
+func (e *Event) IsPublished() bool {
+	if e == nil {
+		return false
+	}
+
+	return e.PublishedAt != nil
+}
+
+func (e *Event) IsDeadLettered() bool {
+	if e == nil {
+		return false
+	}
+
+	return e.DeadLetteredAt != nil
+}
+
+func (e *Event) TodoPayload() (*TodoEventPayload, error) {
+	if e == nil || e.AggregateType != AggregateTypes.Todo {
+		return nil, fmt.Errorf("TodoPayload: not a todo event")
+	}
+
+	var payload TodoEventPayload
+	if err := json.Unmarshal(e.Payload, &payload); err != nil {
+		return nil, fmt.Errorf("TodoPayload: unmarshal payload: %w", err)
+	}
+
+	return &payload, nil
+}
+
+// AggregateKey identifies the aggregate an event belongs to. Events sharing a
+// key must be published in the order they were recorded.
+func (e *Event) AggregateKey() string {
+	if e == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%s:%d", e.AggregateType, e.AggregateID)
+}
@@ -0,0 +1,149 @@
+package todo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+type FileFormat string
+
+var FileFormats = struct {
+	CSV       FileFormat
+	JSONLines FileFormat
+	ICalendar FileFormat
+	TodoTxt   FileFormat
+	Markdown  FileFormat
+}{
+	CSV:       "csv",
+	JSONLines: "jsonl",
+	ICalendar: "ics",
+	TodoTxt:   "todotxt",
+	Markdown:  "md",
+}
+
+// exportedIDPrefix marks the IDs an export writes in place of the external ID
+// of a todo without one, so that an import tells them from the external IDs
+// of other apps.
+const exportedIDPrefix = "todo:"
+
+// ExportedID is the ID an export writes for the todo id, which has no
+// external ID.
+func ExportedID(id TodoID) string {
+	return exportedIDPrefix + id.String()
+}
+
+// ParseExportedID returns the todo ID of an ID written by ExportedID, and
+// false for any other ID.
+func ParseExportedID(s string) (TodoID, bool) {
+	rest, ok := strings.CutPrefix(s, exportedIDPrefix)
+	if !ok {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(rest, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+	return TodoID(id), true
+}
+
+// ImportRow is one todo read from an imported file. Line is the position of
+// the row in the file, used to report errors back to the user. CreatedAt and
+// CompletedAt are read by the formats that carry them, for conversions: an
+// import keeps the timestamps the service assigns.
+type ImportRow struct {
+	Line        int
+	ExternalID  *string
+	Task        string
+	Description *string
+	Status      TodoStatus
+	DueAt       *time.Time
+	CreatedAt   *time.Time
+	CompletedAt *time.Time
+}
+
+type ImportRowError struct {
+	Line    int
+	Field   string
+	Message string
+}
+
+func (e ImportRowError) Error() string {
+	return fmt.Sprintf("line %d: %s: %s", e.Line, e.Field, e.Message)
+}
+
+type ImportOptions struct {
+	DryRun bool
+	// UpdateExisting updates the todo that already has the external ID of a
+	// row instead of skipping the row.
+	UpdateExisting bool
+}
+
+type ImportReport struct {
+	DryRun  bool
+	Created int
+	Updated int
+	Skipped int
+	Errors  []ImportRowError
+}
+
+// Validate checks the row against the constraints of the todos table.
+func (r *ImportRow) Validate() []ImportRowError {
+	var errs []ImportRowError
+
+	if r.Task == "" {
+		errs = append(errs, ImportRowError{Line: r.Line, Field: "task", Message: "must not be empty"})
+	}
+	if utf8.RuneCountInString(r.Task) > TaskMaxLength {
+		errs = append(errs, ImportRowError{
+			Line:    r.Line,
+			Field:   "task",
+			Message: fmt.Sprintf("must be at most %d characters", TaskMaxLength),
+		})
+	}
+	if r.Description != nil && len(*r.Description) > DescriptionMaxBytes {
+		errs = append(errs, ImportRowError{
+			Line:    r.Line,
+			Field:   "description",
+			Message: fmt.Sprintf("must be at most %d bytes", DescriptionMaxBytes),
+		})
+	}
+	if r.ExternalID != nil && utf8.RuneCountInString(*r.ExternalID) > ExternalIDMaxLength {
+		errs = append(errs, ImportRowError{
+			Line:    r.Line,
+			Field:   "external_id",
+			Message: fmt.Sprintf("must be at most %d characters", ExternalIDMaxLength),
+		})
+	}
+	if !r.Status.IsValid() {
+		errs = append(errs, ImportRowError{
+			Line:    r.Line,
+			Field:   "status",
+			Message: fmt.Sprintf("invalid status %d", r.Status),
+		})
+	}
+
+	return errs
+}
+
+func (r *ImportRow) NewTodo(userID UserID) NewTodo {
+	return NewTodo{
+		UserID:      userID,
+		ExternalID:  r.ExternalID,
+		Task:        r.Task,
+		Description: r.Description,
+		Status:      r.Status,
+		DueAt:       r.DueAt,
+	}
+}
+
+func (r *ImportRow) UpdateTodo() UpdateTodo {
+	return UpdateTodo{
+		Task:        &r.Task,
+		Description: r.Description,
+		Status:      &r.Status,
+		DueAt:       r.DueAt,
+	}
+}
@@ -0,0 +1,21 @@
+package todo
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrLeaseLost is returned when a lease is renewed after it expired or was
+// taken over by another holder.
+var ErrLeaseLost = errors.New("lease lost")
+
+// Lease is the right of Holder to run the job called Name until ExpiresAt.
+// Token grows every time the lease is acquired: it is a fencing token, which
+// tells the writes of the current holder from the late writes of a former
+// one that kept running after it lost the lease.
+type Lease struct {
+	Name      string
+	Holder    string
+	Token     int64
+	ExpiresAt time.Time
+}
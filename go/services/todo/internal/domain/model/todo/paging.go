@@ -0,0 +1,48 @@
+package todo
+
+// PagingDirection is the way a page token moves from the page it was issued
+// on.
+type PagingDirection string
+
+var PagingDirections = struct {
+	Next     PagingDirection
+	Previous PagingDirection
+}{
+	Next:     "next",
+	Previous: "previous",
+}
+
+func (d PagingDirection) IsValid() bool {
+	switch d {
+	case PagingDirections.Next, PagingDirections.Previous:
+		return true
+	}
+	return false
+}
+
+// Page is one page of a listing, in the sorting order of the listing whichever
+// way it was reached. The tokens are set when HasNext and HasPrevious are; an
+// empty page has neither.
+type Page[T any] struct {
+	Items             []T
+	NextPageToken     *string
+	PreviousPageToken *string
+	HasNext           bool
+	HasPrevious       bool
+}
+
+// MapPage converts the items of p, keeping its cursors.
+func MapPage[T, U any](p *Page[T], f func(T) U) *Page[U] {
+	items := make([]U, 0, len(p.Items))
+	for _, item := range p.Items {
+		items = append(items, f(item))
+	}
+
+	return &Page[U]{
+		Items:             items,
+		NextPageToken:     p.NextPageToken,
+		PreviousPageToken: p.PreviousPageToken,
+		HasNext:           p.HasNext,
+		HasPrevious:       p.HasPrevious,
+	}
+}
@@ -0,0 +1,147 @@
+package todo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Positions are fractional ranks: base-36 digits read as the fraction
+// 0.d1d2d3..., compared as plain strings. A rank can always be found between
+// two others, so moving a todo only rewrites its own position. Ranks never end
+// with the zero digit, which keeps string and numeric order the same.
+const positionDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+const (
+	// PositionRebalanceLength is the rank length from which the list of a user
+	// is rebalanced in the background.
+	PositionRebalanceLength = 16
+	// PositionMaxLength is the rank length from which the list is rebalanced
+	// before the write completes.
+	PositionMaxLength = 64
+)
+
+type MoveTodo struct {
+	// Exactly one of BeforeID and AfterID is set: the todo is placed right
+	// before or right after that todo.
+	BeforeID *TodoID
+	AfterID  *TodoID
+}
+
+func (m MoveTodo) IsValid() bool {
+	return (m.BeforeID == nil) != (m.AfterID == nil)
+}
+
+func (m MoveTodo) TargetID() TodoID {
+	if m.BeforeID != nil {
+		return *m.BeforeID
+	}
+	return *m.AfterID
+}
+
+// PositionBetween returns a rank that sorts after before and before after. An
+// empty before is the start of the list and an empty after is its end.
+func PositionBetween(before string, after string) (string, error) {
+	if err := validatePosition(before); err != nil {
+		return "", err
+	}
+	if err := validatePosition(after); err != nil {
+		return "", err
+	}
+	if after != "" && before >= after {
+		return "", fmt.Errorf("PositionBetween: %q is not before %q", before, after)
+	}
+
+	return positionMidpoint(before, after), nil
+}
+
+func positionMidpoint(before string, after string) string {
+	if after != "" {
+		// Keep the common prefix, reading missing digits of before as zeros.
+		n := 0
+		for n < len(after) && positionDigitAt(before, n) == after[n] {
+			n++
+		}
+		if n > 0 {
+			rest := ""
+			if n < len(before) {
+				rest = before[n:]
+			}
+			return after[:n] + positionMidpoint(rest, after[n:])
+		}
+	}
+
+	lo := 0
+	if before != "" {
+		lo = strings.IndexByte(positionDigits, before[0])
+	}
+	hi := len(positionDigits)
+	if after != "" {
+		hi = strings.IndexByte(positionDigits, after[0])
+	}
+
+	if hi-lo > 1 {
+		return string(positionDigits[(lo+hi)/2])
+	}
+
+	// The first digits are consecutive: a longer after still leaves room
+	// below it, otherwise continue after the digits of before.
+	if len(after) > 1 {
+		return after[:1]
+	}
+	rest := ""
+	if len(before) > 1 {
+		rest = before[1:]
+	}
+	return string(positionDigits[lo]) + positionMidpoint(rest, "")
+}
+
+func positionDigitAt(s string, i int) byte {
+	if i < len(s) {
+		return s[i]
+	}
+	return positionDigits[0]
+}
+
+// EvenPositions returns n short ranks spread evenly over the whole range, used
+// to rebalance a list while keeping its order.
+func EvenPositions(n int) []string {
+	width := 1
+	for capacity := len(positionDigits); capacity <= n; capacity *= len(positionDigits) {
+		width++
+	}
+	// One more digit leaves room for moves between neighbors.
+	width++
+
+	capacity := 1
+	for i := 0; i < width; i++ {
+		capacity *= len(positionDigits)
+	}
+	step := capacity / (n + 1)
+
+	positions := make([]string, n)
+	for i := range positions {
+		positions[i] = formatPosition((i+1)*step, width)
+	}
+	return positions
+}
+
+func formatPosition(value int, width int) string {
+	digits := make([]byte, width)
+	for i := width - 1; i >= 0; i-- {
+		digits[i] = positionDigits[value%len(positionDigits)]
+		value /= len(positionDigits)
+	}
+	return strings.TrimRight(string(digits), positionDigits[:1])
+}
+
+func validatePosition(s string) error {
+	if strings.HasSuffix(s, positionDigits[:1]) {
+		return fmt.Errorf("invalid position %q: ends with %q", s, positionDigits[:1])
+	}
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(positionDigits, s[i]) < 0 {
+			return fmt.Errorf("invalid position %q", s)
+		}
+	}
+	return nil
+}
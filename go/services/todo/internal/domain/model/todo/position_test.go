@@ -0,0 +1,147 @@
+package todo_test
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+func Test_PositionBetween(t *testing.T) {
+	type args struct {
+		before string
+		after  string
+	}
+
+	type testcase struct {
+		args     args
+		expected string
+		wantErr  bool
+	}
+
+	t.Parallel()
+
+	testTables := map[string]testcase{
+		"Middle of an empty list": {
+			args:     args{before: "", after: ""},
+			expected: "i",
+			wantErr:  false,
+		},
+		"Start of the list": {
+			args:     args{before: "", after: "i"},
+			expected: "9",
+			wantErr:  false,
+		},
+		"End of the list": {
+			args:     args{before: "i", after: ""},
+			expected: "r",
+			wantErr:  false,
+		},
+		"Between consecutive digits": {
+			args:     args{before: "a", after: "b"},
+			expected: "ai",
+			wantErr:  false,
+		},
+		"Between ranks sharing a prefix": {
+			args:     args{before: "a1", after: "a2"},
+			expected: "a1i",
+			wantErr:  false,
+		},
+		"Below a longer rank": {
+			args:     args{before: "a", after: "b5"},
+			expected: "b",
+			wantErr:  false,
+		},
+		"Before a rank starting with zeros": {
+			args:     args{before: "", after: "01"},
+			expected: "00i",
+			wantErr:  false,
+		},
+		"Return error when before is not before after": {
+			args:    args{before: "b", after: "a"},
+			wantErr: true,
+		},
+		"Return error for a rank ending with zero": {
+			args:    args{before: "a0", after: ""},
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			position, err := todo.PositionBetween(tt.args.before, tt.args.after)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(position, tt.expected); diff != "" {
+				t.Fatalf("mismatch (-actual +expected):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_PositionBetween_RepeatedMoves(t *testing.T) {
+	t.Parallel()
+
+	// Moving a todo to the top again and again, and between the same two
+	// todos again and again, always finds a rank in between.
+	top, err := todo.PositionBetween("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lo, hi := top, ""
+	for i := 0; i < 200; i++ {
+		next, err := todo.PositionBetween("", top)
+		if err != nil {
+			t.Fatalf("move %d to the top: %v", i, err)
+		}
+		if next >= top {
+			t.Fatalf("move %d to the top: %q is not before %q", i, next, top)
+		}
+		top = next
+
+		mid, err := todo.PositionBetween(lo, hi)
+		if err != nil {
+			t.Fatalf("move %d between: %v", i, err)
+		}
+		if mid <= lo || (hi != "" && mid >= hi) {
+			t.Fatalf("move %d between: %q is not between %q and %q", i, mid, lo, hi)
+		}
+		if strings.HasSuffix(mid, "0") {
+			t.Fatalf("move %d between: %q ends with zero", i, mid)
+		}
+		hi = mid
+	}
+}
+
+func Test_EvenPositions(t *testing.T) {
+	t.Parallel()
+
+	for _, n := range []int{0, 1, 2, 35, 36, 1000, 50000} {
+		positions := todo.EvenPositions(n)
+		if len(positions) != n {
+			t.Fatalf("EvenPositions(%d) returned %d positions", n, len(positions))
+		}
+		if !sort.StringsAreSorted(positions) {
+			t.Fatalf("EvenPositions(%d) is not sorted", n)
+		}
+		for i, p := range positions {
+			if p == "" || strings.HasSuffix(p, "0") {
+				t.Fatalf("EvenPositions(%d)[%d] = %q", n, i, p)
+			}
+			if i > 0 && positions[i-1] == p {
+				t.Fatalf("EvenPositions(%d) has duplicate %q", n, p)
+			}
+			if len(p) >= todo.PositionRebalanceLength {
+				t.Fatalf("EvenPositions(%d)[%d] = %q is too long", n, i, p)
+			}
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package todo
+
+import "time"
+
+type StatsBucketSize string
+
+var StatsBucketSizes = struct {
+	Day   StatsBucketSize
+	Week  StatsBucketSize
+	Month StatsBucketSize
+}{
+	Day:   "day",
+	Week:  "week",
+	Month: "month",
+}
+
+func (s StatsBucketSize) IsValid() bool {
+	switch s {
+	case StatsBucketSizes.Day, StatsBucketSizes.Week, StatsBucketSizes.Month:
+		return true
+	}
+	return false
+}
+
+const (
+	DefaultStatsPageSize = 31
+	MaxStatsPageSize     = 366
+)
+
+// TodoStatsParam selects the todos of a user created in [From, To). The
+// activity series is paged by bucket; weeks start on Monday.
+type TodoStatsParam struct {
+	UserID     UserID
+	From       time.Time
+	To         time.Time
+	BucketSize StatsBucketSize
+	Paging     CursorPagingParam
+}
+
+type TodoStats struct {
+	CountsByStatus map[TodoStatus]int
+	Total          int
+	// CompletionRate is the share of the todos created in the range that are
+	// done, between 0 and 1.
+	CompletionRate float64
+	// AverageCompletionTime is measured over the todos completed in the range.
+	// It is nil when none were.
+	AverageCompletionTime *time.Duration
+	Activity              *Page[*TodoActivityBucket]
+}
+
+// TodoActivityBucket counts the todos created and completed in the bucket
+// starting at StartAt. Buckets without activity are omitted.
+type TodoActivityBucket struct {
+	StartAt   time.Time
+	Created   int
+	Completed int
+}
+
+func NewTodoStats(countsByStatus map[TodoStatus]int, averageCompletionTime *time.Duration) *TodoStats {
+	stats := &TodoStats{
+		CountsByStatus:        make(map[TodoStatus]int, len(todoStatusNames)),
+		AverageCompletionTime: averageCompletionTime,
+	}
+	for status := range todoStatusNames {
+		stats.CountsByStatus[status] = countsByStatus[status]
+		stats.Total += countsByStatus[status]
+	}
+	if stats.Total > 0 {
+		stats.CompletionRate = float64(stats.CountsByStatus[Done]) / float64(stats.Total)
+	}
+
+	return stats
+}
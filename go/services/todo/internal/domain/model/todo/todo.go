@@ -1,6 +1,7 @@
 package todo
 
 import (
+	"fmt"
 	"strconv"
 	"time"
 )
@@ -15,6 +16,23 @@ const (
 	Done      TodoStatus = 2
 )
 
+const (
+	TaskMaxLength       = 255
+	DescriptionMaxBytes = 65535
+	ExternalIDMaxLength = 255
+)
+
+var todoStatusNames = map[TodoStatus]string{
+	Pending:   "pending",
+	InProcess: "in_progress",
+	Done:      "done",
+}
+
+// TodoStatuses returns every valid status, in value order.
+func TodoStatuses() []TodoStatus {
+	return []TodoStatus{Pending, InProcess, Done}
+}
+
 func (ts TodoStatus) IsValid() bool {
 	switch ts {
 	case Pending, InProcess, Done:
@@ -24,12 +42,38 @@ func (ts TodoStatus) IsValid() bool {
 	}
 }
 
+func (ts TodoStatus) String() string {
+	if name, ok := todoStatusNames[ts]; ok {
+		return name
+	}
+	return strconv.FormatInt(int64(ts), 10)
+}
+
+// ParseTodoStatus accepts both the status name and its numeric value.
+func ParseTodoStatus(s string) (TodoStatus, error) {
+	for status, name := range todoStatusNames {
+		if s == name {
+			return status, nil
+		}
+	}
+
+	n, err := strconv.ParseInt(s, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("unknown todo status %q", s)
+	}
+	return TodoStatus(n), nil
+}
+
 type Todo struct {
 	ID          TodoID
 	UserID      UserID
+	ExternalID  *string
 	Task        string
 	Description *string
 	Status      TodoStatus
+	Position    string
+	DueAt       *time.Time
+	CompletedAt *time.Time
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 	DeletedAt   *time.Time
@@ -37,15 +81,18 @@ type Todo struct {
 
 type NewTodo struct {
 	UserID      UserID
+	ExternalID  *string
 	Task        string
 	Description *string
 	Status      TodoStatus
+	DueAt       *time.Time
 }
 
 type UpdateTodo struct {
 	Task        *string
 	Description *string
 	Status      *TodoStatus
+	DueAt       *time.Time
 }
 
 func (id *TodoID) Int64() int64 {
@@ -67,6 +114,22 @@ func NewTodoID(id int64) *TodoID {
 	return &todoID
 }
 
+// SetStatus keeps CompletedAt in step with the status: it is set when the todo
+// becomes Done and cleared when it is reopened.
+func (t *Todo) SetStatus(status TodoStatus, now time.Time) {
+	if t == nil {
+		return
+	}
+
+	switch {
+	case status != Done:
+		t.CompletedAt = nil
+	case t.Status != Done || t.CompletedAt == nil:
+		t.CompletedAt = &now
+	}
+	t.Status = status
+}
+
 func (t *Todo) IsDeleted() bool {
 	if t == nil {
 		return false
@@ -1,10 +1,16 @@
 package todo
 
 import (
+	"errors"
 	"strconv"
 	"time"
 )
 
+// ErrUserIDRequired is returned when a user is created without an ID in a
+// sharded database: the shard of a user is chosen by their ID, before the
+// database could assign one.
+var ErrUserIDRequired = errors.New("user ID is required")
+
 type UserID int64
 
 type User struct {
@@ -24,11 +30,23 @@ type NewUser struct {
 	UpdatedAt time.Time
 }
 
+// UpdateUser changes the fields that are set.
+type UpdateUser struct {
+	Username *string
+	Email    *string
+}
+
+const (
+	DefaultUserTodosPageSize = 20
+	MaxUserTodosPageSize     = 100
+)
+
+// UserWithTodos is a user with one page of their todos, ordered by creation.
 type UserWithTodos struct {
 	ID       UserID
 	Username string
 	Email    *string
-	Todos    []*Todo `gorm:"foreignKey:UserID"`
+	Todos    *Page[*Todo]
 }
 
 func (id *UserID) Int64() int64 {
@@ -0,0 +1,45 @@
+package todo
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrUserErased is returned when a user is created with the ID of a user
+// whose data was erased.
+var ErrUserErased = errors.New("user was erased")
+
+// UserData is everything the service keeps about a user, handed to them on
+// request.
+type UserData struct {
+	User *User
+	// Todos are all the todos of the user, deleted ones included.
+	Todos []*Todo
+	// Events are the changes of the user and of their todos recorded in the
+	// outbox, published or not.
+	Events []*Event
+}
+
+// WriteUser, WriteTodos and WriteEvents collect the data of the user as it is
+// read, for the callers that need all of it at once.
+func (d *UserData) WriteUser(user *User) error {
+	d.User = user
+	return nil
+}
+
+func (d *UserData) WriteTodos(todos []*Todo) error {
+	d.Todos = append(d.Todos, todos...)
+	return nil
+}
+
+func (d *UserData) WriteEvents(events []*Event) error {
+	d.Events = append(d.Events, events...)
+	return nil
+}
+
+// ErasedUser is the tombstone of a user whose data was erased. It keeps no
+// personal data, only the ID, so that the user cannot be created again.
+type ErasedUser struct {
+	UserID   UserID
+	ErasedAt time.Time
+}
@@ -0,0 +1,204 @@
+package errors_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	apperrors "github.com/phamquanandpad/training-project/go/services/todo/internal/errors"
+)
+
+func TestGRPCErrToAppError(t *testing.T) {
+	type expected struct {
+		errType       apperrors.ErrorType
+		msg           string
+		metadata      map[string]string
+		messages      apperrors.LocalizedMessage
+		primaryLocale string
+	}
+	type testcase struct {
+		err            error
+		expected       expected
+		expectedCauses []error
+	}
+
+	t.Parallel()
+
+	notFound := apperrors.NewNotFoundError(
+		"todo not found",
+		nil,
+		apperrors.LocalizedMessage{apperrors.LocaleEn: "Todo {todo_id} was not found"},
+		apperrors.ToMetadataInt("todo_id", 42),
+	).LocalizedGRPCStatus(apperrors.LocaleEn).Err()
+	resourceExhausted := apperrors.NewResourceExhaustedError("rate limited", nil, nil).GRPCStatus().Err()
+	canceled := apperrors.NewCanceledError("canceled", context.Canceled).GRPCStatus().Err()
+	deadlineExceeded := status.Error(codes.DeadlineExceeded, "context deadline exceeded")
+	foreign, _ := status.New(codes.NotFound, "no such user").WithDetails(&errdetails.ErrorInfo{
+		Reason: "USER_NOT_FOUND",
+		Domain: "auth",
+	})
+	internal := apperrors.NewInternalError("query todos", errors.New("connection refused")).GRPCStatus().Err()
+	wrapped := fmt.Errorf("get todo: %w", notFound)
+
+	testTables := map[string]testcase{
+		"AppError with metadata and its localized message": {
+			err: notFound,
+			expected: expected{
+				errType:       apperrors.ErrorTypes.NotFoundError,
+				msg:           "todo not found",
+				metadata:      map[string]string{"todo_id": "42"},
+				messages:      apperrors.LocalizedMessage{apperrors.LocaleEn: "Todo 42 was not found"},
+				primaryLocale: apperrors.LocaleEn,
+			},
+			expectedCauses: []error{notFound},
+		},
+		"AppError wrapped by the client": {
+			err: wrapped,
+			expected: expected{
+				errType:       apperrors.ErrorTypes.NotFoundError,
+				msg:           "todo not found",
+				metadata:      map[string]string{"todo_id": "42"},
+				messages:      apperrors.LocalizedMessage{apperrors.LocaleEn: "Todo 42 was not found"},
+				primaryLocale: apperrors.LocaleEn,
+			},
+			expectedCauses: []error{wrapped, notFound},
+		},
+		"AppError with the default messages, in the default locale first": {
+			err: resourceExhausted,
+			expected: expected{
+				errType:  apperrors.ErrorTypes.ResourceExhaustedError,
+				msg:      "rate limited",
+				metadata: map[string]string{},
+				messages: apperrors.LocalizedMessage{
+					apperrors.LocaleEn: "Too many requests, please try again later",
+					apperrors.LocaleJa: apperrors.ResourceExhaustedJaMessage,
+					apperrors.LocaleVi: "Quá nhiều yêu cầu, vui lòng thử lại sau",
+				},
+				primaryLocale: apperrors.LocaleJa,
+			},
+			expectedCauses: []error{resourceExhausted},
+		},
+		"AppError without a localized message": {
+			err: canceled,
+			expected: expected{
+				errType:  apperrors.ErrorTypes.CanceledError,
+				msg:      "canceled",
+				metadata: map[string]string{},
+				messages: apperrors.LocalizedMessage{},
+			},
+			expectedCauses: []error{canceled, context.Canceled},
+		},
+		"AppError of a type with the same code as another": {
+			err: internal,
+			expected: expected{
+				errType:  apperrors.ErrorTypes.InternalError,
+				msg:      "query todos",
+				metadata: map[string]string{},
+				messages: apperrors.LocalizedMessage{
+					apperrors.LocaleEn: "An error occurred, please contact the administrator",
+					apperrors.LocaleJa: apperrors.InvalidJaMessage,
+					apperrors.LocaleVi: "Đã xảy ra lỗi, vui lòng liên hệ quản trị viên",
+				},
+				primaryLocale: apperrors.LocaleJa,
+			},
+			expectedCauses: []error{internal},
+		},
+		"Status without details": {
+			err: deadlineExceeded,
+			expected: expected{
+				errType:  apperrors.ErrorTypes.DeadlineExceededError,
+				msg:      "context deadline exceeded",
+				metadata: map[string]string{},
+			},
+			expectedCauses: []error{deadlineExceeded, context.DeadlineExceeded},
+		},
+		"Status with the details of another domain": {
+			err: foreign.Err(),
+			expected: expected{
+				errType:  apperrors.ErrorTypes.NotFoundError,
+				msg:      "no such user",
+				metadata: map[string]string{},
+				messages: apperrors.LocalizedMessage{
+					apperrors.LocaleEn: "The resource was not found",
+					apperrors.LocaleJa: apperrors.NotFoundJaMessage,
+					apperrors.LocaleVi: "Không tìm thấy đối tượng",
+				},
+			},
+		},
+		"Context error": {
+			err: fmt.Errorf("call todo: %w", context.Canceled),
+			expected: expected{
+				errType:  apperrors.ErrorTypes.CanceledError,
+				msg:      "canceled",
+				metadata: map[string]string{},
+			},
+			expectedCauses: []error{context.Canceled},
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			appErr := apperrors.GRPCErrToAppError(tt.err)
+
+			actual := expected{
+				errType:       appErr.Elem.Type,
+				msg:           appErr.Elem.Msg,
+				metadata:      appErr.Elem.Metadata,
+				messages:      appErr.Messages,
+				primaryLocale: appErr.PrimaryLocale,
+			}
+			if diff := cmp.Diff(actual, tt.expected, cmp.AllowUnexported(expected{})); diff != "" {
+				t.Fatalf("GRPCErrToAppError() mismatch (-actual +expected):\n%s", diff)
+			}
+			for _, cause := range tt.expectedCauses {
+				if !errors.Is(appErr, cause) {
+					t.Errorf("errors.Is(GRPCErrToAppError(), %v) = false, want true", cause)
+				}
+			}
+		})
+	}
+}
+
+func TestGRPCErrToAppError_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	appErr := apperrors.NewParameterError(
+		"invalid title",
+		nil,
+		apperrors.LocalizedMessage{
+			apperrors.LocaleEn: "Title {field} is invalid",
+			apperrors.LocaleVi: "Tiêu đề {field} không hợp lệ",
+		},
+		apperrors.ToMetadata("field", "title"),
+	)
+
+	// The locale the server picked for the first request is kept by a client
+	// that asks for none.
+	first := apperrors.GRPCErrToAppError(appErr.LocalizedGRPCStatus(apperrors.LocaleVi).Err())
+	second := apperrors.GRPCErrToAppError(first.GRPCStatus().Err())
+
+	if apperrors.ToGRPCCode(second) != codes.InvalidArgument {
+		t.Errorf("ToGRPCCode() = %v, want %v", apperrors.ToGRPCCode(second), codes.InvalidArgument)
+	}
+	if diff := cmp.Diff(second.Elem.Metadata, appErr.Elem.Metadata); diff != "" {
+		t.Errorf("Elem.Metadata mismatch (-actual +expected):\n%s", diff)
+	}
+	if diff := cmp.Diff(second.Messages, apperrors.LocalizedMessage{
+		apperrors.LocaleEn: "Title title is invalid",
+		apperrors.LocaleVi: "Tiêu đề title không hợp lệ",
+	}); diff != "" {
+		t.Errorf("Messages mismatch (-actual +expected):\n%s", diff)
+	}
+	if second.PrimaryLocale != apperrors.LocaleVi {
+		t.Errorf("PrimaryLocale = %q, want %q", second.PrimaryLocale, apperrors.LocaleVi)
+	}
+}
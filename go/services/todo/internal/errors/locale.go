@@ -0,0 +1,177 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	LocaleEn = "en-US"
+	LocaleJa = "ja-JP"
+	LocaleVi = "vi-VN"
+
+	// DefaultLocale is the locale of the messages when the request asks for
+	// none of the locales of a message.
+	DefaultLocale = LocaleJa
+
+	// LocaleMetadataKey is the gRPC metadata a client sets to choose the
+	// locale, taking precedence over Accept-Language.
+	LocaleMetadataKey = "x-locale"
+)
+
+// acceptLanguageMetadataKeys are the metadata the Accept-Language header
+// reaches the service in, sent by a gRPC client or forwarded by grpc-gateway.
+var acceptLanguageMetadataKeys = []string{"accept-language", "grpcgateway-accept-language"}
+
+// fallbackLocales are tried after the locales of the request.
+var fallbackLocales = []string{DefaultLocale, LocaleEn}
+
+// messageParameterPattern matches the {key} parameters of a message template.
+var messageParameterPattern = regexp.MustCompile(`\{(\w+)\}`)
+
+// LocalizedMessage is a message shown to users, by locale. A message may be a
+// template whose {key} parameters are the metadata of the error.
+type LocalizedMessage map[string]string
+
+// Lookup returns the message of the first of locales it has, then of the
+// fallback locales, then its first message by locale. For every locale, a
+// message of the same locale comes first, then one of the same language: "vi"
+// and "vi-VN" both match "vi-VN".
+func (m LocalizedMessage) Lookup(locales ...string) (locale string, message string, ok bool) {
+	if len(m) == 0 {
+		return "", "", false
+	}
+
+	// The locales of m are sorted for the lookup to be the same every time.
+	haves := make([]string, 0, len(m))
+	for have := range m {
+		haves = append(haves, have)
+	}
+	sort.Strings(haves)
+
+	for _, want := range append(append([]string{}, locales...), fallbackLocales...) {
+		for _, have := range haves {
+			if strings.EqualFold(have, want) {
+				return have, m[have], true
+			}
+		}
+		for _, have := range haves {
+			if strings.EqualFold(language(have), language(want)) {
+				return have, m[have], true
+			}
+		}
+	}
+
+	return haves[0], m[haves[0]], true
+}
+
+func language(locale string) string {
+	lang, _, _ := strings.Cut(locale, "-")
+	return lang
+}
+
+// formatMessage replaces the {key} parameters of template with the metadata
+// of the same key, leaving the others as they are.
+func formatMessage(template string, md map[string]string) string {
+	return messageParameterPattern.ReplaceAllStringFunc(template, func(parameter string) string {
+		if value, ok := md[parameter[1:len(parameter)-1]]; ok {
+			return value
+		}
+		return parameter
+	})
+}
+
+// ParseAcceptLanguage returns the locales of an Accept-Language header, most
+// preferred first. Locales with a zero quality and the wildcard are left out.
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		locale  string
+		quality float64
+	}
+
+	var parsed []weighted
+	for _, part := range strings.Split(header, ",") {
+		locale, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		locale = strings.TrimSpace(locale)
+		if locale == "" || locale == "*" {
+			continue
+		}
+
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			v, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = v
+		}
+		if quality <= 0 {
+			continue
+		}
+		parsed = append(parsed, weighted{locale: locale, quality: quality})
+	}
+
+	sort.SliceStable(parsed, func(i, j int) bool {
+		return parsed[i].quality > parsed[j].quality
+	})
+
+	locales := make([]string, 0, len(parsed))
+	for _, w := range parsed {
+		locales = append(locales, w.locale)
+	}
+	return locales
+}
+
+// LocalesFromIncomingContext returns the locales the incoming gRPC request
+// asks for, most preferred first: the one of LocaleMetadataKey, then the ones
+// of Accept-Language.
+func LocalesFromIncomingContext(ctx context.Context) []string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	var locales []string
+	for _, locale := range md.Get(LocaleMetadataKey) {
+		if locale = strings.TrimSpace(locale); locale != "" {
+			locales = append(locales, locale)
+		}
+	}
+	for _, key := range acceptLanguageMetadataKeys {
+		for _, header := range md.Get(key) {
+			locales = append(locales, ParseAcceptLanguage(header)...)
+		}
+	}
+	return locales
+}
+
+// UnaryServerInterceptor returns the AppError of the handlers with their
+// message in the locale of the request, see LocalesFromIncomingContext.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		return resp, localizeError(ctx, err)
+	}
+}
+
+// StreamServerInterceptor is the UnaryServerInterceptor of streams.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return localizeError(ss.Context(), handler(srv, ss))
+	}
+}
+
+func localizeError(ctx context.Context, err error) error {
+	var appErr AppError
+	if !errors.As(err, &appErr) {
+		return err
+	}
+	return appErr.LocalizedGRPCStatus(LocalesFromIncomingContext(ctx)...).Err()
+}
@@ -0,0 +1,251 @@
+package errors_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	apperrors "github.com/phamquanandpad/training-project/go/services/todo/internal/errors"
+)
+
+func TestLocalizedMessage_Lookup(t *testing.T) {
+	type testcase struct {
+		message         apperrors.LocalizedMessage
+		locales         []string
+		expectedLocale  string
+		expectedMessage string
+		expectedOK      bool
+	}
+
+	t.Parallel()
+
+	catalog := apperrors.LocalizedMessage{
+		apperrors.LocaleEn: "Not found",
+		apperrors.LocaleJa: "対象がありません",
+		apperrors.LocaleVi: "Không tìm thấy",
+	}
+
+	testTables := map[string]testcase{
+		"Same locale": {
+			message:         catalog,
+			locales:         []string{"vi-VN", "en-US"},
+			expectedLocale:  apperrors.LocaleVi,
+			expectedMessage: "Không tìm thấy",
+			expectedOK:      true,
+		},
+		"Same language before the next locale": {
+			message:         catalog,
+			locales:         []string{"fr-FR", "en-GB", "ja-JP"},
+			expectedLocale:  apperrors.LocaleEn,
+			expectedMessage: "Not found",
+			expectedOK:      true,
+		},
+		"Default locale": {
+			message:         catalog,
+			locales:         []string{"fr"},
+			expectedLocale:  apperrors.LocaleJa,
+			expectedMessage: "対象がありません",
+			expectedOK:      true,
+		},
+		"English without the default locale": {
+			message:         apperrors.LocalizedMessage{apperrors.LocaleEn: "Not found", "fr-FR": "Introuvable"},
+			expectedLocale:  apperrors.LocaleEn,
+			expectedMessage: "Not found",
+			expectedOK:      true,
+		},
+		"None of the locales": {
+			message:         apperrors.LocalizedMessage{"fr-FR": "Introuvable", "de-DE": "Nicht gefunden"},
+			locales:         []string{"vi"},
+			expectedLocale:  "de-DE",
+			expectedMessage: "Nicht gefunden",
+			expectedOK:      true,
+		},
+		"No message": {
+			locales: []string{"vi"},
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			locale, message, ok := tt.message.Lookup(tt.locales...)
+			if locale != tt.expectedLocale || message != tt.expectedMessage || ok != tt.expectedOK {
+				t.Fatalf(
+					"LocalizedMessage.Lookup() = %q, %q, %v, want %q, %q, %v",
+					locale, message, ok, tt.expectedLocale, tt.expectedMessage, tt.expectedOK,
+				)
+			}
+		})
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	t.Parallel()
+
+	locales := apperrors.ParseAcceptLanguage("en;q=0.8, vi-VN,fr;q=0, *;q=0.1, vi;q=0.9, ja;q=bad")
+
+	expected := []string{"vi-VN", "vi", "en"}
+	if diff := cmp.Diff(locales, expected); diff != "" {
+		t.Fatalf("ParseAcceptLanguage() mismatch (-actual +expected):\n%s", diff)
+	}
+}
+
+// localizedMessagesOf returns the localized messages of the status of err, in
+// order.
+func localizedMessagesOf(t *testing.T, err error) []*errdetails.LocalizedMessage {
+	t.Helper()
+
+	stt, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("status.FromError(%v) is not a status", err)
+	}
+	var messages []*errdetails.LocalizedMessage
+	for _, detail := range stt.Details() {
+		if message, ok := detail.(*errdetails.LocalizedMessage); ok {
+			messages = append(messages, message)
+		}
+	}
+	return messages
+}
+
+// localizedMessageOf returns the primary localized message of the status of
+// err, the first one.
+func localizedMessageOf(t *testing.T, err error) *errdetails.LocalizedMessage {
+	t.Helper()
+
+	messages := localizedMessagesOf(t, err)
+	if len(messages) == 0 {
+		return nil
+	}
+	return messages[0]
+}
+
+func TestAppError_LocalizedGRPCStatus(t *testing.T) {
+	type testcase struct {
+		err      apperrors.AppError
+		locales  []string
+		expected *errdetails.LocalizedMessage
+	}
+
+	t.Parallel()
+
+	testTables := map[string]testcase{
+		"Default message of the type": {
+			err:      apperrors.NewParameterError("invalid task", nil, nil),
+			expected: &errdetails.LocalizedMessage{Locale: apperrors.LocaleJa, Message: apperrors.ParameterErrorJaMessage},
+		},
+		"Default message in the locale of the request": {
+			err:      apperrors.NewResourceExhaustedError("rate limited", nil, nil),
+			locales:  []string{"vi"},
+			expected: &errdetails.LocalizedMessage{Locale: apperrors.LocaleVi, Message: "Quá nhiều yêu cầu, vui lòng thử lại sau"},
+		},
+		"Template with parameters from the metadata": {
+			err: apperrors.NewNotFoundError(
+				"todo not found",
+				nil,
+				apperrors.LocalizedMessage{
+					apperrors.LocaleEn: "Todo {todo_id} of {user} was not found",
+					apperrors.LocaleJa: "タスク {todo_id} がありません",
+				},
+				apperrors.ToMetadataInt("todo_id", 42),
+			),
+			locales:  []string{"en-US"},
+			expected: &errdetails.LocalizedMessage{Locale: apperrors.LocaleEn, Message: "Todo 42 of {user} was not found"},
+		},
+		"No message": {
+			err:      apperrors.NewCanceledError("canceled", nil),
+			expected: nil,
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			message := localizedMessageOf(t, tt.err.LocalizedGRPCStatus(tt.locales...).Err())
+			if diff := cmp.Diff(message, tt.expected, cmp.Comparer(func(a, b *errdetails.LocalizedMessage) bool {
+				return a.GetLocale() == b.GetLocale() && a.GetMessage() == b.GetMessage()
+			})); diff != "" {
+				t.Fatalf("AppError.LocalizedGRPCStatus() mismatch (-actual +expected):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAppError_LocalizedGRPCStatus_EveryMessage(t *testing.T) {
+	t.Parallel()
+
+	err := apperrors.NewNotFoundError(
+		"todo not found",
+		nil,
+		apperrors.LocalizedMessage{
+			apperrors.LocaleEn: "Todo {todo_id} was not found",
+			apperrors.LocaleJa: "タスク {todo_id} がありません",
+			apperrors.LocaleVi: "Không tìm thấy công việc {todo_id}",
+		},
+		apperrors.ToMetadataInt("todo_id", 42),
+	).LocalizedGRPCStatus("vi").Err()
+
+	var actual []string
+	for _, message := range localizedMessagesOf(t, err) {
+		actual = append(actual, message.GetLocale()+": "+message.GetMessage())
+	}
+	expected := []string{
+		"vi-VN: Không tìm thấy công việc 42",
+		"en-US: Todo 42 was not found",
+		"ja-JP: タスク 42 がありません",
+	}
+	if diff := cmp.Diff(actual, expected); diff != "" {
+		t.Fatalf("localized messages mismatch (-actual +expected):\n%s", diff)
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	type testcase struct {
+		md             metadata.MD
+		expectedLocale string
+	}
+
+	t.Parallel()
+
+	testTables := map[string]testcase{
+		"Locale metadata before Accept-Language": {
+			md:             metadata.Pairs(apperrors.LocaleMetadataKey, "en", "accept-language", "vi-VN"),
+			expectedLocale: apperrors.LocaleEn,
+		},
+		"Accept-Language forwarded by grpc-gateway": {
+			md:             metadata.Pairs("grpcgateway-accept-language", "fr-FR, vi;q=0.5"),
+			expectedLocale: apperrors.LocaleVi,
+		},
+		"No locale": {
+			md:             metadata.MD{},
+			expectedLocale: apperrors.DefaultLocale,
+		},
+	}
+
+	interceptor := apperrors.UnaryServerInterceptor()
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(context.Context, any) (any, error) {
+				return nil, apperrors.NewNotFoundError("todo not found", nil, nil)
+			})
+
+			message := localizedMessageOf(t, err)
+			if message.GetLocale() != tt.expectedLocale {
+				t.Fatalf("locale of the error = %q, want %q", message.GetLocale(), tt.expectedLocale)
+			}
+		})
+	}
+}
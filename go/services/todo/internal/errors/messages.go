@@ -9,4 +9,55 @@ const (
 	InvalidRequestJaMessage     = "不正なリクエストです"
 	PreconditionFailedJaMessage = "許可されていない操作です"
 	ParameterErrorJaMessage     = "パラメーターエラー"
+	ResourceExhaustedJaMessage  = "リクエストが多すぎます、しばらくしてから再度お試しください"
 )
+
+// defaultMessages are the messages of the errors created without a localized
+// message of their own, by error type.
+var defaultMessages = map[ErrorType]LocalizedMessage{
+	ErrorTypes.AlreadyExistedError: {
+		LocaleEn: "The resource already exists",
+		LocaleJa: AlreadyExistsJaMessage,
+		LocaleVi: "Đối tượng đã tồn tại",
+	},
+	ErrorTypes.AuthNError: {
+		LocaleEn: "Authentication failed",
+		LocaleJa: AuthNJaMessage,
+		LocaleVi: "Xác thực không thành công",
+	},
+	ErrorTypes.AuthZError: {
+		LocaleEn: "This operation is not allowed",
+		LocaleJa: AuthZJaMessage,
+		LocaleVi: "Thao tác này không được phép",
+	},
+	ErrorTypes.NotFoundError: {
+		LocaleEn: "The resource was not found",
+		LocaleJa: NotFoundJaMessage,
+		LocaleVi: "Không tìm thấy đối tượng",
+	},
+	ErrorTypes.ParameterError: {
+		LocaleEn: "Invalid parameter",
+		LocaleJa: ParameterErrorJaMessage,
+		LocaleVi: "Tham số không hợp lệ",
+	},
+	ErrorTypes.PreconditionFailedError: {
+		LocaleEn: "Invalid request",
+		LocaleJa: InvalidRequestJaMessage,
+		LocaleVi: "Yêu cầu không hợp lệ",
+	},
+	ErrorTypes.ResourceExhaustedError: {
+		LocaleEn: "Too many requests, please try again later",
+		LocaleJa: ResourceExhaustedJaMessage,
+		LocaleVi: "Quá nhiều yêu cầu, vui lòng thử lại sau",
+	},
+	ErrorTypes.InternalError: {
+		LocaleEn: "An error occurred, please contact the administrator",
+		LocaleJa: InvalidJaMessage,
+		LocaleVi: "Đã xảy ra lỗi, vui lòng liên hệ quản trị viên",
+	},
+	ErrorTypes.UnknownError: {
+		LocaleEn: "An error occurred, please contact the administrator",
+		LocaleJa: InvalidJaMessage,
+		LocaleVi: "Đã xảy ra lỗi, vui lòng liên hệ quản trị viên",
+	},
+}
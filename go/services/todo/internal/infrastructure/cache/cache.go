@@ -0,0 +1,99 @@
+// Package cache caches the todo reads in front of the datastore. Every key of
+// a user includes the generation of the user, and every write through the
+// wrapped gateways gives the user a new generation, so a write invalidates all
+// the cached reads of the user at once, its todos and its lists.
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/config"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+// Backend stores cached values. A ttl of zero keeps the value until it is
+// evicted.
+type Backend interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// NewBackend returns the backend selected by conf, or nil when caching is
+// disabled. The memory backend is only for a process that makes every write
+// of the todos it caches itself.
+func NewBackend(conf *config.CacheConfig) (Backend, func(), error) {
+	switch conf.CacheBackend {
+	case config.CacheBackendNone:
+		return nil, func() {}, nil
+	case config.CacheBackendMemory:
+		return NewLRU(conf.CacheLRUSize), func() {}, nil
+	case config.CacheBackendRedis:
+		redis := NewRedis(RedisConfig{
+			Addr:     conf.CacheRedisAddr,
+			Password: conf.CacheRedisPassword,
+			DB:       conf.CacheRedisDB,
+			Timeout:  conf.CacheRedisTimeout,
+		})
+		return redis, func() { _ = redis.Close() }, nil
+	}
+	return nil, nil, fmt.Errorf("unsupported cache backend %q", conf.CacheBackend)
+}
+
+// NewSharedBackend returns the backend selected by conf for a process that
+// shares the cache with others, such as a worker writing todos the service
+// reads. It refuses the memory backend, whose invalidations other processes
+// would never see.
+func NewSharedBackend(conf *config.CacheConfig) (Backend, func(), error) {
+	if conf.CacheBackend == config.CacheBackendMemory {
+		return nil, nil, fmt.Errorf("the %q cache backend is per process and cannot be shared: use %q", config.CacheBackendMemory, config.CacheBackendRedis)
+	}
+	return NewBackend(conf)
+}
+
+const keyPrefix = "todo:v1:user:"
+
+func generationKey(userID todo.UserID) string {
+	return fmt.Sprintf("%s%d:gen", keyPrefix, userID)
+}
+
+func todoKey(userID todo.UserID, generation string, todoID todo.TodoID) string {
+	return fmt.Sprintf("%s%d:%s:todo:%d", keyPrefix, userID, generation, todoID)
+}
+
+func listKey(userID todo.UserID, generation string, sortingType todo.SortingType) string {
+	return fmt.Sprintf("%s%d:%s:list:%s", keyPrefix, userID, generation, sortingType)
+}
+
+// generation returns the current generation of the user, starting a new one
+// when the user has none yet, e.g. after it has been evicted.
+func generation(ctx context.Context, backend Backend, userID todo.UserID) (string, error) {
+	value, ok, err := backend.Get(ctx, generationKey(userID))
+	if err != nil {
+		return "", err
+	}
+	if ok {
+		return string(value), nil
+	}
+
+	return newGeneration(ctx, backend, userID)
+}
+
+// newGeneration gives the user a new generation, which invalidates every key
+// of the previous one. Generations are random, so that a generation lost to an
+// eviction is never reused.
+func newGeneration(ctx context.Context, backend Backend, userID todo.UserID) (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	generation := hex.EncodeToString(b)
+
+	if err := backend.Set(ctx, generationKey(userID), []byte(generation), 0); err != nil {
+		return "", err
+	}
+	return generation, nil
+}
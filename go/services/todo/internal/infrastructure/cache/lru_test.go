@@ -0,0 +1,102 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/cache"
+)
+
+func TestLRU(t *testing.T) {
+	t.Parallel()
+
+	type set struct {
+		key   string
+		value string
+		ttl   time.Duration
+	}
+
+	type testcase struct {
+		capacity int
+		sets     []set
+		gets     []string // read between the sets and the wait.
+		wait     time.Duration
+		expected map[string]string
+	}
+
+	testTables := map[string]testcase{
+		"Get the values set": {
+			capacity: 2,
+			sets:     []set{{key: "a", value: "1"}, {key: "b", value: "2"}},
+			expected: map[string]string{"a": "1", "b": "2"},
+		},
+		"Set overwrites the value": {
+			capacity: 2,
+			sets:     []set{{key: "a", value: "1"}, {key: "a", value: "2"}},
+			expected: map[string]string{"a": "2"},
+		},
+		"Evict the least recently used value": {
+			capacity: 2,
+			sets:     []set{{key: "a", value: "1"}, {key: "b", value: "2"}, {key: "c", value: "3"}},
+			expected: map[string]string{"b": "2", "c": "3"},
+		},
+		"Reading a value keeps it": {
+			capacity: 2,
+			sets:     []set{{key: "a", value: "1"}, {key: "b", value: "2"}},
+			gets:     []string{"a"},
+			expected: map[string]string{"a": "1"},
+		},
+		"Expired values are dropped": {
+			capacity: 2,
+			sets:     []set{{key: "a", value: "1", ttl: 20 * time.Millisecond}, {key: "b", value: "2"}},
+			wait:     50 * time.Millisecond,
+			expected: map[string]string{"b": "2"},
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			lru := cache.NewLRU(tt.capacity)
+
+			for _, s := range tt.sets {
+				if err := lru.Set(ctx, s.key, []byte(s.value), s.ttl); err != nil {
+					t.Fatalf("LRU.Set() error = %v", err)
+				}
+			}
+			for _, key := range tt.gets {
+				if _, _, err := lru.Get(ctx, key); err != nil {
+					t.Fatalf("LRU.Get() error = %v", err)
+				}
+			}
+			if len(tt.gets) > 0 {
+				// Push out the values not read.
+				if err := lru.Set(ctx, "other", []byte("value"), 0); err != nil {
+					t.Fatalf("LRU.Set() error = %v", err)
+				}
+				tt.expected["other"] = "value"
+			}
+			time.Sleep(tt.wait)
+
+			actual := map[string]string{}
+			for _, key := range []string{"a", "b", "c", "other"} {
+				value, ok, err := lru.Get(ctx, key)
+				if err != nil {
+					t.Fatalf("LRU.Get() error = %v", err)
+				}
+				if ok {
+					actual[key] = string(value)
+				}
+			}
+
+			if diff := cmp.Diff(actual, tt.expected); diff != "" {
+				t.Errorf("LRU values mismatch (-actual +expected):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package cache_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/cache"
+)
+
+// fakeRedisServer serves GET, SET with PX, AUTH and SELECT over RESP, enough
+// for the Redis backend.
+type fakeRedisServer struct {
+	listener net.Listener
+	password string
+
+	mu     sync.Mutex
+	values map[string]fakeRedisValue
+}
+
+type fakeRedisValue struct {
+	value     string
+	expiresAt time.Time
+}
+
+func newFakeRedisServer(t *testing.T, password string) *fakeRedisServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	s := &fakeRedisServer{
+		listener: listener,
+		password: password,
+		values:   map[string]fakeRedisValue{},
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+
+	return s
+}
+
+func (s *fakeRedisServer) Addr() string {
+	return s.listener.Addr().String()
+}
+
+func (s *fakeRedisServer) serve(conn net.Conn) {
+	defer conn.Close()
+	r, w := bufio.NewReader(conn), bufio.NewWriter(conn)
+
+	authenticated := s.password == ""
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			return
+		}
+
+		switch cmd := strings.ToUpper(args[0]); {
+		case cmd == "AUTH":
+			authenticated = len(args) == 2 && args[1] == s.password
+			if !authenticated {
+				fmt.Fprint(w, "-WRONGPASS invalid password\r\n")
+				break
+			}
+			fmt.Fprint(w, "+OK\r\n")
+		case !authenticated:
+			fmt.Fprint(w, "-NOAUTH Authentication required.\r\n")
+		case cmd == "SELECT":
+			fmt.Fprint(w, "+OK\r\n")
+		case cmd == "GET" && len(args) == 2:
+			value, ok := s.get(args[1])
+			if !ok {
+				fmt.Fprint(w, "$-1\r\n")
+				break
+			}
+			fmt.Fprintf(w, "$%d\r\n%s\r\n", len(value), value)
+		case cmd == "SET" && (len(args) == 3 || len(args) == 5 && strings.ToUpper(args[3]) == "PX"):
+			var ttl time.Duration
+			if len(args) == 5 {
+				ms, _ := strconv.Atoi(args[4])
+				ttl = time.Duration(ms) * time.Millisecond
+			}
+			s.set(args[1], args[2], ttl)
+			fmt.Fprint(w, "+OK\r\n")
+		default:
+			fmt.Fprintf(w, "-ERR unknown command '%s'\r\n", args[0])
+		}
+		if err := w.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+func (s *fakeRedisServer) get(key string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v, ok := s.values[key]
+	if !ok || !v.expiresAt.IsZero() && !time.Now().Before(v.expiresAt) {
+		return "", false
+	}
+	return v.value, true
+}
+
+func (s *fakeRedisServer) set(key string, value string, ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v := fakeRedisValue{value: value}
+	if ttl > 0 {
+		v.expiresAt = time.Now().Add(ttl)
+	}
+	s.values[key] = v
+}
+
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "*")))
+	if err != nil || n < 1 {
+		return nil, errors.New("invalid command")
+	}
+
+	args := make([]string, n)
+	for i := range args {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "$")))
+		if err != nil {
+			return nil, err
+		}
+		b := make([]byte, size+2)
+		if _, err := io.ReadFull(r, b); err != nil {
+			return nil, err
+		}
+		args[i] = string(b[:size])
+	}
+	return args, nil
+}
+
+func TestRedis(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	server := newFakeRedisServer(t, "secret")
+	redis := cache.NewRedis(cache.RedisConfig{Addr: server.Addr(), Password: "secret", DB: 1, Timeout: time.Second})
+	defer redis.Close()
+
+	if _, ok, err := redis.Get(ctx, "a"); err != nil || ok {
+		t.Fatalf("Redis.Get() of a missing key = %v, %v, want a miss", ok, err)
+	}
+
+	value := []byte("line 1\r\nline 2")
+	if err := redis.Set(ctx, "a", value, 0); err != nil {
+		t.Fatalf("Redis.Set() error = %v", err)
+	}
+	if err := redis.Set(ctx, "b", []byte("2"), 20*time.Millisecond); err != nil {
+		t.Fatalf("Redis.Set() error = %v", err)
+	}
+
+	got, ok, err := redis.Get(ctx, "a")
+	if err != nil || !ok || string(got) != string(value) {
+		t.Errorf("Redis.Get() = %q, %v, %v, want %q", got, ok, err, value)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	if _, ok, err := redis.Get(ctx, "b"); err != nil || ok {
+		t.Errorf("Redis.Get() of an expired key = %v, %v, want a miss", ok, err)
+	}
+}
+
+func TestRedis_WrongPassword(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeRedisServer(t, "secret")
+	redis := cache.NewRedis(cache.RedisConfig{Addr: server.Addr(), Password: "wrong", Timeout: time.Second})
+	defer redis.Close()
+
+	_, _, err := redis.Get(context.Background(), "a")
+	var redisErr cache.RedisError
+	if !errors.As(err, &redisErr) {
+		t.Errorf("Redis.Get() error = %v, want a RedisError", err)
+	}
+}
+
+func TestRedis_Unreachable(t *testing.T) {
+	t.Parallel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	addr := listener.Addr().String()
+	_ = listener.Close()
+
+	redis := cache.NewRedis(cache.RedisConfig{Addr: addr, Timeout: time.Second})
+	if err := redis.Set(context.Background(), "a", []byte("1"), 0); err == nil {
+		t.Error("Redis.Set() error = nil, want an error for an unreachable server")
+	}
+}
@@ -0,0 +1,159 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+type todoReader struct {
+	next    gateway.TodoQueriesGateway
+	backend Backend
+	ttl     time.Duration
+	loads   singleflight.Group
+}
+
+// NewTodoReader caches GetTodo and ListTodos of next for ttl. Concurrent
+// misses of the same key share one load, run with the context of the first
+// caller. Reads that require the primary are not cached, and the cache is
+// bypassed when the backend fails.
+func NewTodoReader(next gateway.TodoQueriesGateway, backend Backend, ttl time.Duration) gateway.TodoQueriesGateway {
+	return &todoReader{
+		next:    next,
+		backend: backend,
+		ttl:     ttl,
+	}
+}
+
+func (r *todoReader) GetTodo(
+	ctx context.Context,
+	todoID todo.TodoID,
+	userID todo.UserID,
+) (*todo.Todo, error) {
+	var cached *todo.Todo
+	err := r.cached(ctx, userID, func(generation string) string {
+		return todoKey(userID, generation, todoID)
+	}, &cached, func() (any, error) {
+		return r.next.GetTodo(ctx, todoID, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return cached, nil
+}
+
+type cachedTodoList struct {
+	Todos []*todo.Todo `json:"todos"`
+	Total int          `json:"total"`
+}
+
+func (r *todoReader) ListTodos(
+	ctx context.Context,
+	userID todo.UserID,
+	sortingType todo.SortingType,
+) ([]*todo.Todo, int, error) {
+	var cached cachedTodoList
+	err := r.cached(ctx, userID, func(generation string) string {
+		return listKey(userID, generation, sortingType)
+	}, &cached, func() (any, error) {
+		todos, total, err := r.next.ListTodos(ctx, userID, sortingType)
+		if err != nil {
+			return nil, err
+		}
+		return cachedTodoList{Todos: todos, Total: total}, nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return cached.Todos, cached.Total, nil
+}
+
+// ListTodosByExternalIDs is not cached: it is used by imports, which need
+// the todos as they are.
+func (r *todoReader) ListTodosByExternalIDs(
+	ctx context.Context,
+	userID todo.UserID,
+	externalIDs []string,
+) ([]*todo.Todo, error) {
+	return r.next.ListTodosByExternalIDs(ctx, userID, externalIDs)
+}
+
+// ListTodosByIDs is not cached, for the same reason as ListTodosByExternalIDs.
+func (r *todoReader) ListTodosByIDs(
+	ctx context.Context,
+	userID todo.UserID,
+	todoIDs []todo.TodoID,
+) ([]*todo.Todo, error) {
+	return r.next.ListTodosByIDs(ctx, userID, todoIDs)
+}
+
+// cached decodes into dest the value cached under the key of the current
+// generation of the user, or the value returned by load, which is then
+// cached. Every caller gets its own copy, decoded from the encoded value.
+func (r *todoReader) cached(
+	ctx context.Context,
+	userID todo.UserID,
+	key func(generation string) string,
+	dest any,
+	load func() (any, error),
+) error {
+	if gateway.PrimaryReadsRequired(ctx) {
+		return decodeLoaded(load, dest)
+	}
+
+	generation, err := generation(ctx, r.backend, userID)
+	if err != nil {
+		log.Printf("cache: get generation of user %d: %v", userID, err)
+		return decodeLoaded(load, dest)
+	}
+	k := key(generation)
+
+	value, ok, err := r.backend.Get(ctx, k)
+	if err != nil {
+		log.Printf("cache: get %s: %v", k, err)
+	}
+	if ok {
+		return json.Unmarshal(value, dest)
+	}
+
+	shared, err, _ := r.loads.Do(k, func() (any, error) {
+		loaded, err := load()
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(loaded)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := r.backend.Set(ctx, k, value, r.ttl); err != nil {
+			log.Printf("cache: set %s: %v", k, err)
+		}
+		return value, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(shared.([]byte), dest)
+}
+
+func decodeLoaded(load func() (any, error), dest any) error {
+	loaded, err := load()
+	if err != nil {
+		return err
+	}
+	value, err := json.Marshal(loaded)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(value, dest)
+}
@@ -0,0 +1,291 @@
+package cache_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/phamquanandpad/training-project/go/pkg/cast"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/cache"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/memory"
+)
+
+// countingTodoReader counts the reads that reach the store. When release is
+// set, reads wait for it to be closed.
+type countingTodoReader struct {
+	gateway.TodoQueriesGateway
+	calls   atomic.Int32
+	release chan struct{}
+}
+
+func (r *countingTodoReader) GetTodo(ctx context.Context, todoID todo.TodoID, userID todo.UserID) (*todo.Todo, error) {
+	r.calls.Add(1)
+	if r.release != nil {
+		<-r.release
+	}
+	return r.TodoQueriesGateway.GetTodo(ctx, todoID, userID)
+}
+
+func (r *countingTodoReader) ListTodos(ctx context.Context, userID todo.UserID, sortingType todo.SortingType) ([]*todo.Todo, int, error) {
+	r.calls.Add(1)
+	return r.TodoQueriesGateway.ListTodos(ctx, userID, sortingType)
+}
+
+type cachedGateways struct {
+	next       *countingTodoReader
+	reader     gateway.TodoQueriesGateway
+	writer     gateway.TodoCommandsGateway
+	userWriter gateway.UserCommandsGateway
+	eraser     gateway.UserErasureCommandsGateway
+}
+
+func newCachedGateways(backend cache.Backend) cachedGateways {
+	store := memory.NewStore()
+	store.SeedUsers(&todo.User{ID: todo.UserID(1)}, &todo.User{ID: todo.UserID(2)})
+	store.SeedTodos(
+		&todo.Todo{ID: todo.TodoID(1), UserID: todo.UserID(1), Task: "todo task 1", Position: "i"},
+		&todo.Todo{ID: todo.TodoID(2), UserID: todo.UserID(1), Task: "todo task 2", Position: "r"},
+		&todo.Todo{ID: todo.TodoID(3), UserID: todo.UserID(2), Task: "todo task 3", Position: "i"},
+	)
+
+	next := &countingTodoReader{TodoQueriesGateway: memory.NewTodoReader(store)}
+	return cachedGateways{
+		next:       next,
+		reader:     cache.NewTodoReader(next, backend, time.Minute),
+		writer:     cache.NewTodoWriter(memory.NewTodoWriter(store), backend),
+		userWriter: cache.NewUserWriter(memory.NewUserWriter(store), backend),
+		eraser:     cache.NewUserEraser(memory.NewUserEraser(store), backend),
+	}
+}
+
+func backends(t *testing.T) map[string]func() cache.Backend {
+	return map[string]func() cache.Backend{
+		"memory": func() cache.Backend {
+			return cache.NewLRU(100)
+		},
+		"redis": func() cache.Backend {
+			redis := cache.NewRedis(cache.RedisConfig{Addr: newFakeRedisServer(t, "").Addr(), Timeout: time.Second})
+			t.Cleanup(func() { _ = redis.Close() })
+			return redis
+		},
+	}
+}
+
+func Test_todoReader_Cache(t *testing.T) {
+	t.Parallel()
+
+	type testcase struct {
+		// run reads and writes through g, and returns the result of the last
+		// read.
+		run           func(ctx context.Context, g cachedGateways) (any, error)
+		expected      any
+		expectedCalls int32
+	}
+
+	testTables := map[string]testcase{
+		"Get Todo twice loads it once": {
+			run: func(ctx context.Context, g cachedGateways) (any, error) {
+				if _, err := g.reader.GetTodo(ctx, todo.TodoID(1), todo.UserID(1)); err != nil {
+					return nil, err
+				}
+				return g.reader.GetTodo(ctx, todo.TodoID(1), todo.UserID(1))
+			},
+			expected:      &todo.Todo{ID: todo.TodoID(1), UserID: todo.UserID(1), Task: "todo task 1", Position: "i"},
+			expectedCalls: 1,
+		},
+		"Get missing Todo twice loads it once": {
+			run: func(ctx context.Context, g cachedGateways) (any, error) {
+				if _, err := g.reader.GetTodo(ctx, todo.TodoID(3), todo.UserID(1)); err != nil {
+					return nil, err
+				}
+				return g.reader.GetTodo(ctx, todo.TodoID(3), todo.UserID(1))
+			},
+			expected:      (*todo.Todo)(nil),
+			expectedCalls: 1,
+		},
+		"List Todos twice loads them once": {
+			run: func(ctx context.Context, g cachedGateways) (any, error) {
+				if _, _, err := g.reader.ListTodos(ctx, todo.UserID(1), todo.SortingTypes.Position); err != nil {
+					return nil, err
+				}
+				todos, _, err := g.reader.ListTodos(ctx, todo.UserID(1), todo.SortingTypes.Position)
+				return todoTasks(todos), err
+			},
+			expected:      []string{"todo task 1", "todo task 2"},
+			expectedCalls: 1,
+		},
+		"Update Todo invalidates the todo and the lists of the user": {
+			run: func(ctx context.Context, g cachedGateways) (any, error) {
+				if _, err := g.reader.GetTodo(ctx, todo.TodoID(1), todo.UserID(1)); err != nil {
+					return nil, err
+				}
+				if _, _, err := g.reader.ListTodos(ctx, todo.UserID(1), todo.SortingTypes.Position); err != nil {
+					return nil, err
+				}
+				if _, err := g.writer.UpdateTodo(ctx, todo.TodoID(1), todo.UserID(1), todo.UpdateTodo{
+					Task: cast.Ptr("updated todo task 1"),
+				}); err != nil {
+					return nil, err
+				}
+				if _, err := g.reader.GetTodo(ctx, todo.TodoID(1), todo.UserID(1)); err != nil {
+					return nil, err
+				}
+				todos, _, err := g.reader.ListTodos(ctx, todo.UserID(1), todo.SortingTypes.Position)
+				return todoTasks(todos), err
+			},
+			expected:      []string{"updated todo task 1", "todo task 2"},
+			expectedCalls: 4,
+		},
+		"Create Todo invalidates the lists of the user only": {
+			run: func(ctx context.Context, g cachedGateways) (any, error) {
+				if _, _, err := g.reader.ListTodos(ctx, todo.UserID(1), todo.SortingTypes.Position); err != nil {
+					return nil, err
+				}
+				if _, _, err := g.reader.ListTodos(ctx, todo.UserID(2), todo.SortingTypes.Position); err != nil {
+					return nil, err
+				}
+				if _, err := g.writer.CreateTodo(ctx, todo.NewTodo{UserID: todo.UserID(1), Task: "new todo task"}); err != nil {
+					return nil, err
+				}
+				if _, _, err := g.reader.ListTodos(ctx, todo.UserID(2), todo.SortingTypes.Position); err != nil {
+					return nil, err
+				}
+				todos, _, err := g.reader.ListTodos(ctx, todo.UserID(1), todo.SortingTypes.Position)
+				return todoTasks(todos), err
+			},
+			expected:      []string{"todo task 1", "todo task 2", "new todo task"},
+			expectedCalls: 3,
+		},
+		"Delete Todo invalidates a cached miss of another todo": {
+			run: func(ctx context.Context, g cachedGateways) (any, error) {
+				if _, err := g.reader.GetTodo(ctx, todo.TodoID(2), todo.UserID(1)); err != nil {
+					return nil, err
+				}
+				if err := g.writer.SoftDeleteTodo(ctx, todo.TodoID(2), todo.UserID(1)); err != nil {
+					return nil, err
+				}
+				return g.reader.GetTodo(ctx, todo.TodoID(2), todo.UserID(1))
+			},
+			expected:      (*todo.Todo)(nil),
+			expectedCalls: 2,
+		},
+		"Soft delete User invalidates the reads of the user": {
+			run: func(ctx context.Context, g cachedGateways) (any, error) {
+				if _, _, err := g.reader.ListTodos(ctx, todo.UserID(1), todo.SortingTypes.Position); err != nil {
+					return nil, err
+				}
+				if err := g.userWriter.SoftDeleteUser(ctx, todo.UserID(1)); err != nil {
+					return nil, err
+				}
+				todos, _, err := g.reader.ListTodos(ctx, todo.UserID(1), todo.SortingTypes.Position)
+				return todoTasks(todos), err
+			},
+			expected:      []string{"todo task 1", "todo task 2"},
+			expectedCalls: 2,
+		},
+		"Erase User invalidates the reads of the user": {
+			run: func(ctx context.Context, g cachedGateways) (any, error) {
+				if _, err := g.reader.GetTodo(ctx, todo.TodoID(1), todo.UserID(1)); err != nil {
+					return nil, err
+				}
+				if _, err := g.eraser.EraseUser(ctx, todo.UserID(1)); err != nil {
+					return nil, err
+				}
+				return g.reader.GetTodo(ctx, todo.TodoID(1), todo.UserID(1))
+			},
+			expected:      (*todo.Todo)(nil),
+			expectedCalls: 2,
+		},
+		"Reads that require the primary are not cached": {
+			run: func(ctx context.Context, g cachedGateways) (any, error) {
+				ctx = gateway.WithPrimaryReads(ctx)
+				if _, err := g.reader.GetTodo(ctx, todo.TodoID(1), todo.UserID(1)); err != nil {
+					return nil, err
+				}
+				return g.reader.GetTodo(ctx, todo.TodoID(1), todo.UserID(1))
+			},
+			expected:      &todo.Todo{ID: todo.TodoID(1), UserID: todo.UserID(1), Task: "todo task 1", Position: "i"},
+			expectedCalls: 2,
+		},
+	}
+
+	for backendName, newBackend := range backends(t) {
+		newBackend := newBackend
+		for name, tt := range testTables {
+			tt := tt
+			t.Run(backendName+"/"+name, func(t *testing.T) {
+				t.Parallel()
+				g := newCachedGateways(newBackend())
+
+				actual, err := tt.run(context.Background(), g)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				if diff := cmp.Diff(actual, tt.expected); diff != "" {
+					t.Errorf("cached read mismatch (-actual +expected):\n%s", diff)
+				}
+				if calls := g.next.calls.Load(); calls != tt.expectedCalls {
+					t.Errorf("reads of the store = %d, want %d", calls, tt.expectedCalls)
+				}
+			})
+		}
+	}
+}
+
+func Test_todoReader_GetTodo_CollapsesConcurrentMisses(t *testing.T) {
+	t.Parallel()
+
+	g := newCachedGateways(cache.NewLRU(100))
+	g.next.release = make(chan struct{})
+
+	const n = 10
+	var wg sync.WaitGroup
+	results := make([]*todo.Todo, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var err error
+			results[i], err = g.reader.GetTodo(context.Background(), todo.TodoID(1), todo.UserID(1))
+			if err != nil {
+				t.Errorf("todoReader.GetTodo() error = %v", err)
+			}
+		}(i)
+	}
+
+	// Let the first load wait until the others have missed too.
+	for g.next.calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(g.next.release)
+	wg.Wait()
+
+	if calls := g.next.calls.Load(); calls != 1 {
+		t.Errorf("reads of the store = %d, want 1", calls)
+	}
+	for _, result := range results {
+		if result == nil || result.Task != "todo task 1" {
+			t.Errorf("todoReader.GetTodo() = %v, want todo 1", result)
+		}
+	}
+	// Callers get their own copy.
+	if results[0] == results[1] {
+		t.Error("todoReader.GetTodo() callers share the same todo")
+	}
+}
+
+func todoTasks(todos []*todo.Todo) []string {
+	tasks := make([]string, 0, len(todos))
+	for _, t := range todos {
+		tasks = append(tasks, t.Task)
+	}
+	return tasks
+}
@@ -0,0 +1,96 @@
+package cache
+
+import (
+	"context"
+	"log"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+// invalidate gives the user a new generation. A failure is logged rather than
+// returned, since the write itself is done: the stale reads expire with their
+// ttl.
+func invalidate(ctx context.Context, backend Backend, userID todo.UserID) {
+	if _, err := newGeneration(ctx, backend, userID); err != nil {
+		log.Printf("cache: invalidate user %d: %v", userID, err)
+	}
+}
+
+type todoWriter struct {
+	next    gateway.TodoCommandsGateway
+	backend Backend
+}
+
+// NewTodoWriter invalidates the cached reads of the user after every write of
+// next, failed ones included, as a failed write may still have been committed.
+func NewTodoWriter(next gateway.TodoCommandsGateway, backend Backend) gateway.TodoCommandsGateway {
+	return &todoWriter{
+		next:    next,
+		backend: backend,
+	}
+}
+
+func (w *todoWriter) CreateTodo(
+	ctx context.Context,
+	newTodo todo.NewTodo,
+) (*todo.Todo, error) {
+	defer invalidate(ctx, w.backend, newTodo.UserID)
+	return w.next.CreateTodo(ctx, newTodo)
+}
+
+func (w *todoWriter) UpdateTodo(
+	ctx context.Context,
+	todoID todo.TodoID,
+	userID todo.UserID,
+	updateTodo todo.UpdateTodo,
+) (*todo.Todo, error) {
+	defer invalidate(ctx, w.backend, userID)
+	return w.next.UpdateTodo(ctx, todoID, userID, updateTodo)
+}
+
+func (w *todoWriter) SoftDeleteTodo(
+	ctx context.Context,
+	todoID todo.TodoID,
+	userID todo.UserID,
+) error {
+	defer invalidate(ctx, w.backend, userID)
+	return w.next.SoftDeleteTodo(ctx, todoID, userID)
+}
+
+// MoveTodo invalidates every todo of the user, since a move may rebalance
+// the whole list.
+func (w *todoWriter) MoveTodo(
+	ctx context.Context,
+	todoID todo.TodoID,
+	userID todo.UserID,
+	move todo.MoveTodo,
+) (*todo.Todo, error) {
+	defer invalidate(ctx, w.backend, userID)
+	return w.next.MoveTodo(ctx, todoID, userID, move)
+}
+
+type todoPositionWriter struct {
+	next    gateway.TodoPositionCommandsGateway
+	backend Backend
+}
+
+// NewTodoPositionWriter invalidates the cached reads of the user after every
+// rebalance of next.
+func NewTodoPositionWriter(
+	next gateway.TodoPositionCommandsGateway,
+	backend Backend,
+) gateway.TodoPositionCommandsGateway {
+	return &todoPositionWriter{
+		next:    next,
+		backend: backend,
+	}
+}
+
+func (w *todoPositionWriter) RebalancePositions(
+	ctx context.Context,
+	userID todo.UserID,
+) error {
+	defer invalidate(ctx, w.backend, userID)
+	return w.next.RebalancePositions(ctx, userID)
+}
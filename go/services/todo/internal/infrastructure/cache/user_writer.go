@@ -0,0 +1,75 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+// InvalidateUser invalidates the cached reads of the user, for the writes
+// made outside of the wrapped gateways, such as a move to another shard.
+func InvalidateUser(ctx context.Context, backend Backend, userID todo.UserID) {
+	invalidate(ctx, backend, userID)
+}
+
+type userWriter struct {
+	next    gateway.UserCommandsGateway
+	backend Backend
+}
+
+// NewUserWriter invalidates the cached reads of the user after every update
+// or deletion of next, failed ones included.
+func NewUserWriter(next gateway.UserCommandsGateway, backend Backend) gateway.UserCommandsGateway {
+	return &userWriter{
+		next:    next,
+		backend: backend,
+	}
+}
+
+// CreateUser invalidates nothing, since a new user has no cached reads.
+func (w *userWriter) CreateUser(
+	ctx context.Context,
+	newUser todo.NewUser,
+) (*todo.User, error) {
+	return w.next.CreateUser(ctx, newUser)
+}
+
+func (w *userWriter) UpdateUser(
+	ctx context.Context,
+	userID todo.UserID,
+	updateUser todo.UpdateUser,
+) (*todo.User, error) {
+	defer invalidate(ctx, w.backend, userID)
+	return w.next.UpdateUser(ctx, userID, updateUser)
+}
+
+func (w *userWriter) SoftDeleteUser(
+	ctx context.Context,
+	userID todo.UserID,
+) error {
+	defer invalidate(ctx, w.backend, userID)
+	return w.next.SoftDeleteUser(ctx, userID)
+}
+
+type userEraser struct {
+	next    gateway.UserErasureCommandsGateway
+	backend Backend
+}
+
+// NewUserEraser invalidates the cached reads of the user after every erasure
+// of next, failed ones included.
+func NewUserEraser(next gateway.UserErasureCommandsGateway, backend Backend) gateway.UserErasureCommandsGateway {
+	return &userEraser{
+		next:    next,
+		backend: backend,
+	}
+}
+
+func (e *userEraser) EraseUser(
+	ctx context.Context,
+	userID todo.UserID,
+) (*todo.ErasedUser, error) {
+	defer invalidate(ctx, e.backend, userID)
+	return e.next.EraseUser(ctx, userID)
+}
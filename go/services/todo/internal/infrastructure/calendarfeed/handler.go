@@ -0,0 +1,56 @@
+package calendarfeed
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/usecase"
+)
+
+type handler struct {
+	interchange usecase.TodoInterchangeUsecase
+	signer      *TokenSigner
+}
+
+// NewHandler serves GET /calendar/{user_id}.ics?token=... with the user's
+// todos as VTODO components.
+func NewHandler(interchange usecase.TodoInterchangeUsecase, signer *TokenSigner) http.Handler {
+	h := &handler{
+		interchange: interchange,
+		signer:      signer,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /calendar/{file}", h.serveFeed)
+	return mux
+}
+
+func (h *handler) serveFeed(w http.ResponseWriter, r *http.Request) {
+	name, ok := strings.CutSuffix(r.PathValue("file"), ".ics")
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	id, err := strconv.ParseInt(name, 10, 64)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	userID := todo.UserID(id)
+
+	if !h.signer.Verify(userID, r.URL.Query().Get("token")) {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Cache-Control", "private, max-age=300")
+	if err := h.interchange.ExportTodos(r.Context(), userID, todo.FileFormats.ICalendar, w); err != nil {
+		log.Printf("calendar feed: user %d: %v", userID, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
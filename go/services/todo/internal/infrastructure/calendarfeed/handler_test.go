@@ -0,0 +1,104 @@
+package calendarfeed_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/calendarfeed"
+)
+
+type fakeInterchange struct{}
+
+func (fakeInterchange) ExportTodos(_ context.Context, userID todo.UserID, format todo.FileFormat, w io.Writer) error {
+	_, err := io.WriteString(w, string(format)+":"+userID.String())
+	return err
+}
+
+func (fakeInterchange) ImportTodos(
+	_ context.Context,
+	_ todo.UserID,
+	_ todo.FileFormat,
+	_ io.Reader,
+	_ todo.ImportOptions,
+) (*todo.ImportReport, error) {
+	return nil, nil
+}
+
+func Test_handler_serveFeed(t *testing.T) {
+	type expected struct {
+		status int
+		body   string
+	}
+
+	type testcase struct {
+		path     string
+		expected expected
+	}
+
+	t.Parallel()
+
+	signer := calendarfeed.NewTokenSigner("secret", "http://localhost:5008/")
+
+	testTables := map[string]testcase{
+		"Serve the feed of the user the token was issued for": {
+			path: "/calendar/1.ics?token=" + signer.Token(1),
+			expected: expected{
+				status: http.StatusOK,
+				body:   "ics:1",
+			},
+		},
+		"Reject the token of another user": {
+			path: "/calendar/2.ics?token=" + signer.Token(1),
+			expected: expected{
+				status: http.StatusForbidden,
+				body:   "Forbidden\n",
+			},
+		},
+		"Reject a missing token": {
+			path: "/calendar/1.ics",
+			expected: expected{
+				status: http.StatusForbidden,
+				body:   "Forbidden\n",
+			},
+		},
+		"Not found for other files": {
+			path: "/calendar/1.csv?token=" + signer.Token(1),
+			expected: expected{
+				status: http.StatusNotFound,
+				body:   "404 page not found\n",
+			},
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			calendarfeed.NewHandler(fakeInterchange{}, signer).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			actual := expected{status: rec.Code, body: rec.Body.String()}
+			if diff := cmp.Diff(actual, tt.expected, cmp.AllowUnexported(expected{})); diff != "" {
+				t.Fatalf("mismatch (-actual +expected):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_TokenSigner_FeedURL(t *testing.T) {
+	t.Parallel()
+
+	signer := calendarfeed.NewTokenSigner("secret", "http://localhost:5008/")
+
+	expected := "http://localhost:5008/calendar/1.ics?token=" + signer.Token(1)
+	if diff := cmp.Diff(signer.FeedURL(1), expected); diff != "" {
+		t.Fatalf("mismatch (-actual +expected):\n%s", diff)
+	}
+}
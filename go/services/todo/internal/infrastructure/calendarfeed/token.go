@@ -0,0 +1,41 @@
+package calendarfeed
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+// TokenSigner issues the token that protects the calendar feed of a user. The
+// token is an HMAC of the user ID, so nothing has to be stored; rotating the
+// secret revokes every issued feed URL.
+type TokenSigner struct {
+	secret  []byte
+	baseURL string
+}
+
+func NewTokenSigner(secret string, baseURL string) *TokenSigner {
+	return &TokenSigner{
+		secret:  []byte(secret),
+		baseURL: strings.TrimRight(baseURL, "/"),
+	}
+}
+
+func (s *TokenSigner) Token(userID todo.UserID) string {
+	mac := hmac.New(sha256.New, s.secret)
+	_, _ = mac.Write([]byte("calendar-feed:" + userID.String()))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (s *TokenSigner) Verify(userID todo.UserID, token string) bool {
+	return hmac.Equal([]byte(s.Token(userID)), []byte(token))
+}
+
+// FeedURL returns the URL calendar apps subscribe to.
+func (s *TokenSigner) FeedURL(userID todo.UserID) string {
+	return fmt.Sprintf("%s/calendar/%s.ics?token=%s", s.baseURL, userID.String(), s.Token(userID))
+}
@@ -0,0 +1,65 @@
+package datastore_test
+
+import (
+	"testing"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway/gatewaytest"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/datastore"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/testutil"
+)
+
+func TestTodoGateways(t *testing.T) {
+	t.Parallel()
+
+	gatewaytest.RunTodoGatewaysSuite(t, func(t *testing.T) gatewaytest.TodoGateways {
+		gormDB, _ := testutil.InitDB(t)
+
+		// Users without todos in the fixtures.
+		if err := gormDB.Exec(
+			"INSERT INTO users (id, username, password) VALUES (?, ?, ?), (?, ?, ?)",
+			101, "user101", "password",
+			102, "user102", "password",
+		).Error; err != nil {
+			t.Fatalf("insert users: %v", err)
+		}
+
+		return gatewaytest.TodoGateways{
+			Binder:      datastore.NewConnectionBinder(&datastore.TodoConn{GormDB: gormDB}),
+			Reader:      datastore.NewTodoReader(),
+			Writer:      datastore.NewTodoWriter(),
+			UserID:      todo.UserID(101),
+			OtherUserID: todo.UserID(102),
+		}
+	})
+}
+
+func TestUserGateways(t *testing.T) {
+	t.Parallel()
+
+	gatewaytest.RunUserGatewaysSuite(t, func(t *testing.T) gatewaytest.UserGateways {
+		gormDB, _ := testutil.InitDB(t)
+
+		return gatewaytest.UserGateways{
+			Binder:       datastore.NewConnectionBinder(&datastore.TodoConn{GormDB: gormDB}),
+			Reader:       datastore.NewUserReader(),
+			Writer:       datastore.NewUserWriter(),
+			DataReader:   datastore.NewUserDataReader(),
+			Eraser:       datastore.NewUserEraser(),
+			UnusedUserID: todo.UserID(101),
+		}
+	})
+}
+
+func TestLeaseGateways(t *testing.T) {
+	t.Parallel()
+
+	gatewaytest.RunLeaseGatewaysSuite(t, func(t *testing.T) gatewaytest.LeaseGateways {
+		gormDB, _ := testutil.InitDB(t)
+
+		return gatewaytest.LeaseGateways{
+			Binder: datastore.NewConnectionBinder(&datastore.TodoConn{GormDB: gormDB}),
+			Writer: datastore.NewLeaseWriter(),
+		}
+	})
+}
@@ -2,7 +2,9 @@ package datastore
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"log"
 	"time"
 
 	"gorm.io/driver/mysql"
@@ -13,74 +15,358 @@ import (
 	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
 )
 
+const (
+	primaryPoolName   = "primary"
+	replicaPoolPrefix = "replica:"
+
+	connectBaseBackoff = 500 * time.Millisecond
+	connectMaxBackoff  = 5 * time.Second
+)
+
 type TodoConn struct {
 	GormDB *gorm.DB
+	pools  []dbPool
+
+	// shards are the databases of the shards besides the primary, by name,
+	// and shardMap places users on them. Both are nil when sharding is not
+	// configured.
+	shards   map[string]*gorm.DB
+	shardMap *ShardMap
+}
+
+// dbPool is a connection pool opened for the service, the primary or a
+// replica, reported by the status reader.
+type dbPool struct {
+	name string
+	db   *sql.DB
 }
 
 func NewTodoSQLHandler(conf *config.DBConfig) (*TodoConn, func(), error) {
-	sourceDSN := fmt.Sprintf(
-		"%s:%s@tcp(%s:%d)/%s?parseTime=true",
+	if conf.DBDialect == config.DBDialectSQLite {
+		return newSQLiteHandler(conf)
+	}
+
+	sourceDSN := formatDSN(conf, fmt.Sprintf("%s:%d", conf.DBHost, conf.DBPort))
+
+	replicaDSNs := make([]string, 0, len(conf.DBReplicaHosts))
+	for _, host := range conf.DBReplicaHosts {
+		replicaDSNs = append(replicaDSNs, formatDSN(conf, host))
+	}
+
+	primary, closePrimary, err := newSQLHandler(conf, sourceDSN, replicaDSNs)
+	if err != nil || len(conf.DBShards) == 0 {
+		return primary, closePrimary, err
+	}
+
+	return newShardedSQLHandler(conf, primary, closePrimary)
+}
+
+// newShardedSQLHandler opens the shards of conf next to primary, and loads the
+// shard map, reloaded every conf.DBShardMapRefreshInterval until the returned
+// function closes the connections.
+func newShardedSQLHandler(conf *config.DBConfig, primary *TodoConn, closePrimary func()) (*TodoConn, func(), error) {
+	closers := []func(){closePrimary}
+	closeAll := func() {
+		for _, closeFunc := range closers {
+			closeFunc()
+		}
+	}
+
+	names := []string{PrimaryShardName}
+	shards := make(map[string]*TodoConn, len(conf.DBShards))
+	for _, shard := range conf.DBShards {
+		shardConn, closeShard, err := newSQLHandler(conf, formatDSN(conf, shard.Addr), nil)
+		if err != nil {
+			closeAll()
+			return nil, nil, fmt.Errorf("open shard %q: %w", shard.Name, err)
+		}
+		closers = append(closers, closeShard)
+		names = append(names, shard.Name)
+		shards[shard.Name] = shardConn
+	}
+
+	shardMap := NewShardMap(names, conf.DBShardVirtualNodes)
+	if err := shardMap.Load(context.Background(), primary.GormDB); err != nil {
+		closeAll()
+		return nil, nil, fmt.Errorf(": %w", err)
+	}
+
+	ctx, stopRefresh := context.WithCancel(context.Background())
+	go shardMap.Run(ctx, primary.GormDB, conf.DBShardMapRefreshInterval)
+
+	return NewShardedTodoConn(primary, shards, shardMap), func() {
+		stopRefresh()
+		closeAll()
+	}, nil
+}
+
+func formatDSN(conf *config.DBConfig, addr string) string {
+	return fmt.Sprintf(
+		"%s:%s@tcp(%s)/%s?parseTime=true&timeout=%s&readTimeout=%s&writeTimeout=%s",
 		conf.DBUser,
 		conf.DBPass,
-		conf.DBHost,
-		conf.DBPort,
+		addr,
 		conf.DBName,
+		conf.DBDialTimeout,
+		conf.DBReadTimeout,
+		conf.DBWriteTimeout,
 	)
-
-	db, closer, err := newSQLHandler(sourceDSN)
-	return &TodoConn{GormDB: db}, closer, err
 }
 
-func newSQLHandler(dsn string) (*gorm.DB, func(), error) {
+// newSQLHandler sends every statement to the primary, except the reads that
+// ask for the replica resolver. With no replicas, those go to the primary too.
+// It waits up to conf.DBConnectTimeout for the primary to accept connections;
+// replicas are not required at startup, reads fail over until they are up.
+func newSQLHandler(conf *config.DBConfig, dsn string, replicaDSNs []string) (*TodoConn, func(), error) {
 	if _, err := time.LoadLocation("UTC"); err != nil {
 		return nil, nil, fmt.Errorf(": %w", err)
 	}
 
+	var pools []dbPool
+	closeAll := func() {
+		for _, pool := range pools {
+			_ = pool.db.Close()
+		}
+	}
+
+	db, err := openDB(conf, dsn)
+	if err != nil {
+		return nil, nil, fmt.Errorf(": %w", err)
+	}
+	pools = append(pools, dbPool{name: primaryPoolName, db: db})
+
+	ctx, cancel := context.WithTimeout(context.Background(), conf.DBConnectTimeout)
+	defer cancel()
+	if err := pingWithBackoff(ctx, db); err != nil {
+		closeAll()
+		return nil, nil, fmt.Errorf(": %w", err)
+	}
+
 	// nolint: exhaustivestruct
 	conn, err := gorm.Open(
 		mysql.New(mysql.Config{
 			DriverName: "mysql",
-			DSN:        dsn,
+			Conn:       db,
 		}),
 		&gorm.Config{
 			SkipDefaultTransaction: true,
+			TranslateError:         true,
+			// The primary has been pinged above and replicas may still be down.
+			DisableAutomaticPing: true,
 		},
 	)
 	if err != nil {
+		closeAll()
 		return nil, nil, fmt.Errorf(": %w", err)
 	}
-	err = conn.Use(dbresolver.Register(dbresolver.Config{
-		Sources:           []gorm.Dialector{mysql.Open(dsn)},
+
+	resolver := dbresolver.Register(dbresolver.Config{
+		Sources:           []gorm.Dialector{existingConn(db)},
 		TraceResolverMode: true,
-	}))
+	})
+	var policy *ReplicaPolicy
+	if len(replicaDSNs) > 0 {
+		// The primary comes last, as the replica policy fails over to it.
+		replicas := make([]gorm.Dialector, 0, len(replicaDSNs)+1)
+		replicaPools := make([]gorm.ConnPool, 0, len(replicaDSNs))
+		for i, replicaDSN := range replicaDSNs {
+			replicaDB, err := openDB(conf, replicaDSN)
+			if err != nil {
+				closeAll()
+				return nil, nil, fmt.Errorf(": %w", err)
+			}
+			pools = append(pools, dbPool{name: replicaPoolPrefix + conf.DBReplicaHosts[i], db: replicaDB})
+			replicas = append(replicas, existingConn(replicaDB))
+			replicaPools = append(replicaPools, replicaDB)
+		}
+		replicas = append(replicas, existingConn(db))
+
+		policy = NewReplicaPolicy(conf.DBReplicaPolicy, replicaPools, conf.DBReplicaCheckInterval, conf.DBReplicaPingTimeout)
+		resolver = resolver.Register(dbresolver.Config{
+			Replicas:          replicas,
+			Policy:            policy,
+			TraceResolverMode: true,
+		}, replicaResolverName)
+	}
+	err = conn.Use(resolver)
 	if err != nil {
+		closeAll()
 		return nil, nil, fmt.Errorf(": %w", err)
 	}
-	db, err := conn.DB()
+	if policy != nil {
+		err = conn.Use(policy)
+		if err != nil {
+			closeAll()
+			return nil, nil, fmt.Errorf(": %w", err)
+		}
+	}
+	err = registerWriteTracking(conn)
+	if err != nil {
+		closeAll()
+		return nil, nil, fmt.Errorf(": %w", err)
+	}
+	err = registerShardGuard(conn)
 	if err != nil {
+		closeAll()
 		return nil, nil, fmt.Errorf(": %w", err)
 	}
-	if err := db.Ping(); err != nil {
+	err = conn.Use(NewQueryObserver(QueryObserverConfig{SlowQueryThreshold: conf.DBSlowQueryThreshold}))
+	if err != nil {
+		closeAll()
 		return nil, nil, fmt.Errorf(": %w", err)
 	}
 
 	conn.Set("gorm:table_options", "ENGINE=InnoDB")
 
-	return conn, func() {
+	if policy == nil {
+		return &TodoConn{GormDB: conn, pools: pools}, closeAll, nil
+	}
+
+	// Reads go to the replicas which answered the first check, and the
+	// checks go on in the background.
+	checkCtx, stopChecks := context.WithCancel(context.Background())
+	policy.CheckReplicas(checkCtx)
+	go policy.Run(checkCtx)
+
+	return &TodoConn{GormDB: conn, pools: pools}, func() {
+		stopChecks()
+		closeAll()
+	}, nil
+}
+
+// newSQLiteHandler opens the sqlite database of conf, for local development.
+// It has no replicas: every read goes to the database file.
+func newSQLiteHandler(conf *config.DBConfig) (*TodoConn, func(), error) {
+	conn, err := OpenSQLite(conf.DBSQLitePath, time.UTC)
+	if err != nil {
+		return nil, nil, fmt.Errorf(": %w", err)
+	}
+	db, err := conn.DB()
+	if err != nil {
+		return nil, nil, fmt.Errorf(": %w", err)
+	}
+	db.SetMaxOpenConns(conf.DBMaxOpenConns)
+	db.SetMaxIdleConns(conf.DBMaxIdleConns)
+	db.SetConnMaxLifetime(conf.DBConnMaxLifetime)
+	db.SetConnMaxIdleTime(conf.DBConnMaxIdleTime)
+
+	err = registerWriteTracking(conn)
+	if err != nil {
+		_ = db.Close()
+		return nil, nil, fmt.Errorf(": %w", err)
+	}
+	err = registerShardGuard(conn)
+	if err != nil {
+		_ = db.Close()
+		return nil, nil, fmt.Errorf(": %w", err)
+	}
+	err = conn.Use(NewQueryObserver(QueryObserverConfig{SlowQueryThreshold: conf.DBSlowQueryThreshold}))
+	if err != nil {
+		_ = db.Close()
+		return nil, nil, fmt.Errorf(": %w", err)
+	}
+
+	return &TodoConn{GormDB: conn, pools: []dbPool{{name: primaryPoolName, db: db}}}, func() {
 		_ = db.Close()
 	}, nil
 }
 
+// openDB opens a pool without connecting, sized by conf.
+func openDB(conf *config.DBConfig, dsn string) (*sql.DB, error) {
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	db.SetMaxOpenConns(conf.DBMaxOpenConns)
+	db.SetMaxIdleConns(conf.DBMaxIdleConns)
+	db.SetConnMaxLifetime(conf.DBConnMaxLifetime)
+	db.SetConnMaxIdleTime(conf.DBConnMaxIdleTime)
+
+	return db, nil
+}
+
+// existingConn lets dbresolver use a pool opened by openDB instead of opening
+// one of its own, so that every pool is sized and reported the same way.
+func existingConn(db *sql.DB) gorm.Dialector {
+	// nolint: exhaustivestruct
+	return mysql.New(mysql.Config{
+		DriverName:                "mysql",
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	})
+}
+
+// pingWithBackoff pings db until it answers or ctx is done, doubling the wait
+// between attempts, so that the service can start while MySQL is booting.
+func pingWithBackoff(ctx context.Context, db *sql.DB) error {
+	backoff := connectBaseBackoff
+	for attempt := 1; ; attempt++ {
+		err := db.PingContext(ctx)
+		if err == nil {
+			return nil
+		}
+
+		log.Printf("ping database (attempt %d): %v, retrying in %s", attempt, err, backoff)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("database not reachable after %d attempts: %w", attempt, err)
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > connectMaxBackoff {
+			backoff = connectMaxBackoff
+		}
+	}
+}
+
 type binder struct {
 	todoConn *TodoConn
 }
 
-func NewConnectionBinder(todoConn *TodoConn) gateway.Binder {
+func NewConnectionBinder(todoConn *TodoConn) gateway.ShardBinder {
 	return &binder{
 		todoConn: todoConn,
 	}
 }
 
+// Bind binds the database of the shard of the user of ctx, see
+// TodoConn.userDB, and the primary too when the database is sharded.
 func (b binder) Bind(ctx context.Context) context.Context {
-	return WithTodoDB(ctx, b.todoConn.GormDB)
+	ctx = WithTodoDB(withWriteTracker(ctx), b.todoConn.userDB(ctx))
+	if b.todoConn.shardMap != nil {
+		ctx = withPrimaryDB(ctx, b.todoConn.GormDB)
+	}
+	return ctx
+}
+
+// Shards returns the primary and the configured shards.
+func (b binder) Shards() []string {
+	if b.todoConn.shardMap == nil {
+		return []string{PrimaryShardName}
+	}
+
+	shards := []string{PrimaryShardName}
+	for _, shard := range b.todoConn.shardMap.Shards() {
+		if _, ok := b.todoConn.shards[shard]; ok {
+			shards = append(shards, shard)
+		}
+	}
+	return shards
+}
+
+// BindShard binds the database of the shard, and the primary next to it for
+// the leases. An unknown shard binds no database, failing the gateways.
+func (b binder) BindShard(ctx context.Context, shard string) context.Context {
+	db, err := b.todoConn.shardDB(shard)
+	if err != nil {
+		return ctx
+	}
+
+	ctx = WithTodoDB(withWriteTracker(ctx), db)
+	if shard != PrimaryShardName {
+		ctx = withPrimaryDB(ctx, b.todoConn.GormDB)
+	}
+	return ctx
 }
@@ -0,0 +1,118 @@
+package datastore
+
+import (
+	"slices"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/errors"
+)
+
+// CursorPager pages through the rows of a query in the order of Fields, in
+// both directions, with signed page tokens. The last field must make the order
+// unique, e.g. the primary key.
+type CursorPager[T any] struct {
+	Fields []CursorPagingField
+	// Keys returns the values of Fields of a row.
+	Keys   func(row T) []CursorKey
+	Tokens *PageTokenCodec
+	Scope  PageTokenScope
+	// Having applies the cursor to the groups of an aggregate query, whose
+	// fields are output columns.
+	Having bool
+}
+
+// Find reads the page of db after or before token, the first page when token
+// is empty. db must not be ordered nor limited yet. A field sorted in another
+// order than ASC or DESC is a parameter error.
+func (p CursorPager[T]) Find(db *gorm.DB, token *string, size int) (*todo.Page[T], error) {
+	for _, field := range p.Fields {
+		if !field.SortingOrder.IsValid() {
+			return nil, errors.NewParameterError("invalid sorting order", nil, nil)
+		}
+	}
+
+	direction := todo.PagingDirections.Next
+	hasCursor := token != nil && *token != ""
+	if hasCursor {
+		var (
+			keys []CursorKey
+			err  error
+		)
+		direction, keys, err = p.Tokens.Decode(p.Scope, *token)
+		if err != nil {
+			return nil, err
+		}
+		if len(keys) != len(p.Fields) {
+			return nil, invalidPageTokenError(nil)
+		}
+
+		values := make([]any, 0, len(keys))
+		for _, key := range keys {
+			value, _ := key.Value()
+			values = append(values, value)
+		}
+		sql, args := BuildCursorPagingCondition(p.Fields, values, direction)
+		if p.Having {
+			db = db.Having(sql, args...)
+		} else {
+			db = db.Where(sql, args...)
+		}
+	}
+
+	// Rows before the cursor are read nearest first, then put back in order.
+	fields := p.Fields
+	if direction == todo.PagingDirections.Previous {
+		fields = invertCursorPagingFields(fields)
+	}
+	for _, field := range fields {
+		db = db.Order(clause.OrderByColumn{
+			Column: clause.Column{Name: field.Column, Raw: true},
+			Desc:   field.SortingOrder == todo.SortingOrders.Desc,
+		})
+	}
+
+	var rows []T
+	err := db.Limit(size + 1).Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	more := len(rows) > size
+	if more {
+		rows = rows[:size]
+	}
+
+	page := &todo.Page[T]{Items: rows}
+	if direction == todo.PagingDirections.Previous {
+		slices.Reverse(rows)
+		page.HasNext = true
+		page.HasPrevious = more
+	} else {
+		page.HasNext = more
+		page.HasPrevious = hasCursor
+	}
+	if len(rows) == 0 {
+		page.HasNext, page.HasPrevious = false, false
+		return page, nil
+	}
+
+	if page.HasNext {
+		nextPageToken, err := p.Tokens.Encode(p.Scope, todo.PagingDirections.Next, p.Keys(rows[len(rows)-1])...)
+		if err != nil {
+			return nil, err
+		}
+		page.NextPageToken = &nextPageToken
+	}
+	if page.HasPrevious {
+		previousPageToken, err := p.Tokens.Encode(p.Scope, todo.PagingDirections.Previous, p.Keys(rows[0])...)
+		if err != nil {
+			return nil, err
+		}
+		page.PreviousPageToken = &previousPageToken
+	}
+
+	return page, nil
+}
@@ -0,0 +1,100 @@
+package datastore_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/errors"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/datastore"
+)
+
+func Test_CursorPager_Find(t *testing.T) {
+	type pageIDs struct {
+		IDs         []todo.TodoID
+		HasNext     bool
+		HasPrevious bool
+	}
+
+	t.Parallel()
+
+	db, err := datastore.ExtractTodoDB(ctxWithReadDB)
+	if err != nil {
+		t.Fatalf("ExtractTodoDB() error = %v", err)
+	}
+
+	// Todos 4 and 6 are created at the same time, so the ID breaks the tie.
+	pager := datastore.CursorPager[*todo.Todo]{
+		Fields: []datastore.CursorPagingField{
+			{Column: "created_at", SortingOrder: todo.SortingOrders.Desc},
+			{Column: "id", SortingOrder: todo.SortingOrders.Desc},
+		},
+		Keys: func(t *todo.Todo) []datastore.CursorKey {
+			return []datastore.CursorKey{
+				datastore.TimeCursorKey(t.CreatedAt),
+				datastore.IntCursorKey(int64(t.ID)),
+			}
+		},
+		Tokens: testPageTokens,
+		Scope:  datastore.PageTokenScope{Query: "todos:created_at:DESC"},
+	}
+	find := func(token *string) *todo.Page[*todo.Todo] {
+		t.Helper()
+		page, err := pager.Find(db.Model(&todo.Todo{}).Where("deleted_at IS NULL"), token, 2)
+		if err != nil {
+			t.Fatalf("CursorPager.Find() error = %v", err)
+		}
+		return page
+	}
+	ids := func(page *todo.Page[*todo.Todo]) pageIDs {
+		actual := pageIDs{HasNext: page.HasNext, HasPrevious: page.HasPrevious}
+		for _, t := range page.Items {
+			actual.IDs = append(actual.IDs, t.ID)
+		}
+		return actual
+	}
+
+	first := find(nil)
+	second := find(first.NextPageToken)
+	last := find(second.NextPageToken)
+	backToSecond := find(last.PreviousPageToken)
+	backToFirst := find(backToSecond.PreviousPageToken)
+
+	actual := []pageIDs{ids(first), ids(second), ids(last), ids(backToSecond), ids(backToFirst)}
+	expected := []pageIDs{
+		{IDs: []todo.TodoID{6, 4}, HasNext: true, HasPrevious: false},
+		{IDs: []todo.TodoID{3, 2}, HasNext: true, HasPrevious: true},
+		{IDs: []todo.TodoID{1}, HasNext: false, HasPrevious: true},
+		{IDs: []todo.TodoID{3, 2}, HasNext: true, HasPrevious: true},
+		{IDs: []todo.TodoID{6, 4}, HasNext: true, HasPrevious: false},
+	}
+	if diff := cmp.Diff(actual, expected); diff != "" {
+		t.Fatalf("mismatch (-actual +expected):\n%s", diff)
+	}
+}
+
+func Test_CursorPager_Find_InvalidSortingOrder(t *testing.T) {
+	t.Parallel()
+
+	db, err := datastore.ExtractTodoDB(ctxWithReadDB)
+	if err != nil {
+		t.Fatalf("ExtractTodoDB() error = %v", err)
+	}
+
+	pager := datastore.CursorPager[*todo.Todo]{
+		Fields: []datastore.CursorPagingField{
+			{Column: "id", SortingOrder: "DESC, (SELECT CASE WHEN (SELECT count(*) FROM users) > 0 THEN 1 ELSE 1/0 END)"},
+		},
+		Keys: func(t *todo.Todo) []datastore.CursorKey {
+			return []datastore.CursorKey{datastore.IntCursorKey(int64(t.ID))}
+		},
+		Tokens: testPageTokens,
+		Scope:  datastore.PageTokenScope{Query: "todos:id"},
+	}
+
+	_, err = pager.Find(db.Model(&todo.Todo{}), nil, 2)
+	if !errors.IsParameterError(err) {
+		t.Fatalf("CursorPager.Find() error = %v, want a ParameterError", err)
+	}
+}
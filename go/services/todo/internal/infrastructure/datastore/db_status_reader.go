@@ -0,0 +1,64 @@
+package datastore
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+const dbStatusPingTimeout = time.Second
+
+type dbStatusReader struct {
+	todoConn *TodoConn
+}
+
+func NewDBStatusReader(todoConn *TodoConn) gateway.DBStatusQueriesGateway {
+	return &dbStatusReader{
+		todoConn: todoConn,
+	}
+}
+
+// ListDBPoolStatuses reports the primary first, then the replicas. A pool is
+// healthy when it answers a ping. The pools are pinged at the same time, all
+// within dbStatusPingTimeout.
+func (r *dbStatusReader) ListDBPoolStatuses(ctx context.Context) ([]*todo.DBPoolStatus, error) {
+	pingCtx, cancel := context.WithTimeout(ctx, dbStatusPingTimeout)
+	defer cancel()
+
+	healthy := make([]bool, len(r.todoConn.pools))
+	var wg sync.WaitGroup
+	for i, pool := range r.todoConn.pools {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			healthy[i] = pool.db.PingContext(pingCtx) == nil
+		}()
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	statuses := make([]*todo.DBPoolStatus, 0, len(r.todoConn.pools))
+	for i, pool := range r.todoConn.pools {
+		stats := pool.db.Stats()
+		statuses = append(statuses, &todo.DBPoolStatus{
+			Name:               pool.name,
+			Healthy:            healthy[i],
+			MaxOpenConnections: stats.MaxOpenConnections,
+			OpenConnections:    stats.OpenConnections,
+			InUse:              stats.InUse,
+			Idle:               stats.Idle,
+			WaitCount:          stats.WaitCount,
+			WaitDuration:       stats.WaitDuration,
+			MaxIdleClosed:      stats.MaxIdleClosed,
+			MaxIdleTimeClosed:  stats.MaxIdleTimeClosed,
+			MaxLifetimeClosed:  stats.MaxLifetimeClosed,
+		})
+	}
+
+	return statuses, nil
+}
@@ -0,0 +1,90 @@
+package datastore_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/config"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/datastore"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/testutil"
+)
+
+func newTestDBConfig(dbName string) *config.DBConfig {
+	env := testutil.LoadEnv()
+	return &config.DBConfig{
+		DBDialect:         config.DBDialect(env.DBDialect),
+		DBSQLitePath:      dbName,
+		DBHost:            env.DBHost,
+		DBPort:            env.DBPort,
+		DBUser:            env.DBUser,
+		DBPass:            env.DBPass,
+		DBName:            dbName,
+		DBMaxOpenConns:    3,
+		DBMaxIdleConns:    2,
+		DBConnMaxLifetime: time.Minute,
+		DBConnMaxIdleTime: time.Minute,
+		DBDialTimeout:     time.Second,
+		DBReadTimeout:     5 * time.Second,
+		DBWriteTimeout:    5 * time.Second,
+		DBConnectTimeout:  5 * time.Second,
+	}
+}
+
+func Test_dbStatusReader_ListDBPoolStatuses(t *testing.T) {
+	t.Parallel()
+	_, dbName := testutil.InitDB(t)
+
+	todoConn, closeDB, err := datastore.NewTodoSQLHandler(newTestDBConfig(dbName))
+	if err != nil {
+		t.Fatalf("NewTodoSQLHandler() error = %v", err)
+	}
+	defer closeDB()
+
+	statuses, err := datastore.NewDBStatusReader(todoConn).ListDBPoolStatuses(context.Background())
+	if err != nil {
+		t.Fatalf("dbStatusReader.ListDBPoolStatuses() error = %v", err)
+	}
+
+	expected := []*todo.DBPoolStatus{
+		{
+			Name:               "primary",
+			Healthy:            true,
+			MaxOpenConnections: 3,
+		},
+	}
+	ignoreFieldsOpts := []cmp.Option{
+		cmpopts.IgnoreFields(
+			todo.DBPoolStatus{},
+			"OpenConnections", "InUse", "Idle", "WaitCount", "WaitDuration",
+			"MaxIdleClosed", "MaxIdleTimeClosed", "MaxLifetimeClosed",
+		),
+	}
+
+	if diff := cmp.Diff(statuses, expected, ignoreFieldsOpts...); diff != "" {
+		t.Errorf("dbStatusReader.ListDBPoolStatuses() mismatch (-actual +expected):\n%s", diff)
+	}
+}
+
+func TestNewTodoSQLHandler_GivesUpAfterConnectTimeout(t *testing.T) {
+	t.Parallel()
+
+	conf := newTestDBConfig("todo")
+	conf.DBDialect = config.DBDialectMySQL
+	conf.DBHost = "127.0.0.1"
+	conf.DBPort = 1
+	conf.DBConnectTimeout = time.Second
+
+	started := time.Now()
+	_, _, err := datastore.NewTodoSQLHandler(conf)
+	if err == nil {
+		t.Fatal("NewTodoSQLHandler() error = nil, want an error for an unreachable database")
+	}
+	if elapsed := time.Since(started); elapsed < conf.DBConnectTimeout {
+		t.Errorf("NewTodoSQLHandler() gave up after %s, want it to retry for %s", elapsed, conf.DBConnectTimeout)
+	}
+}
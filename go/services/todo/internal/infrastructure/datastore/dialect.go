@@ -0,0 +1,77 @@
+package datastore
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	apperrors "github.com/phamquanandpad/training-project/go/services/todo/internal/errors"
+)
+
+const (
+	dialectMySQL  = "mysql"
+	dialectSQLite = "sqlite"
+)
+
+// sqliteTimeLayouts are the layouts the sqlite driver writes times in.
+var sqliteTimeLayouts = []string{
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05-07:00",
+	time.DateTime,
+	time.DateOnly,
+}
+
+func dialectOf(db *gorm.DB) string {
+	return db.Dialector.Name()
+}
+
+// translateWriteError returns todo.ErrDuplicateKey, wrapping err, when err is
+// a unique key violation of the dialect: gorm.ErrDuplicatedKey when the DB is
+// opened with gorm.Config.TranslateError, or else the MySQL error itself.
+func translateWriteError(err error) error {
+	if errors.Is(err, gorm.ErrDuplicatedKey) || apperrors.IsMySQLDuplicateKeyError(err) {
+		return fmt.Errorf("%w: %w", todo.ErrDuplicateKey, err)
+	}
+	return err
+}
+
+// scannedTime scans a computed datetime column, which sqlite returns as text
+// since the column has no declared type.
+type scannedTime struct {
+	time.Time
+}
+
+func (t *scannedTime) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		t.Time = time.Time{}
+		return nil
+	case time.Time:
+		t.Time = v
+		return nil
+	case []byte:
+		return t.parse(string(v))
+	case string:
+		return t.parse(v)
+	}
+	return fmt.Errorf("cannot scan %T into a time", src)
+}
+
+func (t scannedTime) Value() (driver.Value, error) {
+	return t.Time, nil
+}
+
+func (t *scannedTime) parse(s string) error {
+	for _, layout := range sqliteTimeLayouts {
+		parsed, err := time.Parse(layout, s)
+		if err == nil {
+			t.Time = parsed
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot parse %q as a time", s)
+}
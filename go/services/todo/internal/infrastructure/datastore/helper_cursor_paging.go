@@ -1,7 +1,6 @@
 package datastore
 
 import (
-	"encoding/base64"
 	"fmt"
 	"strings"
 	"time"
@@ -9,56 +8,70 @@ import (
 	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
 )
 
-const (
-	DefaultMinDate = "1970-01-01"
-	DefaultMaxDate = "9999-12-31"
-)
-
 type CursorPagingField struct {
 	Column       string
 	SortingOrder todo.SortingOrder
 }
 
-func BuildPageToken(values ...string) string {
-	if len(values) == 0 {
-		return ""
-	}
-	return base64.StdEncoding.EncodeToString(
-		[]byte(strings.Join(values, "|")),
-	)
+// CursorKey is the typed value of a sorted column in a page token. Exactly one
+// of its fields is set; times keep their full precision and offset.
+type CursorKey struct {
+	Time   *time.Time `json:"t,omitempty"`
+	Int    *int64     `json:"i,omitempty"`
+	String *string    `json:"s,omitempty"`
 }
 
-func BuildPageTokenDateValue(
-	date *time.Time,
-	sortingOrder todo.SortingOrder,
-) string {
-	if date != nil {
-		return date.Format("2006-01-02")
-	}
+func TimeCursorKey(t time.Time) CursorKey {
+	return CursorKey{Time: &t}
+}
 
-	if sortingOrder == todo.SortingOrders.Asc {
-		return DefaultMaxDate
-	}
+func IntCursorKey(i int64) CursorKey {
+	return CursorKey{Int: &i}
+}
 
-	return DefaultMinDate
+func StringCursorKey(s string) CursorKey {
+	return CursorKey{String: &s}
 }
 
-func ParsePageToken(token string) []string {
-	if token == "" {
-		return []string{}
+// Value returns the value to compare the column with, and false when the key
+// does not have exactly one value.
+func (k CursorKey) Value() (any, bool) {
+	var (
+		value any
+		n     int
+	)
+	if k.Time != nil {
+		value, n = *k.Time, n+1
 	}
-	decodedToken, err := base64.StdEncoding.DecodeString(token)
-	if err != nil {
-		return []string{}
+	if k.Int != nil {
+		value, n = *k.Int, n+1
 	}
-	return strings.Split(string(decodedToken), "|")
+	if k.String != nil {
+		value, n = *k.String, n+1
+	}
+	return value, n == 1
 }
 
-func BuildCursorPagingCondition(fields []CursorPagingField, values []any) (string, []any) {
+// BuildCursorPagingCondition selects the rows after values in the order of
+// fields, or before them when direction is previous. It selects nothing when a
+// field is sorted in another order than ASC or DESC.
+func BuildCursorPagingCondition(
+	fields []CursorPagingField,
+	values []any,
+	direction todo.PagingDirection,
+) (string, []any) {
 	nFields := len(fields)
 	if nFields == 0 || nFields != len(values) {
 		return "", nil
 	}
+	for _, field := range fields {
+		if !field.SortingOrder.IsValid() {
+			return "", nil
+		}
+	}
+	if direction == todo.PagingDirections.Previous {
+		fields = invertCursorPagingFields(fields)
+	}
 
 	return buildFlattenedCursorPagingCondition(
 		fields,
@@ -130,3 +143,20 @@ func buildFlattenedCursorPagingCondition(
 
 	return finalSQL, finalArgs
 }
+
+// invertCursorPagingFields reverses the sorting order of every field, to read
+// the rows before a cursor nearest first. Invalid orders are kept as they are.
+func invertCursorPagingFields(fields []CursorPagingField) []CursorPagingField {
+	inverted := make([]CursorPagingField, 0, len(fields))
+	for _, field := range fields {
+		order := field.SortingOrder
+		switch field.SortingOrder {
+		case todo.SortingOrders.Asc:
+			order = todo.SortingOrders.Desc
+		case todo.SortingOrders.Desc:
+			order = todo.SortingOrders.Asc
+		}
+		inverted = append(inverted, CursorPagingField{Column: field.Column, SortingOrder: order})
+	}
+	return inverted
+}
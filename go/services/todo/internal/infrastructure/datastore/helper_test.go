@@ -1,6 +1,28 @@
 package datastore_test
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/datastore"
+)
+
+var testPageTokens = datastore.NewPageTokenCodec("test-page-token-secret")
+
+func encodePageToken(
+	scope datastore.PageTokenScope,
+	direction todo.PagingDirection,
+	keys ...datastore.CursorKey,
+) *string {
+	token, err := testPageTokens.Encode(scope, direction, keys...)
+	if err != nil {
+		panic(err)
+	}
+	return &token
+}
 
 func getLocalTimeByString(expectedDateStr string) time.Time {
 	loc, _ := time.LoadLocation("Asia/Tokyo")
@@ -8,3 +30,13 @@ func getLocalTimeByString(expectedDateStr string) time.Time {
 	expectedDatetime, _ := time.ParseInLocation(layout, expectedDateStr, loc)
 	return expectedDatetime
 }
+
+// jsonPayloadTransformer compares JSON columns by value, since MySQL normalizes
+// key order and spacing.
+var jsonPayloadTransformer = cmp.Transformer("JSONPayload", func(raw json.RawMessage) any {
+	var v any
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return string(raw)
+	}
+	return v
+})
@@ -0,0 +1,239 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+// releasedLeaseExpiry is the expiry of a released lease, long past for every
+// holder.
+var releasedLeaseExpiry = time.Unix(0, 0).UTC()
+
+// leaseRow is a row of the leases table. Rows are never deleted, so that the
+// token of a lease keeps growing across holders.
+type leaseRow struct {
+	todo.Lease
+	UpdatedAt time.Time
+}
+
+func (leaseRow) TableName() string {
+	return "leases"
+}
+
+// dbClockLayout is the layout of the UTC time read by dbNow.
+const dbClockLayout = "2006-01-02 15:04:05.999999"
+
+// dbNow returns the time by the clock of the database db, so that every holder
+// sets and checks the expiries of the leases by the same clock.
+//
+// The clock is read in UTC and written back as an argument rather than used in
+// the statements, as NOW() is in the time zone of the session, which need not
+// be the one the driver writes times in.
+func dbNow(db *gorm.DB) (time.Time, error) {
+	query := "SELECT DATE_FORMAT(UTC_TIMESTAMP(6), '%Y-%m-%d %H:%i:%s.%f')"
+	if dialectOf(db) == dialectSQLite {
+		query = "SELECT strftime('%Y-%m-%d %H:%M:%f', 'now')"
+	}
+
+	var value string
+	if err := db.Raw(query).Scan(&value).Error; err != nil {
+		return time.Time{}, fmt.Errorf("read the clock of the database: %w", err)
+	}
+	now, err := time.ParseInLocation(dbClockLayout, value, time.UTC)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse the clock of the database: %w", err)
+	}
+	return now, nil
+}
+
+type leaseWriter struct{}
+
+// NewLeaseWriter keeps the leases in the leases table of the primary. The
+// expiries are set and checked by the clock of the primary, whatever the
+// clocks of the holders.
+func NewLeaseWriter() gateway.LeaseCommandsGateway {
+	return &leaseWriter{}
+}
+
+func (w *leaseWriter) AcquireLease(
+	ctx context.Context,
+	name string,
+	holder string,
+	ttl time.Duration,
+) (*todo.Lease, error) {
+	tx, err := ExtractTodoDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	db := tx.WithContext(ctx)
+
+	var lease *todo.Lease
+	err = db.Transaction(func(tx *gorm.DB) error {
+		now, err := dbNow(tx)
+		if err != nil {
+			return err
+		}
+
+		// Take the lease over once it expired.
+		result := tx.Model(&leaseRow{}).
+			Where("name = ? AND expires_at <= ?", name, now).
+			Updates(map[string]any{
+				"holder":     holder,
+				"token":      gorm.Expr("token + 1"),
+				"expires_at": now.Add(ttl),
+				"updated_at": now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			// The first holder of the lease creates it; a row created meanwhile
+			// by another holder is theirs.
+			row := &leaseRow{
+				Lease: todo.Lease{
+					Name:      name,
+					Holder:    holder,
+					Token:     1,
+					ExpiresAt: now.Add(ttl),
+				},
+				UpdatedAt: now,
+			}
+			result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(row)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return nil
+			}
+		}
+
+		var row leaseRow
+		if err := tx.Where("name = ?", name).Take(&row).Error; err != nil {
+			return err
+		}
+		lease = &row.Lease
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return lease, nil
+}
+
+func (w *leaseWriter) RenewLease(
+	ctx context.Context,
+	lease *todo.Lease,
+	ttl time.Duration,
+) (*todo.Lease, error) {
+	tx, err := ExtractTodoDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	db := tx.WithContext(ctx)
+	now, err := dbNow(db)
+	if err != nil {
+		return nil, err
+	}
+	expiresAt := now.Add(ttl)
+
+	result := db.
+		Model(&leaseRow{}).
+		Where("name = ? AND holder = ? AND token = ? AND expires_at > ?", lease.Name, lease.Holder, lease.Token, now).
+		Updates(map[string]any{
+			"expires_at": expiresAt,
+			"updated_at": now,
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, todo.ErrLeaseLost
+	}
+
+	renewed := *lease
+	renewed.ExpiresAt = expiresAt
+	return &renewed, nil
+}
+
+func (w *leaseWriter) ReleaseLease(
+	ctx context.Context,
+	lease *todo.Lease,
+) error {
+	tx, err := ExtractTodoDB(ctx)
+	if err != nil {
+		return err
+	}
+
+	db := tx.WithContext(ctx)
+	now, err := dbNow(db)
+	if err != nil {
+		return err
+	}
+
+	return db.
+		Model(&leaseRow{}).
+		Where("name = ? AND holder = ? AND token = ?", lease.Name, lease.Holder, lease.Token).
+		Updates(map[string]any{
+			"expires_at": releasedLeaseExpiry,
+			"updated_at": now,
+		}).
+		Error
+}
+
+// fenced restricts a write to the holder of lease: it changes no row once the
+// lease was acquired again, so that a former holder that stalled past the
+// expiry of its lease cannot overwrite the writes of the next one.
+func fenced(db *gorm.DB, lease *todo.Lease) *gorm.DB {
+	return db.Where(
+		"EXISTS (SELECT 1 FROM leases WHERE leases.name = ? AND leases.token = ?)",
+		lease.Name,
+		lease.Token,
+	)
+}
+
+// fencedOnLeases fences with lease a write db makes to table, and returns the
+// DB of the leases too. The leases are kept on the primary: the write is
+// fenced in SQL when db is the primary, and the lease is checked on the
+// primary before it otherwise, as a statement cannot span two databases.
+func fencedOnLeases(ctx context.Context, db *gorm.DB, table string, lease *todo.Lease) (*gorm.DB, *gorm.DB, error) {
+	primary, ok := extractPrimaryDB(ctx)
+	if !ok {
+		return fenced(db.Table(table), lease), db, nil
+	}
+
+	leases := primary.WithContext(ctx)
+	if err := checkFence(leases, lease); err != nil {
+		return nil, nil, err
+	}
+	return db.Table(table), leases, nil
+}
+
+// checkFence tells why a write fenced by lease changed no row: it returns
+// todo.ErrLeaseLost when the token of the lease is not the current one, and
+// nil when the write had nothing to change.
+func checkFence(db *gorm.DB, lease *todo.Lease) error {
+	var tokens []int64
+	err := db.Model(&leaseRow{}).
+		Where("name = ?", lease.Name).
+		Pluck("token", &tokens).
+		Error
+	if err != nil {
+		return err
+	}
+	if len(tokens) == 0 || tokens[0] != lease.Token {
+		return todo.ErrLeaseLost
+	}
+
+	return nil
+}
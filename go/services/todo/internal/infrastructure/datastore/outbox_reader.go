@@ -0,0 +1,135 @@
+package datastore
+
+import (
+	"context"
+	"time"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+const outboxTableName = "outbox"
+
+type outboxReader struct{}
+
+func NewOutboxReader() gateway.OutboxQueriesGateway {
+	return &outboxReader{}
+}
+
+// ListPendingEvents returns the events due at now that are neither published
+// nor dead-lettered, in the order they were recorded. An event waiting for
+// its next attempt holds back the later events of its aggregate, so that they
+// are published in order; the caller holds them back the same way when an
+// event of the batch fails.
+func (r *outboxReader) ListPendingEvents(
+	ctx context.Context,
+	now time.Time,
+	limit int,
+) ([]*todo.Event, error) {
+	tx, err := ExtractTodoDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	db := tx.WithContext(ctx)
+
+	var events []*todo.Event
+	waiting := db.
+		Table(outboxTableName+" AS waiting").
+		Select("1").
+		Where("waiting.aggregate_type = outbox.aggregate_type AND waiting.aggregate_id = outbox.aggregate_id").
+		Where("waiting.id < outbox.id").
+		Where("waiting.published_at IS NULL AND waiting.dead_lettered_at IS NULL").
+		Where("waiting.next_attempt_at > ?", now)
+
+	err = db.
+		Table(outboxTableName).
+		Where("published_at IS NULL AND dead_lettered_at IS NULL").
+		Where("next_attempt_at <= ?", now).
+		Where("NOT EXISTS (?)", waiting).
+		Order("id ASC").
+		Limit(limit).
+		Find(&events).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// ListUserEventsAfter returns the events of a user recorded after afterID,
+// whether they have been published yet or not.
+func (r *outboxReader) ListUserEventsAfter(
+	ctx context.Context,
+	userID todo.UserID,
+	aggregateType todo.AggregateType,
+	afterID todo.EventID,
+	limit int,
+) ([]*todo.Event, error) {
+	tx, err := ExtractTodoDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	db := tx.WithContext(ctx)
+
+	var events []*todo.Event
+	err = db.
+		Table(outboxTableName).
+		Where("id > ?", afterID).
+		Where("user_id = ? AND aggregate_type = ?", userID, aggregateType).
+		Order("id ASC").
+		Limit(limit).
+		Find(&events).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// ListEventsAfter returns the events recorded after afterID, whether they have
+// been published yet or not.
+func (r *outboxReader) ListEventsAfter(
+	ctx context.Context,
+	afterID todo.EventID,
+	limit int,
+) ([]*todo.Event, error) {
+	tx, err := ExtractTodoDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	db := tx.WithContext(ctx)
+
+	var events []*todo.Event
+	err = db.
+		Table(outboxTableName).
+		Where("id > ?", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&events).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (r *outboxReader) GetLastEventID(ctx context.Context) (todo.EventID, error) {
+	tx, err := ExtractTodoDB(ctx)
+	if err != nil {
+		return 0, err
+	}
+	db := tx.WithContext(ctx)
+
+	var lastID *todo.EventID
+	if err := db.Table(outboxTableName).Select("MAX(id)").Scan(&lastID).Error; err != nil {
+		return 0, err
+	}
+	if lastID == nil {
+		return 0, nil
+	}
+
+	return *lastID, nil
+}
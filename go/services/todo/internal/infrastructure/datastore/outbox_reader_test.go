@@ -0,0 +1,290 @@
+package datastore_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/phamquanandpad/training-project/go/pkg/cast"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/datastore"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/testutil"
+)
+
+// afterEveryEvent is a time every event of the fixtures is due at.
+var afterEveryEvent = getLocalTimeByString("2026-02-01T00:00:00Z")
+
+func Test_outboxReader_ListPendingEvents(t *testing.T) {
+	type args struct {
+		now   time.Time
+		limit int
+	}
+
+	type testcase struct {
+		args     args
+		expected []*todo.Event
+		wantErr  bool
+	}
+
+	t.Parallel()
+
+	event2 := &todo.Event{
+		ID:            2,
+		AggregateType: todo.AggregateTypes.Todo,
+		AggregateID:   2,
+		UserID:        1,
+		EventType:     todo.EventTypes.TodoCreated,
+		Payload:       json.RawMessage(`{"id":2,"user_id":1,"task":"todo task 2","description":"todo description 2","status":1}`),
+		Attempts:      0,
+		OccurredAt:    getLocalTimeByString("2026-01-02T00:00:00Z"),
+		NextAttemptAt: getLocalTimeByString("2026-01-02T00:00:00Z"),
+	}
+	event3 := &todo.Event{
+		ID:            3,
+		AggregateType: todo.AggregateTypes.Todo,
+		AggregateID:   3,
+		UserID:        2,
+		EventType:     todo.EventTypes.TodoCreated,
+		Payload:       json.RawMessage(`{"id":3,"user_id":2,"task":"todo task 3","description":"todo description 3","status":0}`),
+		Attempts:      1,
+		LastError:     cast.Ptr("publish failed"),
+		OccurredAt:    getLocalTimeByString("2026-01-03T00:00:00Z"),
+		NextAttemptAt: getLocalTimeByString("2026-01-03T00:10:00Z"),
+	}
+
+	testTables := map[string]testcase{
+		"List all due events in recorded order": {
+			args:     args{now: getLocalTimeByString("2026-01-03T00:10:00Z"), limit: 10},
+			expected: []*todo.Event{event2, event3},
+			wantErr:  false,
+		},
+		"Leave out events waiting for their next attempt": {
+			args:     args{now: getLocalTimeByString("2026-01-03T00:05:00Z"), limit: 10},
+			expected: []*todo.Event{event2},
+			wantErr:  false,
+		},
+		"List due events up to the limit": {
+			args:     args{now: afterEveryEvent, limit: 1},
+			expected: []*todo.Event{event2},
+			wantErr:  false,
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			outboxReader := datastore.NewOutboxReader()
+
+			actual, err := outboxReader.ListPendingEvents(ctxWithReadDB, tt.args.now, tt.args.limit)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(actual, tt.expected, jsonPayloadTransformer); diff != "" {
+				t.Fatalf("mismatch (-actual +expected):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_outboxReader_ListPendingEvents_HeldBack(t *testing.T) {
+	t.Parallel()
+	gormDB, _ := testutil.InitDB(t)
+
+	type testcase struct {
+		deadLettered bool
+		now          time.Time
+		expected     []todo.EventID
+	}
+
+	testTables := map[string]testcase{
+		"Leave out the events recorded after an event of the same todo waiting for its next attempt": {
+			now:      getLocalTimeByString("2026-01-03T00:05:00Z"),
+			expected: []todo.EventID{2},
+		},
+		"List the events of the todo once its waiting event is due": {
+			now:      getLocalTimeByString("2026-01-03T00:10:00Z"),
+			expected: []todo.EventID{2, 3, 4},
+		},
+		"Dead-lettered event does not hold back the events of its todo": {
+			deadLettered: true,
+			now:          getLocalTimeByString("2026-01-03T00:05:00Z"),
+			expected:     []todo.EventID{2, 4},
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			tx := gormDB.Begin()
+
+			defer tx.Rollback()
+
+			// Event 4 updates todo 3 after event 3, which waits until 00:10.
+			err := tx.Exec(
+				"INSERT INTO outbox (id, aggregate_type, aggregate_id, user_id, event_type, payload, occurred_at, next_attempt_at) VALUES (4, 'todo', 3, 2, 'TodoUpdated', ?, ?, ?)",
+				[]byte(`{"id":3,"user_id":2,"task":"todo task 3 updated","status":0}`),
+				getLocalTimeByString("2026-01-03T00:01:00Z"),
+				getLocalTimeByString("2026-01-03T00:01:00Z"),
+			).Error
+			if err != nil {
+				t.Fatalf("failed to insert event 4: %v", err)
+			}
+			if tt.deadLettered {
+				err := tx.Exec("UPDATE outbox SET dead_lettered_at = ? WHERE id = 3", getLocalTimeByString("2026-01-03T00:02:00Z")).Error
+				if err != nil {
+					t.Fatalf("failed to dead-letter event 3: %v", err)
+				}
+			}
+
+			ctxWithWriteDB := datastore.WithTodoDB(context.Background(), tx)
+			events, err := datastore.NewOutboxReader().ListPendingEvents(ctxWithWriteDB, tt.now, 10)
+			if err != nil {
+				t.Fatalf("outboxReader.ListPendingEvents() error = %v", err)
+			}
+
+			pending := make([]todo.EventID, 0, len(events))
+			for _, e := range events {
+				pending = append(pending, e.ID)
+			}
+			if diff := cmp.Diff(pending, tt.expected); diff != "" {
+				t.Fatalf("mismatch (-actual +expected):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_outboxReader_ListUserEventsAfter(t *testing.T) {
+	type args struct {
+		userID  todo.UserID
+		afterID todo.EventID
+		limit   int
+	}
+
+	type testcase struct {
+		args     args
+		expected []todo.EventID
+		wantErr  bool
+	}
+
+	t.Parallel()
+
+	testTables := map[string]testcase{
+		"List all events of User 1": {
+			args:     args{userID: 1, afterID: 0, limit: 10},
+			expected: []todo.EventID{1, 2},
+			wantErr:  false,
+		},
+		"List events of User 1 after the last seen event": {
+			args:     args{userID: 1, afterID: 1, limit: 10},
+			expected: []todo.EventID{2},
+			wantErr:  false,
+		},
+		"List events of User 2 only": {
+			args:     args{userID: 2, afterID: 0, limit: 10},
+			expected: []todo.EventID{3},
+			wantErr:  false,
+		},
+		"List events up to the limit": {
+			args:     args{userID: 1, afterID: 0, limit: 1},
+			expected: []todo.EventID{1},
+			wantErr:  false,
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			outboxReader := datastore.NewOutboxReader()
+
+			events, err := outboxReader.ListUserEventsAfter(
+				ctxWithReadDB,
+				tt.args.userID,
+				todo.AggregateTypes.Todo,
+				tt.args.afterID,
+				tt.args.limit,
+			)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v wantErr %v", err, tt.wantErr)
+			}
+
+			actual := make([]todo.EventID, 0, len(events))
+			for _, e := range events {
+				actual = append(actual, e.ID)
+			}
+
+			if diff := cmp.Diff(actual, tt.expected); diff != "" {
+				t.Fatalf("mismatch (-actual +expected):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_outboxReader_ListEventsAfter(t *testing.T) {
+	type args struct {
+		afterID todo.EventID
+		limit   int
+	}
+
+	type testcase struct {
+		args     args
+		expected []todo.EventID
+	}
+
+	t.Parallel()
+
+	testTables := map[string]testcase{
+		"List the events of every user": {
+			args:     args{afterID: 0, limit: 10},
+			expected: []todo.EventID{1, 2, 3},
+		},
+		"List events after the last seen event": {
+			args:     args{afterID: 2, limit: 10},
+			expected: []todo.EventID{3},
+		},
+		"List events up to the limit": {
+			args:     args{afterID: 0, limit: 2},
+			expected: []todo.EventID{1, 2},
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			events, err := datastore.NewOutboxReader().ListEventsAfter(ctxWithReadDB, tt.args.afterID, tt.args.limit)
+			if err != nil {
+				t.Fatalf("outboxReader.ListEventsAfter() error = %v", err)
+			}
+
+			actual := make([]todo.EventID, 0, len(events))
+			for _, e := range events {
+				actual = append(actual, e.ID)
+			}
+
+			if diff := cmp.Diff(actual, tt.expected); diff != "" {
+				t.Fatalf("mismatch (-actual +expected):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_outboxReader_GetLastEventID(t *testing.T) {
+	t.Parallel()
+
+	lastID, err := datastore.NewOutboxReader().GetLastEventID(ctxWithReadDB)
+	if err != nil {
+		t.Fatalf("outboxReader.GetLastEventID() error = %v", err)
+	}
+	if lastID != 3 {
+		t.Fatalf("outboxReader.GetLastEventID() = %d, want 3", lastID)
+	}
+}
@@ -0,0 +1,133 @@
+package datastore
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+type outboxWriter struct{}
+
+func NewOutboxWriter() gateway.OutboxCommandsGateway {
+	return &outboxWriter{}
+}
+
+func (w *outboxWriter) MarkEventPublished(
+	ctx context.Context,
+	lease *todo.Lease,
+	eventID todo.EventID,
+) error {
+	tx, err := ExtractTodoDB(ctx)
+	if err != nil {
+		return err
+	}
+
+	db := tx.WithContext(ctx)
+
+	write, leases, err := fencedOnLeases(ctx, db, outboxTableName, lease)
+	if err != nil {
+		return err
+	}
+	result := write.
+		Where("id = ? AND published_at IS NULL", eventID).
+		Update("published_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return checkFence(leases, lease)
+	}
+
+	return nil
+}
+
+func (w *outboxWriter) MarkEventFailed(
+	ctx context.Context,
+	lease *todo.Lease,
+	eventID todo.EventID,
+	nextAttemptAt time.Time,
+	cause error,
+) error {
+	tx, err := ExtractTodoDB(ctx)
+	if err != nil {
+		return err
+	}
+
+	db := tx.WithContext(ctx)
+
+	var lastError *string
+	if cause != nil {
+		msg := cause.Error()
+		lastError = &msg
+	}
+
+	write, leases, err := fencedOnLeases(ctx, db, outboxTableName, lease)
+	if err != nil {
+		return err
+	}
+	result := write.
+		Where("id = ? AND published_at IS NULL", eventID).
+		Updates(map[string]any{
+			"attempts":        gorm.Expr("attempts + 1"),
+			"last_error":      lastError,
+			"next_attempt_at": nextAttemptAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return checkFence(leases, lease)
+	}
+
+	return nil
+}
+
+func (w *outboxWriter) MarkEventDeadLettered(
+	ctx context.Context,
+	lease *todo.Lease,
+	eventID todo.EventID,
+	cause error,
+) error {
+	tx, err := ExtractTodoDB(ctx)
+	if err != nil {
+		return err
+	}
+
+	db := tx.WithContext(ctx)
+
+	var lastError *string
+	if cause != nil {
+		msg := cause.Error()
+		lastError = &msg
+	}
+
+	write, leases, err := fencedOnLeases(ctx, db, outboxTableName, lease)
+	if err != nil {
+		return err
+	}
+	result := write.
+		Where("id = ? AND published_at IS NULL", eventID).
+		Updates(map[string]any{
+			"attempts":         gorm.Expr("attempts + 1"),
+			"last_error":       lastError,
+			"dead_lettered_at": time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return checkFence(leases, lease)
+	}
+
+	return nil
+}
+
+// appendEvent records a domain event in the outbox. It must be called with the
+// same transaction as the write the event describes.
+func appendEvent(db *gorm.DB, event *todo.Event) error {
+	return db.Table(outboxTableName).Create(event).Error
+}
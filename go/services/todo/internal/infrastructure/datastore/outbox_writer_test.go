@@ -0,0 +1,295 @@
+package datastore_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/datastore"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/testutil"
+)
+
+// acquireRelayLease makes the test the holder of the lease of the outbox relay.
+func acquireRelayLease(t *testing.T, ctx context.Context) *todo.Lease {
+	t.Helper()
+
+	lease, err := datastore.NewLeaseWriter().AcquireLease(ctx, "outbox-relay", "relay-a", time.Minute)
+	if err != nil {
+		t.Fatalf("leaseWriter.AcquireLease() error = %v", err)
+	}
+	return lease
+}
+
+func staleLease(lease *todo.Lease) *todo.Lease {
+	stale := *lease
+	stale.Token--
+	return &stale
+}
+
+func Test_outboxWriter_MarkEventPublished(t *testing.T) {
+	t.Parallel()
+	gormDB, _ := testutil.InitDB(t)
+
+	type args struct {
+		eventID todo.EventID
+		stale   bool
+	}
+
+	type testcase struct {
+		args            args
+		expectedPending []todo.EventID
+		expectedErr     error
+	}
+
+	testTables := map[string]testcase{
+		"Mark pending event as published": {
+			args:            args{eventID: 2},
+			expectedPending: []todo.EventID{3},
+		},
+		"Mark already published event does nothing": {
+			args:            args{eventID: 1},
+			expectedPending: []todo.EventID{2, 3},
+		},
+		"Mark unknown event does nothing": {
+			args:            args{eventID: 999},
+			expectedPending: []todo.EventID{2, 3},
+		},
+		"Mark with the token of a former holder does nothing": {
+			args:            args{eventID: 2, stale: true},
+			expectedPending: []todo.EventID{2, 3},
+			expectedErr:     todo.ErrLeaseLost,
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			tx := gormDB.Begin()
+
+			defer tx.Rollback()
+
+			ctxWithWriteDB := datastore.WithTodoDB(context.Background(), tx)
+			lease := acquireRelayLease(t, ctxWithWriteDB)
+			if tt.args.stale {
+				lease = staleLease(lease)
+			}
+
+			outboxWriter := datastore.NewOutboxWriter()
+			err := outboxWriter.MarkEventPublished(ctxWithWriteDB, lease, tt.args.eventID)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("outboxWriter.MarkEventPublished() error = %v, want %v", err, tt.expectedErr)
+			}
+
+			events, err := datastore.NewOutboxReader().ListPendingEvents(ctxWithWriteDB, afterEveryEvent, 10)
+			if err != nil {
+				t.Fatalf("outboxReader.ListPendingEvents() error = %v", err)
+			}
+
+			pending := make([]todo.EventID, 0, len(events))
+			for _, e := range events {
+				pending = append(pending, e.ID)
+			}
+
+			if diff := cmp.Diff(pending, tt.expectedPending); diff != "" {
+				t.Errorf("pending events mismatch (-actual +expected):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_outboxWriter_MarkEventFailed(t *testing.T) {
+	t.Parallel()
+	gormDB, _ := testutil.InitDB(t)
+
+	type args struct {
+		eventID       todo.EventID
+		nextAttemptAt time.Time
+		cause         error
+		stale         bool
+	}
+
+	type expected struct {
+		attempts      int
+		lastError     string
+		nextAttemptAt time.Time
+	}
+
+	type testcase struct {
+		args        args
+		expected    expected
+		expectedErr error
+	}
+
+	testTables := map[string]testcase{
+		"Mark first failure": {
+			args: args{
+				eventID:       2,
+				nextAttemptAt: getLocalTimeByString("2026-01-02T00:01:00Z"),
+				cause:         errors.New("broker unavailable"),
+			},
+			expected: expected{
+				attempts:      1,
+				lastError:     "broker unavailable",
+				nextAttemptAt: getLocalTimeByString("2026-01-02T00:01:00Z"),
+			},
+		},
+		"Mark another failure": {
+			args: args{
+				eventID:       3,
+				nextAttemptAt: getLocalTimeByString("2026-01-03T00:20:00Z"),
+				cause:         errors.New("broker unavailable"),
+			},
+			expected: expected{
+				attempts:      2,
+				lastError:     "broker unavailable",
+				nextAttemptAt: getLocalTimeByString("2026-01-03T00:20:00Z"),
+			},
+		},
+		"Mark with the token of a former holder does nothing": {
+			args: args{
+				eventID:       3,
+				nextAttemptAt: getLocalTimeByString("2026-01-03T00:20:00Z"),
+				cause:         errors.New("broker unavailable"),
+				stale:         true,
+			},
+			expected: expected{
+				attempts:      1,
+				lastError:     "publish failed",
+				nextAttemptAt: getLocalTimeByString("2026-01-03T00:10:00Z"),
+			},
+			expectedErr: todo.ErrLeaseLost,
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			tx := gormDB.Begin()
+
+			defer tx.Rollback()
+
+			ctxWithWriteDB := datastore.WithTodoDB(context.Background(), tx)
+			lease := acquireRelayLease(t, ctxWithWriteDB)
+			if tt.args.stale {
+				lease = staleLease(lease)
+			}
+
+			outboxWriter := datastore.NewOutboxWriter()
+			err := outboxWriter.MarkEventFailed(ctxWithWriteDB, lease, tt.args.eventID, tt.args.nextAttemptAt, tt.args.cause)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("outboxWriter.MarkEventFailed() error = %v, want %v", err, tt.expectedErr)
+			}
+
+			events, err := datastore.NewOutboxReader().ListPendingEvents(ctxWithWriteDB, afterEveryEvent, 10)
+			if err != nil {
+				t.Fatalf("outboxReader.ListPendingEvents() error = %v", err)
+			}
+
+			var actual *todo.Event
+			for _, e := range events {
+				if e.ID == tt.args.eventID {
+					actual = e
+				}
+			}
+			if actual == nil {
+				t.Fatalf("event %d is not pending anymore", tt.args.eventID)
+			}
+
+			if actual.Attempts != tt.expected.attempts {
+				t.Errorf("attempts = %d want %d", actual.Attempts, tt.expected.attempts)
+			}
+			if actual.LastError == nil || *actual.LastError != tt.expected.lastError {
+				t.Errorf("last error = %v want %s", actual.LastError, tt.expected.lastError)
+			}
+			if !actual.NextAttemptAt.Equal(tt.expected.nextAttemptAt) {
+				t.Errorf("next attempt at = %v want %v", actual.NextAttemptAt, tt.expected.nextAttemptAt)
+			}
+		})
+	}
+}
+
+func Test_outboxWriter_MarkEventDeadLettered(t *testing.T) {
+	t.Parallel()
+	gormDB, _ := testutil.InitDB(t)
+
+	type args struct {
+		eventID todo.EventID
+		stale   bool
+	}
+
+	type testcase struct {
+		args                 args
+		expectedPending      []todo.EventID
+		expectedAttempts     int
+		expectedDeadLettered bool
+		expectedErr          error
+	}
+
+	testTables := map[string]testcase{
+		"Dead-letter pending event": {
+			args:                 args{eventID: 3},
+			expectedPending:      []todo.EventID{2},
+			expectedAttempts:     2,
+			expectedDeadLettered: true,
+		},
+		"Dead-letter already published event does nothing": {
+			args:             args{eventID: 1},
+			expectedPending:  []todo.EventID{2, 3},
+			expectedAttempts: 0,
+		},
+		"Dead-letter with the token of a former holder does nothing": {
+			args:             args{eventID: 3, stale: true},
+			expectedPending:  []todo.EventID{2, 3},
+			expectedAttempts: 1,
+			expectedErr:      todo.ErrLeaseLost,
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			tx := gormDB.Begin()
+
+			defer tx.Rollback()
+
+			ctxWithWriteDB := datastore.WithTodoDB(context.Background(), tx)
+			lease := acquireRelayLease(t, ctxWithWriteDB)
+			if tt.args.stale {
+				lease = staleLease(lease)
+			}
+
+			outboxWriter := datastore.NewOutboxWriter()
+			err := outboxWriter.MarkEventDeadLettered(ctxWithWriteDB, lease, tt.args.eventID, errors.New("broker unavailable"))
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("outboxWriter.MarkEventDeadLettered() error = %v, want %v", err, tt.expectedErr)
+			}
+
+			events, err := datastore.NewOutboxReader().ListPendingEvents(ctxWithWriteDB, afterEveryEvent, 10)
+			if err != nil {
+				t.Fatalf("outboxReader.ListPendingEvents() error = %v", err)
+			}
+			pending := make([]todo.EventID, 0, len(events))
+			for _, e := range events {
+				pending = append(pending, e.ID)
+			}
+			if diff := cmp.Diff(pending, tt.expectedPending); diff != "" {
+				t.Errorf("pending events mismatch (-actual +expected):\n%s", diff)
+			}
+
+			events, err = datastore.NewOutboxReader().ListEventsAfter(ctxWithWriteDB, tt.args.eventID-1, 1)
+			if err != nil {
+				t.Fatalf("outboxReader.ListEventsAfter() error = %v", err)
+			}
+			if events[0].Attempts != tt.expectedAttempts {
+				t.Errorf("attempts = %d want %d", events[0].Attempts, tt.expectedAttempts)
+			}
+			if events[0].IsDeadLettered() != tt.expectedDeadLettered {
+				t.Errorf("dead-lettered = %v want %v", events[0].IsDeadLettered(), tt.expectedDeadLettered)
+			}
+		})
+	}
+}
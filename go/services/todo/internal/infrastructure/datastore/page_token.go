@@ -0,0 +1,120 @@
+package datastore
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/errors"
+)
+
+// pageTokenVersion is bumped when the payload changes; tokens of other
+// versions are rejected.
+const pageTokenVersion = 2
+
+// PageTokenScope is what a page token is issued for: the user, and the
+// listing with its sorting and filters. A token only decodes for the scope it
+// was encoded with.
+type PageTokenScope struct {
+	UserID todo.UserID
+	// Query names the listing and holds its sorting and filters, e.g.
+	// "todo_activity:day:ASC:2026-01-01T00:00:00Z:2026-02-01T00:00:00Z".
+	Query string
+}
+
+type pageTokenPayload struct {
+	Version   int                  `json:"v"`
+	Direction todo.PagingDirection `json:"d"`
+	Keys      []CursorKey          `json:"k"`
+}
+
+// PageTokenCodec encodes the cursor keys of a page into an opaque token,
+// signed with an HMAC of the payload and its scope, so that clients can
+// neither forge keys nor reuse a token for another user or query. Rotating
+// the secret invalidates every issued token.
+type PageTokenCodec struct {
+	secret []byte
+}
+
+func NewPageTokenCodec(secret string) *PageTokenCodec {
+	return &PageTokenCodec{
+		secret: []byte(secret),
+	}
+}
+
+// Encode returns the token of the page in direction from keys, the cursor
+// values of the last or first row of a page, in the order of the sorted
+// columns.
+func (c *PageTokenCodec) Encode(
+	scope PageTokenScope,
+	direction todo.PagingDirection,
+	keys ...CursorKey,
+) (string, error) {
+	payload, err := json.Marshal(pageTokenPayload{
+		Version:   pageTokenVersion,
+		Direction: direction,
+		Keys:      keys,
+	})
+	if err != nil {
+		return "", fmt.Errorf("encode page token: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(c.sign(scope, payload)), nil
+}
+
+// Decode returns the direction and the keys of token. A malformed or tampered
+// token, one of another version, or one issued for another scope is a
+// ParameterError.
+func (c *PageTokenCodec) Decode(scope PageTokenScope, token string) (todo.PagingDirection, []CursorKey, error) {
+	encodedPayload, encodedSignature, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", nil, invalidPageTokenError(nil)
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return "", nil, invalidPageTokenError(err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return "", nil, invalidPageTokenError(err)
+	}
+	if !hmac.Equal(signature, c.sign(scope, payload)) {
+		return "", nil, invalidPageTokenError(nil)
+	}
+
+	var p pageTokenPayload
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&p); err != nil {
+		return "", nil, invalidPageTokenError(err)
+	}
+	if p.Version != pageTokenVersion || !p.Direction.IsValid() {
+		return "", nil, invalidPageTokenError(nil)
+	}
+	for _, key := range p.Keys {
+		if _, ok := key.Value(); !ok {
+			return "", nil, invalidPageTokenError(nil)
+		}
+	}
+
+	return p.Direction, p.Keys, nil
+}
+
+// sign covers the scope without storing it in the token, so a token decoded
+// with another scope fails verification.
+func (c *PageTokenCodec) sign(scope PageTokenScope, payload []byte) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+	_, _ = fmt.Fprintf(mac, "page-token:%d:%s:%d:%s\x00", pageTokenVersion, scope.UserID.String(), len(scope.Query), scope.Query)
+	_, _ = mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+func invalidPageTokenError(err error) error {
+	return errors.NewParameterError("invalid page token", err, nil)
+}
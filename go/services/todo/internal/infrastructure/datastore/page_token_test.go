@@ -0,0 +1,103 @@
+package datastore_test
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/errors"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/datastore"
+)
+
+func Test_PageTokenCodec_Decode(t *testing.T) {
+	type expected struct {
+		direction todo.PagingDirection
+		keys      []datastore.CursorKey
+	}
+
+	type testcase struct {
+		token    string
+		expected expected
+		wantErr  bool
+	}
+
+	t.Parallel()
+
+	createdAt := time.Date(2026, 1, 4, 10, 0, 0, 123456789, time.FixedZone("JST", 9*60*60))
+	scope := datastore.PageTokenScope{UserID: 1, Query: "todos:created_at:DESC"}
+	keys := []datastore.CursorKey{
+		datastore.TimeCursorKey(createdAt),
+		datastore.IntCursorKey(42),
+		datastore.StringCursorKey("a|b"),
+	}
+	token := *encodePageToken(scope, todo.PagingDirections.Previous, keys...)
+	payload, signature, _ := strings.Cut(token, ".")
+	otherPayload, otherSignature, _ := strings.Cut(*encodePageToken(scope, todo.PagingDirections.Next, keys...), ".")
+
+	testTables := map[string]testcase{
+		"Decode typed keys and the direction": {
+			token: token,
+			expected: expected{
+				direction: todo.PagingDirections.Previous,
+				keys:      keys,
+			},
+			wantErr: false,
+		},
+		"Return error for a token without signature": {
+			token:   payload,
+			wantErr: true,
+		},
+		"Return error for a tampered payload": {
+			token:   otherPayload + "." + signature,
+			wantErr: true,
+		},
+		"Return error for a payload with another signature": {
+			token:   payload + "." + otherSignature,
+			wantErr: true,
+		},
+		"Return error for a token of another query": {
+			token:   *encodePageToken(datastore.PageTokenScope{UserID: 1, Query: "todos:created_at:ASC"}, todo.PagingDirections.Next, keys...),
+			wantErr: true,
+		},
+		"Return error for a token of another user": {
+			token:   *encodePageToken(datastore.PageTokenScope{UserID: 2, Query: scope.Query}, todo.PagingDirections.Next, keys...),
+			wantErr: true,
+		},
+		"Return error for a key without value": {
+			token:   *encodePageToken(scope, todo.PagingDirections.Next, datastore.CursorKey{}),
+			wantErr: true,
+		},
+		"Return error for an unknown direction": {
+			token:   *encodePageToken(scope, "sideways", keys...),
+			wantErr: true,
+		},
+		"Return error for a former unsigned token": {
+			token:   base64.StdEncoding.EncodeToString([]byte("2026-01-04|42")),
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			direction, keys, err := testPageTokens.Decode(scope, tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.IsParameterError(err) {
+				t.Fatalf("error = %v, want a ParameterError", err)
+			}
+
+			actual := expected{direction: direction, keys: keys}
+			if diff := cmp.Diff(actual, tt.expected, cmp.AllowUnexported(expected{})); diff != "" {
+				t.Fatalf("mismatch (-actual +expected):\n%s", diff)
+			}
+		})
+	}
+}
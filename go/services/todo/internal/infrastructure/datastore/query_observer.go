@@ -0,0 +1,174 @@
+package datastore
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+
+	contextutil "github.com/phamquanandpad/training-project/go/services/todo/internal/utils/context"
+)
+
+const (
+	queryObserverName = "todo:query_observer"
+	instrumentation   = "github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/datastore"
+)
+
+type QueryObserverConfig struct {
+	// SlowQueryThreshold is the latency from which a statement is logged. Zero
+	// turns the log off.
+	SlowQueryThreshold time.Duration
+
+	// The global OpenTelemetry providers and the standard logger are used
+	// when these are nil.
+	TracerProvider trace.TracerProvider
+	MeterProvider  metric.MeterProvider
+	Logger         *log.Logger
+}
+
+// queryObserver is a gorm plugin that records the latency of every statement
+// in a histogram labeled by table and operation, traces every statement in a
+// span carrying the method name of the request, and logs slow statements.
+// Statements are logged and traced with their placeholders, never with their
+// arguments.
+type queryObserver struct {
+	cfg      QueryObserverConfig
+	tracer   trace.Tracer
+	duration metric.Float64Histogram
+}
+
+type queryObservation struct {
+	start time.Time
+	span  trace.Span
+}
+
+func NewQueryObserver(cfg QueryObserverConfig) gorm.Plugin {
+	if cfg.TracerProvider == nil {
+		cfg.TracerProvider = otel.GetTracerProvider()
+	}
+	if cfg.MeterProvider == nil {
+		cfg.MeterProvider = otel.GetMeterProvider()
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = log.Default()
+	}
+
+	return &queryObserver{cfg: cfg}
+}
+
+func (o *queryObserver) Name() string {
+	return queryObserverName
+}
+
+func (o *queryObserver) Initialize(db *gorm.DB) error {
+	o.tracer = o.cfg.TracerProvider.Tracer(instrumentation)
+
+	var err error
+	o.duration, err = o.cfg.MeterProvider.Meter(instrumentation).Float64Histogram(
+		"db.client.query.duration",
+		metric.WithDescription("Latency of the database statements."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return fmt.Errorf("create query duration histogram: %w", err)
+	}
+
+	before, after := queryObserverName+"_before", queryObserverName+"_after"
+	callback := db.Callback()
+	return errors.Join(
+		callback.Create().Before("gorm:create").Register(before, o.before),
+		callback.Create().After("gorm:create").Register(after, o.after("insert")),
+		callback.Query().Before("gorm:query").Register(before, o.before),
+		callback.Query().After("gorm:query").Register(after, o.after("select")),
+		callback.Update().Before("gorm:update").Register(before, o.before),
+		callback.Update().After("gorm:update").Register(after, o.after("update")),
+		callback.Delete().Before("gorm:delete").Register(before, o.before),
+		callback.Delete().After("gorm:delete").Register(after, o.after("delete")),
+		callback.Row().Before("gorm:row").Register(before, o.before),
+		callback.Row().After("gorm:row").Register(after, o.after("select")),
+		// Raw statements are labeled by their first keyword.
+		callback.Raw().Before("gorm:raw").Register(before, o.before),
+		callback.Raw().After("gorm:raw").Register(after, o.after("")),
+	)
+}
+
+func (o *queryObserver) before(db *gorm.DB) {
+	ctx := db.Statement.Context
+	attrs := []attribute.KeyValue{
+		attribute.String("db.system", db.Dialector.Name()),
+	}
+	if method, ok := contextutil.MethodName(ctx); ok {
+		attrs = append(attrs, attribute.String("rpc.method", method))
+	}
+
+	ctx, span := o.tracer.Start(ctx, "db.query", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+	db.Statement.Context = ctx
+	db.InstanceSet(queryObserverName, &queryObservation{start: time.Now(), span: span})
+}
+
+func (o *queryObserver) after(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		v, ok := db.InstanceGet(queryObserverName)
+		if !ok {
+			return
+		}
+		observation, ok := v.(*queryObservation)
+		if !ok {
+			return
+		}
+		elapsed := time.Since(observation.start)
+
+		sql := db.Statement.SQL.String()
+		if operation == "" {
+			operation = statementOperation(sql)
+		}
+		table := db.Statement.Table
+		if table == "" {
+			table = "unknown"
+		}
+		labels := []attribute.KeyValue{
+			attribute.String("db.sql.table", table),
+			attribute.String("db.operation", operation),
+		}
+
+		o.duration.Record(db.Statement.Context, elapsed.Seconds(), metric.WithAttributes(labels...))
+
+		span := observation.span
+		span.SetName(operation + " " + table)
+		span.SetAttributes(labels...)
+		span.SetAttributes(
+			attribute.String("db.statement", sql),
+			attribute.Int64("db.rows_affected", db.RowsAffected),
+		)
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			span.RecordError(db.Error)
+			span.SetStatus(codes.Error, db.Error.Error())
+		}
+		span.End()
+
+		if o.cfg.SlowQueryThreshold > 0 && elapsed >= o.cfg.SlowQueryThreshold {
+			method, _ := contextutil.MethodName(db.Statement.Context)
+			o.cfg.Logger.Printf(
+				"slow query: %s, method = %q, rows = %d, %d args redacted: %s",
+				elapsed, method, db.RowsAffected, len(db.Statement.Vars), sql,
+			)
+		}
+	}
+}
+
+// statementOperation returns the lowercased first keyword of a raw statement.
+func statementOperation(sql string) string {
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return "unknown"
+	}
+	return strings.ToLower(fields[0])
+}
@@ -0,0 +1,184 @@
+package datastore_test
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/datastore"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/testutil"
+	contextutil "github.com/phamquanandpad/training-project/go/services/todo/internal/utils/context"
+)
+
+type queryObserverTest struct {
+	ctx      context.Context
+	spans    *tracetest.SpanRecorder
+	metrics  *sdkmetric.ManualReader
+	slowLogs *bytes.Buffer
+}
+
+func newQueryObserverTest(t *testing.T, threshold time.Duration) *queryObserverTest {
+	t.Helper()
+
+	gormDB, _ := testutil.InitDB(t)
+
+	spans := tracetest.NewSpanRecorder()
+	metrics := sdkmetric.NewManualReader()
+	var slowLogs bytes.Buffer
+	err := gormDB.Use(datastore.NewQueryObserver(datastore.QueryObserverConfig{
+		SlowQueryThreshold: threshold,
+		TracerProvider:     sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)),
+		MeterProvider:      sdkmetric.NewMeterProvider(sdkmetric.WithReader(metrics)),
+		Logger:             log.New(&slowLogs, "", 0),
+	}))
+	if err != nil {
+		t.Fatalf("Use() error = %v", err)
+	}
+
+	ctx := contextutil.WithMethodName(context.Background(), "/todo.todo.v1.TodoService/GetTodo")
+	return &queryObserverTest{
+		ctx:      datastore.WithTodoDB(ctx, gormDB),
+		spans:    spans,
+		metrics:  metrics,
+		slowLogs: &slowLogs,
+	}
+}
+
+func Test_queryObserver_Span(t *testing.T) {
+	t.Parallel()
+	qt := newQueryObserverTest(t, 0)
+
+	if _, err := datastore.NewTodoReader().GetTodo(qt.ctx, 1, 1); err != nil {
+		t.Fatalf("todoReader.GetTodo() error = %v", err)
+	}
+
+	ended := qt.spans.Ended()
+	if len(ended) != 1 {
+		t.Fatalf("got %d spans, want 1", len(ended))
+	}
+	span := ended[0]
+	attrs := make(map[attribute.Key]string)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value.Emit()
+	}
+
+	actual := map[string]string{
+		"name":         span.Name(),
+		"rpc.method":   attrs["rpc.method"],
+		"db.sql.table": attrs["db.sql.table"],
+		"db.operation": attrs["db.operation"],
+	}
+	expected := map[string]string{
+		"name":         "select todos",
+		"rpc.method":   "/todo.todo.v1.TodoService/GetTodo",
+		"db.sql.table": "todos",
+		"db.operation": "select",
+	}
+	if diff := cmp.Diff(actual, expected); diff != "" {
+		t.Fatalf("mismatch (-actual +expected):\n%s", diff)
+	}
+	if !strings.Contains(attrs["db.statement"], "?") {
+		t.Fatalf("db.statement = %q, want placeholders", attrs["db.statement"])
+	}
+}
+
+func Test_queryObserver_Histogram(t *testing.T) {
+	t.Parallel()
+	qt := newQueryObserverTest(t, 0)
+
+	todoReader := datastore.NewTodoReader()
+	for range 2 {
+		if _, err := todoReader.GetTodo(qt.ctx, 1, 1); err != nil {
+			t.Fatalf("todoReader.GetTodo() error = %v", err)
+		}
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := qt.metrics.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	counts := make(map[string]uint64)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "db.client.query.duration" {
+				continue
+			}
+			histogram, ok := m.Data.(metricdata.Histogram[float64])
+			if !ok {
+				t.Fatalf("%s is a %T, want a histogram", m.Name, m.Data)
+			}
+			for _, dp := range histogram.DataPoints {
+				table, _ := dp.Attributes.Value("db.sql.table")
+				operation, _ := dp.Attributes.Value("db.operation")
+				counts[table.AsString()+" "+operation.AsString()] += dp.Count
+			}
+		}
+	}
+
+	expected := map[string]uint64{"todos select": 2}
+	if diff := cmp.Diff(counts, expected); diff != "" {
+		t.Fatalf("mismatch (-actual +expected):\n%s", diff)
+	}
+}
+
+func Test_queryObserver_SlowQueryLog(t *testing.T) {
+	t.Parallel()
+
+	type testcase struct {
+		threshold time.Duration
+		logged    bool
+	}
+
+	testTables := map[string]testcase{
+		"Logged over the threshold": {
+			threshold: time.Nanosecond,
+			logged:    true,
+		},
+		"Not logged under the threshold": {
+			threshold: time.Hour,
+			logged:    false,
+		},
+		"Not logged when disabled": {
+			threshold: 0,
+			logged:    false,
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			qt := newQueryObserverTest(t, tt.threshold)
+
+			db, err := datastore.ExtractTodoDB(qt.ctx)
+			if err != nil {
+				t.Fatalf("ExtractTodoDB() error = %v", err)
+			}
+			if err := db.WithContext(qt.ctx).Exec("UPDATE users SET password = ? WHERE id = ?", "s3cret-password", 1).Error; err != nil {
+				t.Fatalf("Exec() error = %v", err)
+			}
+
+			logs := qt.slowLogs.String()
+			if logged := logs != ""; logged != tt.logged {
+				t.Fatalf("logged = %v, want %v: %q", logged, tt.logged, logs)
+			}
+			if strings.Contains(logs, "s3cret-password") {
+				t.Fatalf("slow query log has the arguments: %q", logs)
+			}
+			if tt.logged && !strings.Contains(logs, "/todo.todo.v1.TodoService/GetTodo") {
+				t.Fatalf("slow query log has no method name: %q", logs)
+			}
+		})
+	}
+}
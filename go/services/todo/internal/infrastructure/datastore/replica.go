@@ -0,0 +1,281 @@
+package datastore
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"log"
+	"math/rand"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/config"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+)
+
+// replicaResolverName names the dbresolver resolver of the read replicas.
+// Statements use it only when asked to, every other statement goes to the
+// primary.
+const replicaResolverName = "todo_replica"
+
+type pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// ReplicaPolicy balances reads over the replicas whose last health check
+// succeeded. The last connection pool it is given is the primary: reads fail
+// over to it when no replica is available. The replicas are pinged in the
+// background, by Run, so that no read waits for a health check; a replica is
+// available once its first check succeeded. Used as a plugin, it retries on
+// the primary the reads failing on a replica.
+type ReplicaPolicy struct {
+	policy        config.DBReplicaPolicy
+	replicas      []gorm.ConnPool
+	checkInterval time.Duration
+	pingTimeout   time.Duration
+	next          atomic.Int64
+
+	mu      sync.RWMutex
+	healthy map[gorm.ConnPool]bool
+}
+
+func NewReplicaPolicy(
+	policy config.DBReplicaPolicy,
+	replicas []gorm.ConnPool,
+	checkInterval time.Duration,
+	pingTimeout time.Duration,
+) *ReplicaPolicy {
+	return &ReplicaPolicy{
+		policy:        policy,
+		replicas:      replicas,
+		checkInterval: checkInterval,
+		pingTimeout:   pingTimeout,
+		healthy:       map[gorm.ConnPool]bool{},
+	}
+}
+
+func (p *ReplicaPolicy) Resolve(connPools []gorm.ConnPool) gorm.ConnPool {
+	primary := connPools[len(connPools)-1]
+	replicas := connPools[:len(connPools)-1]
+	if len(replicas) == 0 {
+		return primary
+	}
+
+	start := 0
+	if p.policy == config.DBReplicaPolicyRoundRobin {
+		start = int(p.next.Add(1) % int64(len(replicas)))
+	} else {
+		start = rand.Intn(len(replicas)) // nolint: gosec
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	for i := range replicas {
+		replica := replicas[(start+i)%len(replicas)]
+		if p.healthy[replica] {
+			return replica
+		}
+	}
+
+	return primary
+}
+
+// CheckReplicas pings every replica, each for up to the ping timeout, and
+// records which ones answered.
+func (p *ReplicaPolicy) CheckReplicas(ctx context.Context) {
+	healthy := make([]bool, len(p.replicas))
+
+	var wg sync.WaitGroup
+	for i, replica := range p.replicas {
+		pool, ok := replica.(pinger)
+		if !ok {
+			healthy[i] = true
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(ctx, p.pingTimeout)
+			defer cancel()
+			healthy[i] = pool.PingContext(ctx) == nil
+		}()
+	}
+	wg.Wait()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i, replica := range p.replicas {
+		p.healthy[replica] = healthy[i]
+	}
+}
+
+// Run checks the replicas every check interval until ctx is done.
+func (p *ReplicaPolicy) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.CheckReplicas(ctx)
+		}
+	}
+}
+
+// isReplica tells whether connPool is one of the replicas of the policy.
+func (p *ReplicaPolicy) isReplica(connPool gorm.ConnPool) bool {
+	for _, replica := range p.replicas {
+		if replica == connPool {
+			return true
+		}
+	}
+	return false
+}
+
+// markDown takes a replica out of the reads until its next check succeeds.
+func (p *ReplicaPolicy) markDown(connPool gorm.ConnPool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.healthy[connPool]; ok {
+		p.healthy[connPool] = false
+	}
+}
+
+func (p *ReplicaPolicy) Name() string {
+	return "todo:replica_policy"
+}
+
+// Initialize runs a read again on the primary, the connection pool of db, when
+// it failed on one of the replicas, as when the replica went down since its
+// last check. A replica which lost its connection is taken out of the reads
+// until its next check.
+func (p *ReplicaPolicy) Initialize(db *gorm.DB) error {
+	retry := func(run func(*gorm.DB)) func(*gorm.DB) {
+		return func(db *gorm.DB) {
+			if db.Error == nil || errors.Is(db.Error, gorm.ErrRecordNotFound) || !p.isReplica(db.Statement.ConnPool) {
+				return
+			}
+			if db.Statement.Context != nil && db.Statement.Context.Err() != nil {
+				return
+			}
+
+			if isConnectionError(db.Error) {
+				p.markDown(db.Statement.ConnPool)
+			}
+			log.Printf("read from a replica failed, retrying on the primary: %v", db.Error)
+
+			db.Error = nil
+			db.RowsAffected = 0
+			db.Statement.ConnPool = db.Config.ConnPool
+			run(db)
+		}
+	}
+
+	query := db.Callback().Query()
+	err := query.After("gorm:query").Before("gorm:preload").Register("todo:retry_on_primary", retry(query.Get("gorm:query")))
+	if err != nil {
+		return err
+	}
+	row := db.Callback().Row()
+	rowQuery := row.Get("gorm:row")
+	return row.After("gorm:row").Register("todo:retry_on_primary", retry(func(db *gorm.DB) {
+		// Only the reads asking for *sql.Rows fail here, and gorm:row
+		// consumed the setting asking for them.
+		db.Statement.Settings.Store("rows", true)
+		rowQuery(db)
+	}))
+}
+
+// isConnectionError tells whether err comes from the connection to the
+// database rather than from the statement.
+func isConnectionError(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, mysql.ErrInvalidConn) ||
+		errors.As(err, &netErr)
+}
+
+type writeTrackerKey struct{}
+
+// writeTracker records that a request wrote to the primary, so that its later
+// reads see the write instead of a lagging replica.
+type writeTracker struct {
+	wrote atomic.Bool
+}
+
+func withWriteTracker(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(writeTrackerKey{}).(*writeTracker); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, writeTrackerKey{}, &writeTracker{})
+}
+
+func markWrite(ctx context.Context) {
+	if tracker, ok := ctx.Value(writeTrackerKey{}).(*writeTracker); ok {
+		tracker.wrote.Store(true)
+	}
+}
+
+func readsFromPrimary(ctx context.Context) bool {
+	if gateway.PrimaryReadsRequired(ctx) {
+		return true
+	}
+	tracker, ok := ctx.Value(writeTrackerKey{}).(*writeTracker)
+	return ok && tracker.wrote.Load()
+}
+
+// ExtractTodoReadDB returns the DB of ctx for a read that may be served by a
+// replica. It reads from the primary when the request wrote before or asked
+// for primary reads.
+func ExtractTodoReadDB(ctx context.Context) (*gorm.DB, error) {
+	tx, err := ExtractTodoDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	db := tx.WithContext(ctx)
+	if readsFromPrimary(ctx) {
+		return db, nil
+	}
+
+	// A new session, so that the DB can be reused for several statements.
+	return db.Clauses(dbresolver.Use(replicaResolverName)).Session(&gorm.Session{}), nil
+}
+
+// registerWriteTracking marks the request as having written after every
+// successful write statement.
+func registerWriteTracking(db *gorm.DB) error {
+	track := func(db *gorm.DB) {
+		if db.Error == nil && db.Statement.Context != nil {
+			markWrite(db.Statement.Context)
+		}
+	}
+	trackRaw := func(db *gorm.DB) {
+		sql := strings.TrimSpace(db.Statement.SQL.String())
+		if len(sql) >= 6 && strings.EqualFold(sql[:6], "select") {
+			return
+		}
+		track(db)
+	}
+
+	if err := db.Callback().Create().After("*").Register("todo:track_write", track); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().After("*").Register("todo:track_write", track); err != nil {
+		return err
+	}
+	if err := db.Callback().Delete().After("*").Register("todo:track_write", track); err != nil {
+		return err
+	}
+	return db.Callback().Raw().After("*").Register("todo:track_write", trackRaw)
+}
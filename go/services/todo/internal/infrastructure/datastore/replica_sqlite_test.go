@@ -0,0 +1,73 @@
+//go:build cgo
+
+package datastore_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/config"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/datastore"
+)
+
+func TestReplicaPolicy_RetryOnPrimary(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	primary, err := datastore.OpenSQLite(filepath.Join(dir, "primary.db"), time.UTC)
+	if err != nil {
+		t.Fatalf("OpenSQLite() error = %v", err)
+	}
+	replica, err := datastore.OpenSQLite(filepath.Join(dir, "replica.db"), time.UTC)
+	if err != nil {
+		t.Fatalf("OpenSQLite() error = %v", err)
+	}
+
+	// The table is missing on the replica, so that reads fail there.
+	if err := primary.Exec("CREATE TABLE probes (id INTEGER PRIMARY KEY)").Error; err != nil {
+		t.Fatalf("create table error = %v", err)
+	}
+	if err := primary.Exec("INSERT INTO probes (id) VALUES (1), (2)").Error; err != nil {
+		t.Fatalf("insert error = %v", err)
+	}
+
+	policy := datastore.NewReplicaPolicy(config.DBReplicaPolicyRandom, []gorm.ConnPool{replica.ConnPool}, time.Hour, time.Second)
+	policy.CheckReplicas(context.Background())
+	err = primary.Use(dbresolver.Register(dbresolver.Config{
+		Replicas: []gorm.Dialector{
+			sqlite.New(sqlite.Config{Conn: replica.ConnPool}),
+			sqlite.New(sqlite.Config{Conn: primary.ConnPool}),
+		},
+		Policy: policy,
+	}))
+	if err != nil {
+		t.Fatalf("Use(dbresolver) error = %v", err)
+	}
+	if err := primary.Use(policy); err != nil {
+		t.Fatalf("Use(policy) error = %v", err)
+	}
+
+	var plucked []int64
+	if err := primary.Table("probes").Order("id").Pluck("id", &plucked).Error; err != nil {
+		t.Fatalf("Pluck() error = %v", err)
+	}
+	var scanned []int64
+	if err := primary.Raw("SELECT id FROM probes ORDER BY id").Scan(&scanned).Error; err != nil {
+		t.Fatalf("Raw().Scan() error = %v", err)
+	}
+
+	expected := []int64{1, 2}
+	if diff := cmp.Diff(plucked, expected); diff != "" {
+		t.Errorf("Pluck() mismatch (-actual +expected):\n%s", diff)
+	}
+	if diff := cmp.Diff(scanned, expected); diff != "" {
+		t.Errorf("Raw().Scan() mismatch (-actual +expected):\n%s", diff)
+	}
+}
@@ -0,0 +1,130 @@
+package datastore_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"gorm.io/gorm"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/config"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/datastore"
+)
+
+type fakeConnPool struct {
+	name string
+	down bool
+
+	mu    sync.Mutex
+	pings int
+}
+
+func (p *fakeConnPool) PrepareContext(context.Context, string) (*sql.Stmt, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (p *fakeConnPool) ExecContext(context.Context, string, ...any) (sql.Result, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (p *fakeConnPool) QueryContext(context.Context, string, ...any) (*sql.Rows, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (p *fakeConnPool) QueryRowContext(context.Context, string, ...any) *sql.Row {
+	return nil
+}
+
+func (p *fakeConnPool) PingContext(context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.pings++
+	if p.down {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func TestReplicaPolicy_Resolve(t *testing.T) {
+	type testcase struct {
+		down     []bool
+		expected []string
+	}
+
+	t.Parallel()
+
+	testTables := map[string]testcase{
+		"Balance reads over the healthy replicas": {
+			down:     []bool{false, false},
+			expected: []string{"replica-2", "replica-1", "replica-2", "replica-1"},
+		},
+		"Skip a replica that is down": {
+			down:     []bool{true, false},
+			expected: []string{"replica-2", "replica-2", "replica-2", "replica-2"},
+		},
+		"Fail over to the primary when every replica is down": {
+			down:     []bool{true, true},
+			expected: []string{"primary", "primary", "primary", "primary"},
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			replicas := []*fakeConnPool{
+				{name: "replica-1", down: tt.down[0]},
+				{name: "replica-2", down: tt.down[1]},
+			}
+			pools := []gorm.ConnPool{replicas[0], replicas[1], &fakeConnPool{name: "primary"}}
+			policy := datastore.NewReplicaPolicy(config.DBReplicaPolicyRoundRobin, pools[:2], time.Minute, time.Second)
+			policy.CheckReplicas(context.Background())
+
+			actual := make([]string, 0, len(tt.expected))
+			for range tt.expected {
+				actual = append(actual, policy.Resolve(pools).(*fakeConnPool).name)
+			}
+
+			if diff := cmp.Diff(actual, tt.expected); diff != "" {
+				t.Errorf("resolved pools mismatch (-actual +expected):\n%s", diff)
+			}
+			for _, replica := range replicas {
+				if replica.pings != 1 {
+					t.Errorf("%s pings = %d, want 1: reads use the last check", replica.name, replica.pings)
+				}
+			}
+		})
+	}
+}
+
+func TestReplicaPolicy_CheckReplicas(t *testing.T) {
+	t.Parallel()
+
+	replica := &fakeConnPool{name: "replica"}
+	pools := []gorm.ConnPool{replica, &fakeConnPool{name: "primary"}}
+	policy := datastore.NewReplicaPolicy(config.DBReplicaPolicyRandom, pools[:1], time.Hour, time.Second)
+
+	if got := policy.Resolve(pools).(*fakeConnPool).name; got != "primary" {
+		t.Fatalf("Resolve() = %s, want primary until the replica was checked", got)
+	}
+
+	policy.CheckReplicas(context.Background())
+	if got := policy.Resolve(pools).(*fakeConnPool).name; got != "replica" {
+		t.Fatalf("Resolve() = %s, want the replica once it answered", got)
+	}
+
+	replica.down = true
+	if got := policy.Resolve(pools).(*fakeConnPool).name; got != "replica" {
+		t.Errorf("Resolve() = %s, want the replica until it is checked again", got)
+	}
+	policy.CheckReplicas(context.Background())
+	if got := policy.Resolve(pools).(*fakeConnPool).name; got != "primary" {
+		t.Errorf("Resolve() = %s, want primary once the replica is down", got)
+	}
+}
@@ -0,0 +1,187 @@
+package datastore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+)
+
+// ForeignKey is a foreign key constraint of a table, column by column.
+type ForeignKey struct {
+	TableName            string
+	ConstraintName       string
+	ColumnName           string
+	ReferencedTableName  string
+	ReferencedColumnName string
+	UpdateRule           string
+	DeleteRule           string
+}
+
+// LoadTableNames returns the names of the tables of the database dbName, or of
+// the sqlite database db, without the internal tables of sqlite.
+func LoadTableNames(ctx context.Context, db *sql.DB, dialect string, dbName string) ([]string, error) {
+	query := fmt.Sprintf("SHOW TABLES FROM `%s`", dbName)
+	if dialect == dialectSQLite {
+		query = `SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'`
+	}
+
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("show tables: %w", err)
+	}
+	defer rows.Close()
+
+	var tableNames []string
+	for rows.Next() {
+		var tableName string
+		if err := rows.Scan(&tableName); err != nil {
+			return nil, fmt.Errorf("scan row: %w", err)
+		}
+		tableNames = append(tableNames, tableName)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sql rows: %w", err)
+	}
+
+	return tableNames, nil
+}
+
+// LoadForeignKeys returns the foreign keys of tableNames in the database
+// dbName. The foreign keys of sqlite have no name, ConstraintName is empty.
+func LoadForeignKeys(
+	ctx context.Context,
+	db *sql.DB,
+	dialect string,
+	dbName string,
+	tableNames []string,
+) ([]ForeignKey, error) {
+	var foreignKeys []ForeignKey
+	for _, tableName := range tableNames {
+		fks, err := loadTableForeignKeys(ctx, db, dialect, dbName, tableName)
+		if err != nil {
+			return nil, fmt.Errorf("get foreign key, table = %s: %w", tableName, err)
+		}
+		foreignKeys = append(foreignKeys, fks...)
+	}
+
+	return foreignKeys, nil
+}
+
+func loadTableForeignKeys(
+	ctx context.Context,
+	db *sql.DB,
+	dialect string,
+	dbName string,
+	tableName string,
+) ([]ForeignKey, error) {
+	query := `
+        SELECT
+            kcu.constraint_name,
+            kcu.column_name,
+            kcu.referenced_table_name,
+            kcu.referenced_column_name,
+            rc.update_rule,
+            rc.delete_rule
+        FROM
+            information_schema.TABLE_CONSTRAINTS tc
+        JOIN
+            information_schema.KEY_COLUMN_USAGE kcu ON tc.constraint_name = kcu.constraint_name AND tc.table_schema = kcu.table_schema AND tc.table_name = kcu.table_name
+        JOIN
+            information_schema.REFERENTIAL_CONSTRAINTS rc ON tc.constraint_name = rc.constraint_name AND tc.table_schema = rc.constraint_schema
+        WHERE
+            tc.constraint_type = 'FOREIGN KEY'
+            AND tc.table_schema = ?
+            AND tc.table_name = ?
+    `
+	args := []any{dbName, tableName}
+	if dialect == dialectSQLite {
+		query = `SELECT '', "from", "table", "to", on_update, on_delete FROM pragma_foreign_key_list(?)`
+		args = []any{tableName}
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("get foreign keys: %w", err)
+	}
+	defer rows.Close()
+
+	var foreignKeys []ForeignKey
+	for rows.Next() {
+		fk := ForeignKey{
+			TableName: tableName,
+		}
+		if err := rows.Scan(
+			&fk.ConstraintName,
+			&fk.ColumnName,
+			&fk.ReferencedTableName,
+			&fk.ReferencedColumnName,
+			&fk.UpdateRule,
+			&fk.DeleteRule,
+		); err != nil {
+			return nil, err
+		}
+		foreignKeys = append(foreignKeys, fk)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sql rows: %w", err)
+	}
+
+	return foreignKeys, nil
+}
+
+// DeleteOrder orders tableNames so that every table comes before the tables it
+// references, the order their rows can be deleted in. Tables are otherwise
+// sorted by name; references to a table itself or to other tables are
+// ignored. It fails when the references of tableNames form a cycle.
+func DeleteOrder(tableNames []string, foreignKeys []ForeignKey) ([]string, error) {
+	pending := make(map[string]struct{}, len(tableNames))
+	for _, tableName := range tableNames {
+		pending[tableName] = struct{}{}
+	}
+
+	// referencedBy counts, by table, the pending tables referencing it.
+	referencedBy := map[string]map[string]struct{}{}
+	for _, fk := range foreignKeys {
+		_, from := pending[fk.TableName]
+		_, to := pending[fk.ReferencedTableName]
+		if !from || !to || fk.TableName == fk.ReferencedTableName {
+			continue
+		}
+		if referencedBy[fk.ReferencedTableName] == nil {
+			referencedBy[fk.ReferencedTableName] = map[string]struct{}{}
+		}
+		referencedBy[fk.ReferencedTableName][fk.TableName] = struct{}{}
+	}
+
+	order := make([]string, 0, len(tableNames))
+	for len(pending) > 0 {
+		var next []string
+		for tableName := range pending {
+			if len(referencedBy[tableName]) == 0 {
+				next = append(next, tableName)
+			}
+		}
+		if len(next) == 0 {
+			cycle := make([]string, 0, len(pending))
+			for tableName := range pending {
+				cycle = append(cycle, tableName)
+			}
+			slices.Sort(cycle)
+			return nil, fmt.Errorf("foreign keys of tables %v form a cycle", cycle)
+		}
+
+		slices.Sort(next)
+		for _, tableName := range next {
+			delete(pending, tableName)
+			for _, referencing := range referencedBy {
+				delete(referencing, tableName)
+			}
+		}
+		order = append(order, next...)
+	}
+
+	return order, nil
+}
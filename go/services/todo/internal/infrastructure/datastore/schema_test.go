@@ -0,0 +1,68 @@
+package datastore_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/datastore"
+)
+
+func TestDeleteOrder(t *testing.T) {
+	type testcase struct {
+		tableNames  []string
+		foreignKeys []datastore.ForeignKey
+		expected    []string
+		expectedErr bool
+	}
+
+	t.Parallel()
+
+	testTables := map[string]testcase{
+		"Tables without foreign keys are sorted by name": {
+			tableNames: []string{"users", "outbox", "leases"},
+			expected:   []string{"leases", "outbox", "users"},
+		},
+		"A table comes before the tables it references": {
+			tableNames: []string{"users", "todos", "comments", "outbox"},
+			foreignKeys: []datastore.ForeignKey{
+				{TableName: "todos", ReferencedTableName: "users"},
+				{TableName: "comments", ReferencedTableName: "todos"},
+				{TableName: "comments", ReferencedTableName: "users"},
+			},
+			expected: []string{"comments", "outbox", "todos", "users"},
+		},
+		"References to itself and to other tables are ignored": {
+			tableNames: []string{"users", "todos"},
+			foreignKeys: []datastore.ForeignKey{
+				{TableName: "todos", ReferencedTableName: "todos"},
+				{TableName: "todos", ReferencedTableName: "users"},
+				{TableName: "users", ReferencedTableName: "accounts"},
+			},
+			expected: []string{"todos", "users"},
+		},
+		"References forming a cycle": {
+			tableNames: []string{"a", "b", "c"},
+			foreignKeys: []datastore.ForeignKey{
+				{TableName: "a", ReferencedTableName: "b"},
+				{TableName: "b", ReferencedTableName: "a"},
+			},
+			expectedErr: true,
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			order, err := datastore.DeleteOrder(tt.tableNames, tt.foreignKeys)
+			if (err != nil) != tt.expectedErr {
+				t.Fatalf("DeleteOrder() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+			if diff := cmp.Diff(order, tt.expected); diff != "" {
+				t.Fatalf("DeleteOrder() mismatch (-actual +expected):\n%s", diff)
+			}
+		})
+	}
+}
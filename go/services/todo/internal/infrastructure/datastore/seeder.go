@@ -0,0 +1,235 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/go-testfixtures/testfixtures/v3"
+	"gorm.io/gorm"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+const (
+	// migrationsTableName is the table of golang-migrate, kept by Reset.
+	migrationsTableName = "schema_migrations"
+
+	seedBatchSize = 500
+	// seedPassword is the password of the inserted users, the one of the
+	// fixtures.
+	seedPassword = "password"
+)
+
+// keptTables are the tables Reset leaves as they are:
+//   - erased_users: erased users must never come back, whatever is seeded.
+//   - leases: emptying them would restart the fencing tokens, letting a
+//     worker still holding a lease write.
+//   - user_shards: the shard map is not seed data.
+//
+// user_emails is emptied: it indexes the emails of the users, which are all
+// deleted.
+var keptTables = []string{migrationsTableName, "erased_users", "leases", "user_shards"}
+
+// devDatabaseMarkers are the words of a database name or host marking a
+// development database.
+var devDatabaseMarkers = []string{"dev", "development", "local", "localhost", "test"}
+
+// IsDevDatabase reports whether the database dbName on dbHost is marked as a
+// development one: its name or host has one of the words dev, development,
+// local, localhost or test, or its host is a loopback address.
+func IsDevDatabase(dbName, dbHost string) bool {
+	if ip := net.ParseIP(dbHost); ip != nil && ip.IsLoopback() {
+		return true
+	}
+
+	isSeparator := func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}
+	for _, value := range []string{dbName, dbHost} {
+		for _, word := range strings.FieldsFunc(strings.ToLower(value), isSeparator) {
+			if slices.Contains(devDatabaseMarkers, word) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SeederConfig is the database seeded, which Reset only empties when it is a
+// development one or the reset is confirmed.
+type SeederConfig struct {
+	DBName string
+	DBHost string
+	// ConfirmReset lets Reset empty a database that is not marked as a
+	// development one.
+	ConfirmReset bool
+}
+
+// Seeder fills a development database, outside of the gateways: it records no
+// event and keeps the IDs and times of what it inserts.
+type Seeder struct {
+	db  *gorm.DB
+	cfg SeederConfig
+}
+
+// NewSeeder seeds the primary of todoConn, the database of cfg. Shards are not
+// supported: users would be seeded on the primary whatever their shard.
+func NewSeeder(todoConn *TodoConn, cfg SeederConfig) (*Seeder, error) {
+	if len(todoConn.shards) > 0 {
+		return nil, fmt.Errorf("seeding a sharded database is not supported")
+	}
+
+	return &Seeder{db: todoConn.GormDB, cfg: cfg}, nil
+}
+
+// Reset empties every table but keptTables, the tables referencing others
+// first, and restarts their IDs. It returns the tables in the order they were
+// emptied. The IDs of the users restart after the largest one still in the
+// kept tables, so that no new user gets the tombstone or shard of a former
+// one.
+//
+// It refuses to empty a MySQL database unless IsDevDatabase or the reset is
+// confirmed. SQLite databases are local files, always development ones.
+//
+// Rows are deleted rather than truncated, as MySQL refuses to truncate a table
+// referenced by a foreign key even once the referencing tables are empty.
+func (s *Seeder) Reset(ctx context.Context) ([]string, error) {
+	dialect := dialectOf(s.db)
+	if dialect != dialectSQLite && !s.cfg.ConfirmReset && !IsDevDatabase(s.cfg.DBName, s.cfg.DBHost) {
+		return nil, fmt.Errorf(
+			"refusing to reset the database %s on %s, which is not marked as a development one: confirm the reset to empty it",
+			s.cfg.DBName, s.cfg.DBHost,
+		)
+	}
+
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("get underlying db: %w", err)
+	}
+
+	tableNames, err := LoadTableNames(ctx, sqlDB, dialect, s.cfg.DBName)
+	if err != nil {
+		return nil, fmt.Errorf("load table names: %w", err)
+	}
+	foreignKeys, err := LoadForeignKeys(ctx, sqlDB, dialect, s.cfg.DBName, tableNames)
+	if err != nil {
+		return nil, fmt.Errorf("load foreign keys: %w", err)
+	}
+
+	tableNames = slices.DeleteFunc(tableNames, func(tableName string) bool {
+		return slices.Contains(keptTables, tableName)
+	})
+	order, err := DeleteOrder(tableNames, foreignKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	db := s.db.WithContext(ctx)
+	var lastUserID int64
+	err = db.Raw(
+		"SELECT COALESCE(MAX(user_id), 0) FROM (" +
+			"SELECT user_id FROM erased_users UNION ALL SELECT user_id FROM user_shards" +
+			") kept",
+	).Scan(&lastUserID).Error
+	if err != nil {
+		return nil, fmt.Errorf("get the last kept user id: %w", err)
+	}
+
+	for _, tableName := range order {
+		if err := db.Exec(fmt.Sprintf("DELETE FROM `%s`", tableName)).Error; err != nil {
+			return nil, fmt.Errorf("empty %s: %w", tableName, err)
+		}
+
+		var lastID int64
+		if tableName == "users" {
+			lastID = lastUserID
+		}
+		if err := restartIDs(db, dialect, tableName, lastID); err != nil {
+			return nil, fmt.Errorf("restart ids of %s: %w", tableName, err)
+		}
+	}
+
+	return order, nil
+}
+
+// restartIDs makes the next ID of the empty table tableName lastID+1.
+func restartIDs(db *gorm.DB, dialect, tableName string, lastID int64) error {
+	if dialect != dialectSQLite {
+		return db.Exec(fmt.Sprintf("ALTER TABLE `%s` AUTO_INCREMENT = %d", tableName, lastID+1)).Error
+	}
+
+	if err := db.Exec("DELETE FROM sqlite_sequence WHERE name = ?", tableName).Error; err != nil {
+		return err
+	}
+	if lastID == 0 {
+		return nil
+	}
+	return db.Exec("INSERT INTO sqlite_sequence (name, seq) VALUES (?, ?)", tableName, lastID).Error
+}
+
+// LoadFixtures loads the fixture files of dir, replacing the rows of their
+// tables, the way the tests load them into their template database.
+func (s *Seeder) LoadFixtures(dir string) error {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return fmt.Errorf("get underlying db: %w", err)
+	}
+
+	fixtures, err := testfixtures.New(
+		testfixtures.Database(sqlDB),
+		testfixtures.Dialect(dialectOf(s.db)),
+		testfixtures.Directory(dir),
+		// Seeding is meant for development databases, which are not named
+		// after tests.
+		testfixtures.DangerousSkipTestDatabaseCheck(),
+	)
+	if err != nil {
+		return fmt.Errorf("create fixtures loader: %w", err)
+	}
+
+	if err := fixtures.Load(); err != nil {
+		return fmt.Errorf("load fixtures: %w", err)
+	}
+
+	return nil
+}
+
+// Insert inserts the users of data and their todos, in one transaction. The
+// IDs of the users are set once inserted, and the todos get the ID of their
+// user. The events of data are ignored.
+func (s *Seeder) Insert(ctx context.Context, data []*todo.UserData) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	rows := make([]*userRow, 0, len(data))
+	for _, d := range data {
+		rows = append(rows, &userRow{User: *d.User, Password: seedPassword})
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.CreateInBatches(rows, seedBatchSize).Error; err != nil {
+			return fmt.Errorf("insert users: %w", err)
+		}
+
+		var todos []*todo.Todo
+		for i, d := range data {
+			*d.User = rows[i].User
+			for _, t := range d.Todos {
+				t.UserID = d.User.ID
+				todos = append(todos, t)
+			}
+		}
+		if len(todos) == 0 {
+			return nil
+		}
+		if err := tx.CreateInBatches(todos, seedBatchSize).Error; err != nil {
+			return fmt.Errorf("insert todos: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,202 @@
+package datastore_test
+
+import (
+	"context"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"gorm.io/gorm"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/datastore"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/seed"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/testutil"
+)
+
+func newTestSeeder(t *testing.T) (*datastore.Seeder, *gorm.DB) {
+	t.Helper()
+
+	_, dbName := testutil.InitDB(t)
+	todoConn, closeDB, err := datastore.NewTodoSQLHandler(newTestDBConfig(dbName))
+	if err != nil {
+		t.Fatalf("NewTodoSQLHandler() error = %v", err)
+	}
+	t.Cleanup(closeDB)
+
+	seeder, err := datastore.NewSeeder(todoConn, datastore.SeederConfig{DBName: dbName, ConfirmReset: true})
+	if err != nil {
+		t.Fatalf("NewSeeder() error = %v", err)
+	}
+	return seeder, todoConn.GormDB
+}
+
+func countRows(t *testing.T, db *gorm.DB, tableNames ...string) map[string]int64 {
+	t.Helper()
+
+	counts := make(map[string]int64, len(tableNames))
+	for _, tableName := range tableNames {
+		var count int64
+		if err := db.Table(tableName).Count(&count).Error; err != nil {
+			t.Fatalf("count %s: %v", tableName, err)
+		}
+		counts[tableName] = count
+	}
+	return counts
+}
+
+func TestSeeder_Reset(t *testing.T) {
+	t.Parallel()
+	seeder, db := newTestSeeder(t)
+	ctx := context.Background()
+
+	order, err := seeder.Reset(ctx)
+	if err != nil {
+		t.Fatalf("Seeder.Reset() error = %v", err)
+	}
+
+	expectedOrder := []string{"outbox", "todos", "user_emails", "users"}
+	if diff := cmp.Diff(order, expectedOrder); diff != "" {
+		t.Fatalf("Seeder.Reset() mismatch (-actual +expected):\n%s", diff)
+	}
+	for tableName, count := range countRows(t, db, expectedOrder...) {
+		if count != 0 {
+			t.Fatalf("table %s has %d rows after reset, want 0", tableName, count)
+		}
+	}
+
+	// IDs restart from 1.
+	data := seed.Generate(seed.Config{Users: 1, TodosPerUser: 1, Now: time.Now(), History: time.Hour})
+	if err := seeder.Insert(ctx, data); err != nil {
+		t.Fatalf("Seeder.Insert() error = %v", err)
+	}
+	if data[0].User.ID != 1 || data[0].Todos[0].ID != 1 {
+		t.Fatalf("IDs of the first user and todo after reset = %d, %d, want 1, 1", data[0].User.ID, data[0].Todos[0].ID)
+	}
+}
+
+func TestSeeder_Reset_KeptTables(t *testing.T) {
+	t.Parallel()
+	seeder, db := newTestSeeder(t)
+	ctx := context.Background()
+
+	if err := db.Exec("INSERT INTO erased_users (user_id, erased_at) VALUES (?, ?)", 7, time.Now()).Error; err != nil {
+		t.Fatalf("insert erased user: %v", err)
+	}
+	if _, err := seeder.Reset(ctx); err != nil {
+		t.Fatalf("Seeder.Reset() error = %v", err)
+	}
+
+	if count := countRows(t, db, "erased_users")["erased_users"]; count != 1 {
+		t.Fatalf("erased_users has %d rows after reset, want 1", count)
+	}
+
+	// IDs of the users restart after the erased one.
+	data := seed.Generate(seed.Config{Users: 1, TodosPerUser: 1, Now: time.Now(), History: time.Hour})
+	if err := seeder.Insert(ctx, data); err != nil {
+		t.Fatalf("Seeder.Insert() error = %v", err)
+	}
+	if data[0].User.ID != 8 {
+		t.Fatalf("ID of the first user after reset = %d, want 8", data[0].User.ID)
+	}
+}
+
+func TestIsDevDatabase(t *testing.T) {
+	type testcase struct {
+		dbName   string
+		dbHost   string
+		expected bool
+	}
+
+	t.Parallel()
+
+	testTables := map[string]testcase{
+		"Loopback host":       {dbName: "todo", dbHost: "127.0.0.1", expected: true},
+		"Localhost":           {dbName: "todo", dbHost: "localhost", expected: true},
+		"Dev name":            {dbName: "todo_dev", dbHost: "db.internal", expected: true},
+		"Dev host":            {dbName: "todo", dbHost: "mysql.dev.example.com", expected: true},
+		"Test name":           {dbName: "todo_test_0a1b", dbHost: "db.internal", expected: true},
+		"Marker within words": {dbName: "todo_devices", dbHost: "db.prod.example.com", expected: false},
+		"Production database": {dbName: "todo", dbHost: "db.prod.example.com", expected: false},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if actual := datastore.IsDevDatabase(tt.dbName, tt.dbHost); actual != tt.expected {
+				t.Fatalf("IsDevDatabase(%q, %q) = %v, want %v", tt.dbName, tt.dbHost, actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSeeder_LoadFixtures(t *testing.T) {
+	t.Parallel()
+	seeder, db := newTestSeeder(t)
+	ctx := context.Background()
+
+	_, currentFilename, _, ok := runtime.Caller(0)
+	if !ok {
+		t.Fatal("runtime.Caller error")
+	}
+	fixturesDir := filepath.Join(filepath.Dir(currentFilename), "../../../testdata/todo_fixtures")
+
+	if _, err := seeder.Reset(ctx); err != nil {
+		t.Fatalf("Seeder.Reset() error = %v", err)
+	}
+	if err := seeder.LoadFixtures(fixturesDir); err != nil {
+		t.Fatalf("Seeder.LoadFixtures() error = %v", err)
+	}
+
+	counts := countRows(t, db, "users", "todos", "outbox")
+	for tableName, count := range counts {
+		if count == 0 {
+			t.Fatalf("table %s has no rows after loading the fixtures", tableName)
+		}
+	}
+}
+
+func TestSeeder_Insert(t *testing.T) {
+	t.Parallel()
+	seeder, db := newTestSeeder(t)
+	ctx := context.Background()
+
+	if _, err := seeder.Reset(ctx); err != nil {
+		t.Fatalf("Seeder.Reset() error = %v", err)
+	}
+
+	data := seed.Generate(seed.Config{
+		Users:        3,
+		TodosPerUser: 4,
+		Seed:         1,
+		Now:          time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		History:      30 * 24 * time.Hour,
+	})
+	if err := seeder.Insert(ctx, data); err != nil {
+		t.Fatalf("Seeder.Insert() error = %v", err)
+	}
+
+	expectedCounts := map[string]int64{"users": 3, "todos": 12, "outbox": 0}
+	if diff := cmp.Diff(countRows(t, db, "users", "todos", "outbox"), expectedCounts); diff != "" {
+		t.Fatalf("row counts mismatch (-actual +expected):\n%s", diff)
+	}
+
+	for _, d := range data {
+		var todos []*todo.Todo
+		if err := db.Where("user_id = ?", d.User.ID).Order("position").Find(&todos).Error; err != nil {
+			t.Fatalf("list todos: %v", err)
+		}
+		if len(todos) != len(d.Todos) {
+			t.Fatalf("user %d has %d todos, want %d", d.User.ID, len(todos), len(d.Todos))
+		}
+		for i, got := range todos {
+			if got.ID != d.Todos[i].ID || got.Task != d.Todos[i].Task || got.Status != d.Todos[i].Status {
+				t.Fatalf("todo %d of user %d = %+v, want %+v", i, d.User.ID, got, d.Todos[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package datastore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+const (
+	shardPoolPrefix = "shard:"
+
+	// readOnlyUserSetting is set on the DB bound for a user whose writes are
+	// paused, see registerShardGuard.
+	readOnlyUserSetting = "todo:read_only_user"
+)
+
+// ErrUserReadOnly is returned by the writes of a user who is being moved to
+// another shard. They can be retried once the move is over.
+var ErrUserReadOnly = errors.New("user is being moved to another shard, writes are paused")
+
+// NewShardedTodoConn routes the users that shardMap places on the shards to
+// their database, and every other request to primary. The pools of the shards
+// are reported after the ones of primary.
+func NewShardedTodoConn(primary *TodoConn, shards map[string]*TodoConn, shardMap *ShardMap) *TodoConn {
+	conn := &TodoConn{
+		GormDB:   primary.GormDB,
+		pools:    append([]dbPool(nil), primary.pools...),
+		shards:   make(map[string]*gorm.DB, len(shards)),
+		shardMap: shardMap,
+	}
+
+	for _, name := range shardMap.Shards() {
+		shard, ok := shards[name]
+		if !ok {
+			continue
+		}
+		conn.shards[name] = shard.GormDB
+		for _, pool := range shard.pools {
+			conn.pools = append(conn.pools, dbPool{name: shardPoolPrefix + name, db: pool.db})
+		}
+	}
+
+	return conn
+}
+
+// shardDB returns the DB of the shard.
+func (c *TodoConn) shardDB(shard string) (*gorm.DB, error) {
+	if shard == PrimaryShardName {
+		return c.GormDB, nil
+	}
+	db, ok := c.shards[shard]
+	if !ok {
+		return nil, fmt.Errorf("shard %q is not configured", shard)
+	}
+	return db, nil
+}
+
+// userDB returns the DB of the shard of the user of ctx. Requests without a
+// user, and every request when sharding is not configured, go to the primary.
+func (c *TodoConn) userDB(ctx context.Context) *gorm.DB {
+	user := todo.ExtractUser(ctx)
+	if c.shardMap == nil || user == nil {
+		return c.GormDB
+	}
+
+	shard, readOnly := c.shardMap.Shard(user.ID)
+	db, err := c.shardDB(shard)
+	if err != nil {
+		// The map only loads entries of configured shards.
+		return c.GormDB
+	}
+	if readOnly {
+		// A new session, so that the setting is kept by every statement.
+		db = db.Set(readOnlyUserSetting, user.ID).Session(&gorm.Session{})
+	}
+
+	return db
+}
+
+// registerShardGuard fails the writes made with a DB bound for a user whose
+// writes are paused, before they reach the database.
+func registerShardGuard(db *gorm.DB) error {
+	guard := func(db *gorm.DB) {
+		if userID, ok := db.Get(readOnlyUserSetting); ok {
+			_ = db.AddError(fmt.Errorf("user %v: %w", userID, ErrUserReadOnly))
+		}
+	}
+	guardRaw := func(db *gorm.DB) {
+		sql := strings.TrimSpace(db.Statement.SQL.String())
+		if len(sql) >= 6 && strings.EqualFold(sql[:6], "select") {
+			return
+		}
+		guard(db)
+	}
+
+	if err := db.Callback().Create().Before("*").Register("todo:shard_guard", guard); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().Before("*").Register("todo:shard_guard", guard); err != nil {
+		return err
+	}
+	if err := db.Callback().Delete().Before("*").Register("todo:shard_guard", guard); err != nil {
+		return err
+	}
+	return db.Callback().Raw().Before("*").Register("todo:shard_guard", guardRaw)
+}
@@ -0,0 +1,212 @@
+package datastore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+// PrimaryShardName names the shard of the primary database, which also keeps
+// the shard map.
+const PrimaryShardName = "primary"
+
+// userShardRow is an entry of the shard map, kept in the user_shards table of
+// the primary.
+type userShardRow struct {
+	UserID    todo.UserID
+	Shard     string
+	ReadOnly  bool
+	UpdatedAt time.Time
+}
+
+func (userShardRow) TableName() string {
+	return "user_shards"
+}
+
+type userShard struct {
+	shard    string
+	readOnly bool
+}
+
+type ringPoint struct {
+	hash  uint64
+	shard string
+}
+
+// ShardMap places users on shards. A user with an entry in the user_shards
+// table is on the shard of the entry; any other user is placed by a
+// consistent-hash ring of the shards, so that adding a shard only moves the
+// users hashed to its points. The entries are loaded in memory: Shard never
+// queries the database.
+type ShardMap struct {
+	shards map[string]struct{}
+	ring   []ringPoint
+
+	mu      sync.RWMutex
+	entries map[todo.UserID]userShard
+}
+
+// NewShardMap returns the map of shards with no entries loaded. Each shard is
+// placed virtualNodes times on the ring.
+func NewShardMap(shards []string, virtualNodes int) *ShardMap {
+	if virtualNodes < 1 {
+		virtualNodes = 1
+	}
+
+	m := &ShardMap{
+		shards:  make(map[string]struct{}, len(shards)),
+		ring:    make([]ringPoint, 0, len(shards)*virtualNodes),
+		entries: map[todo.UserID]userShard{},
+	}
+	for _, shard := range shards {
+		m.shards[shard] = struct{}{}
+		for i := 0; i < virtualNodes; i++ {
+			m.ring = append(m.ring, ringPoint{hash: hashKey(shard + "#" + strconv.Itoa(i)), shard: shard})
+		}
+	}
+	sort.Slice(m.ring, func(i, j int) bool {
+		if m.ring[i].hash != m.ring[j].hash {
+			return m.ring[i].hash < m.ring[j].hash
+		}
+		return m.ring[i].shard < m.ring[j].shard
+	})
+
+	return m
+}
+
+// Shards returns the names of the shards, sorted.
+func (m *ShardMap) Shards() []string {
+	shards := make([]string, 0, len(m.shards))
+	for shard := range m.shards {
+		shards = append(shards, shard)
+	}
+	sort.Strings(shards)
+	return shards
+}
+
+func (m *ShardMap) HasShard(shard string) bool {
+	_, ok := m.shards[shard]
+	return ok
+}
+
+// Shard returns the shard of the user, and whether their writes are paused
+// while they are moved to another shard.
+func (m *ShardMap) Shard(userID todo.UserID) (string, bool) {
+	m.mu.RLock()
+	entry, ok := m.entries[userID]
+	m.mu.RUnlock()
+	if ok {
+		return entry.shard, entry.readOnly
+	}
+
+	return m.hashShard(userID), false
+}
+
+// hashShard returns the shard of the first point of the ring at or after the
+// hash of the user.
+func (m *ShardMap) hashShard(userID todo.UserID) string {
+	h := hashKey(userID.String())
+	i := sort.Search(len(m.ring), func(i int) bool {
+		return m.ring[i].hash >= h
+	})
+	if i == len(m.ring) {
+		i = 0
+	}
+	return m.ring[i].shard
+}
+
+// Load replaces the entries with the user_shards table of db, the primary.
+// An entry of a shard that is not configured fails the load and keeps the
+// previous entries, as its user would be routed to a shard without their data.
+func (m *ShardMap) Load(ctx context.Context, db *gorm.DB) error {
+	var rows []*userShardRow
+	if err := db.WithContext(ctx).Find(&rows).Error; err != nil {
+		return fmt.Errorf("load shard map: %w", err)
+	}
+
+	entries := make(map[todo.UserID]userShard, len(rows))
+	for _, row := range rows {
+		if !m.HasShard(row.Shard) {
+			return fmt.Errorf("load shard map: user %s is on unknown shard %q", row.UserID.String(), row.Shard)
+		}
+		entries[row.UserID] = userShard{shard: row.Shard, readOnly: row.ReadOnly}
+	}
+
+	m.mu.Lock()
+	m.entries = entries
+	m.mu.Unlock()
+
+	return nil
+}
+
+// Run reloads the entries every interval until ctx is done. A failed load is
+// logged and the previous entries are kept.
+func (m *ShardMap) Run(ctx context.Context, db *gorm.DB, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := m.Load(ctx, db); err != nil && ctx.Err() == nil {
+				log.Printf("refresh shard map: %v", err)
+			}
+		}
+	}
+}
+
+// SetUserShard stores the entry of the user in db, the primary, and in the map.
+// Other processes see it once they reload the map.
+func (m *ShardMap) SetUserShard(
+	ctx context.Context,
+	db *gorm.DB,
+	userID todo.UserID,
+	shard string,
+	readOnly bool,
+) error {
+	if !m.HasShard(shard) {
+		return fmt.Errorf("set shard of user %s: unknown shard %q", userID.String(), shard)
+	}
+
+	row := &userShardRow{
+		UserID:    userID,
+		Shard:     shard,
+		ReadOnly:  readOnly,
+		UpdatedAt: time.Now(),
+	}
+	err := db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"shard", "read_only", "updated_at"}),
+		}).
+		Create(row).Error
+	if err != nil {
+		return fmt.Errorf("set shard of user %s: %w", userID.String(), err)
+	}
+
+	m.mu.Lock()
+	m.entries[userID] = userShard{shard: shard, readOnly: readOnly}
+	m.mu.Unlock()
+
+	return nil
+}
+
+// hashKey spreads keys that differ by a digit only, like the virtual nodes of
+// a shard, evenly around the ring.
+func hashKey(key string) uint64 {
+	sum := sha256.Sum256([]byte(key))
+	return binary.BigEndian.Uint64(sum[:8])
+}
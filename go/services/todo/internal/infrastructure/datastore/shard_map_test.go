@@ -0,0 +1,101 @@
+package datastore_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/datastore"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/testutil"
+)
+
+func TestShardMap_Shard_SpreadsUsers(t *testing.T) {
+	t.Parallel()
+
+	const users = 3000
+	shardMap := datastore.NewShardMap([]string{"primary", "a", "b"}, 64)
+
+	counts := map[string]int{}
+	for userID := todo.UserID(1); userID <= users; userID++ {
+		shard, readOnly := shardMap.Shard(userID)
+		if readOnly {
+			t.Fatalf("ShardMap.Shard(%d) is read-only without an entry", userID)
+		}
+		counts[shard]++
+	}
+
+	for _, shard := range shardMap.Shards() {
+		if counts[shard] < users/5 {
+			t.Errorf("shard %q has %d of %d users, want at least %d", shard, counts[shard], users, users/5)
+		}
+	}
+}
+
+func TestShardMap_Shard_AddingAShardOnlyMovesUsersToIt(t *testing.T) {
+	t.Parallel()
+
+	before := datastore.NewShardMap([]string{"primary", "a"}, 64)
+	after := datastore.NewShardMap([]string{"primary", "a", "b"}, 64)
+
+	moved := 0
+	for userID := todo.UserID(1); userID <= 3000; userID++ {
+		from, _ := before.Shard(userID)
+		to, _ := after.Shard(userID)
+		if from == to {
+			continue
+		}
+		if to != "b" {
+			t.Fatalf("user %d moved from %q to %q, want to the added shard", userID, from, to)
+		}
+		moved++
+	}
+
+	if moved == 0 {
+		t.Fatal("no user moved to the added shard")
+	}
+}
+
+func TestShardMap_Load(t *testing.T) {
+	t.Parallel()
+	gormDB, _ := testutil.InitDB(t)
+	ctx := context.Background()
+
+	shardMap := datastore.NewShardMap([]string{"primary", "a", "b"}, 64)
+	userID := todo.UserID(42)
+	hashed, _ := shardMap.Shard(userID)
+	pinned := "a"
+	if hashed == pinned {
+		pinned = "b"
+	}
+
+	if err := shardMap.SetUserShard(ctx, gormDB, userID, pinned, true); err != nil {
+		t.Fatalf("ShardMap.SetUserShard() error = %v", err)
+	}
+
+	loaded := datastore.NewShardMap([]string{"primary", "a", "b"}, 64)
+	if err := loaded.Load(ctx, gormDB); err != nil {
+		t.Fatalf("ShardMap.Load() error = %v", err)
+	}
+	if shard, readOnly := loaded.Shard(userID); shard != pinned || !readOnly {
+		t.Fatalf("ShardMap.Shard() = %q, %v, want %q, true", shard, readOnly, pinned)
+	}
+
+	// A map without the shard of an entry keeps its previous entries.
+	withoutShard := datastore.NewShardMap([]string{"primary", pinned}, 64)
+	if err := withoutShard.SetUserShard(ctx, gormDB, userID+1, pinned, false); err != nil {
+		t.Fatalf("ShardMap.SetUserShard() error = %v", err)
+	}
+	if err := gormDB.Exec("UPDATE user_shards SET shard = ? WHERE user_id = ?", "removed", userID).Error; err != nil {
+		t.Fatalf("update user_shards: %v", err)
+	}
+	if err := withoutShard.Load(ctx, gormDB); err == nil {
+		t.Fatal("ShardMap.Load() error = nil, want an error for an unknown shard")
+	}
+	if shard, _ := withoutShard.Shard(userID + 1); shard != pinned {
+		t.Fatalf("ShardMap.Shard() = %q after a failed load, want %q", shard, pinned)
+	}
+
+	if err := shardMap.SetUserShard(ctx, gormDB, userID, "removed", false); err == nil {
+		t.Fatal("ShardMap.SetUserShard() error = nil, want an error for an unknown shard")
+	}
+}
@@ -0,0 +1,369 @@
+package datastore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+const defaultUserMoverBatchSize = 500
+
+// errTodoIDConflict is returned when a todo of the moved user has the ID of a
+// todo of another user on the target shard. Todo IDs are only unique across
+// shards when each shard has its own auto_increment_offset.
+var errTodoIDConflict = errors.New("todo ID used by another user on the target shard")
+
+// errEventIDConflict is returned when an event of the moved user has the ID of
+// an event of another user on the target shard, for the same reason.
+var errEventIDConflict = errors.New("event ID used by another user on the target shard")
+
+// The copies keep every column of the rows, their update times included.
+var (
+	upsertUserColumns = clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"username", "email", "password", "created_at", "updated_at", "deleted_at"}),
+	}
+	upsertTodoColumns = clause.OnConflict{
+		Columns: []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"user_id", "external_id", "task", "description", "status", "position",
+			"due_at", "completed_at", "created_at", "updated_at", "deleted_at",
+		}),
+	}
+	upsertEventColumns = clause.OnConflict{
+		Columns: []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"aggregate_type", "aggregate_id", "user_id", "event_type", "payload",
+			"attempts", "last_error", "occurred_at", "next_attempt_at", "published_at",
+			"dead_lettered_at",
+		}),
+	}
+)
+
+type UserMoverConfig struct {
+	// Settle is how long every process takes to see a change of the shard map:
+	// the refresh interval of the map plus the longest request.
+	Settle    time.Duration
+	BatchSize int
+	// DeleteSource deletes the rows of the user from their former shard once
+	// no process reads them anymore.
+	DeleteSource bool
+}
+
+type UserMoveReport struct {
+	From string
+	To   string
+	// Todos is the number of todos of the user, copied to To.
+	Todos int
+}
+
+// UserMover moves users between shards while the service runs. The rows of a
+// user are copied while they keep writing to their shard; then their writes
+// are paused for the time every process takes to see the pause, the rows are
+// copied again, and their entry of the shard map is flipped to the target
+// shard. Their reads are served throughout. The events of the user move with
+// them: the relay may publish the pending ones from both shards until the
+// flip, which the consumers of an at-least-once outbox already expect.
+type UserMover struct {
+	conn  *TodoConn
+	cfg   UserMoverConfig
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+func NewUserMover(conn *TodoConn, cfg UserMoverConfig) *UserMover {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultUserMoverBatchSize
+	}
+
+	return &UserMover{
+		conn:  conn,
+		cfg:   cfg,
+		sleep: sleepContext,
+	}
+}
+
+// MoveUser moves the user to the shard to. A move that failed can be run
+// again: the rows left on the target shard are copied anew.
+func (m *UserMover) MoveUser(ctx context.Context, userID todo.UserID, to string) (*UserMoveReport, error) {
+	shardMap := m.conn.shardMap
+	if shardMap == nil {
+		return nil, errors.New("move user: sharding is not configured")
+	}
+	if err := shardMap.Load(ctx, m.conn.GormDB); err != nil {
+		return nil, fmt.Errorf("move user: %w", err)
+	}
+
+	from, readOnly := shardMap.Shard(userID)
+	report := &UserMoveReport{From: from, To: to}
+	dst, err := m.conn.shardDB(to)
+	if err != nil {
+		return nil, fmt.Errorf("move user: %w", err)
+	}
+	if from == to {
+		// A failed move may have left the writes of the user paused.
+		if readOnly {
+			if err := shardMap.SetUserShard(ctx, m.conn.GormDB, userID, from, false); err != nil {
+				return nil, fmt.Errorf("move user: %w", err)
+			}
+		}
+		return report, nil
+	}
+	src, err := m.conn.shardDB(from)
+	if err != nil {
+		return nil, fmt.Errorf("move user: %w", err)
+	}
+
+	if err := deleteUserRows(ctx, dst, userID); err != nil {
+		return nil, fmt.Errorf("move user: clear target shard: %w", err)
+	}
+	if _, err := m.copyUserRows(ctx, src, dst, userID); err != nil {
+		return nil, fmt.Errorf("move user: copy: %w", err)
+	}
+
+	if err := shardMap.SetUserShard(ctx, m.conn.GormDB, userID, from, true); err != nil {
+		return nil, fmt.Errorf("move user: pause writes: %w", err)
+	}
+	report.Todos, err = m.copyPaused(ctx, src, dst, userID)
+	if err != nil {
+		// Resume the writes on the former shard; the copy is redone by the next
+		// move.
+		if resumeErr := shardMap.SetUserShard(context.WithoutCancel(ctx), m.conn.GormDB, userID, from, false); resumeErr != nil {
+			err = errors.Join(err, resumeErr)
+		}
+		return nil, fmt.Errorf("move user: %w", err)
+	}
+
+	if err := shardMap.SetUserShard(ctx, m.conn.GormDB, userID, to, false); err != nil {
+		return nil, fmt.Errorf("move user: flip shard map: %w", err)
+	}
+
+	// The events left on the former shard would be published again by the
+	// relay of that shard, so they are deleted even when the rows are kept.
+	if err := deleteUserEvents(src.WithContext(ctx), userID); err != nil {
+		return nil, fmt.Errorf("move user: delete events from former shard: %w", err)
+	}
+
+	if m.cfg.DeleteSource {
+		// Processes that have not seen the flip still read from the former
+		// shard.
+		if err := m.sleep(ctx, m.cfg.Settle); err != nil {
+			return nil, fmt.Errorf("move user: %w", err)
+		}
+		if err := deleteUserRows(ctx, src, userID); err != nil {
+			return nil, fmt.Errorf("move user: delete from former shard: %w", err)
+		}
+	}
+
+	return report, nil
+}
+
+// copyPaused copies the rows of the user again once no process writes them.
+func (m *UserMover) copyPaused(ctx context.Context, src, dst *gorm.DB, userID todo.UserID) (int, error) {
+	if err := m.sleep(ctx, m.cfg.Settle); err != nil {
+		return 0, err
+	}
+	return m.copyUserRows(ctx, src, dst, userID)
+}
+
+// PinUsers adds an entry to the shard map for every user stored on the shard
+// they are placed on by the hash ring, so that they stay there when shards
+// are added or removed. It returns the number of entries added.
+func (m *UserMover) PinUsers(ctx context.Context) (int, error) {
+	shardMap := m.conn.shardMap
+	if shardMap == nil {
+		return 0, errors.New("pin users: sharding is not configured")
+	}
+	if err := shardMap.Load(ctx, m.conn.GormDB); err != nil {
+		return 0, fmt.Errorf("pin users: %w", err)
+	}
+
+	pinned := 0
+	for _, shard := range shardMap.Shards() {
+		db, err := m.conn.shardDB(shard)
+		if err != nil {
+			return pinned, fmt.Errorf("pin users: %w", err)
+		}
+
+		var lastID todo.UserID
+		for {
+			var userIDs []todo.UserID
+			err := db.WithContext(ctx).
+				Model(&userRow{}).
+				Where("id > ?", lastID).
+				Order("id").
+				Limit(m.cfg.BatchSize).
+				Pluck("id", &userIDs).Error
+			if err != nil {
+				return pinned, fmt.Errorf("pin users: list users of shard %q: %w", shard, err)
+			}
+			if len(userIDs) == 0 {
+				break
+			}
+			lastID = userIDs[len(userIDs)-1]
+
+			rows := make([]*userShardRow, 0, len(userIDs))
+			for _, userID := range userIDs {
+				// Users of another shard are copies left by a move.
+				if placed, _ := shardMap.Shard(userID); placed == shard {
+					rows = append(rows, &userShardRow{UserID: userID, Shard: shard, UpdatedAt: time.Now()})
+				}
+			}
+			if len(rows) == 0 {
+				continue
+			}
+
+			// Entries added meanwhile by a move are kept.
+			result := m.conn.GormDB.WithContext(ctx).
+				Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "user_id"}}, DoNothing: true}).
+				Create(&rows)
+			if result.Error != nil {
+				return pinned, fmt.Errorf("pin users: %w", result.Error)
+			}
+			pinned += int(result.RowsAffected)
+		}
+	}
+
+	if err := shardMap.Load(ctx, m.conn.GormDB); err != nil {
+		return pinned, fmt.Errorf("pin users: %w", err)
+	}
+
+	return pinned, nil
+}
+
+// copyUserRows upserts the user, their todos, deleted ones included, and their
+// events from src to dst. It returns the number of todos copied.
+func (m *UserMover) copyUserRows(ctx context.Context, src, dst *gorm.DB, userID todo.UserID) (int, error) {
+	src = src.WithContext(ctx)
+	dst = dst.WithContext(ctx)
+
+	var user userRow
+	err := src.Where("id = ?", userID).Take(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, fmt.Errorf("user %s not found", userID.String())
+	}
+	if err != nil {
+		return 0, err
+	}
+	if err := dst.Clauses(upsertUserColumns).Create(&user).Error; err != nil {
+		return 0, fmt.Errorf("copy user: %w", err)
+	}
+
+	copied := 0
+	var lastID todo.TodoID
+	for {
+		var todos []*todo.Todo
+		err := src.
+			Where("user_id = ? AND id > ?", userID, lastID).
+			Order("id").
+			Limit(m.cfg.BatchSize).
+			Find(&todos).Error
+		if err != nil {
+			return copied, fmt.Errorf("list todos: %w", err)
+		}
+		if len(todos) == 0 {
+			break
+		}
+		lastID = todos[len(todos)-1].ID
+
+		todoIDs := make([]todo.TodoID, 0, len(todos))
+		for _, t := range todos {
+			todoIDs = append(todoIDs, t.ID)
+		}
+		var conflicts int64
+		err = dst.Model(&todo.Todo{}).
+			Where("id IN ? AND user_id <> ?", todoIDs, userID).
+			Count(&conflicts).Error
+		if err != nil {
+			return copied, fmt.Errorf("check todo IDs: %w", err)
+		}
+		if conflicts > 0 {
+			return copied, errTodoIDConflict
+		}
+
+		if err := dst.Clauses(upsertTodoColumns).Create(&todos).Error; err != nil {
+			return copied, fmt.Errorf("copy todos: %w", err)
+		}
+		copied += len(todos)
+	}
+
+	if err := m.copyUserEvents(src, dst, userID); err != nil {
+		return copied, err
+	}
+	return copied, nil
+}
+
+// copyUserEvents upserts the events of the user, published ones included,
+// from src to dst.
+func (m *UserMover) copyUserEvents(src, dst *gorm.DB, userID todo.UserID) error {
+	var lastID todo.EventID
+	for {
+		var events []*todo.Event
+		err := src.Table(outboxTableName).
+			Where("user_id = ? AND id > ?", userID, lastID).
+			Order("id").
+			Limit(m.cfg.BatchSize).
+			Find(&events).Error
+		if err != nil {
+			return fmt.Errorf("list events: %w", err)
+		}
+		if len(events) == 0 {
+			return nil
+		}
+		lastID = events[len(events)-1].ID
+
+		eventIDs := make([]todo.EventID, 0, len(events))
+		for _, e := range events {
+			eventIDs = append(eventIDs, e.ID)
+		}
+		var conflicts int64
+		err = dst.Table(outboxTableName).
+			Where("id IN ? AND user_id <> ?", eventIDs, userID).
+			Count(&conflicts).Error
+		if err != nil {
+			return fmt.Errorf("check event IDs: %w", err)
+		}
+		if conflicts > 0 {
+			return errEventIDConflict
+		}
+
+		if err := dst.Table(outboxTableName).Clauses(upsertEventColumns).Create(&events).Error; err != nil {
+			return fmt.Errorf("copy events: %w", err)
+		}
+	}
+}
+
+// deleteUserRows deletes the events, the todos and the row of the user from
+// db.
+func deleteUserRows(ctx context.Context, db *gorm.DB, userID todo.UserID) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := deleteUserEvents(tx, userID); err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&todo.Todo{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", userID).Delete(&userRow{}).Error
+	})
+}
+
+func deleteUserEvents(db *gorm.DB, userID todo.UserID) error {
+	return db.Table(outboxTableName).Where("user_id = ?", userID).Delete(&todo.Event{}).Error
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
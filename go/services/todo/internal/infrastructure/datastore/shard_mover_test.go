@@ -0,0 +1,163 @@
+package datastore_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gorm.io/gorm"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/datastore"
+)
+
+func listUserTodos(t *testing.T, db *gorm.DB, userID todo.UserID) []*todo.Todo {
+	t.Helper()
+
+	var todos []*todo.Todo
+	if err := db.Where("user_id = ?", userID).Order("id").Find(&todos).Error; err != nil {
+		t.Fatalf("list todos: %v", err)
+	}
+	return todos
+}
+
+func listUserEventIDs(t *testing.T, db *gorm.DB, userID todo.UserID) []todo.EventID {
+	t.Helper()
+
+	var eventIDs []todo.EventID
+	if err := db.Table("outbox").Where("user_id = ?", userID).Order("id").Pluck("id", &eventIDs).Error; err != nil {
+		t.Fatalf("list events: %v", err)
+	}
+	return eventIDs
+}
+
+func TestUserMover_MoveUser(t *testing.T) {
+	type testcase struct {
+		deleteSource bool
+	}
+
+	t.Parallel()
+
+	testTables := map[string]testcase{
+		"Move a user and keep their former rows": {
+			deleteSource: false,
+		},
+		"Move a user and delete their former rows": {
+			deleteSource: true,
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			sharded := newShardedTestConn(t)
+			ctx := context.Background()
+
+			if err := sharded.shardMap.SetUserShard(ctx, sharded.primary.GormDB, 1, datastore.PrimaryShardName, false); err != nil {
+				t.Fatalf("ShardMap.SetUserShard() error = %v", err)
+			}
+			// Rows left on the target shard by an earlier move are replaced.
+			if err := sharded.shardB.GormDB.Exec("UPDATE todos SET task = 'stale' WHERE user_id = 1").Error; err != nil {
+				t.Fatalf("update todos: %v", err)
+			}
+			expected := listUserTodos(t, sharded.primary.GormDB, 1)
+			expectedEventIDs := listUserEventIDs(t, sharded.primary.GormDB, 1)
+
+			mover := datastore.NewUserMover(sharded.conn, datastore.UserMoverConfig{
+				BatchSize:    2,
+				DeleteSource: tt.deleteSource,
+			})
+			report, err := mover.MoveUser(ctx, 1, "b")
+			if err != nil {
+				t.Fatalf("UserMover.MoveUser() error = %v", err)
+			}
+
+			expectedReport := &datastore.UserMoveReport{From: datastore.PrimaryShardName, To: "b", Todos: len(expected)}
+			if diff := cmp.Diff(report, expectedReport); diff != "" {
+				t.Fatalf("UserMover.MoveUser() mismatch (-actual +expected):\n%s", diff)
+			}
+			if diff := cmp.Diff(listUserTodos(t, sharded.shardB.GormDB, 1), expected); diff != "" {
+				t.Fatalf("todos of the target shard mismatch (-actual +expected):\n%s", diff)
+			}
+			if diff := cmp.Diff(listUserEventIDs(t, sharded.shardB.GormDB, 1), expectedEventIDs); diff != "" {
+				t.Fatalf("events of the target shard mismatch (-actual +expected):\n%s", diff)
+			}
+			// The relay of the former shard must not publish the events again.
+			if eventIDs := listUserEventIDs(t, sharded.primary.GormDB, 1); len(eventIDs) != 0 {
+				t.Fatalf("events %v left on the former shard, want none", eventIDs)
+			}
+
+			loaded := datastore.NewShardMap([]string{datastore.PrimaryShardName, "b"}, 64)
+			if err := loaded.Load(ctx, sharded.primary.GormDB); err != nil {
+				t.Fatalf("ShardMap.Load() error = %v", err)
+			}
+			if shard, readOnly := loaded.Shard(1); shard != "b" || readOnly {
+				t.Fatalf("ShardMap.Shard() = %q, %v, want \"b\", false", shard, readOnly)
+			}
+
+			remaining := len(listUserTodos(t, sharded.primary.GormDB, 1))
+			if tt.deleteSource && remaining != 0 {
+				t.Fatalf("%d todos left on the former shard, want 0", remaining)
+			}
+			if !tt.deleteSource && remaining != len(expected) {
+				t.Fatalf("%d todos left on the former shard, want %d", remaining, len(expected))
+			}
+		})
+	}
+}
+
+func TestUserMover_MoveUser_FailsOnTodoIDConflict(t *testing.T) {
+	t.Parallel()
+	sharded := newShardedTestConn(t)
+	ctx := context.Background()
+
+	if err := sharded.shardMap.SetUserShard(ctx, sharded.primary.GormDB, 1, datastore.PrimaryShardName, false); err != nil {
+		t.Fatalf("ShardMap.SetUserShard() error = %v", err)
+	}
+	// Todo 1 of user 1 has the ID of a todo of user 2 on the target shard.
+	if err := sharded.shardB.GormDB.Exec("DELETE FROM todos WHERE id = 1").Error; err != nil {
+		t.Fatalf("delete todo: %v", err)
+	}
+	if err := sharded.shardB.GormDB.Exec("UPDATE todos SET id = 1 WHERE id = 3").Error; err != nil {
+		t.Fatalf("update todo: %v", err)
+	}
+
+	_, err := datastore.NewUserMover(sharded.conn, datastore.UserMoverConfig{}).MoveUser(ctx, 1, "b")
+	if err == nil {
+		t.Fatal("UserMover.MoveUser() error = nil, want a todo ID conflict")
+	}
+
+	if shard, readOnly := sharded.shardMap.Shard(1); shard != datastore.PrimaryShardName || readOnly {
+		t.Fatalf("ShardMap.Shard() = %q, %v after a failed move, want the former shard", shard, readOnly)
+	}
+}
+
+func TestUserMover_PinUsers(t *testing.T) {
+	t.Parallel()
+	sharded := newShardedTestConn(t)
+	ctx := context.Background()
+
+	pinned, err := datastore.NewUserMover(sharded.conn, datastore.UserMoverConfig{BatchSize: 2}).PinUsers(ctx)
+	if err != nil {
+		t.Fatalf("UserMover.PinUsers() error = %v", err)
+	}
+	// Both shards hold the 3 users of the fixtures, each is pinned to the
+	// shard the ring places them on.
+	if pinned != 3 {
+		t.Fatalf("UserMover.PinUsers() = %d, want 3", pinned)
+	}
+
+	// With another shard on the ring, the pinned users stay where they are.
+	grown := datastore.NewShardMap([]string{datastore.PrimaryShardName, "b", "c"}, 64)
+	if err := grown.Load(ctx, sharded.primary.GormDB); err != nil {
+		t.Fatalf("ShardMap.Load() error = %v", err)
+	}
+	for userID := todo.UserID(1); userID <= 3; userID++ {
+		before, _ := sharded.shardMap.Shard(userID)
+		after, _ := grown.Shard(userID)
+		if before != after {
+			t.Errorf("user %d moved from %q to %q after pinning", userID, before, after)
+		}
+	}
+}
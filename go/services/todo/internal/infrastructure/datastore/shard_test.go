@@ -0,0 +1,195 @@
+package datastore_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/phamquanandpad/training-project/go/pkg/cast"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/datastore"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/testutil"
+)
+
+type shardedTestConn struct {
+	conn     *datastore.TodoConn
+	shardMap *datastore.ShardMap
+	primary  *datastore.TodoConn
+	shardB   *datastore.TodoConn
+}
+
+// newShardedTestConn returns the primary and the shard "b", both loaded with
+// the fixtures.
+func newShardedTestConn(t *testing.T) *shardedTestConn {
+	t.Helper()
+
+	open := func() *datastore.TodoConn {
+		_, dbName := testutil.InitDB(t)
+		conn, closeDB, err := datastore.NewTodoSQLHandler(newTestDBConfig(dbName))
+		if err != nil {
+			t.Fatalf("NewTodoSQLHandler() error = %v", err)
+		}
+		t.Cleanup(closeDB)
+		return conn
+	}
+
+	primary := open()
+	shardB := open()
+	shardMap := datastore.NewShardMap([]string{datastore.PrimaryShardName, "b"}, 64)
+
+	return &shardedTestConn{
+		conn:     datastore.NewShardedTodoConn(primary, map[string]*datastore.TodoConn{"b": shardB}, shardMap),
+		shardMap: shardMap,
+		primary:  primary,
+		shardB:   shardB,
+	}
+}
+
+func TestBinder_BindsTheShardOfTheUser(t *testing.T) {
+	t.Parallel()
+	sharded := newShardedTestConn(t)
+	ctx := context.Background()
+
+	if err := sharded.shardMap.SetUserShard(ctx, sharded.primary.GormDB, 1, "b", false); err != nil {
+		t.Fatalf("ShardMap.SetUserShard() error = %v", err)
+	}
+	if err := sharded.shardB.GormDB.Exec("UPDATE users SET username = ? WHERE id = 1", "user1 on b").Error; err != nil {
+		t.Fatalf("update user: %v", err)
+	}
+
+	binder := datastore.NewConnectionBinder(sharded.conn)
+	userReader := datastore.NewUserReader()
+
+	user, err := userReader.GetUser(binder.Bind(todo.WithUser(ctx, &todo.User{ID: 1})), 1)
+	if err != nil {
+		t.Fatalf("userReader.GetUser() error = %v", err)
+	}
+	if user == nil || user.Username != "user1 on b" {
+		t.Fatalf("userReader.GetUser() = %+v, want the user of shard b", user)
+	}
+
+	// Requests without a user go to the primary.
+	user, err = userReader.GetUser(binder.Bind(ctx), 1)
+	if err != nil {
+		t.Fatalf("userReader.GetUser() error = %v", err)
+	}
+	if user == nil || user.Username != "user1" {
+		t.Fatalf("userReader.GetUser() = %+v, want the user of the primary", user)
+	}
+}
+
+func TestBinder_PausesTheWritesOfAReadOnlyUser(t *testing.T) {
+	t.Parallel()
+	sharded := newShardedTestConn(t)
+	ctx := context.Background()
+
+	if err := sharded.shardMap.SetUserShard(ctx, sharded.primary.GormDB, 1, "b", true); err != nil {
+		t.Fatalf("ShardMap.SetUserShard() error = %v", err)
+	}
+
+	boundCtx := datastore.NewConnectionBinder(sharded.conn).Bind(todo.WithUser(ctx, &todo.User{ID: 1}))
+
+	got, err := datastore.NewTodoReader().GetTodo(boundCtx, 1, 1)
+	if err != nil || got == nil {
+		t.Fatalf("todoReader.GetTodo() = %v, %v, want the todo", got, err)
+	}
+
+	_, err = datastore.NewTodoWriter().UpdateTodo(boundCtx, 1, 1, todo.UpdateTodo{Task: cast.Ptr("paused")})
+	if !errors.Is(err, datastore.ErrUserReadOnly) {
+		t.Fatalf("todoWriter.UpdateTodo() error = %v, want ErrUserReadOnly", err)
+	}
+
+	var task string
+	if err := sharded.shardB.GormDB.Raw("SELECT task FROM todos WHERE id = 1").Scan(&task).Error; err != nil {
+		t.Fatalf("select todo: %v", err)
+	}
+	if task == "paused" {
+		t.Fatal("the todo of a read-only user was updated")
+	}
+}
+
+func TestUserWriter_CreateUser_Sharded(t *testing.T) {
+	t.Parallel()
+	sharded := newShardedTestConn(t)
+	ctx := context.Background()
+
+	if err := sharded.shardMap.SetUserShard(ctx, sharded.primary.GormDB, 100, datastore.PrimaryShardName, false); err != nil {
+		t.Fatalf("ShardMap.SetUserShard() error = %v", err)
+	}
+	if err := sharded.shardMap.SetUserShard(ctx, sharded.primary.GormDB, 101, "b", false); err != nil {
+		t.Fatalf("ShardMap.SetUserShard() error = %v", err)
+	}
+
+	binder := datastore.NewConnectionBinder(sharded.conn)
+	userWriter := datastore.NewUserWriter()
+	create := func(newUser todo.NewUser) error {
+		_, err := userWriter.CreateUser(binder.Bind(todo.WithUser(ctx, &todo.User{ID: newUser.ID})), newUser)
+		return err
+	}
+
+	// The shard of a user is chosen by their ID.
+	if err := create(todo.NewUser{Username: "no id"}); !errors.Is(err, todo.ErrUserIDRequired) {
+		t.Fatalf("userWriter.CreateUser() error = %v, want todo.ErrUserIDRequired", err)
+	}
+
+	// The email of a user of the primary is used on shard b too.
+	email := cast.Ptr("sharded@example.com")
+	if err := create(todo.NewUser{ID: 100, Username: "user100", Email: email}); err != nil {
+		t.Fatalf("userWriter.CreateUser() error = %v", err)
+	}
+	if err := create(todo.NewUser{ID: 101, Username: "user101", Email: email}); !errors.Is(err, todo.ErrDuplicateKey) {
+		t.Fatalf("userWriter.CreateUser() error = %v, want todo.ErrDuplicateKey", err)
+	}
+
+	// Erasing the user frees their email.
+	eraseCtx := binder.Bind(todo.WithUser(ctx, &todo.User{ID: 100}))
+	if _, err := datastore.NewUserEraser().EraseUser(eraseCtx, 100); err != nil {
+		t.Fatalf("userEraser.EraseUser() error = %v", err)
+	}
+	if err := create(todo.NewUser{ID: 101, Username: "user101", Email: email}); err != nil {
+		t.Fatalf("userWriter.CreateUser() error = %v", err)
+	}
+}
+
+func TestOutboxWriter_MarkEventPublished_OnShard(t *testing.T) {
+	t.Parallel()
+	sharded := newShardedTestConn(t)
+	ctx := context.Background()
+
+	binder := datastore.NewConnectionBinder(sharded.conn)
+	if diff := cmp.Diff(binder.Shards(), []string{datastore.PrimaryShardName, "b"}); diff != "" {
+		t.Fatalf("binder.Shards() mismatch (-actual +expected):\n%s", diff)
+	}
+
+	// The leases are kept on the primary only.
+	lease, err := datastore.NewLeaseWriter().AcquireLease(binder.Bind(ctx), "outbox-relay", "relay-a", time.Minute)
+	if err != nil {
+		t.Fatalf("leaseWriter.AcquireLease() error = %v", err)
+	}
+	shardCtx := binder.BindShard(ctx, "b")
+	outboxWriter := datastore.NewOutboxWriter()
+
+	stale := *lease
+	stale.Token--
+	if err := outboxWriter.MarkEventPublished(shardCtx, &stale, 2); !errors.Is(err, todo.ErrLeaseLost) {
+		t.Fatalf("outboxWriter.MarkEventPublished() error = %v, want %v", err, todo.ErrLeaseLost)
+	}
+	if err := outboxWriter.MarkEventPublished(shardCtx, lease, 2); err != nil {
+		t.Fatalf("outboxWriter.MarkEventPublished() error = %v", err)
+	}
+
+	events, err := datastore.NewOutboxReader().ListPendingEvents(shardCtx, time.Now(), 10)
+	if err != nil {
+		t.Fatalf("outboxReader.ListPendingEvents() error = %v", err)
+	}
+	pending := make([]todo.EventID, 0, len(events))
+	for _, e := range events {
+		pending = append(pending, e.ID)
+	}
+	if diff := cmp.Diff(pending, []todo.EventID{3}); diff != "" {
+		t.Fatalf("pending events of shard b mismatch (-actual +expected):\n%s", diff)
+	}
+}
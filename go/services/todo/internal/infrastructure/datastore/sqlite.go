@@ -0,0 +1,144 @@
+//go:build cgo
+
+package datastore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	sqliteschema "github.com/phamquanandpad/training-project/go/services/todo/database/sqlite"
+)
+
+// OpenSQLite opens the sqlite database file at path and creates the missing
+// tables. SQLite keeps datetimes as text and compares them as text, so every
+// time is written in loc, with the same offset, and read back in loc.
+func OpenSQLite(path string, loc *time.Location) (*gorm.DB, error) {
+	dsn := fmt.Sprintf(
+		"file:%s?_fk=1&_busy_timeout=5000&_txlock=immediate&_journal_mode=WAL&_loc=%s",
+		path,
+		url.QueryEscape(loc.String()),
+	)
+	db, err := sql.Open(sqlite.DriverName, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := db.Exec(sqliteschema.Schema); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("apply sqlite schema: %w", err)
+	}
+
+	// nolint: exhaustivestruct
+	conn, err := gorm.Open(
+		sqlite.New(sqlite.Config{
+			DriverName: sqlite.DriverName,
+			Conn:       &sqliteConnPool{db: db, loc: loc},
+		}),
+		&gorm.Config{
+			SkipDefaultTransaction: true,
+			TranslateError:         true,
+			NowFunc: func() time.Time {
+				return time.Now().In(loc)
+			},
+		},
+	)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// sqliteConnPool moves the time arguments of every statement to loc before
+// they reach the driver, which writes them with their own offset.
+type sqliteConnPool struct {
+	db  *sql.DB
+	loc *time.Location
+}
+
+func (p *sqliteConnPool) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return p.db.PrepareContext(ctx, query)
+}
+
+func (p *sqliteConnPool) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return p.db.ExecContext(ctx, query, timesIn(p.loc, args)...)
+}
+
+func (p *sqliteConnPool) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return p.db.QueryContext(ctx, query, timesIn(p.loc, args)...)
+}
+
+func (p *sqliteConnPool) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return p.db.QueryRowContext(ctx, query, timesIn(p.loc, args)...)
+}
+
+func (p *sqliteConnPool) BeginTx(ctx context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	tx, err := p.db.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &sqliteTx{tx: tx, loc: p.loc}, nil
+}
+
+func (p *sqliteConnPool) GetDBConn() (*sql.DB, error) {
+	return p.db, nil
+}
+
+type sqliteTx struct {
+	tx  *sql.Tx
+	loc *time.Location
+}
+
+func (t *sqliteTx) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return t.tx.PrepareContext(ctx, query)
+}
+
+func (t *sqliteTx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return t.tx.ExecContext(ctx, query, timesIn(t.loc, args)...)
+}
+
+func (t *sqliteTx) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return t.tx.QueryContext(ctx, query, timesIn(t.loc, args)...)
+}
+
+func (t *sqliteTx) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return t.tx.QueryRowContext(ctx, query, timesIn(t.loc, args)...)
+}
+
+func (t *sqliteTx) StmtContext(ctx context.Context, stmt *sql.Stmt) *sql.Stmt {
+	return t.tx.StmtContext(ctx, stmt)
+}
+
+func (t *sqliteTx) Commit() error {
+	return t.tx.Commit()
+}
+
+func (t *sqliteTx) Rollback() error {
+	return t.tx.Rollback()
+}
+
+func timesIn(loc *time.Location, args []any) []any {
+	converted := make([]any, len(args))
+	for i, arg := range args {
+		switch v := arg.(type) {
+		case time.Time:
+			converted[i] = v.In(loc)
+		case *time.Time:
+			if v != nil {
+				converted[i] = v.In(loc)
+			} else {
+				converted[i] = arg
+			}
+		default:
+			converted[i] = arg
+		}
+	}
+	return converted
+}
@@ -0,0 +1,16 @@
+//go:build !cgo
+
+package datastore
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// OpenSQLite fails: the sqlite driver needs cgo, and this binary was built
+// without it, as the Docker image is. Such builds support MySQL only.
+func OpenSQLite(_ string, _ *time.Location) (*gorm.DB, error) {
+	return nil, errors.New("sqlite is not supported by this build: build with CGO_ENABLED=1")
+}
@@ -0,0 +1,43 @@
+package datastore
+
+import (
+	"context"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+type todoPositionReader struct{}
+
+func NewTodoPositionReader() gateway.TodoPositionQueriesGateway {
+	return &todoPositionReader{}
+}
+
+func (r *todoPositionReader) ListUserIDsWithLongPositions(
+	ctx context.Context,
+	length int,
+	limit int,
+) ([]todo.UserID, error) {
+	tx, err := ExtractTodoDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	db := tx.WithContext(ctx)
+
+	var userIDs []todo.UserID
+	err = db.
+		Model(&todo.Todo{}).
+		Distinct("user_id").
+		Where("deleted_at IS NULL").
+		// Positions are ASCII, so LENGTH counts characters on every dialect.
+		Where("LENGTH(position) >= ?", length).
+		Order("user_id ASC").
+		Limit(limit).
+		Pluck("user_id", &userIDs).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	return userIDs, nil
+}
@@ -0,0 +1,156 @@
+package datastore
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+type todoPositionWriter struct{}
+
+func NewTodoPositionWriter() gateway.TodoPositionCommandsGateway {
+	return &todoPositionWriter{}
+}
+
+func (w *todoPositionWriter) RebalancePositions(
+	ctx context.Context,
+	userID todo.UserID,
+) error {
+	tx, err := ExtractTodoDB(ctx)
+	if err != nil {
+		return err
+	}
+
+	return tx.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return rebalancePositions(tx, userID)
+	})
+}
+
+// rebalancePositions gives the todos of the user short, evenly spread
+// positions in their current order. Every todo that moves is recorded as
+// updated, so that subscribers see the new positions; updated_at is kept.
+func rebalancePositions(db *gorm.DB, userID todo.UserID) error {
+	var todos []*todo.Todo
+	if err := db.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ? AND deleted_at IS NULL", userID).
+		Order(todoOrders[todo.SortingTypes.Position]).
+		Find(&todos).
+		Error; err != nil {
+		return err
+	}
+
+	positions := todo.EvenPositions(len(todos))
+	for i, t := range todos {
+		if t.Position == positions[i] {
+			continue
+		}
+		t.Position = positions[i]
+
+		if err := db.
+			Model(t).
+			UpdateColumns(map[string]any{
+				"position":   t.Position,
+				"updated_at": gorm.Expr("updated_at"),
+			}).
+			Error; err != nil {
+			return err
+		}
+
+		event, err := todo.NewTodoEvent(todo.EventTypes.TodoUpdated, t)
+		if err != nil {
+			return err
+		}
+		if err := appendEvent(db, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// lastPosition returns the position of the last todo of the user, or an empty
+// string when the user has none.
+func lastPosition(db *gorm.DB, userID todo.UserID) (string, error) {
+	var last todo.Todo
+	err := db.
+		Select("position").
+		Where("user_id = ? AND deleted_at IS NULL", userID).
+		Order("position DESC, id DESC").
+		First(&last).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return last.Position, nil
+}
+
+// neighborPosition returns the position of the todo right before or right
+// after target in the list of the user, leaving out the todo being moved.
+func neighborPosition(db *gorm.DB, target *todo.Todo, movedID todo.TodoID, after bool) (string, error) {
+	condition, order := "position < ? OR (position = ? AND id < ?)", "position DESC, id DESC"
+	if after {
+		condition, order = "position > ? OR (position = ? AND id > ?)", "position ASC, id ASC"
+	}
+
+	var neighbor todo.Todo
+	err := db.
+		Select("position").
+		Where("user_id = ? AND deleted_at IS NULL", target.UserID).
+		Where("id <> ?", movedID).
+		Where(condition, target.Position, target.Position, target.ID).
+		Order(order).
+		First(&neighbor).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return neighbor.Position, nil
+}
+
+// errPositionUnset reports a todo without a position, such as one written
+// before positions existed; rebalancing gives it one.
+var errPositionUnset = errors.New("position is not set")
+
+// placePosition returns a position between the bounds. When the bounds leave
+// no room for a position shorter than todo.PositionMaxLength, the list of the
+// user is rebalanced once and the bounds are read again.
+func placePosition(
+	db *gorm.DB,
+	userID todo.UserID,
+	bounds func() (before string, after string, err error),
+) (string, error) {
+	for rebalanced := false; ; rebalanced = true {
+		before, after, err := bounds()
+		if err != nil && !errors.Is(err, errPositionUnset) {
+			return "", err
+		}
+		if err == nil {
+			var position string
+			position, err = todo.PositionBetween(before, after)
+			if err == nil && (len(position) < todo.PositionMaxLength || rebalanced) {
+				return position, nil
+			}
+		}
+		if rebalanced {
+			return "", err
+		}
+
+		if err := rebalancePositions(db, userID); err != nil {
+			return "", err
+		}
+	}
+}
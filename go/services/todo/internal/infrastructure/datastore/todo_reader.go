@@ -10,6 +10,17 @@ import (
 	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
 )
 
+// todoOrders map the supported sorting types to their ORDER BY clause. IDs
+// break ties, so that pages are stable.
+var todoOrders = map[todo.SortingType]string{
+	todo.SortingTypes.CreatedAt: "created_at DESC, id DESC",
+	todo.SortingTypes.UpdatedAt: "updated_at DESC, id DESC",
+	todo.SortingTypes.ID:        "id ASC",
+	todo.SortingTypes.Position:  "position ASC, id ASC",
+}
+
+// todoReader reads from a replica when one is configured, see
+// ExtractTodoReadDB.
 type todoReader struct{}
 
 func NewTodoReader() gateway.TodoQueriesGateway {
@@ -21,11 +32,10 @@ func (r *todoReader) GetTodo(
 	todoID todo.TodoID,
 	userID todo.UserID,
 ) (*todo.Todo, error) {
-	tx, err := ExtractTodoDB(ctx)
+	db, err := ExtractTodoReadDB(ctx)
 	if err != nil {
 		return nil, err
 	}
-	db := tx.WithContext(ctx)
 
 	todo := new(todo.Todo)
 	err = db.
@@ -43,20 +53,27 @@ func (r *todoReader) GetTodo(
 	return todo, nil
 }
 
+// ListTodos falls back to the newest todos first for unsupported sorting
+// types.
 func (r *todoReader) ListTodos(
 	ctx context.Context,
 	userID todo.UserID,
+	sortingType todo.SortingType,
 ) ([]*todo.Todo, int, error) {
-	tx, er := ExtractTodoDB(ctx)
+	db, er := ExtractTodoReadDB(ctx)
 	if er != nil {
 		return nil, 0, er
 	}
-	db := tx.WithContext(ctx)
+
+	order, ok := todoOrders[sortingType]
+	if !ok {
+		order = todoOrders[todo.SortingTypes.CreatedAt]
+	}
 
 	var todos []*todo.Todo
 	err := db.
 		Where("deleted_at IS NULL").Where("user_id = ?", userID).
-		Order("created_at DESC").
+		Order(order).
 		Find(&todos).
 		Error
 	if err != nil {
@@ -67,3 +84,62 @@ func (r *todoReader) ListTodos(
 
 	return todos, total, nil
 }
+
+// ListTodosByExternalIDs also returns soft-deleted todos, since they keep
+// holding their external ID.
+func (r *todoReader) ListTodosByExternalIDs(
+	ctx context.Context,
+	userID todo.UserID,
+	externalIDs []string,
+) ([]*todo.Todo, error) {
+	if len(externalIDs) == 0 {
+		return []*todo.Todo{}, nil
+	}
+
+	db, err := ExtractTodoReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var todos []*todo.Todo
+	err = db.
+		Where("user_id = ?", userID).
+		Where("external_id IN ?", externalIDs).
+		Order("id ASC").
+		Find(&todos).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	return todos, nil
+}
+
+// ListTodosByIDs also returns soft-deleted todos, like ListTodosByExternalIDs.
+func (r *todoReader) ListTodosByIDs(
+	ctx context.Context,
+	userID todo.UserID,
+	todoIDs []todo.TodoID,
+) ([]*todo.Todo, error) {
+	if len(todoIDs) == 0 {
+		return []*todo.Todo{}, nil
+	}
+
+	db, err := ExtractTodoReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var todos []*todo.Todo
+	err = db.
+		Where("user_id = ?", userID).
+		Where("id IN ?", todoIDs).
+		Order("id ASC").
+		Find(&todos).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	return todos, nil
+}
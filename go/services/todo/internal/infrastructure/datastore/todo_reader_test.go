@@ -36,6 +36,7 @@ func Test_todoReader_GetTodo(t *testing.T) {
 				Task:        "todo task 1",
 				Description: cast.Ptr("todo description 1"),
 				Status:      todo.Pending, // 0
+				Position:    "i",
 				CreatedAt:   getLocalTimeByString("2026-01-01T00:00:00Z"),
 				UpdatedAt:   getLocalTimeByString("2026-01-01T00:00:00Z"),
 			},
@@ -72,7 +73,8 @@ func Test_todoReader_GetTodo(t *testing.T) {
 
 func Test_todoReader_ListTodos(t *testing.T) {
 	type args struct {
-		userID todo.UserID
+		userID      todo.UserID
+		sortingType todo.SortingType
 	}
 
 	type expected struct {
@@ -90,7 +92,7 @@ func Test_todoReader_ListTodos(t *testing.T) {
 
 	testTables := map[string]testcase{
 		"List Todos for User 1": {
-			args: args{userID: 1},
+			args: args{userID: 1, sortingType: todo.SortingTypes.CreatedAt},
 			expected: expected{
 				todos: []*todo.Todo{
 					{
@@ -99,6 +101,7 @@ func Test_todoReader_ListTodos(t *testing.T) {
 						Task:        "todo task 2",
 						Description: cast.Ptr("todo description 2"),
 						Status:      todo.InProcess,
+						Position:    "r",
 						CreatedAt:   getLocalTimeByString("2026-01-02T00:00:00Z"),
 						UpdatedAt:   getLocalTimeByString("2026-01-02T00:00:00Z"),
 					},
@@ -108,6 +111,7 @@ func Test_todoReader_ListTodos(t *testing.T) {
 						Task:        "todo task 1",
 						Description: cast.Ptr("todo description 1"),
 						Status:      todo.Pending,
+						Position:    "i",
 						CreatedAt:   getLocalTimeByString("2026-01-01T00:00:00Z"),
 						UpdatedAt:   getLocalTimeByString("2026-01-01T00:00:00Z"),
 					},
@@ -117,7 +121,7 @@ func Test_todoReader_ListTodos(t *testing.T) {
 			wantErr: false,
 		},
 		"List Todos for User 2": {
-			args: args{userID: 2},
+			args: args{userID: 2, sortingType: todo.SortingTypes.CreatedAt},
 			expected: expected{
 				todos: []*todo.Todo{
 					{
@@ -126,6 +130,7 @@ func Test_todoReader_ListTodos(t *testing.T) {
 						Task:        "todo task 3",
 						Description: cast.Ptr("todo description 3"),
 						Status:      todo.Pending,
+						Position:    "i",
 						CreatedAt:   getLocalTimeByString("2026-01-03T00:00:00Z"),
 						UpdatedAt:   getLocalTimeByString("2026-01-03T00:00:00Z"),
 					},
@@ -134,6 +139,68 @@ func Test_todoReader_ListTodos(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		"List Todos for User 3 newest first": {
+			args: args{userID: 3, sortingType: todo.SortingTypes.CreatedAt},
+			expected: expected{
+				todos: []*todo.Todo{
+					{
+						ID:          6,
+						UserID:      3,
+						Task:        "todo task 6",
+						Description: cast.Ptr("todo description 6"),
+						Status:      todo.Done,
+						Position:    "r",
+						CompletedAt: cast.Ptr(getLocalTimeByString("2026-01-06T12:00:00Z")),
+						CreatedAt:   getLocalTimeByString("2026-01-04T00:00:00Z"),
+						UpdatedAt:   getLocalTimeByString("2026-01-06T12:00:00Z"),
+					},
+					{
+						ID:          4,
+						UserID:      3,
+						ExternalID:  cast.Ptr("ext-4"),
+						Task:        "todo task 4",
+						Description: cast.Ptr("todo description 4"),
+						Status:      todo.InProcess,
+						Position:    "i",
+						CreatedAt:   getLocalTimeByString("2026-01-04T00:00:00Z"),
+						UpdatedAt:   getLocalTimeByString("2026-01-04T00:00:00Z"),
+					},
+				},
+				total: 2,
+			},
+			wantErr: false,
+		},
+		"List Todos for User 3 in manual order": {
+			args: args{userID: 3, sortingType: todo.SortingTypes.Position},
+			expected: expected{
+				todos: []*todo.Todo{
+					{
+						ID:          4,
+						UserID:      3,
+						ExternalID:  cast.Ptr("ext-4"),
+						Task:        "todo task 4",
+						Description: cast.Ptr("todo description 4"),
+						Status:      todo.InProcess,
+						Position:    "i",
+						CreatedAt:   getLocalTimeByString("2026-01-04T00:00:00Z"),
+						UpdatedAt:   getLocalTimeByString("2026-01-04T00:00:00Z"),
+					},
+					{
+						ID:          6,
+						UserID:      3,
+						Task:        "todo task 6",
+						Description: cast.Ptr("todo description 6"),
+						Status:      todo.Done,
+						Position:    "r",
+						CompletedAt: cast.Ptr(getLocalTimeByString("2026-01-06T12:00:00Z")),
+						CreatedAt:   getLocalTimeByString("2026-01-04T00:00:00Z"),
+						UpdatedAt:   getLocalTimeByString("2026-01-06T12:00:00Z"),
+					},
+				},
+				total: 2,
+			},
+			wantErr: false,
+		},
 	}
 
 	for name, tt := range testTables {
@@ -143,7 +210,7 @@ func Test_todoReader_ListTodos(t *testing.T) {
 
 			todoReader := datastore.NewTodoReader()
 
-			todos, total, err := todoReader.ListTodos(ctxWithReadDB, tt.args.userID)
+			todos, total, err := todoReader.ListTodos(ctxWithReadDB, tt.args.userID, tt.args.sortingType)
 			if (err != nil) != tt.wantErr {
 				t.Fatalf("error = %v wantErr %v", err, tt.wantErr)
 			}
@@ -158,3 +225,76 @@ func Test_todoReader_ListTodos(t *testing.T) {
 		})
 	}
 }
+
+func Test_todoReader_ListTodosByExternalIDs(t *testing.T) {
+	type args struct {
+		userID      todo.UserID
+		externalIDs []string
+	}
+
+	type testcase struct {
+		args     args
+		expected []todo.TodoID
+		wantErr  bool
+	}
+
+	t.Parallel()
+
+	testTables := map[string]testcase{
+		"List Todos of User 3 by external ID": {
+			args: args{
+				userID:      3,
+				externalIDs: []string{"ext-4", "ext-unknown"},
+			},
+			expected: []todo.TodoID{4},
+			wantErr:  false,
+		},
+		"List soft deleted Todos too": {
+			args: args{
+				userID:      1,
+				externalIDs: []string{"ext-5"},
+			},
+			expected: []todo.TodoID{5},
+			wantErr:  false,
+		},
+		"Do not list Todos of another User": {
+			args: args{
+				userID:      1,
+				externalIDs: []string{"ext-4"},
+			},
+			expected: []todo.TodoID{},
+			wantErr:  false,
+		},
+		"List nothing without external IDs": {
+			args: args{
+				userID:      1,
+				externalIDs: nil,
+			},
+			expected: []todo.TodoID{},
+			wantErr:  false,
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			todoReader := datastore.NewTodoReader()
+
+			todos, err := todoReader.ListTodosByExternalIDs(ctxWithReadDB, tt.args.userID, tt.args.externalIDs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v wantErr %v", err, tt.wantErr)
+			}
+
+			actual := make([]todo.TodoID, 0, len(todos))
+			for _, td := range todos {
+				actual = append(actual, td.ID)
+			}
+
+			if diff := cmp.Diff(actual, tt.expected); diff != "" {
+				t.Fatalf("mismatch (-actual +expected):\n%s", diff)
+			}
+		})
+	}
+}
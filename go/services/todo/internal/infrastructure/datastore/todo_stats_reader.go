@@ -0,0 +1,206 @@
+package datastore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/errors"
+)
+
+const activityBucketColumn = "bucket_start"
+
+// activityBucketExprs truncate a datetime column to the start of its bucket.
+// Weeks start on Monday, as WEEKDAY counts from it. On sqlite the local date is
+// the date part of the stored text, and the bucket keeps its offset so that it
+// compares with time arguments as text.
+var activityBucketExprs = map[string]map[todo.StatsBucketSize]string{
+	dialectMySQL: {
+		todo.StatsBucketSizes.Day:   "DATE(%[1]s)",
+		todo.StatsBucketSizes.Week:  "DATE_SUB(DATE(%[1]s), INTERVAL WEEKDAY(%[1]s) DAY)",
+		todo.StatsBucketSizes.Month: "DATE_SUB(DATE(%[1]s), INTERVAL DAYOFMONTH(%[1]s) - 1 DAY)",
+	},
+	dialectSQLite: {
+		todo.StatsBucketSizes.Day: "SUBSTR(%[1]s, 1, 10) || ' 00:00:00' || SUBSTR(%[1]s, -6)",
+		todo.StatsBucketSizes.Week: "DATE(SUBSTR(%[1]s, 1, 10), '-' || ((CAST(STRFTIME('%%w', SUBSTR(%[1]s, 1, 10)) AS INTEGER) + 6) %% 7) || ' days')" +
+			" || ' 00:00:00' || SUBSTR(%[1]s, -6)",
+		todo.StatsBucketSizes.Month: "DATE(SUBSTR(%[1]s, 1, 10), 'start of month') || ' 00:00:00' || SUBSTR(%[1]s, -6)",
+	},
+}
+
+// completionSecondsExprs give the seconds from creation to completion.
+var completionSecondsExprs = map[string]string{
+	dialectMySQL:  "TIMESTAMPDIFF(SECOND, created_at, completed_at)",
+	dialectSQLite: "(JULIANDAY(completed_at) - JULIANDAY(created_at)) * 86400",
+}
+
+type todoStatsReader struct {
+	pageTokens *PageTokenCodec
+}
+
+func NewTodoStatsReader(pageTokens *PageTokenCodec) gateway.TodoStatsQueriesGateway {
+	return &todoStatsReader{
+		pageTokens: pageTokens,
+	}
+}
+
+// CountTodosByStatus counts the todos created in [from, to) by their current
+// status.
+func (r *todoStatsReader) CountTodosByStatus(
+	ctx context.Context,
+	userID todo.UserID,
+	from time.Time,
+	to time.Time,
+) (map[todo.TodoStatus]int, error) {
+	tx, err := ExtractTodoDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	db := tx.WithContext(ctx)
+
+	var rows []struct {
+		Status todo.TodoStatus
+		Count  int
+	}
+	err = db.
+		Model(&todo.Todo{}).
+		Select("status, COUNT(*) AS count").
+		Where("user_id = ? AND deleted_at IS NULL", userID).
+		Where("created_at >= ? AND created_at < ?", from, to).
+		Group("status").
+		Scan(&rows).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[todo.TodoStatus]int, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+
+	return counts, nil
+}
+
+// GetAverageCompletionTime averages the time from creation to Done of the
+// todos completed in [from, to).
+func (r *todoStatsReader) GetAverageCompletionTime(
+	ctx context.Context,
+	userID todo.UserID,
+	from time.Time,
+	to time.Time,
+) (*time.Duration, error) {
+	tx, err := ExtractTodoDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	db := tx.WithContext(ctx)
+
+	var seconds sql.NullFloat64
+	err = db.
+		Model(&todo.Todo{}).
+		Select("AVG("+completionSecondsExprs[dialectOf(db)]+")").
+		Where("user_id = ? AND deleted_at IS NULL", userID).
+		Where("completed_at >= ? AND completed_at < ?", from, to).
+		Row().
+		Scan(&seconds)
+	if err != nil {
+		return nil, err
+	}
+	if !seconds.Valid {
+		return nil, nil
+	}
+
+	average := time.Duration(seconds.Float64 * float64(time.Second)).Round(time.Second)
+	return &average, nil
+}
+
+// activityRow is a bucket of the activity series as read from the database.
+type activityRow struct {
+	StartAt   scannedTime
+	Created   int
+	Completed int
+}
+
+// ListTodoActivity counts the todos created and completed in each bucket of
+// [param.From, param.To). The page tokens hold the start of the first or last
+// bucket of the page they were issued on, and are bound to the user, the
+// range, the bucket size and the sorting order.
+func (r *todoStatsReader) ListTodoActivity(
+	ctx context.Context,
+	param todo.TodoStatsParam,
+) (*todo.Page[*todo.TodoActivityBucket], error) {
+	tx, err := ExtractTodoDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	db := tx.WithContext(ctx)
+
+	bucketExpr, ok := activityBucketExprs[dialectOf(db)][param.BucketSize]
+	if !ok {
+		return nil, errors.NewParameterError(
+			"ListTodoActivity: unsupported bucket size",
+			nil,
+			nil,
+			errors.ToMetadata("bucket_size", string(param.BucketSize)),
+		)
+	}
+
+	created := db.
+		Model(&todo.Todo{}).
+		Select(fmt.Sprintf(bucketExpr, "created_at")+" AS "+activityBucketColumn+", 1 AS created, 0 AS completed").
+		Where("user_id = ? AND deleted_at IS NULL", param.UserID).
+		Where("created_at >= ? AND created_at < ?", param.From, param.To)
+	completed := db.
+		Model(&todo.Todo{}).
+		Select(fmt.Sprintf(bucketExpr, "completed_at")+" AS "+activityBucketColumn+", 0 AS created, 1 AS completed").
+		Where("user_id = ? AND deleted_at IS NULL", param.UserID).
+		Where("completed_at >= ? AND completed_at < ?", param.From, param.To)
+
+	pager := CursorPager[activityRow]{
+		Fields: []CursorPagingField{{Column: activityBucketColumn, SortingOrder: param.Paging.SortingOrder}},
+		Keys: func(row activityRow) []CursorKey {
+			return []CursorKey{TimeCursorKey(row.StartAt.Time)}
+		},
+		Tokens: r.pageTokens,
+		Scope:  ActivityPageTokenScope(param),
+		Having: true,
+	}
+	page, err := pager.Find(
+		db.
+			Table("(? UNION ALL ?) AS activity", created, completed).
+			Select(activityBucketColumn+" AS start_at, SUM(created) AS created, SUM(completed) AS completed").
+			Group(activityBucketColumn),
+		param.Paging.Token,
+		param.Paging.Size,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return todo.MapPage(page, func(row activityRow) *todo.TodoActivityBucket {
+		return &todo.TodoActivityBucket{
+			StartAt:   row.StartAt.Time,
+			Created:   row.Created,
+			Completed: row.Completed,
+		}
+	}), nil
+}
+
+// ActivityPageTokenScope is the scope of the page tokens of the activity
+// series listed for param.
+func ActivityPageTokenScope(param todo.TodoStatsParam) PageTokenScope {
+	return PageTokenScope{
+		UserID: param.UserID,
+		Query: fmt.Sprintf(
+			"todo_activity:%s:%s:%s:%s",
+			param.BucketSize,
+			param.Paging.SortingOrder,
+			param.From.UTC().Format(time.RFC3339Nano),
+			param.To.UTC().Format(time.RFC3339Nano),
+		),
+	}
+}
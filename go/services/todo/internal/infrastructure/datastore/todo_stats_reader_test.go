@@ -0,0 +1,296 @@
+package datastore_test
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/phamquanandpad/training-project/go/pkg/cast"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/errors"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/datastore"
+)
+
+func Test_todoStatsReader_CountTodosByStatus(t *testing.T) {
+	type args struct {
+		userID todo.UserID
+		from   time.Time
+		to     time.Time
+	}
+
+	type testcase struct {
+		args     args
+		expected map[todo.TodoStatus]int
+		wantErr  bool
+	}
+
+	t.Parallel()
+
+	testTables := map[string]testcase{
+		"Count Todos of User 3 by status": {
+			args: args{
+				userID: 3,
+				from:   getLocalTimeByString("2026-01-01T00:00:00Z"),
+				to:     getLocalTimeByString("2026-01-08T00:00:00Z"),
+			},
+			expected: map[todo.TodoStatus]int{
+				todo.InProcess: 1,
+				todo.Done:      1,
+			},
+			wantErr: false,
+		},
+		"Do not count Todos created before the range or soft deleted": {
+			args: args{
+				userID: 1,
+				from:   getLocalTimeByString("2026-01-02T00:00:00Z"),
+				to:     getLocalTimeByString("2026-01-08T00:00:00Z"),
+			},
+			expected: map[todo.TodoStatus]int{
+				todo.InProcess: 1,
+			},
+			wantErr: false,
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			counts, err := datastore.NewTodoStatsReader(testPageTokens).CountTodosByStatus(ctxWithReadDB, tt.args.userID, tt.args.from, tt.args.to)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(counts, tt.expected); diff != "" {
+				t.Fatalf("mismatch (-actual +expected):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_todoStatsReader_GetAverageCompletionTime(t *testing.T) {
+	type args struct {
+		userID todo.UserID
+		from   time.Time
+		to     time.Time
+	}
+
+	type testcase struct {
+		args     args
+		expected *time.Duration
+		wantErr  bool
+	}
+
+	t.Parallel()
+
+	testTables := map[string]testcase{
+		"Average the completion time of User 3": {
+			args: args{
+				userID: 3,
+				from:   getLocalTimeByString("2026-01-01T00:00:00Z"),
+				to:     getLocalTimeByString("2026-01-08T00:00:00Z"),
+			},
+			expected: cast.Ptr(60 * time.Hour),
+			wantErr:  false,
+		},
+		"Return nil when no Todo was completed": {
+			args: args{
+				userID: 1,
+				from:   getLocalTimeByString("2026-01-01T00:00:00Z"),
+				to:     getLocalTimeByString("2026-01-08T00:00:00Z"),
+			},
+			expected: nil,
+			wantErr:  false,
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			average, err := datastore.NewTodoStatsReader(testPageTokens).GetAverageCompletionTime(ctxWithReadDB, tt.args.userID, tt.args.from, tt.args.to)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(average, tt.expected); diff != "" {
+				t.Fatalf("mismatch (-actual +expected):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_todoStatsReader_ListTodoActivity(t *testing.T) {
+	type testcase struct {
+		args     todo.TodoStatsParam
+		expected *todo.Page[*todo.TodoActivityBucket]
+		wantErr  bool
+	}
+
+	t.Parallel()
+
+	param := func(bucketSize todo.StatsBucketSize, paging todo.CursorPagingParam) todo.TodoStatsParam {
+		return todo.TodoStatsParam{
+			UserID:     3,
+			From:       getLocalTimeByString("2026-01-01T00:00:00Z"),
+			To:         getLocalTimeByString("2026-01-08T00:00:00Z"),
+			BucketSize: bucketSize,
+			Paging:     paging,
+		}
+	}
+	dayAscScope := datastore.ActivityPageTokenScope(param(
+		todo.StatsBucketSizes.Day,
+		todo.CursorPagingParam{Size: 1, SortingOrder: todo.SortingOrders.Asc},
+	))
+	jan4 := datastore.TimeCursorKey(getLocalTimeByString("2026-01-04T00:00:00Z"))
+	jan6 := datastore.TimeCursorKey(getLocalTimeByString("2026-01-06T00:00:00Z"))
+
+	testTables := map[string]testcase{
+		"List daily activity": {
+			args: param(todo.StatsBucketSizes.Day, todo.CursorPagingParam{Size: 31, SortingOrder: todo.SortingOrders.Asc}),
+			expected: &todo.Page[*todo.TodoActivityBucket]{
+				Items: []*todo.TodoActivityBucket{
+					{StartAt: getLocalTimeByString("2026-01-04T00:00:00Z"), Created: 2, Completed: 0},
+					{StartAt: getLocalTimeByString("2026-01-06T00:00:00Z"), Created: 0, Completed: 1},
+				},
+			},
+			wantErr: false,
+		},
+		"List daily activity newest first": {
+			args: param(todo.StatsBucketSizes.Day, todo.CursorPagingParam{Size: 31, SortingOrder: todo.SortingOrders.Desc}),
+			expected: &todo.Page[*todo.TodoActivityBucket]{
+				Items: []*todo.TodoActivityBucket{
+					{StartAt: getLocalTimeByString("2026-01-06T00:00:00Z"), Created: 0, Completed: 1},
+					{StartAt: getLocalTimeByString("2026-01-04T00:00:00Z"), Created: 2, Completed: 0},
+				},
+			},
+			wantErr: false,
+		},
+		"List weekly activity from Monday": {
+			args: param(todo.StatsBucketSizes.Week, todo.CursorPagingParam{Size: 31, SortingOrder: todo.SortingOrders.Asc}),
+			expected: &todo.Page[*todo.TodoActivityBucket]{
+				Items: []*todo.TodoActivityBucket{
+					{StartAt: getLocalTimeByString("2025-12-29T00:00:00Z"), Created: 2, Completed: 0},
+					{StartAt: getLocalTimeByString("2026-01-05T00:00:00Z"), Created: 0, Completed: 1},
+				},
+			},
+			wantErr: false,
+		},
+		"List monthly activity": {
+			args: param(todo.StatsBucketSizes.Month, todo.CursorPagingParam{Size: 31, SortingOrder: todo.SortingOrders.Asc}),
+			expected: &todo.Page[*todo.TodoActivityBucket]{
+				Items: []*todo.TodoActivityBucket{
+					{StartAt: getLocalTimeByString("2026-01-01T00:00:00Z"), Created: 2, Completed: 1},
+				},
+			},
+			wantErr: false,
+		},
+		"List the first page": {
+			args: param(todo.StatsBucketSizes.Day, todo.CursorPagingParam{Size: 1, SortingOrder: todo.SortingOrders.Asc}),
+			expected: &todo.Page[*todo.TodoActivityBucket]{
+				Items: []*todo.TodoActivityBucket{
+					{StartAt: getLocalTimeByString("2026-01-04T00:00:00Z"), Created: 2, Completed: 0},
+				},
+				NextPageToken: encodePageToken(dayAscScope, todo.PagingDirections.Next, jan4),
+				HasNext:       true,
+			},
+			wantErr: false,
+		},
+		"List the page after the token": {
+			args: param(todo.StatsBucketSizes.Day, todo.CursorPagingParam{
+				Token:        encodePageToken(dayAscScope, todo.PagingDirections.Next, jan4),
+				Size:         1,
+				SortingOrder: todo.SortingOrders.Asc,
+			}),
+			expected: &todo.Page[*todo.TodoActivityBucket]{
+				Items: []*todo.TodoActivityBucket{
+					{StartAt: getLocalTimeByString("2026-01-06T00:00:00Z"), Created: 0, Completed: 1},
+				},
+				PreviousPageToken: encodePageToken(dayAscScope, todo.PagingDirections.Previous, jan6),
+				HasPrevious:       true,
+			},
+			wantErr: false,
+		},
+		"List the page before the token": {
+			args: param(todo.StatsBucketSizes.Day, todo.CursorPagingParam{
+				Token:        encodePageToken(dayAscScope, todo.PagingDirections.Previous, jan6),
+				Size:         1,
+				SortingOrder: todo.SortingOrders.Asc,
+			}),
+			expected: &todo.Page[*todo.TodoActivityBucket]{
+				Items: []*todo.TodoActivityBucket{
+					{StartAt: getLocalTimeByString("2026-01-04T00:00:00Z"), Created: 2, Completed: 0},
+				},
+				NextPageToken: encodePageToken(dayAscScope, todo.PagingDirections.Next, jan4),
+				HasNext:       true,
+			},
+			wantErr: false,
+		},
+		"Return error for an unsigned page token": {
+			args: param(todo.StatsBucketSizes.Day, todo.CursorPagingParam{
+				Token:        cast.Ptr(base64.StdEncoding.EncodeToString([]byte("2026-01-04"))),
+				Size:         1,
+				SortingOrder: todo.SortingOrders.Asc,
+			}),
+			wantErr: true,
+		},
+		"Return error for a page token signed with another secret": {
+			args: param(todo.StatsBucketSizes.Day, todo.CursorPagingParam{
+				Token: func() *string {
+					token, _ := datastore.NewPageTokenCodec("another-secret").Encode(dayAscScope, todo.PagingDirections.Next, jan4)
+					return &token
+				}(),
+				Size:         1,
+				SortingOrder: todo.SortingOrders.Asc,
+			}),
+			wantErr: true,
+		},
+		"Return error for a page token of another user": {
+			args: param(todo.StatsBucketSizes.Day, todo.CursorPagingParam{
+				Token:        encodePageToken(datastore.PageTokenScope{UserID: 1, Query: dayAscScope.Query}, todo.PagingDirections.Next, jan4),
+				Size:         1,
+				SortingOrder: todo.SortingOrders.Asc,
+			}),
+			wantErr: true,
+		},
+		"Return error for a page token of another sorting order": {
+			args: param(todo.StatsBucketSizes.Day, todo.CursorPagingParam{
+				Token:        encodePageToken(dayAscScope, todo.PagingDirections.Next, jan4),
+				Size:         1,
+				SortingOrder: todo.SortingOrders.Desc,
+			}),
+			wantErr: true,
+		},
+		"Return error for a page token with other keys": {
+			args: param(todo.StatsBucketSizes.Day, todo.CursorPagingParam{
+				Token:        encodePageToken(dayAscScope, todo.PagingDirections.Next, jan4, datastore.IntCursorKey(1)),
+				Size:         1,
+				SortingOrder: todo.SortingOrders.Asc,
+			}),
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := datastore.NewTodoStatsReader(testPageTokens).ListTodoActivity(ctxWithReadDB, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.IsParameterError(err) {
+				t.Fatalf("error = %v, want a ParameterError", err)
+			}
+
+			if diff := cmp.Diff(actual, tt.expected); diff != "" {
+				t.Fatalf("mismatch (-actual +expected):\n%s", diff)
+			}
+		})
+	}
+}
@@ -18,6 +18,8 @@ func NewTodoWriter() gateway.TodoCommandsGateway {
 	return &todoWriter{}
 }
 
+// CreateTodo fails with todo.ErrDuplicateKey when the user already has a todo
+// with the external ID of newTodo.
 func (w *todoWriter) CreateTodo(
 	ctx context.Context,
 	newTodo todo.NewTodo,
@@ -30,15 +32,38 @@ func (w *todoWriter) CreateTodo(
 	db := tx.WithContext(ctx)
 	createdTodo := todo.Todo{
 		UserID:      newTodo.UserID,
+		ExternalID:  newTodo.ExternalID,
 		Task:        newTodo.Task,
 		Description: newTodo.Description,
-		Status:      newTodo.Status,
+		DueAt:       newTodo.DueAt,
 	}
+	createdTodo.SetStatus(newTodo.Status, time.Now())
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		// New todos go to the end of the manual order.
+		position, err := placePosition(tx, newTodo.UserID, func() (string, string, error) {
+			last, err := lastPosition(tx, newTodo.UserID)
+			return last, "", err
+		})
+		if err != nil {
+			return err
+		}
+		createdTodo.Position = position
 
-	if err := db.
-		Create(&createdTodo).
-		Error; err != nil {
-		return nil, err
+		if err := tx.
+			Create(&createdTodo).
+			Error; err != nil {
+			return err
+		}
+
+		event, err := todo.NewTodoEvent(todo.EventTypes.TodoCreated, &createdTodo)
+		if err != nil {
+			return err
+		}
+		return appendEvent(tx, event)
+	})
+	if err != nil {
+		return nil, translateWriteError(err)
 	}
 	return &createdTodo, nil
 }
@@ -72,14 +97,28 @@ func (w *todoWriter) UpdateTodo(
 		t.Task = *updateTodo.Task
 	}
 	if updateTodo.Status != nil {
-		t.Status = *updateTodo.Status
+		t.SetStatus(*updateTodo.Status, time.Now())
 	}
 	if updateTodo.Description != nil {
 		t.Description = updateTodo.Description
 	}
+	if updateTodo.DueAt != nil {
+		t.DueAt = updateTodo.DueAt
+	}
 
-	if err := db.Save(&t).Error; err != nil {
-		return nil, err
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&t).Error; err != nil {
+			return err
+		}
+
+		event, err := todo.NewTodoEvent(todo.EventTypes.TodoUpdated, &t)
+		if err != nil {
+			return err
+		}
+		return appendEvent(tx, event)
+	})
+	if err != nil {
+		return nil, translateWriteError(err)
 	}
 	return &t, nil
 }
@@ -109,8 +148,106 @@ func (w *todoWriter) SoftDeleteTodo(
 	}
 
 	t.DeletedAt = cast.Ptr(time.Now())
-	if err := db.Save(&t).Error; err != nil {
-		return err
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&t).Error; err != nil {
+			return err
+		}
+
+		event, err := todo.NewTodoEvent(todo.EventTypes.TodoDeleted, &t)
+		if err != nil {
+			return err
+		}
+		return appendEvent(tx, event)
+	})
+}
+
+// MoveTodo places the todo right before or right after another todo of the
+// user. Only the moved todo is written, unless the list has to be rebalanced.
+// It returns nil when either todo does not exist.
+func (w *todoWriter) MoveTodo(
+	ctx context.Context,
+	todoID todo.TodoID,
+	userID todo.UserID,
+	move todo.MoveTodo,
+) (*todo.Todo, error) {
+	tx, err := ExtractTodoDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	db := tx.WithContext(ctx)
+
+	var moved *todo.Todo
+	err = db.Transaction(func(tx *gorm.DB) error {
+		t, err := findUserTodo(tx, todoID, userID)
+		if err != nil || t == nil {
+			return err
+		}
+		if target, err := findUserTodo(tx, move.TargetID(), userID); err != nil || target == nil {
+			return err
+		}
+		if move.TargetID() == todoID {
+			moved = t
+			return nil
+		}
+
+		after := move.AfterID != nil
+		position, err := placePosition(tx, userID, func() (string, string, error) {
+			// Read again, the target moves when the list is rebalanced.
+			target, err := findUserTodo(tx, move.TargetID(), userID)
+			if err != nil {
+				return "", "", err
+			}
+			if target == nil {
+				return "", "", gorm.ErrRecordNotFound
+			}
+			if target.Position == "" {
+				return "", "", errPositionUnset
+			}
+
+			neighbor, err := neighborPosition(tx, target, todoID, after)
+			if err != nil {
+				return "", "", err
+			}
+			if after {
+				return target.Position, neighbor, nil
+			}
+			return neighbor, target.Position, nil
+		})
+		if err != nil {
+			return err
+		}
+		t.Position = position
+
+		if err := tx.Save(t).Error; err != nil {
+			return err
+		}
+
+		event, err := todo.NewTodoEvent(todo.EventTypes.TodoUpdated, t)
+		if err != nil {
+			return err
+		}
+		moved = t
+		return appendEvent(tx, event)
+	})
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	return moved, nil
+}
+
+func findUserTodo(db *gorm.DB, todoID todo.TodoID, userID todo.UserID) (*todo.Todo, error) {
+	var t todo.Todo
+	if err := db.
+		Where("id = ? AND deleted_at IS NULL", todoID).
+		Where("user_id = ?", userID).
+		First(&t).
+		Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &t, nil
 }
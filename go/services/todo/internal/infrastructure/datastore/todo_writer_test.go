@@ -2,7 +2,9 @@ package datastore_test
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -43,6 +45,7 @@ func Test_todoWriter_CreateTodo(t *testing.T) {
 				Task:        "new todo task 1",
 				Description: cast.Ptr("new todo description 1"),
 				Status:      todo.Pending,
+				Position:    "v",
 			},
 			wantErr: false,
 		},
@@ -58,6 +61,7 @@ func Test_todoWriter_CreateTodo(t *testing.T) {
 				Task:        "new todo task 2",
 				Description: nil,
 				Status:      todo.Pending,
+				Position:    "v",
 			},
 			wantErr: false,
 		},
@@ -93,6 +97,25 @@ func Test_todoWriter_CreateTodo(t *testing.T) {
 	}
 }
 
+func Test_todoWriter_CreateTodo_DuplicateExternalID(t *testing.T) {
+	t.Parallel()
+	gormDB, _ := testutil.InitDB(t)
+
+	tx := gormDB.Begin()
+	defer tx.Rollback()
+
+	ctxWithWriteDB := datastore.WithTodoDB(context.Background(), tx)
+	_, err := datastore.NewTodoWriter().CreateTodo(ctxWithWriteDB, todo.NewTodo{
+		UserID:     todo.UserID(3),
+		ExternalID: cast.Ptr("ext-4"),
+		Task:       "new todo task",
+		Status:     todo.Pending,
+	})
+	if !errors.Is(err, todo.ErrDuplicateKey) {
+		t.Errorf("todoWriter.CreateTodo() error = %v, want %v", err, todo.ErrDuplicateKey)
+	}
+}
+
 func Test_todoWriter_UpdateTodo(t *testing.T) {
 	t.Parallel()
 	gormDB, _ := testutil.InitDB(t)
@@ -124,6 +147,7 @@ func Test_todoWriter_UpdateTodo(t *testing.T) {
 				Task:        "updated todo task 1",
 				Description: cast.Ptr("todo description 1"),
 				Status:      todo.Pending,
+				Position:    "i",
 			},
 			wantErr: false,
 		},
@@ -241,3 +265,259 @@ func Test_todoWriter_SoftDeleteTodo(t *testing.T) {
 		})
 	}
 }
+
+func Test_todoWriter_UpdateTodo_CompletedAt(t *testing.T) {
+	t.Parallel()
+	gormDB, _ := testutil.InitDB(t)
+
+	type args struct {
+		todoID todo.TodoID
+		userID todo.UserID
+		status todo.TodoStatus
+	}
+
+	type testcase struct {
+		args          args
+		wantCompleted bool
+	}
+
+	testTables := map[string]testcase{
+		"Record the completion time when the Todo is done": {
+			args: args{
+				todoID: todo.TodoID(2),
+				userID: todo.UserID(1),
+				status: todo.Done,
+			},
+			wantCompleted: true,
+		},
+		"Clear the completion time when the Todo is reopened": {
+			args: args{
+				todoID: todo.TodoID(6),
+				userID: todo.UserID(3),
+				status: todo.InProcess,
+			},
+			wantCompleted: false,
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			tx := gormDB.Begin()
+
+			defer tx.Rollback()
+
+			ctxWithWriteDB := datastore.WithTodoDB(context.Background(), tx)
+			res, err := datastore.NewTodoWriter().UpdateTodo(
+				ctxWithWriteDB,
+				tt.args.todoID,
+				tt.args.userID,
+				todo.UpdateTodo{Status: cast.Ptr(tt.args.status)},
+			)
+			if err != nil {
+				t.Fatalf("todoWriter.UpdateTodo() error = %v", err)
+			}
+
+			if (res.CompletedAt != nil) != tt.wantCompleted {
+				t.Errorf("todoWriter.UpdateTodo() CompletedAt = %v, wantCompleted %v", res.CompletedAt, tt.wantCompleted)
+			}
+		})
+	}
+}
+
+func Test_todoWriter_MoveTodo(t *testing.T) {
+	t.Parallel()
+	gormDB, _ := testutil.InitDB(t)
+
+	type args struct {
+		todoID todo.TodoID
+		userID todo.UserID
+		move   todo.MoveTodo
+	}
+
+	type testcase struct {
+		args             args
+		expectedPosition *string
+		wantErr          bool
+	}
+
+	testTables := map[string]testcase{
+		"Move Todo after the last Todo": {
+			args: args{
+				todoID: todo.TodoID(1),
+				userID: todo.UserID(1),
+				move:   todo.MoveTodo{AfterID: todo.NewTodoID(2)},
+			},
+			expectedPosition: cast.Ptr("v"),
+			wantErr:          false,
+		},
+		"Move Todo before the first Todo": {
+			args: args{
+				todoID: todo.TodoID(2),
+				userID: todo.UserID(1),
+				move:   todo.MoveTodo{BeforeID: todo.NewTodoID(1)},
+			},
+			expectedPosition: cast.Ptr("9"),
+			wantErr:          false,
+		},
+		"Return nil when the Todo belongs to another user": {
+			args: args{
+				todoID: todo.TodoID(3),
+				userID: todo.UserID(1),
+				move:   todo.MoveTodo{AfterID: todo.NewTodoID(1)},
+			},
+			expectedPosition: nil,
+			wantErr:          false,
+		},
+		"Return nil when the target Todo belongs to another user": {
+			args: args{
+				todoID: todo.TodoID(1),
+				userID: todo.UserID(1),
+				move:   todo.MoveTodo{AfterID: todo.NewTodoID(4)},
+			},
+			expectedPosition: nil,
+			wantErr:          false,
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			tx := gormDB.Begin()
+
+			defer tx.Rollback()
+
+			ctxWithWriteDB := datastore.WithTodoDB(context.Background(), tx)
+			res, err := datastore.NewTodoWriter().MoveTodo(ctxWithWriteDB, tt.args.todoID, tt.args.userID, tt.args.move)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("todoWriter.MoveTodo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var actualPosition *string
+			if res != nil {
+				actualPosition = &res.Position
+			}
+
+			if diff := cmp.Diff(actualPosition, tt.expectedPosition); diff != "" {
+				t.Errorf("todoWriter.MoveTodo() position mismatch (-actual +expected):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_todoWriter_RecordsOutboxEvents(t *testing.T) {
+	t.Parallel()
+	gormDB, _ := testutil.InitDB(t)
+
+	type testcase struct {
+		write             func(ctx context.Context) error
+		ignoreAggregateID bool
+		expected          []*todo.Event
+	}
+
+	testTables := map[string]testcase{
+		"Create Todo records TodoCreated": {
+			write: func(ctx context.Context) error {
+				_, err := datastore.NewTodoWriter().CreateTodo(ctx, todo.NewTodo{
+					UserID: todo.UserID(1),
+					Task:   "new todo task",
+					Status: todo.Pending,
+				})
+				return err
+			},
+			ignoreAggregateID: true,
+			expected: []*todo.Event{
+				{
+					AggregateType: todo.AggregateTypes.Todo,
+					UserID:        todo.UserID(1),
+					EventType:     todo.EventTypes.TodoCreated,
+				},
+			},
+		},
+		"Update Todo records TodoUpdated": {
+			write: func(ctx context.Context) error {
+				_, err := datastore.NewTodoWriter().UpdateTodo(ctx, todo.TodoID(1), todo.UserID(1), todo.UpdateTodo{
+					Status: cast.Ptr(todo.Done),
+				})
+				return err
+			},
+			expected: []*todo.Event{
+				{
+					AggregateType: todo.AggregateTypes.Todo,
+					AggregateID:   1,
+					UserID:        todo.UserID(1),
+					EventType:     todo.EventTypes.TodoUpdated,
+				},
+			},
+		},
+		"Soft Delete Todo records TodoDeleted": {
+			write: func(ctx context.Context) error {
+				return datastore.NewTodoWriter().SoftDeleteTodo(ctx, todo.TodoID(2), todo.UserID(1))
+			},
+			expected: []*todo.Event{
+				{
+					AggregateType: todo.AggregateTypes.Todo,
+					AggregateID:   2,
+					UserID:        todo.UserID(1),
+					EventType:     todo.EventTypes.TodoDeleted,
+				},
+			},
+		},
+		"Move Todo records TodoUpdated": {
+			write: func(ctx context.Context) error {
+				_, err := datastore.NewTodoWriter().MoveTodo(ctx, todo.TodoID(1), todo.UserID(1), todo.MoveTodo{
+					AfterID: todo.NewTodoID(2),
+				})
+				return err
+			},
+			expected: []*todo.Event{
+				{
+					AggregateType: todo.AggregateTypes.Todo,
+					AggregateID:   1,
+					UserID:        todo.UserID(1),
+					EventType:     todo.EventTypes.TodoUpdated,
+				},
+			},
+		},
+		"Update Todo of another User records nothing": {
+			write: func(ctx context.Context) error {
+				_, err := datastore.NewTodoWriter().UpdateTodo(ctx, todo.TodoID(3), todo.UserID(1), todo.UpdateTodo{
+					Status: cast.Ptr(todo.Done),
+				})
+				return err
+			},
+			expected: []*todo.Event{},
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			tx := gormDB.Begin()
+
+			defer tx.Rollback()
+
+			ctxWithWriteDB := datastore.WithTodoDB(context.Background(), tx)
+			if err := tt.write(ctxWithWriteDB); err != nil {
+				t.Fatalf("write error = %v", err)
+			}
+
+			events, err := datastore.NewOutboxReader().ListPendingEvents(ctxWithWriteDB, time.Now(), 10)
+			if err != nil {
+				t.Fatalf("outboxReader.ListPendingEvents() error = %v", err)
+			}
+
+			// The first two pending events come from the fixtures.
+			ignoreFieldsOpts := []cmp.Option{
+				cmpopts.IgnoreFields(todo.Event{}, "ID", "Payload", "OccurredAt", "NextAttemptAt"),
+			}
+			if tt.ignoreAggregateID {
+				ignoreFieldsOpts = append(ignoreFieldsOpts, cmpopts.IgnoreFields(todo.Event{}, "AggregateID"))
+			}
+
+			if diff := cmp.Diff(events[2:], tt.expected, ignoreFieldsOpts...); diff != "" {
+				t.Errorf("recorded events mismatch (-actual +expected):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package datastore
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+type userDataReader struct{}
+
+func NewUserDataReader() gateway.UserDataQueriesGateway {
+	return &userDataReader{}
+}
+
+// ReadUserData reads the user, their todos and their events in one
+// transaction, so that they are consistent with each other, and writes each
+// page to sink as soon as it is read.
+func (r *userDataReader) ReadUserData(
+	ctx context.Context,
+	userID todo.UserID,
+	pageSize int,
+	sink gateway.UserDataSink,
+) (bool, error) {
+	db, err := ExtractTodoReadDB(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	found := false
+	err = db.Transaction(func(tx *gorm.DB) error {
+		var row userRow
+		err := tx.Where("id = ?", userID).Take(&row).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		found = true
+		if err := sink.WriteUser(&row.User); err != nil {
+			return err
+		}
+
+		var afterTodoID todo.TodoID
+		for {
+			var todos []*todo.Todo
+			err := tx.
+				Where("user_id = ? AND id > ?", userID, afterTodoID).
+				Order("id").
+				Limit(pageSize).
+				Find(&todos).
+				Error
+			if err != nil {
+				return err
+			}
+			if len(todos) == 0 {
+				break
+			}
+			if err := sink.WriteTodos(todos); err != nil {
+				return err
+			}
+			if len(todos) < pageSize {
+				break
+			}
+			afterTodoID = todos[len(todos)-1].ID
+		}
+
+		var afterEventID todo.EventID
+		for {
+			var events []*todo.Event
+			err := tx.
+				Table(outboxTableName).
+				Where("user_id = ? AND id > ?", userID, afterEventID).
+				Order("id").
+				Limit(pageSize).
+				Find(&events).
+				Error
+			if err != nil {
+				return err
+			}
+			if len(events) == 0 {
+				break
+			}
+			if err := sink.WriteEvents(events); err != nil {
+				return err
+			}
+			if len(events) < pageSize {
+				break
+			}
+			afterEventID = events[len(events)-1].ID
+		}
+
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return found, nil
+}
@@ -0,0 +1,101 @@
+package datastore_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/datastore"
+)
+
+// pagesSink records the IDs of each page written to it.
+type pagesSink struct {
+	user       *todo.User
+	todoPages  [][]todo.TodoID
+	eventPages [][]todo.EventID
+}
+
+func (s *pagesSink) WriteUser(user *todo.User) error {
+	s.user = user
+	return nil
+}
+
+func (s *pagesSink) WriteTodos(todos []*todo.Todo) error {
+	page := make([]todo.TodoID, 0, len(todos))
+	for _, t := range todos {
+		page = append(page, t.ID)
+	}
+	s.todoPages = append(s.todoPages, page)
+	return nil
+}
+
+func (s *pagesSink) WriteEvents(events []*todo.Event) error {
+	page := make([]todo.EventID, 0, len(events))
+	for _, e := range events {
+		page = append(page, e.ID)
+	}
+	s.eventPages = append(s.eventPages, page)
+	return nil
+}
+
+func Test_userDataReader_ReadUserData(t *testing.T) {
+	type args struct {
+		userID   todo.UserID
+		pageSize int
+	}
+
+	type testcase struct {
+		args               args
+		expectedFound      bool
+		expectedTodoPages  [][]todo.TodoID
+		expectedEventPages [][]todo.EventID
+	}
+
+	t.Parallel()
+
+	testTables := map[string]testcase{
+		"Read the todos and events of User 1 in pages": {
+			args:               args{userID: 1, pageSize: 2},
+			expectedFound:      true,
+			expectedTodoPages:  [][]todo.TodoID{{1, 2}, {5}},
+			expectedEventPages: [][]todo.EventID{{1, 2}},
+		},
+		"Read everything in one page when it fits": {
+			args:               args{userID: 1, pageSize: 10},
+			expectedFound:      true,
+			expectedTodoPages:  [][]todo.TodoID{{1, 2, 5}},
+			expectedEventPages: [][]todo.EventID{{1, 2}},
+		},
+		"Write nothing for an unknown user": {
+			args:          args{userID: 999, pageSize: 10},
+			expectedFound: false,
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			sink := &pagesSink{}
+			found, err := datastore.NewUserDataReader().ReadUserData(ctxWithReadDB, tt.args.userID, tt.args.pageSize, sink)
+			if err != nil {
+				t.Fatalf("userDataReader.ReadUserData() error = %v", err)
+			}
+
+			if found != tt.expectedFound {
+				t.Errorf("found = %v want %v", found, tt.expectedFound)
+			}
+			if found != (sink.user != nil) {
+				t.Errorf("user written = %v want %v", sink.user != nil, found)
+			}
+			if diff := cmp.Diff(sink.todoPages, tt.expectedTodoPages); diff != "" {
+				t.Errorf("todo pages mismatch (-actual +expected):\n%s", diff)
+			}
+			if diff := cmp.Diff(sink.eventPages, tt.expectedEventPages); diff != "" {
+				t.Errorf("event pages mismatch (-actual +expected):\n%s", diff)
+			}
+		})
+	}
+}
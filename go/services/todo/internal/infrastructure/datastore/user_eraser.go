@@ -0,0 +1,112 @@
+package datastore
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+// erasedUserRow is a row of the erased_users table, the tombstones of the
+// erased users.
+type erasedUserRow struct {
+	todo.ErasedUser
+}
+
+func (erasedUserRow) TableName() string {
+	return "erased_users"
+}
+
+type userEraser struct{}
+
+func NewUserEraser() gateway.UserErasureCommandsGateway {
+	return &userEraser{}
+}
+
+// EraseUser hard-deletes the rows of the user: their outbox events go too,
+// published or not, as their payloads hold personal data. Subscribers learn of
+// the erasure from the UserErased event recorded in their place. In a sharded
+// database the emails the user claimed on the primary are freed afterwards.
+func (e *userEraser) EraseUser(
+	ctx context.Context,
+	userID todo.UserID,
+) (*todo.ErasedUser, error) {
+	tx, err := ExtractTodoDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	db := tx.WithContext(ctx)
+
+	var erased *todo.ErasedUser
+	err = db.Transaction(func(tx *gorm.DB) error {
+		var row userRow
+		err := tx.Where("id = ?", userID).Take(&row).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("user_id = ?", userID).Delete(&todo.Todo{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Table(outboxTableName).Where("user_id = ?", userID).Delete(&todo.Event{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("id = ?", userID).Delete(&userRow{}).Error; err != nil {
+			return err
+		}
+
+		tombstone := erasedUserRow{
+			ErasedUser: todo.ErasedUser{
+				UserID:   userID,
+				ErasedAt: time.Now(),
+			},
+		}
+		if err := tx.Create(&tombstone).Error; err != nil {
+			return err
+		}
+
+		event, err := todo.NewUserErasedEvent(&tombstone.ErasedUser)
+		if err != nil {
+			return err
+		}
+		if err := appendEvent(tx, event); err != nil {
+			return err
+		}
+
+		erased = &tombstone.ErasedUser
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if primary, sharded := extractPrimaryDB(ctx); sharded && erased != nil {
+		if err := primary.WithContext(ctx).Where("user_id = ?", userID).Delete(&userEmailRow{}).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	return erased, nil
+}
+
+// isUserErased reports whether the ID is the one of an erased user. IDs left
+// to the database are never erased ones.
+func isUserErased(db *gorm.DB, userID todo.UserID) (bool, error) {
+	if userID == 0 {
+		return false, nil
+	}
+
+	var count int64
+	if err := db.Model(&erasedUserRow{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
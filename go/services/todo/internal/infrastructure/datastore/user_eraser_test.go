@@ -0,0 +1,60 @@
+package datastore_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/datastore"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/testutil"
+)
+
+func Test_userEraser_EraseUser(t *testing.T) {
+	t.Parallel()
+	gormDB, _ := testutil.InitDB(t)
+
+	tx := gormDB.Begin()
+	defer tx.Rollback()
+
+	ctx := datastore.WithTodoDB(context.Background(), tx)
+	otherTodos := listUserTodos(t, tx, 2)
+
+	erased, err := datastore.NewUserEraser().EraseUser(ctx, 1)
+	if err != nil {
+		t.Fatalf("userEraser.EraseUser() error = %v", err)
+	}
+	if erased == nil || erased.UserID != 1 {
+		t.Fatalf("userEraser.EraseUser() = %+v, want the tombstone of user 1", erased)
+	}
+
+	found, err := datastore.NewUserDataReader().ReadUserData(ctx, 1, 10, &todo.UserData{})
+	if err != nil {
+		t.Fatalf("userDataReader.ReadUserData() error = %v", err)
+	}
+	if found {
+		t.Fatalf("userDataReader.ReadUserData() found user 1 after erasure")
+	}
+
+	if remaining := listUserTodos(t, tx, 1); len(remaining) != 0 {
+		t.Fatalf("%d todos left for the erased user, want 0", len(remaining))
+	}
+	if diff := cmp.Diff(listUserTodos(t, tx, 2), otherTodos); diff != "" {
+		t.Fatalf("todos of another user mismatch (-actual +expected):\n%s", diff)
+	}
+
+	// Only the event of the erasure is left for the user.
+	var eventTypes []todo.EventType
+	if err := tx.Table("outbox").Where("user_id = ?", 1).Order("id").Pluck("event_type", &eventTypes).Error; err != nil {
+		t.Fatalf("list events: %v", err)
+	}
+	if diff := cmp.Diff(eventTypes, []todo.EventType{todo.EventTypes.UserErased}); diff != "" {
+		t.Fatalf("events mismatch (-actual +expected):\n%s", diff)
+	}
+
+	erasedAgain, err := datastore.NewUserEraser().EraseUser(ctx, 1)
+	if err != nil || erasedAgain != nil {
+		t.Fatalf("userEraser.EraseUser() = %+v, %v for an erased user, want nil, nil", erasedAgain, err)
+	}
+}
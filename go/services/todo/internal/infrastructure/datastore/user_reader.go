@@ -0,0 +1,121 @@
+package datastore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+// userRow is a row of the users table. Passwords are kept by the auth service,
+// so the users created by this service have an empty one.
+type userRow struct {
+	todo.User
+	Password string
+}
+
+func (userRow) TableName() string {
+	return "users"
+}
+
+// userReader reads from a replica when one is configured, see
+// ExtractTodoReadDB.
+type userReader struct {
+	pageTokens *PageTokenCodec
+}
+
+func NewUserReader() gateway.UserQueriesGateway {
+	return &userReader{}
+}
+
+func NewUserWithTodosReader(pageTokens *PageTokenCodec) gateway.UserWithTodosQueriesGateway {
+	return &userReader{
+		pageTokens: pageTokens,
+	}
+}
+
+// GetUser returns nil for unknown and deleted users.
+func (r *userReader) GetUser(
+	ctx context.Context,
+	userID int64,
+) (*todo.User, error) {
+	db, err := ExtractTodoReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return findUser(db, todo.UserID(userID))
+}
+
+// GetUserWithTodos pages through the todos of the user newest first, unless
+// paging.SortingOrder says otherwise.
+func (r *userReader) GetUserWithTodos(
+	ctx context.Context,
+	userID todo.UserID,
+	paging todo.CursorPagingParam,
+) (*todo.UserWithTodos, error) {
+	db, err := ExtractTodoReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := findUser(db, userID)
+	if err != nil || u == nil {
+		return nil, err
+	}
+
+	order := paging.SortingOrder
+	if order == "" {
+		order = todo.SortingOrders.Desc
+	}
+	pager := CursorPager[*todo.Todo]{
+		Fields: []CursorPagingField{
+			{Column: "created_at", SortingOrder: order},
+			{Column: "id", SortingOrder: order},
+		},
+		Keys: func(t *todo.Todo) []CursorKey {
+			return []CursorKey{TimeCursorKey(t.CreatedAt), IntCursorKey(t.ID.Int64())}
+		},
+		Tokens: r.pageTokens,
+		Scope: PageTokenScope{
+			UserID: userID,
+			Query:  fmt.Sprintf("user_todos:created_at:%s", order),
+		},
+	}
+	todos, err := pager.Find(
+		db.Model(&todo.Todo{}).Where("user_id = ? AND deleted_at IS NULL", userID),
+		paging.Token,
+		paging.Size,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &todo.UserWithTodos{
+		ID:       u.ID,
+		Username: u.Username,
+		Email:    u.Email,
+		Todos:    todos,
+	}, nil
+}
+
+// findUser returns nil for unknown and deleted users.
+func findUser(db *gorm.DB, userID todo.UserID) (*todo.User, error) {
+	var row userRow
+	err := db.
+		Where("id = ? AND deleted_at IS NULL", userID).
+		First(&row).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &row.User, nil
+}
@@ -0,0 +1,123 @@
+package datastore_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/phamquanandpad/training-project/go/pkg/cast"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/datastore"
+)
+
+func Test_userReader_GetUserWithTodos(t *testing.T) {
+	type args struct {
+		userID todo.UserID
+		paging todo.CursorPagingParam
+	}
+
+	type testcase struct {
+		args     args
+		expected *todo.UserWithTodos
+		wantErr  bool
+	}
+
+	t.Parallel()
+
+	firstPageToken := encodePageToken(
+		datastore.PageTokenScope{UserID: 1, Query: "user_todos:created_at:DESC"},
+		todo.PagingDirections.Next,
+		datastore.TimeCursorKey(getLocalTimeByString("2026-01-02T00:00:00Z")),
+		datastore.IntCursorKey(2),
+	)
+
+	testTables := map[string]testcase{
+		"Get User with the first page of todos": {
+			args: args{
+				userID: 1,
+				paging: todo.CursorPagingParam{Size: 1},
+			},
+			expected: &todo.UserWithTodos{
+				ID:       1,
+				Username: "user1",
+				Email:    cast.Ptr("user1@example.com"),
+				Todos: &todo.Page[*todo.Todo]{
+					Items:         []*todo.Todo{{ID: 2, UserID: 1}},
+					NextPageToken: firstPageToken,
+					HasNext:       true,
+				},
+			},
+			wantErr: false,
+		},
+		"Get User with the next page of todos, without deleted ones": {
+			args: args{
+				userID: 1,
+				paging: todo.CursorPagingParam{Size: 1, Token: firstPageToken},
+			},
+			expected: &todo.UserWithTodos{
+				ID:       1,
+				Username: "user1",
+				Email:    cast.Ptr("user1@example.com"),
+				Todos: &todo.Page[*todo.Todo]{
+					Items: []*todo.Todo{{ID: 1, UserID: 1}},
+					PreviousPageToken: encodePageToken(
+						datastore.PageTokenScope{UserID: 1, Query: "user_todos:created_at:DESC"},
+						todo.PagingDirections.Previous,
+						datastore.TimeCursorKey(getLocalTimeByString("2026-01-01T00:00:00Z")),
+						datastore.IntCursorKey(1),
+					),
+					HasPrevious: true,
+				},
+			},
+			wantErr: false,
+		},
+		"Return error for a page token of another User": {
+			args: args{
+				userID: 2,
+				paging: todo.CursorPagingParam{Size: 1, Token: firstPageToken},
+			},
+			expected: nil,
+			wantErr:  true,
+		},
+		"Deleted User returns nil": {
+			args: args{
+				userID: 3,
+				paging: todo.CursorPagingParam{Size: 1},
+			},
+			expected: nil,
+			wantErr:  false,
+		},
+		"Not found and return nil": {
+			args: args{
+				userID: 999,
+				paging: todo.CursorPagingParam{Size: 1},
+			},
+			expected: nil,
+			wantErr:  false,
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			userReader := datastore.NewUserWithTodosReader(testPageTokens)
+
+			actual, err := userReader.GetUserWithTodos(ctxWithReadDB, tt.args.userID, tt.args.paging)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v wantErr %v", err, tt.wantErr)
+			}
+
+			// The todos are compared by ID, their fields are covered by the todo
+			// reader tests.
+			ignoreFieldsOpts := []cmp.Option{
+				cmpopts.IgnoreFields(todo.Todo{}, "ExternalID", "Task", "Description", "Status", "Position", "DueAt", "CompletedAt", "CreatedAt", "UpdatedAt", "DeletedAt"),
+			}
+			if diff := cmp.Diff(actual, tt.expected, ignoreFieldsOpts...); diff != "" {
+				t.Fatalf("mismatch (-actual +expected):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,254 @@
+package datastore
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+type userWriter struct{}
+
+func NewUserWriter() gateway.UserCommandsGateway {
+	return &userWriter{}
+}
+
+// CreateUser keeps the ID and times of newUser when they are set. It fails
+// with todo.ErrDuplicateKey when the ID or the email is already used, by a
+// deleted user too, and with todo.ErrUserErased when the ID is the one of an
+// erased user. In a sharded database the ID is required, and the email is
+// claimed on the primary first, see claimUserEmail.
+func (w *userWriter) CreateUser(
+	ctx context.Context,
+	newUser todo.NewUser,
+) (*todo.User, error) {
+	tx, err := ExtractTodoDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	primary, sharded := extractPrimaryDB(ctx)
+	claimed := false
+	if sharded {
+		if newUser.ID == 0 {
+			return nil, todo.ErrUserIDRequired
+		}
+		if claimed, err = claimUserEmail(ctx, primary, newUser.ID, newUser.Email); err != nil {
+			return nil, translateWriteError(err)
+		}
+	}
+
+	db := tx.WithContext(ctx)
+	row := userRow{
+		User: todo.User{
+			ID:        newUser.ID,
+			Username:  newUser.Username,
+			Email:     newUser.Email,
+			CreatedAt: newUser.CreatedAt,
+			UpdatedAt: newUser.UpdatedAt,
+		},
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		erased, err := isUserErased(tx, newUser.ID)
+		if err != nil {
+			return err
+		}
+		if erased {
+			return todo.ErrUserErased
+		}
+
+		if err := tx.Create(&row).Error; err != nil {
+			return err
+		}
+
+		event, err := todo.NewUserEvent(todo.EventTypes.UserCreated, &row.User)
+		if err != nil {
+			return err
+		}
+		return appendEvent(tx, event)
+	})
+	if err != nil {
+		if claimed {
+			_ = releaseUserEmail(ctx, primary, newUser.ID, *newUser.Email)
+		}
+		return nil, translateWriteError(err)
+	}
+	return &row.User, nil
+}
+
+// UpdateUser returns nil when the user does not exist or is deleted. It fails
+// with todo.ErrDuplicateKey when the email is used by another user.
+func (w *userWriter) UpdateUser(
+	ctx context.Context,
+	userID todo.UserID,
+	updateUser todo.UpdateUser,
+) (*todo.User, error) {
+	tx, err := ExtractTodoDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	primary, sharded := extractPrimaryDB(ctx)
+	claimed := false
+	if sharded {
+		if claimed, err = claimUserEmail(ctx, primary, userID, updateUser.Email); err != nil {
+			return nil, translateWriteError(err)
+		}
+	}
+
+	db := tx.WithContext(ctx)
+
+	var (
+		updated  *todo.User
+		previous *string
+	)
+	err = db.Transaction(func(tx *gorm.DB) error {
+		u, err := findUser(tx, userID)
+		if err != nil || u == nil {
+			return err
+		}
+		previous = u.Email
+
+		if updateUser.Username != nil {
+			u.Username = *updateUser.Username
+		}
+		if updateUser.Email != nil {
+			u.Email = updateUser.Email
+		}
+		u.UpdatedAt = time.Now()
+
+		if err := tx.
+			Model(&userRow{}).
+			Where("id = ?", u.ID).
+			Updates(map[string]any{
+				"username":   u.Username,
+				"email":      u.Email,
+				"updated_at": u.UpdatedAt,
+			}).
+			Error; err != nil {
+			return err
+		}
+
+		event, err := todo.NewUserEvent(todo.EventTypes.UserUpdated, u)
+		if err != nil {
+			return err
+		}
+		if err := appendEvent(tx, event); err != nil {
+			return err
+		}
+
+		updated = u
+		return nil
+	})
+	if err != nil || updated == nil {
+		if claimed {
+			_ = releaseUserEmail(ctx, primary, userID, *updateUser.Email)
+		}
+		if err != nil {
+			return nil, translateWriteError(err)
+		}
+		return nil, nil
+	}
+
+	// The previous email is free once the new one is stored.
+	if sharded && updateUser.Email != nil && previous != nil && *previous != *updateUser.Email {
+		if err := releaseUserEmail(ctx, primary, userID, *previous); err != nil {
+			return nil, err
+		}
+	}
+	return updated, nil
+}
+
+// SoftDeleteUser does nothing when the user does not exist or is already
+// deleted. The todos of the user are kept.
+func (w *userWriter) SoftDeleteUser(
+	ctx context.Context,
+	userID todo.UserID,
+) error {
+	tx, err := ExtractTodoDB(ctx)
+	if err != nil {
+		return err
+	}
+
+	db := tx.WithContext(ctx)
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		u, err := findUser(tx, userID)
+		if err != nil || u == nil {
+			return err
+		}
+
+		now := time.Now()
+		u.DeletedAt = &now
+		u.UpdatedAt = now
+		if err := tx.
+			Model(&userRow{}).
+			Where("id = ?", u.ID).
+			Updates(map[string]any{
+				"deleted_at": u.DeletedAt,
+				"updated_at": u.UpdatedAt,
+			}).
+			Error; err != nil {
+			return err
+		}
+
+		event, err := todo.NewUserEvent(todo.EventTypes.UserDeleted, u)
+		if err != nil {
+			return err
+		}
+		return appendEvent(tx, event)
+	})
+}
+
+// userEmailRow claims an email for a user. The user_emails table is used on
+// the primary of a sharded database only, where it keeps the emails unique
+// across the shards as the unique index of users does on each of them.
+type userEmailRow struct {
+	Email  string
+	UserID todo.UserID
+}
+
+func (userEmailRow) TableName() string {
+	return "user_emails"
+}
+
+// claimUserEmail claims the email for the user on primary, and reports
+// whether it was not claimed by them yet. It fails with a duplicate key error
+// when another user claimed it. A nil email claims nothing.
+func claimUserEmail(ctx context.Context, primary *gorm.DB, userID todo.UserID, email *string) (bool, error) {
+	if email == nil {
+		return false, nil
+	}
+
+	db := primary.WithContext(ctx)
+	err := db.Create(&userEmailRow{Email: *email, UserID: userID}).Error
+	if err == nil {
+		return true, nil
+	}
+
+	// A claim left by an earlier attempt of the same user is theirs already.
+	var claim userEmailRow
+	if takeErr := db.Where("email = ?", *email).Take(&claim).Error; takeErr != nil {
+		if errors.Is(takeErr, gorm.ErrRecordNotFound) {
+			return false, err
+		}
+		return false, takeErr
+	}
+	if claim.UserID != userID {
+		return false, err
+	}
+	return false, nil
+}
+
+// releaseUserEmail frees the email claimed by the user on primary.
+func releaseUserEmail(ctx context.Context, primary *gorm.DB, userID todo.UserID, email string) error {
+	return primary.WithContext(context.WithoutCancel(ctx)).
+		Where("email = ? AND user_id = ?", email, userID).
+		Delete(&userEmailRow{}).
+		Error
+}
@@ -0,0 +1,110 @@
+package datastore_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/phamquanandpad/training-project/go/pkg/cast"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/datastore"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/testutil"
+)
+
+func Test_userWriter_RecordsOutboxEvents(t *testing.T) {
+	t.Parallel()
+	gormDB, _ := testutil.InitDB(t)
+
+	type testcase struct {
+		write    func(ctx context.Context) error
+		expected []*todo.Event
+	}
+
+	testTables := map[string]testcase{
+		"Create User records UserCreated": {
+			write: func(ctx context.Context) error {
+				_, err := datastore.NewUserWriter().CreateUser(ctx, todo.NewUser{
+					ID:       todo.UserID(101),
+					Username: "new user",
+				})
+				return err
+			},
+			expected: []*todo.Event{
+				{
+					AggregateType: todo.AggregateTypes.User,
+					AggregateID:   101,
+					UserID:        todo.UserID(101),
+					EventType:     todo.EventTypes.UserCreated,
+				},
+			},
+		},
+		"Update User records UserUpdated": {
+			write: func(ctx context.Context) error {
+				_, err := datastore.NewUserWriter().UpdateUser(ctx, todo.UserID(1), todo.UpdateUser{
+					Username: cast.Ptr("renamed user"),
+				})
+				return err
+			},
+			expected: []*todo.Event{
+				{
+					AggregateType: todo.AggregateTypes.User,
+					AggregateID:   1,
+					UserID:        todo.UserID(1),
+					EventType:     todo.EventTypes.UserUpdated,
+				},
+			},
+		},
+		"Soft Delete User records UserDeleted": {
+			write: func(ctx context.Context) error {
+				return datastore.NewUserWriter().SoftDeleteUser(ctx, todo.UserID(2))
+			},
+			expected: []*todo.Event{
+				{
+					AggregateType: todo.AggregateTypes.User,
+					AggregateID:   2,
+					UserID:        todo.UserID(2),
+					EventType:     todo.EventTypes.UserDeleted,
+				},
+			},
+		},
+		"Update deleted User records nothing": {
+			write: func(ctx context.Context) error {
+				_, err := datastore.NewUserWriter().UpdateUser(ctx, todo.UserID(3), todo.UpdateUser{
+					Username: cast.Ptr("renamed user"),
+				})
+				return err
+			},
+			expected: []*todo.Event{},
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			tx := gormDB.Begin()
+
+			defer tx.Rollback()
+
+			ctxWithWriteDB := datastore.WithTodoDB(context.Background(), tx)
+			if err := tt.write(ctxWithWriteDB); err != nil {
+				t.Fatalf("write error = %v", err)
+			}
+
+			events, err := datastore.NewOutboxReader().ListPendingEvents(ctxWithWriteDB, time.Now(), 10)
+			if err != nil {
+				t.Fatalf("outboxReader.ListPendingEvents() error = %v", err)
+			}
+
+			// The first two pending events come from the fixtures.
+			ignoreFieldsOpts := []cmp.Option{
+				cmpopts.IgnoreFields(todo.Event{}, "ID", "Payload", "OccurredAt", "NextAttemptAt"),
+			}
+			if diff := cmp.Diff(events[2:], tt.expected, ignoreFieldsOpts...); diff != "" {
+				t.Errorf("recorded events mismatch (-actual +expected):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package interchange
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+var csvHeader = []string{"external_id", "task", "description", "status", "created_at", "updated_at"}
+
+type csvCodec struct{}
+
+func NewCSVCodec() gateway.TodoCodec {
+	return &csvCodec{}
+}
+
+func (c *csvCodec) Encode(w io.Writer, todos []*todo.Todo) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return fmt.Errorf("write csv header: %w", err)
+	}
+
+	for _, t := range todos {
+		description := ""
+		if t.Description != nil {
+			description = *t.Description
+		}
+
+		if err := cw.Write([]string{
+			exportExternalID(t),
+			t.Task,
+			description,
+			t.Status.String(),
+			formatTime(t.CreatedAt),
+			formatTime(t.UpdatedAt),
+		}); err != nil {
+			return fmt.Errorf("write csv row: %w", err)
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// Decode maps columns by their header name, so their order does not matter
+// and unknown columns such as created_at are ignored.
+func (c *csvCodec) Decode(r io.Reader) ([]*todo.ImportRow, []todo.ImportRowError, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("read csv header: %w", err)
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[name] = i
+	}
+	if _, ok := columns["task"]; !ok {
+		return nil, nil, errors.New("csv header has no task column")
+	}
+
+	var (
+		rows      []*todo.ImportRow
+		rowErrors []todo.ImportRowError
+	)
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		line, _ := cr.FieldPos(0)
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				rowErrors = append(rowErrors, todo.ImportRowError{Line: parseErr.Line, Field: "", Message: parseErr.Err.Error()})
+				continue
+			}
+			return nil, nil, fmt.Errorf("read csv row: %w", err)
+		}
+
+		field := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return record[i]
+		}
+
+		row := &todo.ImportRow{
+			Line:        line,
+			ExternalID:  optionalString(field("external_id")),
+			Task:        field("task"),
+			Description: optionalString(field("description")),
+			Status:      todo.Pending,
+		}
+
+		if s := field("status"); s != "" {
+			status, err := todo.ParseTodoStatus(s)
+			if err != nil {
+				rowErrors = append(rowErrors, todo.ImportRowError{Line: line, Field: "status", Message: err.Error()})
+				continue
+			}
+			row.Status = status
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, rowErrors, nil
+}
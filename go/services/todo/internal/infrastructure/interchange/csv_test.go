@@ -0,0 +1,123 @@
+package interchange_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/phamquanandpad/training-project/go/pkg/cast"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/interchange"
+)
+
+func Test_csvCodec_Encode(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	todos := []*todo.Todo{
+		{
+			ID:          1,
+			UserID:      1,
+			Task:        "todo task 1",
+			Description: cast.Ptr("line 1\nline 2, with comma"),
+			Status:      todo.InProcess,
+			CreatedAt:   createdAt,
+			UpdatedAt:   createdAt,
+		},
+		{
+			ID:         2,
+			UserID:     1,
+			ExternalID: cast.Ptr("ext-2"),
+			Task:       "todo task 2",
+			Status:     todo.Done,
+			CreatedAt:  createdAt,
+			UpdatedAt:  createdAt,
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := interchange.NewCSVCodec().Encode(&buf, todos); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	expected := "external_id,task,description,status,created_at,updated_at\n" +
+		"todo:1,todo task 1,\"line 1\nline 2, with comma\",in_progress,2026-01-01T00:00:00Z,2026-01-01T00:00:00Z\n" +
+		"ext-2,todo task 2,,done,2026-01-01T00:00:00Z,2026-01-01T00:00:00Z\n"
+
+	if diff := cmp.Diff(buf.String(), expected); diff != "" {
+		t.Fatalf("mismatch (-actual +expected):\n%s", diff)
+	}
+}
+
+func Test_csvCodec_Decode(t *testing.T) {
+	type expected struct {
+		rows      []*todo.ImportRow
+		rowErrors []todo.ImportRowError
+	}
+
+	type testcase struct {
+		input    string
+		expected expected
+		wantErr  bool
+	}
+
+	t.Parallel()
+
+	testTables := map[string]testcase{
+		"Decode rows in any column order": {
+			input: "status,task,external_id,description\n" +
+				"done,todo task 1,ext-1,todo description 1\n" +
+				"1,todo task 2,,\n" +
+				",todo task 3,,\n",
+			expected: expected{
+				rows: []*todo.ImportRow{
+					{Line: 2, ExternalID: cast.Ptr("ext-1"), Task: "todo task 1", Description: cast.Ptr("todo description 1"), Status: todo.Done},
+					{Line: 3, Task: "todo task 2", Status: todo.InProcess},
+					{Line: 4, Task: "todo task 3", Status: todo.Pending},
+				},
+			},
+			wantErr: false,
+		},
+		"Report rows with unknown status": {
+			input: "task,status\n" +
+				"todo task 1,archived\n" +
+				"todo task 2,pending\n",
+			expected: expected{
+				rows: []*todo.ImportRow{
+					{Line: 3, Task: "todo task 2", Status: todo.Pending},
+				},
+				rowErrors: []todo.ImportRowError{
+					{Line: 2, Field: "status", Message: `unknown todo status "archived"`},
+				},
+			},
+			wantErr: false,
+		},
+		"Fail without task column": {
+			input:   "title,status\nfoo,done\n",
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			rows, rowErrors, err := interchange.NewCSVCodec().Decode(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(rows, tt.expected.rows); diff != "" {
+				t.Fatalf("rows mismatch (-actual +expected):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(rowErrors, tt.expected.rowErrors); diff != "" {
+				t.Fatalf("row errors mismatch (-actual +expected):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,274 @@
+package interchange
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+// iCalendar (RFC 5545) constants.
+const (
+	icsProductID     = "-//training-project//todo//EN"
+	icsDateTime      = "20060102T150405Z"
+	icsLocalDateTime = "20060102T150405"
+	icsDate          = "20060102"
+	icsMaxLineOctets = 75
+)
+
+var icsStatuses = map[todo.TodoStatus]string{
+	todo.Pending:   "NEEDS-ACTION",
+	todo.InProcess: "IN-PROCESS",
+	todo.Done:      "COMPLETED",
+}
+
+type icsCodec struct{}
+
+// NewICalendarCodec returns a codec that maps todos to VTODO components:
+// task to SUMMARY, status to STATUS and the external ID to UID, so that
+// importing an exported file again finds the same todos.
+func NewICalendarCodec() gateway.TodoCodec {
+	return &icsCodec{}
+}
+
+func (c *icsCodec) Encode(w io.Writer, todos []*todo.Todo) error {
+	bw := bufio.NewWriter(w)
+	write := func(name, value string) {
+		writeICSLine(bw, name+":"+value)
+	}
+
+	write("BEGIN", "VCALENDAR")
+	write("VERSION", "2.0")
+	write("PRODID", icsProductID)
+	write("CALSCALE", "GREGORIAN")
+	for _, t := range todos {
+		write("BEGIN", "VTODO")
+		if id := exportExternalID(t); id != "" {
+			write("UID", escapeICSText(id))
+		}
+		write("DTSTAMP", t.UpdatedAt.UTC().Format(icsDateTime))
+		write("CREATED", t.CreatedAt.UTC().Format(icsDateTime))
+		write("LAST-MODIFIED", t.UpdatedAt.UTC().Format(icsDateTime))
+		write("SUMMARY", escapeICSText(t.Task))
+		if t.Description != nil {
+			write("DESCRIPTION", escapeICSText(*t.Description))
+		}
+		write("STATUS", icsStatuses[t.Status])
+		if t.DueAt != nil {
+			write("DUE", t.DueAt.UTC().Format(icsDateTime))
+		}
+		write("END", "VTODO")
+	}
+	write("END", "VCALENDAR")
+
+	return bw.Flush()
+}
+
+// Decode reads the VTODO components of a calendar. Other components, such as
+// VEVENT or VTIMEZONE, the components nested in a VTODO, such as VALARM, and
+// unknown properties are ignored.
+func (c *icsCodec) Decode(r io.Reader) ([]*todo.ImportRow, []todo.ImportRowError, error) {
+	lines, err := unfoldICSLines(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read ics: %w", err)
+	}
+	if len(lines) == 0 || !strings.EqualFold(lines[0].text, "BEGIN:VCALENDAR") {
+		return nil, nil, errors.New("not an iCalendar file")
+	}
+
+	var (
+		rows      []*todo.ImportRow
+		rowErrors []todo.ImportRowError
+		row       *todo.ImportRow
+		rowErr    *todo.ImportRowError
+		// depth counts the components open inside the current VTODO.
+		depth int
+	)
+	for _, l := range lines {
+		prop, err := parseICSProperty(l.text)
+		if err != nil {
+			if row != nil && depth == 0 && rowErr == nil {
+				rowErr = &todo.ImportRowError{Line: l.number, Field: "", Message: err.Error()}
+			}
+			continue
+		}
+
+		switch {
+		case row == nil:
+			if prop.name == "BEGIN" && strings.EqualFold(prop.value, "VTODO") {
+				row = &todo.ImportRow{Line: l.number, Status: todo.Pending}
+				rowErr = nil
+				depth = 0
+			}
+		case prop.name == "BEGIN":
+			depth++
+		case prop.name == "END" && depth > 0:
+			depth--
+		case prop.name == "END" && strings.EqualFold(prop.value, "VTODO"):
+			if rowErr != nil {
+				rowErrors = append(rowErrors, *rowErr)
+			} else {
+				rows = append(rows, row)
+			}
+			row = nil
+		case depth == 0 && rowErr == nil:
+			if err := applyICSProperty(row, prop); err != nil {
+				rowErr = &todo.ImportRowError{Line: l.number, Field: strings.ToLower(prop.name), Message: err.Error()}
+			}
+		}
+	}
+
+	return rows, rowErrors, nil
+}
+
+func applyICSProperty(row *todo.ImportRow, prop icsProperty) error {
+	switch prop.name {
+	case "UID":
+		row.ExternalID = optionalString(unescapeICSText(prop.value))
+	case "SUMMARY":
+		row.Task = unescapeICSText(prop.value)
+	case "DESCRIPTION":
+		description := unescapeICSText(prop.value)
+		row.Description = &description
+	case "STATUS":
+		for status, name := range icsStatuses {
+			if strings.EqualFold(prop.value, name) {
+				row.Status = status
+				return nil
+			}
+		}
+		return fmt.Errorf("unsupported status %q", prop.value)
+	case "DUE":
+		due, err := parseICSTime(prop)
+		if err != nil {
+			return err
+		}
+		row.DueAt = &due
+	}
+
+	return nil
+}
+
+type icsLine struct {
+	number int
+	text   string
+}
+
+// unfoldICSLines joins folded content lines and remembers the physical line
+// each one starts on.
+func unfoldICSLines(r io.Reader) ([]icsLine, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxJSONLineBytes)
+
+	var lines []icsLine
+	for number := 1; scanner.Scan(); number++ {
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if text == "" {
+			continue
+		}
+		if (text[0] == ' ' || text[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1].text += text[1:]
+			continue
+		}
+		lines = append(lines, icsLine{number: number, text: text})
+	}
+
+	return lines, scanner.Err()
+}
+
+type icsProperty struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+func parseICSProperty(text string) (icsProperty, error) {
+	// The value starts at the first colon outside of a quoted parameter.
+	colon := -1
+	quoted := false
+	for i, r := range text {
+		if r == '"' {
+			quoted = !quoted
+		}
+		if r == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return icsProperty{}, fmt.Errorf("malformed content line %q", text)
+	}
+
+	parts := strings.Split(text[:colon], ";")
+	prop := icsProperty{
+		name:   strings.ToUpper(parts[0]),
+		params: map[string]string{},
+		value:  text[colon+1:],
+	}
+	for _, p := range parts[1:] {
+		if k, v, ok := strings.Cut(p, "="); ok {
+			prop.params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+		}
+	}
+
+	return prop, nil
+}
+
+// parseICSTime accepts UTC and local date-times, with or without TZID, and
+// plain dates. Floating times without TZID are read as UTC.
+func parseICSTime(prop icsProperty) (time.Time, error) {
+	loc := time.UTC
+	if tzid, ok := prop.params["TZID"]; ok {
+		l, err := time.LoadLocation(tzid)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("unknown TZID %q", tzid)
+		}
+		loc = l
+	}
+
+	for _, layout := range []string{icsDateTime, icsLocalDateTime, icsDate} {
+		if t, err := time.ParseInLocation(layout, prop.value, loc); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date-time %q", prop.value)
+}
+
+var (
+	icsTextEscaper   = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	icsTextUnescaper = strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n")
+)
+
+func escapeICSText(s string) string {
+	return icsTextEscaper.Replace(s)
+}
+
+func unescapeICSText(s string) string {
+	return icsTextUnescaper.Replace(s)
+}
+
+// writeICSLine folds lines longer than 75 octets without splitting UTF-8
+// sequences, and ends them with CRLF as RFC 5545 requires.
+func writeICSLine(w *bufio.Writer, line string) {
+	limit := icsMaxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		_, _ = w.WriteString(line[:cut])
+		_, _ = w.WriteString("\r\n ")
+		line = line[cut:]
+		// Continuation lines start with a space, which counts towards the limit.
+		limit = icsMaxLineOctets - 1
+	}
+	_, _ = w.WriteString(line)
+	_, _ = w.WriteString("\r\n")
+}
@@ -0,0 +1,218 @@
+package interchange_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/phamquanandpad/training-project/go/pkg/cast"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/interchange"
+)
+
+func Test_icsCodec_Encode(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	todos := []*todo.Todo{
+		{
+			ID:          1,
+			UserID:      1,
+			Task:        "Buy milk, eggs; bread",
+			Description: cast.Ptr("line 1\nline 2"),
+			Status:      todo.InProcess,
+			DueAt:       cast.Ptr(time.Date(2026, 1, 2, 9, 30, 0, 0, time.UTC)),
+			CreatedAt:   createdAt,
+			UpdatedAt:   createdAt,
+		},
+		{
+			ID:         2,
+			UserID:     1,
+			ExternalID: cast.Ptr("abc@example.com"),
+			Task:       strings.Repeat("あ", 30),
+			Status:     todo.Done,
+			CreatedAt:  createdAt,
+			UpdatedAt:  createdAt,
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := interchange.NewICalendarCodec().Encode(&buf, todos); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	expected := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//training-project//todo//EN",
+		"CALSCALE:GREGORIAN",
+		"BEGIN:VTODO",
+		"UID:todo:1",
+		"DTSTAMP:20260101T000000Z",
+		"CREATED:20260101T000000Z",
+		"LAST-MODIFIED:20260101T000000Z",
+		`SUMMARY:Buy milk\, eggs\; bread`,
+		`DESCRIPTION:line 1\nline 2`,
+		"STATUS:IN-PROCESS",
+		"DUE:20260102T093000Z",
+		"END:VTODO",
+		"BEGIN:VTODO",
+		"UID:abc@example.com",
+		"DTSTAMP:20260101T000000Z",
+		"CREATED:20260101T000000Z",
+		"LAST-MODIFIED:20260101T000000Z",
+		"SUMMARY:" + strings.Repeat("あ", 22),
+		" " + strings.Repeat("あ", 8),
+		"STATUS:COMPLETED",
+		"END:VTODO",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+
+	if diff := cmp.Diff(buf.String(), expected); diff != "" {
+		t.Fatalf("mismatch (-actual +expected):\n%s", diff)
+	}
+}
+
+func Test_icsCodec_Decode(t *testing.T) {
+	type expected struct {
+		rows      []*todo.ImportRow
+		rowErrors []todo.ImportRowError
+	}
+
+	type testcase struct {
+		input    string
+		expected expected
+		wantErr  bool
+	}
+
+	t.Parallel()
+
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+
+	testTables := map[string]testcase{
+		"Decode VTODO components and ignore other components": {
+			input: strings.Join([]string{
+				"BEGIN:VCALENDAR",
+				"VERSION:2.0",
+				"BEGIN:VEVENT",
+				"UID:event-1",
+				"SUMMARY:not a todo",
+				"END:VEVENT",
+				"BEGIN:VTODO",
+				"UID:todo-1",
+				"SUMMARY:Buy milk\\, eggs",
+				"DESCRIPTION:folded descri",
+				" ption",
+				"STATUS:COMPLETED",
+				"DUE;TZID=Asia/Tokyo:20260102T093000",
+				"X-UNKNOWN:ignored",
+				"END:VTODO",
+				"BEGIN:VTODO",
+				"UID:todo-2",
+				"SUMMARY:all day",
+				"DUE;VALUE=DATE:20260103",
+				"END:VTODO",
+				"END:VCALENDAR",
+			}, "\r\n"),
+			expected: expected{
+				rows: []*todo.ImportRow{
+					{
+						Line:        7,
+						ExternalID:  cast.Ptr("todo-1"),
+						Task:        "Buy milk, eggs",
+						Description: cast.Ptr("folded description"),
+						Status:      todo.Done,
+						DueAt:       cast.Ptr(time.Date(2026, 1, 2, 9, 30, 0, 0, tokyo)),
+					},
+					{
+						Line:       16,
+						ExternalID: cast.Ptr("todo-2"),
+						Task:       "all day",
+						Status:     todo.Pending,
+						DueAt:      cast.Ptr(time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC)),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		"Ignore the components nested in a VTODO": {
+			input: strings.Join([]string{
+				"BEGIN:VCALENDAR",
+				"BEGIN:VTODO",
+				"UID:todo-1",
+				"SUMMARY:Call mom",
+				"DESCRIPTION:Ask about the trip",
+				"BEGIN:VALARM",
+				"ACTION:DISPLAY",
+				"DESCRIPTION:Reminder",
+				"TRIGGER:-PT15M",
+				"STATUS:unknown",
+				"DUE:not a date",
+				"END:VALARM",
+				"END:VTODO",
+				"END:VCALENDAR",
+			}, "\n"),
+			expected: expected{
+				rows: []*todo.ImportRow{
+					{
+						Line:        2,
+						ExternalID:  cast.Ptr("todo-1"),
+						Task:        "Call mom",
+						Description: cast.Ptr("Ask about the trip"),
+						Status:      todo.Pending,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		"Report VTODO with unsupported values": {
+			input: strings.Join([]string{
+				"BEGIN:VCALENDAR",
+				"BEGIN:VTODO",
+				"SUMMARY:cancelled",
+				"STATUS:CANCELLED",
+				"END:VTODO",
+				"BEGIN:VTODO",
+				"SUMMARY:bad due",
+				"DUE:tomorrow",
+				"END:VTODO",
+				"END:VCALENDAR",
+			}, "\n"),
+			expected: expected{
+				rowErrors: []todo.ImportRowError{
+					{Line: 4, Field: "status", Message: `unsupported status "CANCELLED"`},
+					{Line: 8, Field: "due", Message: `invalid date-time "tomorrow"`},
+				},
+			},
+			wantErr: false,
+		},
+		"Fail on files that are not calendars": {
+			input:   "task,status\nfoo,done\n",
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			rows, rowErrors, err := interchange.NewICalendarCodec().Decode(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(rows, tt.expected.rows); diff != "" {
+				t.Fatalf("rows mismatch (-actual +expected):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(rowErrors, tt.expected.rowErrors); diff != "" {
+				t.Fatalf("row errors mismatch (-actual +expected):\n%s", diff)
+			}
+		})
+	}
+}
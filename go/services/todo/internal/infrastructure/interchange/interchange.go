@@ -0,0 +1,132 @@
+package interchange
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+// NewCodecs returns the codecs of every supported file format.
+func NewCodecs() map[todo.FileFormat]gateway.TodoCodec {
+	return map[todo.FileFormat]gateway.TodoCodec{
+		todo.FileFormats.CSV:       NewCSVCodec(),
+		todo.FileFormats.JSONLines: NewJSONLinesCodec(),
+		todo.FileFormats.ICalendar: NewICalendarCodec(),
+		todo.FileFormats.TodoTxt:   NewTodoTxtCodec(),
+		todo.FileFormats.Markdown:  NewMarkdownCodec(),
+	}
+}
+
+// exportExternalID falls back to the todo ID, marked by todo.ExportedID, so
+// that todos created in the app are also deduplicated when an export is
+// imported again: the import looks the ID up among the todos without an
+// external ID. Todos that were never stored, such as those converted offline
+// by cmd/todofile, have neither and export an empty ID.
+func exportExternalID(t *todo.Todo) string {
+	if t.ExternalID != nil {
+		return *t.ExternalID
+	}
+	if t.ID == 0 {
+		return ""
+	}
+	return todo.ExportedID(t.ID)
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// Plain-text formats keep the fields that have no syntax of their own in
+// key:value tags, as todo.txt does.
+const (
+	tagExternalID = "id"
+	tagDue        = "due"
+	tagStatus     = "status"
+)
+
+const tagDate = "2006-01-02"
+
+// formatTags returns the tags of the fields a plain-text format cannot express
+// otherwise. The status tag is only needed for in-progress todos, because the
+// formats mark done todos themselves.
+func formatTags(t *todo.Todo) []string {
+	var tags []string
+	if id := exportExternalID(t); id != "" {
+		tags = append(tags, tagExternalID+":"+url.PathEscape(id))
+	}
+	if t.Status == todo.InProcess {
+		tags = append(tags, tagStatus+":"+t.Status.String())
+	}
+	if t.DueAt != nil {
+		tags = append(tags, tagDue+":"+formatDueTag(*t.DueAt))
+	}
+	return tags
+}
+
+// formatDueTag writes a due date at midnight UTC as a plain date, which is
+// what todo.txt clients expect, and any other time in full.
+func formatDueTag(t time.Time) string {
+	t = t.UTC()
+	if t.Equal(t.Truncate(24 * time.Hour)) {
+		return t.Format(tagDate)
+	}
+	return t.Format(time.RFC3339)
+}
+
+// applyTag sets the row field of a known tag. It reports false for other
+// key:value words, which are left in the task text.
+func applyTag(row *todo.ImportRow, word string) (bool, *todo.ImportRowError) {
+	key, value, ok := strings.Cut(word, ":")
+	if !ok || value == "" {
+		return false, nil
+	}
+
+	rowErr := func(field string, format string, args ...any) *todo.ImportRowError {
+		return &todo.ImportRowError{Line: row.Line, Field: field, Message: fmt.Sprintf(format, args...)}
+	}
+
+	switch key {
+	case tagExternalID:
+		id, err := url.PathUnescape(value)
+		if err != nil {
+			return true, rowErr("external_id", "invalid id %q", value)
+		}
+		row.ExternalID = &id
+	case tagDue:
+		due, err := parseDueTag(value)
+		if err != nil {
+			return true, rowErr("due", "invalid date %q", value)
+		}
+		row.DueAt = &due
+	case tagStatus:
+		status, err := todo.ParseTodoStatus(value)
+		if err != nil {
+			return true, rowErr("status", "unsupported status %q", value)
+		}
+		if row.Status != todo.Done {
+			row.Status = status
+		}
+	default:
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func parseDueTag(s string) (time.Time, error) {
+	if t, err := time.Parse(tagDate, s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
@@ -0,0 +1,113 @@
+package interchange
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+// maxJSONLineBytes leaves room for the largest description the todos table
+// accepts plus the other fields.
+const maxJSONLineBytes = 1 << 20
+
+type jsonLine struct {
+	ExternalID  *string         `json:"external_id"`
+	Task        string          `json:"task"`
+	Description *string         `json:"description"`
+	Status      json.RawMessage `json:"status"`
+	CreatedAt   string          `json:"created_at,omitempty"`
+	UpdatedAt   string          `json:"updated_at,omitempty"`
+}
+
+type jsonLinesCodec struct{}
+
+func NewJSONLinesCodec() gateway.TodoCodec {
+	return &jsonLinesCodec{}
+}
+
+func (c *jsonLinesCodec) Encode(w io.Writer, todos []*todo.Todo) error {
+	enc := json.NewEncoder(w)
+	for _, t := range todos {
+		status, err := json.Marshal(t.Status.String())
+		if err != nil {
+			return fmt.Errorf("marshal status: %w", err)
+		}
+
+		if err := enc.Encode(jsonLine{
+			ExternalID:  optionalString(exportExternalID(t)),
+			Task:        t.Task,
+			Description: t.Description,
+			Status:      status,
+			CreatedAt:   formatTime(t.CreatedAt),
+			UpdatedAt:   formatTime(t.UpdatedAt),
+		}); err != nil {
+			return fmt.Errorf("write json line: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (c *jsonLinesCodec) Decode(r io.Reader) ([]*todo.ImportRow, []todo.ImportRowError, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxJSONLineBytes)
+
+	var (
+		rows      []*todo.ImportRow
+		rowErrors []todo.ImportRowError
+	)
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		var l jsonLine
+		if err := json.Unmarshal(data, &l); err != nil {
+			rowErrors = append(rowErrors, todo.ImportRowError{Line: line, Field: "", Message: err.Error()})
+			continue
+		}
+
+		status, err := parseJSONStatus(l.Status)
+		if err != nil {
+			rowErrors = append(rowErrors, todo.ImportRowError{Line: line, Field: "status", Message: err.Error()})
+			continue
+		}
+
+		rows = append(rows, &todo.ImportRow{
+			Line:        line,
+			ExternalID:  l.ExternalID,
+			Task:        l.Task,
+			Description: l.Description,
+			Status:      status,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("read json lines: %w", err)
+	}
+
+	return rows, rowErrors, nil
+}
+
+// parseJSONStatus accepts the status as a name or as its numeric value.
+func parseJSONStatus(raw json.RawMessage) (todo.TodoStatus, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return todo.Pending, nil
+	}
+
+	var name string
+	if err := json.Unmarshal(raw, &name); err == nil {
+		return todo.ParseTodoStatus(name)
+	}
+
+	var n int32
+	if err := json.Unmarshal(raw, &n); err != nil {
+		return 0, fmt.Errorf("status must be a name or a number")
+	}
+	return todo.TodoStatus(n), nil
+}
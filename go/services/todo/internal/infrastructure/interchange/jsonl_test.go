@@ -0,0 +1,96 @@
+package interchange_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/phamquanandpad/training-project/go/pkg/cast"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/interchange"
+)
+
+func Test_jsonLinesCodec_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	todos := []*todo.Todo{
+		{
+			ID:          1,
+			UserID:      1,
+			Task:        "todo task 1",
+			Description: cast.Ptr("todo description 1"),
+			Status:      todo.InProcess,
+			CreatedAt:   createdAt,
+			UpdatedAt:   createdAt,
+		},
+		{
+			ID:         2,
+			UserID:     1,
+			ExternalID: cast.Ptr("ext-2"),
+			Task:       "todo task 2",
+			Status:     todo.Done,
+			CreatedAt:  createdAt,
+			UpdatedAt:  createdAt,
+		},
+	}
+
+	codec := interchange.NewJSONLinesCodec()
+
+	var buf bytes.Buffer
+	if err := codec.Encode(&buf, todos); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	rows, rowErrors, err := codec.Decode(&buf)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	expected := []*todo.ImportRow{
+		{Line: 1, ExternalID: cast.Ptr("todo:1"), Task: "todo task 1", Description: cast.Ptr("todo description 1"), Status: todo.InProcess},
+		{Line: 2, ExternalID: cast.Ptr("ext-2"), Task: "todo task 2", Status: todo.Done},
+	}
+
+	if diff := cmp.Diff(rows, expected); diff != "" {
+		t.Fatalf("rows mismatch (-actual +expected):\n%s", diff)
+	}
+
+	if len(rowErrors) != 0 {
+		t.Fatalf("unexpected row errors: %v", rowErrors)
+	}
+}
+
+func Test_jsonLinesCodec_Decode(t *testing.T) {
+	t.Parallel()
+
+	input := `{"task":"todo task 1","status":2}
+
+{"task":"todo task 2","status":"archived"}
+not json
+{"task":"todo task 3"}
+`
+
+	rows, rowErrors, err := interchange.NewJSONLinesCodec().Decode(bytes.NewBufferString(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	expectedRows := []*todo.ImportRow{
+		{Line: 1, Task: "todo task 1", Status: todo.Done},
+		{Line: 5, Task: "todo task 3", Status: todo.Pending},
+	}
+	if diff := cmp.Diff(rows, expectedRows); diff != "" {
+		t.Fatalf("rows mismatch (-actual +expected):\n%s", diff)
+	}
+
+	lines := make([]int, 0, len(rowErrors))
+	for _, e := range rowErrors {
+		lines = append(lines, e.Line)
+	}
+	if diff := cmp.Diff(lines, []int{3, 4}); diff != "" {
+		t.Fatalf("row error lines mismatch (-actual +expected):\n%s", diff)
+	}
+}
@@ -0,0 +1,155 @@
+package interchange
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+const (
+	markdownIndent        = "  "
+	markdownCommentPrefix = "<!--"
+	markdownCommentSuffix = "-->"
+)
+
+var markdownChecklistItem = regexp.MustCompile(`^[-*+] \[([ xX])\] (.*)$`)
+
+type markdownCodec struct{}
+
+// NewMarkdownCodec returns a codec for GitHub-style Markdown checklists. Every
+// top-level item is a todo, checked when the todo is done:
+//
+//   - [x] Buy milk <!-- id:12 due:2026-01-03 -->
+//     The description, indented under the item.
+//   - [ ] Nested items are subtasks, kept in the description.
+//
+// The external ID, the due date and the in-progress status are written as
+// tags in an HTML comment, which GitHub does not render. Lines outside of the
+// checklist, such as headings, are ignored on import.
+func NewMarkdownCodec() gateway.TodoCodec {
+	return &markdownCodec{}
+}
+
+func (c *markdownCodec) Encode(w io.Writer, todos []*todo.Todo) error {
+	bw := bufio.NewWriter(w)
+	for _, t := range todos {
+		check := " "
+		if t.Status == todo.Done {
+			check = "x"
+		}
+
+		item := fmt.Sprintf("- [%s] %s", check, strings.Join(strings.Fields(t.Task), " "))
+		if tags := formatTags(t); len(tags) > 0 {
+			item += " " + markdownCommentPrefix + " " + strings.Join(tags, " ") + " " + markdownCommentSuffix
+		}
+		lines := []string{item}
+
+		if t.Description != nil && *t.Description != "" {
+			for _, l := range strings.Split(strings.ReplaceAll(*t.Description, "\r\n", "\n"), "\n") {
+				if l != "" {
+					l = markdownIndent + l
+				}
+				lines = append(lines, l)
+			}
+		}
+
+		if _, err := bw.WriteString(strings.Join(lines, "\n") + "\n"); err != nil {
+			return fmt.Errorf("write markdown item: %w", err)
+		}
+	}
+
+	return bw.Flush()
+}
+
+func (c *markdownCodec) Decode(r io.Reader) ([]*todo.ImportRow, []todo.ImportRowError, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), todo.DescriptionMaxBytes+1)
+
+	var (
+		rows        []*todo.ImportRow
+		rowErrors   []todo.ImportRowError
+		row         *todo.ImportRow
+		description []string
+		blanks      int
+	)
+	flush := func() {
+		if row == nil {
+			return
+		}
+		row.Description = optionalString(strings.Join(description, "\n"))
+		rows = append(rows, row)
+		row, description = nil, nil
+	}
+
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSuffix(scanner.Text(), "\r")
+
+		switch {
+		case strings.TrimSpace(text) == "":
+			// Kept only when more of the description follows.
+			blanks++
+		case text[0] == ' ' || text[0] == '\t':
+			if row == nil {
+				continue
+			}
+			for ; blanks > 0; blanks-- {
+				description = append(description, "")
+			}
+			description = append(description, dedentMarkdown(text))
+		default:
+			flush()
+			blanks = 0
+			m := markdownChecklistItem.FindStringSubmatch(strings.TrimRight(text, " \t"))
+			if m == nil {
+				continue
+			}
+			// The lines under an item with errors are skipped with it.
+			var rowErr *todo.ImportRowError
+			row, rowErr = parseMarkdownItem(line, m[1], m[2])
+			if rowErr != nil {
+				rowErrors = append(rowErrors, *rowErr)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("read markdown: %w", err)
+	}
+	flush()
+
+	return rows, rowErrors, nil
+}
+
+func parseMarkdownItem(line int, check string, text string) (*todo.ImportRow, *todo.ImportRowError) {
+	row := &todo.ImportRow{Line: line, Status: todo.Pending}
+	if check != " " {
+		row.Status = todo.Done
+	}
+
+	if strings.HasSuffix(text, markdownCommentSuffix) {
+		if i := strings.LastIndex(text, markdownCommentPrefix); i >= 0 {
+			comment := text[i+len(markdownCommentPrefix) : len(text)-len(markdownCommentSuffix)]
+			text = strings.TrimSpace(text[:i])
+			for _, word := range strings.Fields(comment) {
+				if _, rowErr := applyTag(row, word); rowErr != nil {
+					return nil, rowErr
+				}
+			}
+		}
+	}
+	row.Task = text
+
+	return row, nil
+}
+
+// dedentMarkdown removes the indentation that places a line under its item.
+func dedentMarkdown(s string) string {
+	if rest, ok := strings.CutPrefix(s, markdownIndent); ok {
+		return rest
+	}
+	return s[1:]
+}
@@ -0,0 +1,142 @@
+package interchange_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/phamquanandpad/training-project/go/pkg/cast"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/interchange"
+)
+
+func Test_markdownCodec_Encode(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	todos := []*todo.Todo{
+		{
+			ID:          1,
+			UserID:      1,
+			Task:        "Plan trip",
+			Description: cast.Ptr("Before March\n\n- [x] Book flights\n- [ ] Book hotel"),
+			Status:      todo.InProcess,
+			DueAt:       cast.Ptr(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)),
+			CreatedAt:   createdAt,
+			UpdatedAt:   createdAt,
+		},
+		{
+			ID:         2,
+			UserID:     1,
+			ExternalID: cast.Ptr("ext-2"),
+			Task:       "Buy milk",
+			Status:     todo.Done,
+			CreatedAt:  createdAt,
+			UpdatedAt:  createdAt,
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := interchange.NewMarkdownCodec().Encode(&buf, todos); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	expected := "- [ ] Plan trip <!-- id:todo:1 status:in_progress due:2026-03-01 -->\n" +
+		"  Before March\n" +
+		"\n" +
+		"  - [x] Book flights\n" +
+		"  - [ ] Book hotel\n" +
+		"- [x] Buy milk <!-- id:ext-2 -->\n"
+
+	if diff := cmp.Diff(buf.String(), expected); diff != "" {
+		t.Fatalf("mismatch (-actual +expected):\n%s", diff)
+	}
+}
+
+func Test_markdownCodec_Decode(t *testing.T) {
+	type expected struct {
+		rows      []*todo.ImportRow
+		rowErrors []todo.ImportRowError
+	}
+
+	type testcase struct {
+		input    string
+		expected expected
+	}
+
+	t.Parallel()
+
+	testTables := map[string]testcase{
+		"Decode top-level items with nested subtasks": {
+			input: "# Trip\n" +
+				"\n" +
+				"- [ ] Plan trip <!-- id:1 status:in_progress due:2026-03-01 -->\n" +
+				"  Before March\n" +
+				"\n" +
+				"  - [X] Book flights\n" +
+				"\t- [ ] Book hotel\n" +
+				"\n" +
+				"Some notes that are not a todo.\n" +
+				"  - [ ] indented under a paragraph\n" +
+				"* [x] Buy milk\n" +
+				"- Not a checklist item\n",
+			expected: expected{
+				rows: []*todo.ImportRow{
+					{
+						Line:        3,
+						ExternalID:  cast.Ptr("1"),
+						Task:        "Plan trip",
+						Description: cast.Ptr("Before March\n\n- [X] Book flights\n- [ ] Book hotel"),
+						Status:      todo.InProcess,
+						DueAt:       cast.Ptr(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)),
+					},
+					{
+						Line:   11,
+						Task:   "Buy milk",
+						Status: todo.Done,
+					},
+				},
+			},
+		},
+		"Report items with invalid tags and skip their subtasks": {
+			input: "- [ ] Pay rent <!-- due:tomorrow -->\n" +
+				"  - [ ] Find the bank details\n" +
+				"- [ ] Water plants\n",
+			expected: expected{
+				rows: []*todo.ImportRow{
+					{
+						Line:   3,
+						Task:   "Water plants",
+						Status: todo.Pending,
+					},
+				},
+				rowErrors: []todo.ImportRowError{
+					{Line: 1, Field: "due", Message: `invalid date "tomorrow"`},
+				},
+			},
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			rows, rowErrors, err := interchange.NewMarkdownCodec().Decode(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+
+			if diff := cmp.Diff(rows, tt.expected.rows); diff != "" {
+				t.Fatalf("rows mismatch (-actual +expected):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(rowErrors, tt.expected.rowErrors); diff != "" {
+				t.Fatalf("row errors mismatch (-actual +expected):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package interchange
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+// todoTxtPriorityKey tags the priority of a todo in its task, because todos
+// have no priority of their own. It is the tag todo.txt clients use for the
+// priority of completed tasks.
+const todoTxtPriorityKey = "pri"
+
+var (
+	todoTxtPriority    = regexp.MustCompile(`^\([A-Z]\)$`)
+	todoTxtPriorityTag = regexp.MustCompile(`^` + todoTxtPriorityKey + `:[A-Z]$`)
+)
+
+type todoTxtCodec struct{}
+
+// NewTodoTxtCodec returns a codec for the todo.txt format
+// (https://github.com/todotxt/todo.txt). Every todo is one line:
+//
+//	x 2026-01-02 2026-01-01 Buy milk +home @shop id:12 due:2026-01-03
+//
+// +project and @context words are part of the task, as the format intends.
+// The external ID, the due date and the in-progress status are written as
+// tags. The completion date, which the format only allows before a creation
+// date, is written when the todo has both. Both dates are decoded, but an
+// import keeps the timestamps the service assigns. The description has no
+// place in the format and is neither exported nor changed by an import.
+func NewTodoTxtCodec() gateway.TodoCodec {
+	return &todoTxtCodec{}
+}
+
+func (c *todoTxtCodec) Encode(w io.Writer, todos []*todo.Todo) error {
+	bw := bufio.NewWriter(w)
+	for _, t := range todos {
+		var words []string
+		task, priority := splitTodoTxtPriority(t.Task)
+
+		// Todos converted offline by cmd/todofile may have no timestamps.
+		hasCreatedAt := !t.CreatedAt.IsZero()
+		switch {
+		case t.Status == todo.Done:
+			words = append(words, "x")
+			if hasCreatedAt && t.CompletedAt != nil {
+				words = append(words, t.CompletedAt.UTC().Format(tagDate))
+			} else {
+				// A lone date after x would be read as the completion date.
+				hasCreatedAt = false
+			}
+			if priority != "" {
+				task = append(task, todoTxtPriorityKey+":"+priority)
+			}
+		case priority != "":
+			words = append(words, "("+priority+")")
+		}
+		if hasCreatedAt {
+			words = append(words, t.CreatedAt.UTC().Format(tagDate))
+		}
+		words = append(words, task...)
+		words = append(words, formatTags(t)...)
+
+		if _, err := bw.WriteString(strings.Join(words, " ") + "\n"); err != nil {
+			return fmt.Errorf("write todo.txt line: %w", err)
+		}
+	}
+
+	return bw.Flush()
+}
+
+func (c *todoTxtCodec) Decode(r io.Reader) ([]*todo.ImportRow, []todo.ImportRowError, error) {
+	scanner := bufio.NewScanner(r)
+
+	var (
+		rows      []*todo.ImportRow
+		rowErrors []todo.ImportRowError
+	)
+	for line := 1; scanner.Scan(); line++ {
+		words := strings.Fields(scanner.Text())
+		if len(words) == 0 {
+			continue
+		}
+
+		row, rowErr := parseTodoTxtLine(line, words)
+		if rowErr != nil {
+			rowErrors = append(rowErrors, *rowErr)
+			continue
+		}
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("read todo.txt: %w", err)
+	}
+
+	return rows, rowErrors, nil
+}
+
+func parseTodoTxtLine(line int, words []string) (*todo.ImportRow, *todo.ImportRowError) {
+	row := &todo.ImportRow{Line: line, Status: todo.Pending}
+
+	var priority string
+	switch {
+	case words[0] == "x":
+		row.Status = todo.Done
+		// The completion date, then the creation date.
+		row.CompletedAt, words = parseTodoTxtDate(words[1:])
+		if row.CompletedAt != nil {
+			row.CreatedAt, words = parseTodoTxtDate(words)
+		}
+	case todoTxtPriority.MatchString(words[0]):
+		priority = words[0][1:2]
+		row.CreatedAt, words = parseTodoTxtDate(words[1:])
+	default:
+		row.CreatedAt, words = parseTodoTxtDate(words)
+	}
+
+	task := make([]string, 0, len(words)+1)
+	for _, word := range words {
+		applied, rowErr := applyTag(row, word)
+		if rowErr != nil {
+			return nil, rowErr
+		}
+		if !applied {
+			task = append(task, word)
+		}
+	}
+
+	row.Task = strings.Join(task, " ")
+	if _, tagged := splitTodoTxtPriority(row.Task); priority != "" && tagged == "" {
+		row.Task += " " + todoTxtPriorityKey + ":" + priority
+	}
+
+	return row, nil
+}
+
+// splitTodoTxtPriority removes the pri: tag from the words of a task and
+// returns the priority it held.
+func splitTodoTxtPriority(task string) ([]string, string) {
+	var (
+		words    []string
+		priority string
+	)
+	for _, word := range strings.Fields(task) {
+		if priority == "" && todoTxtPriorityTag.MatchString(word) {
+			priority = word[len(todoTxtPriorityKey)+1:]
+			continue
+		}
+		words = append(words, word)
+	}
+	return words, priority
+}
+
+// parseTodoTxtDate parses the date the words start with, if any, and returns
+// the words after it.
+func parseTodoTxtDate(words []string) (*time.Time, []string) {
+	if len(words) == 0 {
+		return nil, words
+	}
+	date, err := time.Parse(tagDate, words[0])
+	if err != nil {
+		return nil, words
+	}
+	return &date, words[1:]
+}
@@ -0,0 +1,191 @@
+package interchange_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/phamquanandpad/training-project/go/pkg/cast"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/interchange"
+)
+
+func Test_todoTxtCodec_Encode(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	completedAt := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	updatedAt := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	todos := []*todo.Todo{
+		{
+			ID:          1,
+			UserID:      1,
+			Task:        "Call mom +family @phone pri:A",
+			Description: cast.Ptr("not exported"),
+			Status:      todo.Pending,
+			DueAt:       cast.Ptr(time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC)),
+			CreatedAt:   createdAt,
+			UpdatedAt:   updatedAt,
+		},
+		{
+			ID:          2,
+			UserID:      1,
+			ExternalID:  cast.Ptr("ext 2"),
+			Task:        "Write\nreport pri:B",
+			Status:      todo.Done,
+			CompletedAt: &completedAt,
+			CreatedAt:   createdAt,
+			UpdatedAt:   updatedAt,
+		},
+		{
+			ID:        4,
+			UserID:    1,
+			Task:      "Pay rent",
+			Status:    todo.Done,
+			CreatedAt: createdAt,
+			UpdatedAt: updatedAt,
+		},
+		{
+			ID:        3,
+			UserID:    1,
+			Task:      "Fix bike",
+			Status:    todo.InProcess,
+			DueAt:     cast.Ptr(time.Date(2026, 1, 3, 9, 30, 0, 0, time.UTC)),
+			CreatedAt: createdAt,
+			UpdatedAt: updatedAt,
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := interchange.NewTodoTxtCodec().Encode(&buf, todos); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	expected := "(A) 2026-01-01 Call mom +family @phone id:todo:1 due:2026-01-03\n" +
+		"x 2026-01-02 2026-01-01 Write report pri:B id:ext%202\n" +
+		"x Pay rent id:todo:4\n" +
+		"2026-01-01 Fix bike id:todo:3 status:in_progress due:2026-01-03T09:30:00Z\n"
+
+	if diff := cmp.Diff(buf.String(), expected); diff != "" {
+		t.Fatalf("mismatch (-actual +expected):\n%s", diff)
+	}
+}
+
+func Test_todoTxtCodec_Decode(t *testing.T) {
+	type expected struct {
+		rows      []*todo.ImportRow
+		rowErrors []todo.ImportRowError
+	}
+
+	type testcase struct {
+		input    string
+		expected expected
+	}
+
+	t.Parallel()
+
+	testTables := map[string]testcase{
+		"Decode priorities, dates and tags": {
+			input: "(A) 2026-01-01 Call mom +family @phone id:1 due:2026-01-03\n" +
+				"\n" +
+				"x 2026-01-02 2026-01-01 Write report pri:B id:ext%202\n" +
+				"Fix bike status:in_progress due:2026-01-03T09:30:00Z see:http://example.com\n" +
+				"x (C) Not a priority\n",
+			expected: expected{
+				rows: []*todo.ImportRow{
+					{
+						Line:       1,
+						ExternalID: cast.Ptr("1"),
+						Task:       "Call mom +family @phone pri:A",
+						Status:     todo.Pending,
+						DueAt:      cast.Ptr(time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC)),
+						CreatedAt:  cast.Ptr(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)),
+					},
+					{
+						Line:        3,
+						ExternalID:  cast.Ptr("ext 2"),
+						Task:        "Write report pri:B",
+						Status:      todo.Done,
+						CreatedAt:   cast.Ptr(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)),
+						CompletedAt: cast.Ptr(time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)),
+					},
+					{
+						Line:   4,
+						Task:   "Fix bike see:http://example.com",
+						Status: todo.InProcess,
+						DueAt:  cast.Ptr(time.Date(2026, 1, 3, 9, 30, 0, 0, time.UTC)),
+					},
+					{
+						Line:   5,
+						Task:   "(C) Not a priority",
+						Status: todo.Done,
+					},
+				},
+			},
+		},
+		"Report invalid tags": {
+			input: "Pay rent due:tomorrow\n" +
+				"Water plants status:someday\n",
+			expected: expected{
+				rowErrors: []todo.ImportRowError{
+					{Line: 1, Field: "due", Message: `invalid date "tomorrow"`},
+					{Line: 2, Field: "status", Message: `unsupported status "someday"`},
+				},
+			},
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			rows, rowErrors, err := interchange.NewTodoTxtCodec().Decode(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+
+			if diff := cmp.Diff(rows, tt.expected.rows); diff != "" {
+				t.Fatalf("rows mismatch (-actual +expected):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(rowErrors, tt.expected.rowErrors); diff != "" {
+				t.Fatalf("row errors mismatch (-actual +expected):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_todoTxtCodec_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	codec := interchange.NewTodoTxtCodec()
+	input := "x 2026-01-02 2026-01-01 Write report id:todo:2\n" +
+		"(A) 2026-01-03 Call mom id:todo:1\n"
+
+	rows, rowErrors, err := codec.Decode(strings.NewReader(input))
+	if err != nil || len(rowErrors) > 0 {
+		t.Fatalf("Decode() error = %v, row errors = %v", err, rowErrors)
+	}
+
+	todos := make([]*todo.Todo, 0, len(rows))
+	for _, row := range rows {
+		id, _ := todo.ParseExportedID(*row.ExternalID)
+		converted := &todo.Todo{ID: id, Task: row.Task, Status: row.Status, CompletedAt: row.CompletedAt}
+		if row.CreatedAt != nil {
+			converted.CreatedAt = *row.CreatedAt
+		}
+		todos = append(todos, converted)
+	}
+
+	var buf bytes.Buffer
+	if err := codec.Encode(&buf, todos); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if diff := cmp.Diff(buf.String(), input); diff != "" {
+		t.Fatalf("mismatch (-actual +expected):\n%s", diff)
+	}
+}
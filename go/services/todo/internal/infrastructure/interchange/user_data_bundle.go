@@ -0,0 +1,276 @@
+package interchange
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+// userDataBundleVersion is bumped when the files of the bundle change.
+const userDataBundleVersion = 1
+
+type bundleManifest struct {
+	Version    int      `json:"version"`
+	UserID     int64    `json:"user_id"`
+	ExportedAt string   `json:"exported_at"`
+	Files      []string `json:"files"`
+}
+
+type bundleUser struct {
+	ID        int64   `json:"id"`
+	Username  string  `json:"username"`
+	Email     *string `json:"email"`
+	CreatedAt string  `json:"created_at"`
+	UpdatedAt string  `json:"updated_at"`
+	DeletedAt *string `json:"deleted_at"`
+}
+
+type bundleTodo struct {
+	ID          int64   `json:"id"`
+	ExternalID  *string `json:"external_id"`
+	Task        string  `json:"task"`
+	Description *string `json:"description"`
+	Status      string  `json:"status"`
+	Position    string  `json:"position"`
+	DueAt       *string `json:"due_at"`
+	CompletedAt *string `json:"completed_at"`
+	CreatedAt   string  `json:"created_at"`
+	UpdatedAt   string  `json:"updated_at"`
+	DeletedAt   *string `json:"deleted_at"`
+}
+
+type userDataBundle struct {
+	now func() time.Time
+}
+
+// NewUserDataBundle writes the data of a user as a zip archive of JSON files:
+// manifest.json, user.json, todos.json, with the deleted todos, and
+// events.json, the recorded changes with their payloads as stored. The todos
+// and events are written to the archive page by page, as they are read.
+func NewUserDataBundle() gateway.UserDataEncoder {
+	return &userDataBundle{now: time.Now}
+}
+
+// bundleFiles are the files of the bundle after the manifest, in order.
+var bundleFiles = []string{"user.json", "todos.json", "events.json"}
+
+// bundleArrays are the files of bundleFiles written page by page.
+var bundleArrays = []string{"todos.json", "events.json"}
+
+func (b *userDataBundle) NewWriter(w io.Writer) gateway.UserDataWriter {
+	return &bundleWriter{
+		zw:         zip.NewWriter(w),
+		exportedAt: b.now().UTC(),
+	}
+}
+
+// bundleWriter writes the files of the bundle in order. todos.json and
+// events.json are JSON arrays written an element at a time, so that only a
+// page of them is held at once.
+type bundleWriter struct {
+	zw          *zip.Writer
+	exportedAt  time.Time
+	userWritten bool
+
+	// next is the index in bundleArrays of the next array to open.
+	next int
+	// array is the file of the array being written, nil between arrays.
+	array    io.Writer
+	elements int
+}
+
+func (b *bundleWriter) WriteUser(user *todo.User) error {
+	if b.userWritten {
+		return errors.New("user.json already written")
+	}
+	b.userWritten = true
+
+	manifest := bundleManifest{
+		Version:    userDataBundleVersion,
+		UserID:     user.ID.Int64(),
+		ExportedAt: formatTime(b.exportedAt),
+		Files:      bundleFiles,
+	}
+	if err := writeBundleFile(b.zw, "manifest.json", manifest, b.exportedAt); err != nil {
+		return err
+	}
+
+	return writeBundleFile(b.zw, "user.json", bundleUser{
+		ID:        user.ID.Int64(),
+		Username:  user.Username,
+		Email:     user.Email,
+		CreatedAt: formatTime(user.CreatedAt),
+		UpdatedAt: formatTime(user.UpdatedAt),
+		DeletedAt: formatOptionalTime(user.DeletedAt),
+	}, b.exportedAt)
+}
+
+func (b *bundleWriter) WriteTodos(todos []*todo.Todo) error {
+	if err := b.openArray("todos.json"); err != nil {
+		return err
+	}
+	for _, t := range todos {
+		err := b.writeElement(bundleTodo{
+			ID:          t.ID.Int64(),
+			ExternalID:  t.ExternalID,
+			Task:        t.Task,
+			Description: t.Description,
+			Status:      t.Status.String(),
+			Position:    t.Position,
+			DueAt:       formatOptionalTime(t.DueAt),
+			CompletedAt: formatOptionalTime(t.CompletedAt),
+			CreatedAt:   formatTime(t.CreatedAt),
+			UpdatedAt:   formatTime(t.UpdatedAt),
+			DeletedAt:   formatOptionalTime(t.DeletedAt),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (b *bundleWriter) WriteEvents(events []*todo.Event) error {
+	if err := b.openArray("events.json"); err != nil {
+		return err
+	}
+	for _, e := range events {
+		if err := b.writeElement(e); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Close writes the files left as empty arrays and ends the archive.
+func (b *bundleWriter) Close() error {
+	if !b.userWritten {
+		return nil
+	}
+
+	if err := b.openArray(bundleArrays[len(bundleArrays)-1]); err != nil {
+		return err
+	}
+	if err := b.closeArray(); err != nil {
+		return err
+	}
+	if err := b.zw.Close(); err != nil {
+		return fmt.Errorf("close bundle: %w", err)
+	}
+
+	return nil
+}
+
+// openArray makes name the array being written, opening the arrays before it
+// that were not written as empty ones.
+func (b *bundleWriter) openArray(name string) error {
+	if !b.userWritten {
+		return fmt.Errorf("%s written before user.json", name)
+	}
+	if b.array != nil && bundleArrays[b.next-1] == name {
+		return nil
+	}
+
+	for b.next < len(bundleArrays) {
+		if err := b.closeArray(); err != nil {
+			return err
+		}
+
+		next := bundleArrays[b.next]
+		f, err := b.zw.CreateHeader(&zip.FileHeader{
+			Name:     next,
+			Method:   zip.Deflate,
+			Modified: b.exportedAt,
+		})
+		if err != nil {
+			return fmt.Errorf("create %s: %w", next, err)
+		}
+		if _, err := io.WriteString(f, "["); err != nil {
+			return fmt.Errorf("write %s: %w", next, err)
+		}
+		b.next++
+		b.array = f
+		b.elements = 0
+
+		if next == name {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s written out of order", name)
+}
+
+// writeElement writes v to the array being written, indented as the other
+// files of the bundle.
+func (b *bundleWriter) writeElement(v any) error {
+	content, err := json.MarshalIndent(v, "  ", "  ")
+	if err != nil {
+		return fmt.Errorf("write %s: %w", bundleArrays[b.next-1], err)
+	}
+
+	sep := ",\n  "
+	if b.elements == 0 {
+		sep = "\n  "
+	}
+	if _, err := io.WriteString(b.array, sep); err != nil {
+		return fmt.Errorf("write %s: %w", bundleArrays[b.next-1], err)
+	}
+	if _, err := b.array.Write(content); err != nil {
+		return fmt.Errorf("write %s: %w", bundleArrays[b.next-1], err)
+	}
+	b.elements++
+
+	return nil
+}
+
+func (b *bundleWriter) closeArray() error {
+	if b.array == nil {
+		return nil
+	}
+
+	end := "]\n"
+	if b.elements > 0 {
+		end = "\n]\n"
+	}
+	if _, err := io.WriteString(b.array, end); err != nil {
+		return fmt.Errorf("write %s: %w", bundleArrays[b.next-1], err)
+	}
+	b.array = nil
+
+	return nil
+}
+
+func writeBundleFile(zw *zip.Writer, name string, content any, modified time.Time) error {
+	f, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: modified,
+	})
+	if err != nil {
+		return fmt.Errorf("create %s: %w", name, err)
+	}
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(content); err != nil {
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+
+	return nil
+}
+
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := formatTime(*t)
+	return &s
+}
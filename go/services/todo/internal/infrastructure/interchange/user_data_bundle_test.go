@@ -0,0 +1,167 @@
+package interchange_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/phamquanandpad/training-project/go/pkg/cast"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/interchange"
+)
+
+func Test_userDataBundle_NewWriter(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	deletedAt := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	data := &todo.UserData{
+		User: &todo.User{
+			ID:        1,
+			Username:  "user1",
+			Email:     cast.Ptr("user1@example.com"),
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+		},
+		Todos: []*todo.Todo{
+			{ID: 1, UserID: 1, Task: "todo task 1", Status: todo.Done, Position: "i", CreatedAt: createdAt, UpdatedAt: createdAt},
+			{ID: 2, UserID: 1, Task: "todo task 2", CreatedAt: createdAt, UpdatedAt: deletedAt, DeletedAt: &deletedAt},
+		},
+		Events: []*todo.Event{
+			{
+				ID:            1,
+				AggregateType: todo.AggregateTypes.User,
+				AggregateID:   1,
+				UserID:        1,
+				EventType:     todo.EventTypes.UserCreated,
+				Payload:       json.RawMessage(`{"id":1}`),
+				OccurredAt:    createdAt,
+			},
+		},
+	}
+
+	// The todos are written a page at a time.
+	var buf bytes.Buffer
+	w := interchange.NewUserDataBundle().NewWriter(&buf)
+	if err := w.WriteUser(data.User); err != nil {
+		t.Fatalf("WriteUser() error = %v", err)
+	}
+	for _, page := range [][]*todo.Todo{data.Todos[:1], data.Todos[1:]} {
+		if err := w.WriteTodos(page); err != nil {
+			t.Fatalf("WriteTodos() error = %v", err)
+		}
+	}
+	if err := w.WriteEvents(data.Events); err != nil {
+		t.Fatalf("WriteEvents() error = %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	names, files := readBundle(t, buf.Bytes())
+
+	if diff := cmp.Diff(names, []string{"manifest.json", "user.json", "todos.json", "events.json"}); diff != "" {
+		t.Fatalf("files mismatch (-actual +expected):\n%s", diff)
+	}
+
+	expectedTodos := []any{
+		map[string]any{
+			"id": 1.0, "external_id": nil, "task": "todo task 1", "description": nil, "status": "done",
+			"position": "i", "due_at": nil, "completed_at": nil,
+			"created_at": "2026-01-01T00:00:00Z", "updated_at": "2026-01-01T00:00:00Z", "deleted_at": nil,
+		},
+		map[string]any{
+			"id": 2.0, "external_id": nil, "task": "todo task 2", "description": nil, "status": "pending",
+			"position": "", "due_at": nil, "completed_at": nil,
+			"created_at": "2026-01-01T00:00:00Z", "updated_at": "2026-01-02T00:00:00Z", "deleted_at": "2026-01-02T00:00:00Z",
+		},
+	}
+	if diff := cmp.Diff(files["todos.json"], expectedTodos); diff != "" {
+		t.Errorf("todos.json mismatch (-actual +expected):\n%s", diff)
+	}
+
+	expectedUser := map[string]any{
+		"id": 1.0, "username": "user1", "email": "user1@example.com",
+		"created_at": "2026-01-01T00:00:00Z", "updated_at": "2026-01-01T00:00:00Z", "deleted_at": nil,
+	}
+	if diff := cmp.Diff(files["user.json"], expectedUser); diff != "" {
+		t.Errorf("user.json mismatch (-actual +expected):\n%s", diff)
+	}
+
+	events, _ := files["events.json"].([]any)
+	if len(events) != 1 {
+		t.Errorf("events.json has %d events, want 1", len(events))
+	}
+}
+
+func Test_userDataBundle_NewWriter_NoTodos(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	w := interchange.NewUserDataBundle().NewWriter(&buf)
+	if err := w.WriteUser(&todo.User{ID: 1, Username: "user1"}); err != nil {
+		t.Fatalf("WriteUser() error = %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	names, files := readBundle(t, buf.Bytes())
+	if diff := cmp.Diff(names, []string{"manifest.json", "user.json", "todos.json", "events.json"}); diff != "" {
+		t.Fatalf("files mismatch (-actual +expected):\n%s", diff)
+	}
+	for _, name := range []string{"todos.json", "events.json"} {
+		if diff := cmp.Diff(files[name], []any{}); diff != "" {
+			t.Errorf("%s mismatch (-actual +expected):\n%s", name, diff)
+		}
+	}
+}
+
+func Test_userDataBundle_NewWriter_NoUser(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := interchange.NewUserDataBundle().NewWriter(&buf).Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("%d bytes written without a user, want none", buf.Len())
+	}
+}
+
+// readBundle returns the names of the files of the bundle, in order, and their
+// decoded JSON contents.
+func readBundle(t *testing.T, bundle []byte) ([]string, map[string]any) {
+	t.Helper()
+
+	zr, err := zip.NewReader(bytes.NewReader(bundle), int64(len(bundle)))
+	if err != nil {
+		t.Fatalf("zip.NewReader() error = %v", err)
+	}
+	files := map[string]any{}
+	names := make([]string, 0, len(zr.File))
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", f.Name, err)
+		}
+		content, err := io.ReadAll(rc)
+		_ = rc.Close()
+		if err != nil {
+			t.Fatalf("read %s: %v", f.Name, err)
+		}
+		var v any
+		if err := json.Unmarshal(content, &v); err != nil {
+			t.Fatalf("unmarshal %s: %v", f.Name, err)
+		}
+		files[f.Name] = v
+	}
+
+	return names, files
+}
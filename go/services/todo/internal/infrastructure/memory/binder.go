@@ -0,0 +1,30 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+)
+
+// shardName is the name of the single shard of a store.
+const shardName = "memory"
+
+type binder struct{}
+
+// NewConnectionBinder returns a binder for the gateways of this package, which
+// hold their store themselves: the context is returned as is.
+func NewConnectionBinder() gateway.ShardBinder {
+	return &binder{}
+}
+
+func (b binder) Bind(ctx context.Context) context.Context {
+	return ctx
+}
+
+func (b binder) Shards() []string {
+	return []string{shardName}
+}
+
+func (b binder) BindShard(ctx context.Context, _ string) context.Context {
+	return ctx
+}
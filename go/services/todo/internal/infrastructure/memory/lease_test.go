@@ -0,0 +1,19 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway/gatewaytest"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/memory"
+)
+
+func TestLeaseGateways(t *testing.T) {
+	t.Parallel()
+
+	gatewaytest.RunLeaseGatewaysSuite(t, func(t *testing.T) gatewaytest.LeaseGateways {
+		return gatewaytest.LeaseGateways{
+			Binder: memory.NewConnectionBinder(),
+			Writer: memory.NewLeaseWriter(memory.NewStore()),
+		}
+	})
+}
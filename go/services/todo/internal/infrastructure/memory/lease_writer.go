@@ -0,0 +1,83 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+type leaseWriter struct {
+	store *Store
+}
+
+func NewLeaseWriter(store *Store) gateway.LeaseCommandsGateway {
+	return &leaseWriter{store: store}
+}
+
+func (w *leaseWriter) AcquireLease(
+	_ context.Context,
+	name string,
+	holder string,
+	ttl time.Duration,
+) (*todo.Lease, error) {
+	w.store.mu.Lock()
+	defer w.store.mu.Unlock()
+
+	now := time.Now()
+	current, ok := w.store.leases[name]
+	if ok && current.ExpiresAt.After(now) {
+		return nil, nil
+	}
+
+	lease := &todo.Lease{
+		Name:      name,
+		Holder:    holder,
+		Token:     1,
+		ExpiresAt: now.Add(ttl),
+	}
+	if ok {
+		lease.Token = current.Token + 1
+	}
+	w.store.leases[name] = lease
+
+	c := *lease
+	return &c, nil
+}
+
+func (w *leaseWriter) RenewLease(
+	_ context.Context,
+	lease *todo.Lease,
+	ttl time.Duration,
+) (*todo.Lease, error) {
+	w.store.mu.Lock()
+	defer w.store.mu.Unlock()
+
+	now := time.Now()
+	current, ok := w.store.leases[lease.Name]
+	if !ok || !holds(current, lease) || !current.ExpiresAt.After(now) {
+		return nil, todo.ErrLeaseLost
+	}
+	current.ExpiresAt = now.Add(ttl)
+
+	c := *current
+	return &c, nil
+}
+
+func (w *leaseWriter) ReleaseLease(
+	_ context.Context,
+	lease *todo.Lease,
+) error {
+	w.store.mu.Lock()
+	defer w.store.mu.Unlock()
+
+	if current, ok := w.store.leases[lease.Name]; ok && holds(current, lease) {
+		current.ExpiresAt = time.Time{}
+	}
+	return nil
+}
+
+func holds(current, lease *todo.Lease) bool {
+	return current.Holder == lease.Holder && current.Token == lease.Token
+}
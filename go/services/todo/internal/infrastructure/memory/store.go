@@ -0,0 +1,143 @@
+// Package memory implements the gateways in process memory, for use-case tests
+// and local runs. It follows the datastore semantics: todos are soft deleted,
+// every lookup is scoped to its user and missing records are returned as nil.
+package memory
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+// Store holds the records shared by the gateways of this package. It is safe
+// for concurrent use.
+type Store struct {
+	mu sync.RWMutex
+
+	todos      map[todo.TodoID]*todo.Todo
+	users      map[todo.UserID]*todo.User
+	erased     map[todo.UserID]*todo.ErasedUser
+	leases     map[string]*todo.Lease
+	lastTodoID todo.TodoID
+	lastUserID todo.UserID
+}
+
+func NewStore() *Store {
+	return &Store{
+		todos:  map[todo.TodoID]*todo.Todo{},
+		users:  map[todo.UserID]*todo.User{},
+		erased: map[todo.UserID]*todo.ErasedUser{},
+		leases: map[string]*todo.Lease{},
+	}
+}
+
+// SeedTodos stores copies of the todos as they are, IDs and positions
+// included, the way fixtures are loaded into the database.
+func (s *Store) SeedTodos(todos ...*todo.Todo) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, t := range todos {
+		s.todos[t.ID] = cloneTodo(t)
+		if t.ID > s.lastTodoID {
+			s.lastTodoID = t.ID
+		}
+	}
+}
+
+// SeedUsers stores copies of the users as they are.
+func (s *Store) SeedUsers(users ...*todo.User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, u := range users {
+		s.users[u.ID] = cloneUser(u)
+		if u.ID > s.lastUserID {
+			s.lastUserID = u.ID
+		}
+	}
+}
+
+// findUserTodo returns the todo of the user that is not deleted, or nil. The
+// caller holds the lock.
+func (s *Store) findUserTodo(todoID todo.TodoID, userID todo.UserID) *todo.Todo {
+	t, ok := s.todos[todoID]
+	if !ok || t.UserID != userID || t.IsDeleted() {
+		return nil
+	}
+	return t
+}
+
+// userTodos returns the todos of the user that are not deleted, sorted by
+// sortingType. The caller holds the lock.
+func (s *Store) userTodos(userID todo.UserID, sortingType todo.SortingType) []*todo.Todo {
+	less, ok := todoOrders[sortingType]
+	if !ok {
+		less = todoOrders[todo.SortingTypes.CreatedAt]
+	}
+
+	todos := make([]*todo.Todo, 0)
+	for _, t := range s.todos {
+		if t.UserID == userID && !t.IsDeleted() {
+			todos = append(todos, t)
+		}
+	}
+	sort.Slice(todos, func(i, j int) bool {
+		return less(todos[i], todos[j])
+	})
+
+	return todos
+}
+
+// todoOrders mirror the ORDER BY clauses of the datastore. IDs break ties.
+var todoOrders = map[todo.SortingType]func(a, b *todo.Todo) bool{
+	todo.SortingTypes.CreatedAt: func(a, b *todo.Todo) bool {
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+		return a.ID > b.ID
+	},
+	todo.SortingTypes.UpdatedAt: func(a, b *todo.Todo) bool {
+		if !a.UpdatedAt.Equal(b.UpdatedAt) {
+			return a.UpdatedAt.After(b.UpdatedAt)
+		}
+		return a.ID > b.ID
+	},
+	todo.SortingTypes.ID: func(a, b *todo.Todo) bool {
+		return a.ID < b.ID
+	},
+	todo.SortingTypes.Position: positionLess,
+}
+
+func positionLess(a, b *todo.Todo) bool {
+	if a.Position != b.Position {
+		return a.Position < b.Position
+	}
+	return a.ID < b.ID
+}
+
+func cloneTodo(t *todo.Todo) *todo.Todo {
+	c := *t
+	c.ExternalID = clonePtr(t.ExternalID)
+	c.Description = clonePtr(t.Description)
+	c.DueAt = clonePtr(t.DueAt)
+	c.CompletedAt = clonePtr(t.CompletedAt)
+	c.DeletedAt = clonePtr(t.DeletedAt)
+	return &c
+}
+
+func cloneUser(u *todo.User) *todo.User {
+	c := *u
+	c.Email = clonePtr(u.Email)
+	c.DeletedAt = clonePtr(u.DeletedAt)
+	return &c
+}
+
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
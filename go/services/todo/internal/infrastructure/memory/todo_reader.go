@@ -0,0 +1,109 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+type todoReader struct {
+	store *Store
+}
+
+func NewTodoReader(store *Store) gateway.TodoQueriesGateway {
+	return &todoReader{store: store}
+}
+
+func (r *todoReader) GetTodo(
+	_ context.Context,
+	todoID todo.TodoID,
+	userID todo.UserID,
+) (*todo.Todo, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	t := r.store.findUserTodo(todoID, userID)
+	if t == nil {
+		return nil, nil
+	}
+
+	return cloneTodo(t), nil
+}
+
+// ListTodos falls back to the newest todos first for unsupported sorting
+// types.
+func (r *todoReader) ListTodos(
+	_ context.Context,
+	userID todo.UserID,
+	sortingType todo.SortingType,
+) ([]*todo.Todo, int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	todos := r.store.userTodos(userID, sortingType)
+	for i, t := range todos {
+		todos[i] = cloneTodo(t)
+	}
+
+	return todos, len(todos), nil
+}
+
+// ListTodosByExternalIDs also returns soft-deleted todos, since they keep
+// holding their external ID.
+func (r *todoReader) ListTodosByExternalIDs(
+	_ context.Context,
+	userID todo.UserID,
+	externalIDs []string,
+) ([]*todo.Todo, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	wanted := make(map[string]struct{}, len(externalIDs))
+	for _, externalID := range externalIDs {
+		wanted[externalID] = struct{}{}
+	}
+
+	todos := make([]*todo.Todo, 0)
+	for _, t := range r.store.todos {
+		if t.UserID != userID || t.ExternalID == nil {
+			continue
+		}
+		if _, ok := wanted[*t.ExternalID]; ok {
+			todos = append(todos, cloneTodo(t))
+		}
+	}
+	sort.Slice(todos, func(i, j int) bool {
+		return todos[i].ID < todos[j].ID
+	})
+
+	return todos, nil
+}
+
+// ListTodosByIDs also returns soft-deleted todos, like ListTodosByExternalIDs.
+func (r *todoReader) ListTodosByIDs(
+	_ context.Context,
+	userID todo.UserID,
+	todoIDs []todo.TodoID,
+) ([]*todo.Todo, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	wanted := make(map[todo.TodoID]struct{}, len(todoIDs))
+	for _, todoID := range todoIDs {
+		wanted[todoID] = struct{}{}
+	}
+
+	todos := make([]*todo.Todo, 0)
+	for todoID := range wanted {
+		if t, ok := r.store.todos[todoID]; ok && t.UserID == userID {
+			todos = append(todos, cloneTodo(t))
+		}
+	}
+	sort.Slice(todos, func(i, j int) bool {
+		return todos[i].ID < todos[j].ID
+	})
+
+	return todos, nil
+}
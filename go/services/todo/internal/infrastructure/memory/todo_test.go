@@ -0,0 +1,93 @@
+package memory_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway/gatewaytest"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/memory"
+)
+
+func TestTodoGateways(t *testing.T) {
+	t.Parallel()
+
+	gatewaytest.RunTodoGatewaysSuite(t, func(t *testing.T) gatewaytest.TodoGateways {
+		store := memory.NewStore()
+		store.SeedUsers(
+			&todo.User{ID: todo.UserID(1), Username: "user1"},
+			&todo.User{ID: todo.UserID(2), Username: "user2"},
+		)
+
+		return gatewaytest.TodoGateways{
+			Binder:      memory.NewConnectionBinder(),
+			Reader:      memory.NewTodoReader(store),
+			Writer:      memory.NewTodoWriter(store),
+			UserID:      todo.UserID(1),
+			OtherUserID: todo.UserID(2),
+		}
+	})
+}
+
+func Test_todoWriter_CreateTodo_Concurrently(t *testing.T) {
+	t.Parallel()
+
+	store := memory.NewStore()
+	todoWriter := memory.NewTodoWriter(store)
+	todoReader := memory.NewTodoReader(store)
+
+	const n = 50
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := todoWriter.CreateTodo(context.Background(), todo.NewTodo{
+				UserID: todo.UserID(1),
+				Task:   "task",
+			}); err != nil {
+				t.Errorf("todoWriter.CreateTodo() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	todos, total, err := todoReader.ListTodos(context.Background(), todo.UserID(1), todo.SortingTypes.Position)
+	if err != nil {
+		t.Fatalf("todoReader.ListTodos() error = %v", err)
+	}
+	if total != n {
+		t.Errorf("todoReader.ListTodos() total = %d, want %d", total, n)
+	}
+
+	seen := map[string]struct{}{}
+	for _, created := range todos {
+		if _, ok := seen[created.Position]; ok {
+			t.Errorf("todoReader.ListTodos() position %q is used twice", created.Position)
+		}
+		seen[created.Position] = struct{}{}
+	}
+}
+
+func Test_todoReader_GetTodo_ReturnsCopy(t *testing.T) {
+	t.Parallel()
+
+	store := memory.NewStore()
+	store.SeedTodos(&todo.Todo{ID: todo.TodoID(1), UserID: todo.UserID(1), Task: "task", Position: "i"})
+	todoReader := memory.NewTodoReader(store)
+
+	got, err := todoReader.GetTodo(context.Background(), todo.TodoID(1), todo.UserID(1))
+	if err != nil {
+		t.Fatalf("todoReader.GetTodo() error = %v", err)
+	}
+	got.Task = "changed"
+
+	again, err := todoReader.GetTodo(context.Background(), todo.TodoID(1), todo.UserID(1))
+	if err != nil {
+		t.Fatalf("todoReader.GetTodo() error = %v", err)
+	}
+	if again.Task != "task" {
+		t.Errorf("todoReader.GetTodo() task = %q, want the stored todo unchanged", again.Task)
+	}
+}
@@ -0,0 +1,214 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+type todoWriter struct {
+	store *Store
+}
+
+func NewTodoWriter(store *Store) gateway.TodoCommandsGateway {
+	return &todoWriter{store: store}
+}
+
+// CreateTodo fails with todo.ErrDuplicateKey when the user already has a
+// todo, deleted or not, with the same external ID.
+func (w *todoWriter) CreateTodo(
+	_ context.Context,
+	newTodo todo.NewTodo,
+) (*todo.Todo, error) {
+	w.store.mu.Lock()
+	defer w.store.mu.Unlock()
+
+	if newTodo.ExternalID != nil {
+		for _, t := range w.store.todos {
+			if t.UserID == newTodo.UserID && t.ExternalID != nil && *t.ExternalID == *newTodo.ExternalID {
+				return nil, todo.ErrDuplicateKey
+			}
+		}
+	}
+
+	now := time.Now()
+	createdTodo := &todo.Todo{
+		UserID:      newTodo.UserID,
+		ExternalID:  clonePtr(newTodo.ExternalID),
+		Task:        newTodo.Task,
+		Description: clonePtr(newTodo.Description),
+		DueAt:       clonePtr(newTodo.DueAt),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	createdTodo.SetStatus(newTodo.Status, now)
+
+	// New todos go to the end of the manual order.
+	position, err := w.placePosition(newTodo.UserID, func() (string, string, error) {
+		todos := w.store.userTodos(newTodo.UserID, todo.SortingTypes.Position)
+		if len(todos) == 0 {
+			return "", "", nil
+		}
+		return todos[len(todos)-1].Position, "", nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	createdTodo.Position = position
+
+	w.store.lastTodoID++
+	createdTodo.ID = w.store.lastTodoID
+	w.store.todos[createdTodo.ID] = createdTodo
+
+	return cloneTodo(createdTodo), nil
+}
+
+func (w *todoWriter) UpdateTodo(
+	_ context.Context,
+	todoID todo.TodoID,
+	userID todo.UserID,
+	updateTodo todo.UpdateTodo,
+) (*todo.Todo, error) {
+	w.store.mu.Lock()
+	defer w.store.mu.Unlock()
+
+	t := w.store.findUserTodo(todoID, userID)
+	if t == nil {
+		return nil, nil
+	}
+
+	now := time.Now()
+	if updateTodo.Task != nil {
+		t.Task = *updateTodo.Task
+	}
+	if updateTodo.Status != nil {
+		t.SetStatus(*updateTodo.Status, now)
+	}
+	if updateTodo.Description != nil {
+		t.Description = clonePtr(updateTodo.Description)
+	}
+	if updateTodo.DueAt != nil {
+		t.DueAt = clonePtr(updateTodo.DueAt)
+	}
+	t.UpdatedAt = now
+
+	return cloneTodo(t), nil
+}
+
+func (w *todoWriter) SoftDeleteTodo(
+	_ context.Context,
+	todoID todo.TodoID,
+	userID todo.UserID,
+) error {
+	w.store.mu.Lock()
+	defer w.store.mu.Unlock()
+
+	t := w.store.findUserTodo(todoID, userID)
+	if t == nil {
+		return nil
+	}
+
+	now := time.Now()
+	t.DeletedAt = &now
+	t.UpdatedAt = now
+
+	return nil
+}
+
+// MoveTodo places the todo right before or right after another todo of the
+// user. It returns nil when either todo does not exist.
+func (w *todoWriter) MoveTodo(
+	_ context.Context,
+	todoID todo.TodoID,
+	userID todo.UserID,
+	move todo.MoveTodo,
+) (*todo.Todo, error) {
+	w.store.mu.Lock()
+	defer w.store.mu.Unlock()
+
+	t := w.store.findUserTodo(todoID, userID)
+	if t == nil || w.store.findUserTodo(move.TargetID(), userID) == nil {
+		return nil, nil
+	}
+	if move.TargetID() == todoID {
+		return cloneTodo(t), nil
+	}
+
+	after := move.AfterID != nil
+	position, err := w.placePosition(userID, func() (string, string, error) {
+		// Read again, the target moves when the list is rebalanced.
+		target := w.store.findUserTodo(move.TargetID(), userID)
+		if target.Position == "" {
+			return "", "", errPositionUnset
+		}
+
+		neighbor := w.neighborPosition(target, todoID, after)
+		if after {
+			return target.Position, neighbor, nil
+		}
+		return neighbor, target.Position, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	t.Position = position
+	t.UpdatedAt = time.Now()
+
+	return cloneTodo(t), nil
+}
+
+// neighborPosition returns the position of the todo right before or right
+// after target in the list of the user, leaving out the todo being moved.
+func (w *todoWriter) neighborPosition(target *todo.Todo, movedID todo.TodoID, after bool) string {
+	todos := w.store.userTodos(target.UserID, todo.SortingTypes.Position)
+	neighbor := ""
+	for _, t := range todos {
+		if t.ID == movedID || t.ID == target.ID {
+			continue
+		}
+		if after && positionLess(target, t) {
+			return t.Position
+		}
+		if !after && positionLess(t, target) {
+			neighbor = t.Position
+		}
+	}
+	return neighbor
+}
+
+// errPositionUnset reports a todo without a position; rebalancing gives it
+// one.
+var errPositionUnset = errors.New("position is not set")
+
+// placePosition returns a position between the bounds. When the bounds leave
+// no room for a position shorter than todo.PositionMaxLength, the list of the
+// user is rebalanced once and the bounds are read again.
+func (w *todoWriter) placePosition(
+	userID todo.UserID,
+	bounds func() (before string, after string, err error),
+) (string, error) {
+	for rebalanced := false; ; rebalanced = true {
+		before, after, err := bounds()
+		if err != nil && !errors.Is(err, errPositionUnset) {
+			return "", err
+		}
+		if err == nil {
+			var position string
+			position, err = todo.PositionBetween(before, after)
+			if err == nil && (len(position) < todo.PositionMaxLength || rebalanced) {
+				return position, nil
+			}
+		}
+		if rebalanced {
+			return "", err
+		}
+
+		todos := w.store.userTodos(userID, todo.SortingTypes.Position)
+		for i, position := range todo.EvenPositions(len(todos)) {
+			todos[i].Position = position
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+type userDataReader struct {
+	store *Store
+}
+
+func NewUserDataReader(store *Store) gateway.UserDataQueriesGateway {
+	return &userDataReader{store: store}
+}
+
+// ReadUserData writes the user, deleted or not, with all their todos to sink.
+// The store keeps no events.
+func (r *userDataReader) ReadUserData(
+	_ context.Context,
+	userID todo.UserID,
+	pageSize int,
+	sink gateway.UserDataSink,
+) (bool, error) {
+	r.store.mu.RLock()
+	u, ok := r.store.users[userID]
+	if !ok {
+		r.store.mu.RUnlock()
+		return false, nil
+	}
+	user := cloneUser(u)
+	todos := make([]*todo.Todo, 0)
+	for _, t := range r.store.todos {
+		if t.UserID == userID {
+			todos = append(todos, cloneTodo(t))
+		}
+	}
+	r.store.mu.RUnlock()
+
+	sort.Slice(todos, func(i, j int) bool {
+		return todos[i].ID < todos[j].ID
+	})
+
+	if err := sink.WriteUser(user); err != nil {
+		return true, err
+	}
+	for len(todos) > 0 {
+		n := min(pageSize, len(todos))
+		if err := sink.WriteTodos(todos[:n]); err != nil {
+			return true, err
+		}
+		todos = todos[n:]
+	}
+
+	return true, nil
+}
+
+type userEraser struct {
+	store *Store
+}
+
+func NewUserEraser(store *Store) gateway.UserErasureCommandsGateway {
+	return &userEraser{store: store}
+}
+
+// EraseUser deletes the user and their todos, and records the tombstone.
+func (e *userEraser) EraseUser(
+	_ context.Context,
+	userID todo.UserID,
+) (*todo.ErasedUser, error) {
+	e.store.mu.Lock()
+	defer e.store.mu.Unlock()
+
+	if _, ok := e.store.users[userID]; !ok {
+		return nil, nil
+	}
+
+	for id, t := range e.store.todos {
+		if t.UserID == userID {
+			delete(e.store.todos, id)
+		}
+	}
+	delete(e.store.users, userID)
+
+	erased := &todo.ErasedUser{
+		UserID:   userID,
+		ErasedAt: time.Now(),
+	}
+	e.store.erased[userID] = erased
+
+	c := *erased
+	return &c, nil
+}
@@ -0,0 +1,32 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+type userReader struct {
+	store *Store
+}
+
+func NewUserReader(store *Store) gateway.UserQueriesGateway {
+	return &userReader{store: store}
+}
+
+// GetUser returns nil for unknown and deleted users.
+func (r *userReader) GetUser(
+	_ context.Context,
+	userID int64,
+) (*todo.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	u, ok := r.store.users[todo.UserID(userID)]
+	if !ok || u.IsDeleted() {
+		return nil, nil
+	}
+
+	return cloneUser(u), nil
+}
@@ -0,0 +1,27 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway/gatewaytest"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/memory"
+)
+
+func TestUserGateways(t *testing.T) {
+	t.Parallel()
+
+	gatewaytest.RunUserGatewaysSuite(t, func(t *testing.T) gatewaytest.UserGateways {
+		store := memory.NewStore()
+		store.SeedUsers(&todo.User{ID: todo.UserID(1), Username: "user1"})
+
+		return gatewaytest.UserGateways{
+			Binder:       memory.NewConnectionBinder(),
+			Reader:       memory.NewUserReader(store),
+			Writer:       memory.NewUserWriter(store),
+			DataReader:   memory.NewUserDataReader(store),
+			Eraser:       memory.NewUserEraser(store),
+			UnusedUserID: todo.UserID(2),
+		}
+	})
+}
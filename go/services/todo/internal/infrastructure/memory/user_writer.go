@@ -0,0 +1,120 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+type userWriter struct {
+	store *Store
+}
+
+func NewUserWriter(store *Store) gateway.UserCommandsGateway {
+	return &userWriter{store: store}
+}
+
+// CreateUser keeps the ID and times of newUser when they are set. It fails
+// with todo.ErrDuplicateKey when the ID or the email is already used, and
+// with todo.ErrUserErased when the ID is the one of an erased user.
+func (w *userWriter) CreateUser(
+	_ context.Context,
+	newUser todo.NewUser,
+) (*todo.User, error) {
+	w.store.mu.Lock()
+	defer w.store.mu.Unlock()
+
+	if _, ok := w.store.erased[newUser.ID]; ok {
+		return nil, todo.ErrUserErased
+	}
+	if _, ok := w.store.users[newUser.ID]; ok {
+		return nil, todo.ErrDuplicateKey
+	}
+	if newUser.Email != nil {
+		for _, u := range w.store.users {
+			if u.Email != nil && *u.Email == *newUser.Email {
+				return nil, todo.ErrDuplicateKey
+			}
+		}
+	}
+
+	now := time.Now()
+	createdUser := &todo.User{
+		ID:        newUser.ID,
+		Username:  newUser.Username,
+		Email:     clonePtr(newUser.Email),
+		CreatedAt: newUser.CreatedAt,
+		UpdatedAt: newUser.UpdatedAt,
+	}
+	if createdUser.ID == 0 {
+		w.store.lastUserID++
+		createdUser.ID = w.store.lastUserID
+	} else if createdUser.ID > w.store.lastUserID {
+		w.store.lastUserID = createdUser.ID
+	}
+	if createdUser.CreatedAt.IsZero() {
+		createdUser.CreatedAt = now
+	}
+	if createdUser.UpdatedAt.IsZero() {
+		createdUser.UpdatedAt = now
+	}
+	w.store.users[createdUser.ID] = createdUser
+
+	return cloneUser(createdUser), nil
+}
+
+// UpdateUser returns nil when the user does not exist or is deleted. It fails
+// with todo.ErrDuplicateKey when the email is used by another user.
+func (w *userWriter) UpdateUser(
+	_ context.Context,
+	userID todo.UserID,
+	updateUser todo.UpdateUser,
+) (*todo.User, error) {
+	w.store.mu.Lock()
+	defer w.store.mu.Unlock()
+
+	u, ok := w.store.users[userID]
+	if !ok || u.IsDeleted() {
+		return nil, nil
+	}
+	if updateUser.Email != nil {
+		for _, other := range w.store.users {
+			if other.ID != userID && other.Email != nil && *other.Email == *updateUser.Email {
+				return nil, todo.ErrDuplicateKey
+			}
+		}
+	}
+
+	if updateUser.Username != nil {
+		u.Username = *updateUser.Username
+	}
+	if updateUser.Email != nil {
+		u.Email = clonePtr(updateUser.Email)
+	}
+	u.UpdatedAt = time.Now()
+
+	return cloneUser(u), nil
+}
+
+// SoftDeleteUser does nothing when the user does not exist or is already
+// deleted. The todos of the user are kept.
+func (w *userWriter) SoftDeleteUser(
+	_ context.Context,
+	userID todo.UserID,
+) error {
+	w.store.mu.Lock()
+	defer w.store.mu.Unlock()
+
+	u, ok := w.store.users[userID]
+	if !ok || u.IsDeleted() {
+		return nil
+	}
+
+	now := time.Now()
+	u.DeletedAt = &now
+	u.UpdatedAt = now
+
+	return nil
+}
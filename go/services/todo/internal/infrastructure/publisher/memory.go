@@ -0,0 +1,69 @@
+package publisher
+
+import (
+	"context"
+	"sync"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+// InMemoryBroker is a process-local Publisher that fans events out to
+// subscribers. It is meant for local runs and tests.
+type InMemoryBroker struct {
+	mu          sync.RWMutex
+	subscribers map[int]subscriber
+	nextID      int
+}
+
+type subscriber struct {
+	ch   chan *todo.Event
+	done <-chan struct{}
+}
+
+func NewInMemoryBroker() *InMemoryBroker {
+	return &InMemoryBroker{
+		subscribers: map[int]subscriber{},
+	}
+}
+
+// Publish delivers the event to every active subscriber, waiting for each one
+// to accept it so that no event is dropped.
+func (b *InMemoryBroker) Publish(ctx context.Context, event *todo.Event) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, sub := range b.subscribers {
+		select {
+		case sub.ch <- event:
+		case <-sub.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+// Subscribe registers a new subscriber. The returned channel is closed once ctx
+// is done.
+func (b *InMemoryBroker) Subscribe(ctx context.Context, bufferSize int) <-chan *todo.Event {
+	ch := make(chan *todo.Event, bufferSize)
+
+	b.mu.Lock()
+	id := b.nextID
+	b.nextID++
+	b.subscribers[id] = subscriber{ch: ch, done: ctx.Done()}
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		b.mu.Lock()
+		delete(b.subscribers, id)
+		b.mu.Unlock()
+
+		close(ch)
+	}()
+
+	return ch
+}
@@ -0,0 +1,28 @@
+package publisher
+
+import (
+	"context"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+type multiPublisher struct {
+	publishers []gateway.Publisher
+}
+
+// NewMultiPublisher returns a Publisher that publishes every event to all of
+// the given publishers in order, stopping at the first failure.
+func NewMultiPublisher(publishers ...gateway.Publisher) gateway.Publisher {
+	return &multiPublisher{publishers: publishers}
+}
+
+func (p *multiPublisher) Publish(ctx context.Context, event *todo.Event) error {
+	for _, pub := range p.publishers {
+		if err := pub.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+// NDJSONFilePublisher appends every event as one JSON line to a file.
+type NDJSONFilePublisher struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func NewNDJSONFilePublisher(path string) (*NDJSONFilePublisher, func(), error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, nil, fmt.Errorf("open ndjson file: %w", err)
+	}
+
+	return &NDJSONFilePublisher{file: f}, func() {
+		_ = f.Close()
+	}, nil
+}
+
+func (p *NDJSONFilePublisher) Publish(ctx context.Context, event *todo.Event) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal event: %w", err)
+	}
+	line = append(line, '\n')
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, err := p.file.Write(line); err != nil {
+		return fmt.Errorf("write event: %w", err)
+	}
+
+	// The relay marks the event as published right after this call returns,
+	// so make sure it is on disk first.
+	if err := p.file.Sync(); err != nil {
+		return fmt.Errorf("sync ndjson file: %w", err)
+	}
+
+	return nil
+}
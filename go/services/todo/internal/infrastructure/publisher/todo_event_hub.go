@@ -0,0 +1,242 @@
+package publisher
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/errors"
+)
+
+const (
+	defaultMaxStreamsPerUser = 5
+	defaultBufferSize        = 64
+	defaultHeartbeatInterval = 15 * time.Second
+	defaultReplayBatchSize   = 100
+	defaultReplayOverlap     = 100
+)
+
+// TodoEventHubConfig configures a TodoEventHub; settings that are not
+// positive take their default.
+type TodoEventHubConfig struct {
+	MaxStreamsPerUser int
+	BufferSize        int
+	HeartbeatInterval time.Duration
+	ReplayBatchSize   int
+	// ReplayOverlap is how many event IDs before the last event of a stream
+	// are replayed again when it resumes, for the events committed after it
+	// although recorded before it. Streams are at least once: a client skips
+	// the events it already got by their ID.
+	ReplayOverlap int
+}
+
+// TodoEventHub fans todo events out to the WatchTodos streams of their user.
+// It is a Publisher, fed by the worker.OutboxTailer of the process serving the
+// streams: every such process owns its hub.
+type TodoEventHub struct {
+	binder       gateway.Binder
+	outboxReader gateway.OutboxQueriesGateway
+	cfg          TodoEventHubConfig
+
+	mu      sync.Mutex
+	streams map[todo.UserID]map[*todoStream]struct{}
+}
+
+type todoStream struct {
+	events chan *todo.Event
+}
+
+func NewTodoEventHub(
+	binder gateway.Binder,
+	outboxReader gateway.OutboxQueriesGateway,
+	cfg TodoEventHubConfig,
+) *TodoEventHub {
+	if cfg.MaxStreamsPerUser <= 0 {
+		cfg.MaxStreamsPerUser = defaultMaxStreamsPerUser
+	}
+	if cfg.BufferSize <= 0 {
+		cfg.BufferSize = defaultBufferSize
+	}
+	if cfg.HeartbeatInterval <= 0 {
+		cfg.HeartbeatInterval = defaultHeartbeatInterval
+	}
+	if cfg.ReplayBatchSize <= 0 {
+		cfg.ReplayBatchSize = defaultReplayBatchSize
+	}
+	if cfg.ReplayOverlap <= 0 {
+		cfg.ReplayOverlap = defaultReplayOverlap
+	}
+
+	return &TodoEventHub{
+		binder:       binder,
+		outboxReader: outboxReader,
+		cfg:          cfg,
+		streams:      map[todo.UserID]map[*todoStream]struct{}{},
+	}
+}
+
+// Publish never blocks the relay: a stream whose buffer is full is closed, and
+// its client is expected to reconnect and resume from the last event it got.
+func (h *TodoEventHub) Publish(_ context.Context, event *todo.Event) error {
+	if event.AggregateType != todo.AggregateTypes.Todo {
+		return nil
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for s := range h.streams[event.UserID] {
+		select {
+		case s.events <- event:
+		default:
+			h.removeLocked(event.UserID, s)
+		}
+	}
+
+	return nil
+}
+
+// Watch streams the todo events of a user until ctx is done or send fails.
+// When lastEventID is set, the events recorded after it, and those of the
+// replay overlap before it, are replayed first.
+func (h *TodoEventHub) Watch(
+	ctx context.Context,
+	userID todo.UserID,
+	lastEventID *todo.EventID,
+	send func(*todo.Event) error,
+	sendHeartbeat func(time.Time) error,
+) error {
+	s, err := h.subscribe(userID)
+	if err != nil {
+		return err
+	}
+	defer h.unsubscribe(userID, s)
+
+	// Subscribe before replaying so nothing published meanwhile is lost, and
+	// skip the live copies of events that were already replayed.
+	replayed, err := h.replay(ctx, userID, lastEventID, send)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(h.cfg.HeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-s.events:
+			if !ok {
+				return errors.NewResourceExhaustedError(
+					"Watch: stream fell behind, resume from the last received event",
+					nil,
+					nil,
+					errors.ToMetadataInt("buffer_size", h.cfg.BufferSize),
+				)
+			}
+			if _, ok := replayed[event.ID]; ok {
+				delete(replayed, event.ID)
+				continue
+			}
+			if err := send(event); err != nil {
+				return err
+			}
+		case t := <-ticker.C:
+			if err := sendHeartbeat(t); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (h *TodoEventHub) replay(
+	ctx context.Context,
+	userID todo.UserID,
+	lastEventID *todo.EventID,
+	send func(*todo.Event) error,
+) (map[todo.EventID]struct{}, error) {
+	replayed := map[todo.EventID]struct{}{}
+	if lastEventID == nil {
+		return replayed, nil
+	}
+
+	// The events of the user are kept on their shard.
+	bindCtx := h.binder.Bind(todo.WithUser(ctx, &todo.User{ID: userID}))
+	afterID := *lastEventID - todo.EventID(h.cfg.ReplayOverlap)
+	if afterID < 0 {
+		afterID = 0
+	}
+	for {
+		events, err := h.outboxReader.ListUserEventsAfter(
+			bindCtx,
+			userID,
+			todo.AggregateTypes.Todo,
+			afterID,
+			h.cfg.ReplayBatchSize,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, event := range events {
+			if err := send(event); err != nil {
+				return nil, err
+			}
+			replayed[event.ID] = struct{}{}
+			afterID = event.ID
+		}
+
+		if len(events) < h.cfg.ReplayBatchSize {
+			return replayed, nil
+		}
+	}
+}
+
+func (h *TodoEventHub) subscribe(userID todo.UserID) (*todoStream, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.streams[userID]) >= h.cfg.MaxStreamsPerUser {
+		return nil, errors.NewResourceExhaustedError(
+			"Watch: too many streams for the user",
+			nil,
+			nil,
+			errors.ToMetadataInt("max_streams_per_user", h.cfg.MaxStreamsPerUser),
+		)
+	}
+
+	s := &todoStream{events: make(chan *todo.Event, h.cfg.BufferSize)}
+	if h.streams[userID] == nil {
+		h.streams[userID] = map[*todoStream]struct{}{}
+	}
+	h.streams[userID][s] = struct{}{}
+
+	return s, nil
+}
+
+func (h *TodoEventHub) unsubscribe(userID todo.UserID, s *todoStream) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.removeLocked(userID, s)
+}
+
+// removeLocked must be called with h.mu held.
+func (h *TodoEventHub) removeLocked(userID todo.UserID, s *todoStream) {
+	streams, ok := h.streams[userID]
+	if !ok {
+		return
+	}
+	if _, ok := streams[s]; !ok {
+		return
+	}
+
+	delete(streams, s)
+	close(s.events)
+	if len(streams) == 0 {
+		delete(h.streams, userID)
+	}
+}
@@ -0,0 +1,257 @@
+package publisher_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/errors"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/publisher"
+)
+
+type fakeBinder struct{}
+
+func (fakeBinder) Bind(ctx context.Context) context.Context { return ctx }
+
+type fakeOutboxReader struct {
+	events []*todo.Event
+}
+
+func (r *fakeOutboxReader) ListPendingEvents(_ context.Context, _ time.Time, _ int) ([]*todo.Event, error) {
+	return nil, nil
+}
+
+func (r *fakeOutboxReader) ListEventsAfter(_ context.Context, _ todo.EventID, _ int) ([]*todo.Event, error) {
+	return nil, nil
+}
+
+func (r *fakeOutboxReader) GetLastEventID(_ context.Context) (todo.EventID, error) {
+	return 0, nil
+}
+
+func (r *fakeOutboxReader) ListUserEventsAfter(
+	_ context.Context,
+	userID todo.UserID,
+	aggregateType todo.AggregateType,
+	afterID todo.EventID,
+	limit int,
+) ([]*todo.Event, error) {
+	var events []*todo.Event
+	for _, e := range r.events {
+		if e.UserID == userID && e.AggregateType == aggregateType && e.ID > afterID && len(events) < limit {
+			events = append(events, e)
+		}
+	}
+	return events, nil
+}
+
+func newTodoEvent(id todo.EventID, userID todo.UserID) *todo.Event {
+	return &todo.Event{
+		ID:            id,
+		AggregateType: todo.AggregateTypes.Todo,
+		AggregateID:   int64(id),
+		UserID:        userID,
+		EventType:     todo.EventTypes.TodoUpdated,
+	}
+}
+
+func newHub(events ...*todo.Event) *publisher.TodoEventHub {
+	return publisher.NewTodoEventHub(
+		fakeBinder{},
+		&fakeOutboxReader{events: events},
+		publisher.TodoEventHubConfig{
+			MaxStreamsPerUser: 1,
+			BufferSize:        2,
+			HeartbeatInterval: time.Hour,
+			ReplayBatchSize:   2,
+			ReplayOverlap:     2,
+		},
+	)
+}
+
+// watch runs hub.Watch in the background and returns the events it sends.
+func watch(
+	ctx context.Context,
+	hub *publisher.TodoEventHub,
+	userID todo.UserID,
+	lastEventID *todo.EventID,
+) (<-chan todo.EventID, <-chan error) {
+	received := make(chan todo.EventID, 16)
+	done := make(chan error, 1)
+	go func() {
+		done <- hub.Watch(ctx, userID, lastEventID, func(e *todo.Event) error {
+			received <- e.ID
+			return nil
+		}, func(time.Time) error {
+			return nil
+		})
+	}()
+	return received, done
+}
+
+func receive(t *testing.T, received <-chan todo.EventID, n int) []todo.EventID {
+	t.Helper()
+
+	ids := make([]todo.EventID, 0, n)
+	for len(ids) < n {
+		select {
+		case id := <-received:
+			ids = append(ids, id)
+		case <-time.After(time.Second):
+			t.Fatalf("received %v, want %d events", ids, n)
+		}
+	}
+	return ids
+}
+
+// publishUntilDelivered retries until the stream has subscribed to the hub.
+func publishUntilDelivered(t *testing.T, hub *publisher.TodoEventHub, received <-chan todo.EventID, event *todo.Event) {
+	t.Helper()
+
+	deadline := time.After(time.Second)
+	for {
+		if err := hub.Publish(context.Background(), event); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+		select {
+		case id := <-received:
+			if id != event.ID {
+				t.Fatalf("received event %d, want %d", id, event.ID)
+			}
+			return
+		case <-time.After(10 * time.Millisecond):
+		case <-deadline:
+			t.Fatalf("event %d was not delivered", event.ID)
+		}
+	}
+}
+
+func Test_TodoEventHub_Watch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Replay events after the last event ID of the user and the overlap before it", func(t *testing.T) {
+		t.Parallel()
+
+		hub := newHub(
+			newTodoEvent(1, 1),
+			newTodoEvent(2, 1),
+			newTodoEvent(3, 2),
+			newTodoEvent(4, 1),
+			newTodoEvent(5, 1),
+		)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		// Event 2 may have been committed after event 3 was received.
+		received, _ := watch(ctx, hub, 1, todo.NewEventID(3))
+
+		if diff := cmp.Diff(receive(t, received, 3), []todo.EventID{2, 4, 5}); diff != "" {
+			t.Fatalf("replayed events mismatch (-actual +expected):\n%s", diff)
+		}
+
+		// The live copy of a replayed event is skipped.
+		if err := hub.Publish(context.Background(), newTodoEvent(5, 1)); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+		if err := hub.Publish(context.Background(), newTodoEvent(6, 1)); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+		if diff := cmp.Diff(receive(t, received, 1), []todo.EventID{6}); diff != "" {
+			t.Fatalf("live events mismatch (-actual +expected):\n%s", diff)
+		}
+	})
+
+	t.Run("Replay and go live with the default config", func(t *testing.T) {
+		t.Parallel()
+
+		hub := publisher.NewTodoEventHub(
+			fakeBinder{},
+			&fakeOutboxReader{events: []*todo.Event{newTodoEvent(1, 1), newTodoEvent(2, 1)}},
+			publisher.TodoEventHubConfig{},
+		)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		received, _ := watch(ctx, hub, 1, todo.NewEventID(0))
+
+		if diff := cmp.Diff(receive(t, received, 2), []todo.EventID{1, 2}); diff != "" {
+			t.Fatalf("replayed events mismatch (-actual +expected):\n%s", diff)
+		}
+		// The replay ended, so live events get through.
+		publishUntilDelivered(t, hub, received, newTodoEvent(3, 1))
+	})
+
+	t.Run("Reject streams over the limit per user", func(t *testing.T) {
+		t.Parallel()
+
+		hub := newHub()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		received, _ := watch(ctx, hub, 1, nil)
+		publishUntilDelivered(t, hub, received, newTodoEvent(1, 1))
+
+		_, done := watch(ctx, hub, 1, nil)
+		select {
+		case err := <-done:
+			if errors.ToGRPCCode(err) != errors.ToGRPCCode(errors.NewResourceExhaustedError("", nil, nil)) {
+				t.Fatalf("Watch() error = %v, want resource exhausted", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("second stream was accepted")
+		}
+	})
+
+	t.Run("Close slow streams without blocking the publisher", func(t *testing.T) {
+		t.Parallel()
+
+		hub := newHub()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		blocked := make(chan struct{})
+		first := true
+		done := make(chan error, 1)
+		go func() {
+			done <- hub.Watch(ctx, 1, nil, func(*todo.Event) error {
+				if first {
+					first = false
+					close(blocked)
+					<-ctx.Done()
+				}
+				return nil
+			}, func(time.Time) error {
+				return nil
+			})
+		}()
+
+		deadline := time.After(time.Second)
+	waitSubscribed:
+		for id := todo.EventID(1); ; id++ {
+			if err := hub.Publish(context.Background(), newTodoEvent(id, 1)); err != nil {
+				t.Fatalf("Publish() error = %v", err)
+			}
+			select {
+			case <-blocked:
+				break waitSubscribed
+			case <-time.After(10 * time.Millisecond):
+			case <-deadline:
+				t.Fatal("stream did not receive any event")
+			}
+		}
+
+		// The consumer is stuck, so the buffer fills up and the stream is dropped.
+		for id := todo.EventID(100); id < 105; id++ {
+			if err := hub.Publish(context.Background(), newTodoEvent(id, 1)); err != nil {
+				t.Fatalf("Publish() error = %v", err)
+			}
+		}
+
+		// A new stream is accepted again once the slow one is gone.
+		received, _ := watch(ctx, hub, 1, nil)
+		publishUntilDelivered(t, hub, received, newTodoEvent(200, 1))
+	})
+}
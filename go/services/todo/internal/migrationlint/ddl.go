@@ -0,0 +1,191 @@
+package migrationlint
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ident matches a table, column or index name, quoted or not, with its
+// database or not, capturing the name.
+const ident = "`?(?:\\w+`?\\.`?)?(\\w+)`?"
+
+var (
+	createTablePattern = regexp.MustCompile(`(?i)^CREATE\s+(?:TEMPORARY\s+)?TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?` + ident)
+	dropTablePattern   = regexp.MustCompile(`(?i)^DROP\s+(?:TEMPORARY\s+)?TABLE\s+(?:IF\s+EXISTS\s+)?(.+?)(?:\s+(?:RESTRICT|CASCADE))?$`)
+	alterTablePattern  = regexp.MustCompile(`(?i)^ALTER\s+(?:ONLINE\s+)?TABLE\s+` + ident + `\s+(.*)$`)
+	createIndexPattern = regexp.MustCompile(
+		`(?i)^CREATE\s+(?:UNIQUE\s+|FULLTEXT\s+|SPATIAL\s+)?INDEX\s+` + ident + `\s+(?:USING\s+\w+\s+)?ON\s+` + ident,
+	)
+	dropIndexPattern = regexp.MustCompile(`(?i)^DROP\s+INDEX\s+` + ident + `\s+ON\s+` + ident)
+	algorithmPattern = regexp.MustCompile(`(?i)\bALGORITHM\s*=?\s*(\w+)`)
+	checkPattern     = regexp.MustCompile(`(?i)\bCHECK\s*\(`)
+	inPattern        = regexp.MustCompile(`(?is)^\s*` + ident + `\s+IN\s*(\(.*\))\s*$`)
+
+	addConstraintPattern = regexp.MustCompile(
+		`(?i)^ADD\s+(?:CONSTRAINT|CHECK|PRIMARY|FOREIGN|UNIQUE|FULLTEXT|SPATIAL|INDEX|KEY)\b`,
+	)
+	addIndexPattern        = regexp.MustCompile(`(?i)^ADD\s+(?:UNIQUE\s+|FULLTEXT\s+|SPATIAL\s+)?(?:INDEX|KEY)\s+` + ident)
+	addColumnPattern       = regexp.MustCompile(`(?i)^ADD\s+(?:COLUMN\s+)?` + ident)
+	dropConstraintPattern  = regexp.MustCompile(`(?i)^DROP\s+(?:PRIMARY\s+KEY|FOREIGN\s+KEY|CONSTRAINT|CHECK|DEFAULT)\b`)
+	dropIndexClausePattern = regexp.MustCompile(`(?i)^DROP\s+(?:INDEX|KEY)\s+` + ident)
+	dropColumnPattern      = regexp.MustCompile(`(?i)^DROP\s+(?:COLUMN\s+)?` + ident)
+	changeColumnPattern    = regexp.MustCompile(`(?i)^CHANGE\s+(?:COLUMN\s+)?` + ident + `\s+` + ident)
+	renameColumnPattern    = regexp.MustCompile(`(?i)^RENAME\s+COLUMN\s+` + ident + `\s+TO\s+` + ident)
+	renameIndexPattern     = regexp.MustCompile(`(?i)^RENAME\s+(?:INDEX|KEY)\s+` + ident + `\s+TO\s+` + ident)
+)
+
+type ddlKind int
+
+const (
+	ddlOther ddlKind = iota
+	ddlCreateTable
+	ddlDropTable
+	ddlAlterTable
+	ddlCreateIndex
+	ddlDropIndex
+)
+
+// ddl is what a statement changes in the schema. Columns and indexes are
+// named without their table.
+type ddl struct {
+	kind ddlKind
+	// table is the table created, altered or indexed.
+	table string
+	// algorithm is the ALGORITHM the statement asks for, upper case.
+	algorithm string
+
+	droppedTables  []string
+	addedColumns   []string
+	droppedColumns []string
+	// renamedColumns are the former names of the renamed columns.
+	renamedColumns []string
+	addedIndexes   []string
+	droppedIndexes []string
+	checks         []check
+}
+
+// check is a CHECK constraint limiting a column to a list of values.
+type check struct {
+	column string
+	// values are the values allowed, unquoted.
+	values []string
+}
+
+// parseDDL reads what the statement sql changes. Statements it does not
+// understand, DML included, are ddlOther.
+func parseDDL(sql string) ddl {
+	var d ddl
+	if match := algorithmPattern.FindStringSubmatch(sql); match != nil {
+		d.algorithm = strings.ToUpper(match[1])
+	}
+
+	switch {
+	case createTablePattern.MatchString(sql):
+		d.kind = ddlCreateTable
+		d.table = createTablePattern.FindStringSubmatch(sql)[1]
+	case dropTablePattern.MatchString(sql):
+		d.kind = ddlDropTable
+		for _, table := range splitTopLevel(dropTablePattern.FindStringSubmatch(sql)[1]) {
+			d.droppedTables = append(d.droppedTables, lastName(table))
+		}
+	case alterTablePattern.MatchString(sql):
+		match := alterTablePattern.FindStringSubmatch(sql)
+		d.kind = ddlAlterTable
+		d.table = match[1]
+		for _, clause := range splitTopLevel(match[2]) {
+			d.parseAlterClause(clause)
+		}
+	case createIndexPattern.MatchString(sql):
+		match := createIndexPattern.FindStringSubmatch(sql)
+		d.kind = ddlCreateIndex
+		d.table = match[2]
+		d.addedIndexes = []string{match[1]}
+	case dropIndexPattern.MatchString(sql):
+		match := dropIndexPattern.FindStringSubmatch(sql)
+		d.kind = ddlDropIndex
+		d.table = match[2]
+		d.droppedIndexes = []string{match[1]}
+	default:
+		return d
+	}
+
+	d.checks = parseChecks(sql)
+	return d
+}
+
+func (d *ddl) parseAlterClause(clause string) {
+	switch {
+	case addIndexPattern.MatchString(clause):
+		d.addedIndexes = append(d.addedIndexes, addIndexPattern.FindStringSubmatch(clause)[1])
+	case addConstraintPattern.MatchString(clause):
+	case addColumnPattern.MatchString(clause):
+		d.addedColumns = append(d.addedColumns, addColumnPattern.FindStringSubmatch(clause)[1])
+	case dropConstraintPattern.MatchString(clause):
+	case dropIndexClausePattern.MatchString(clause):
+		d.droppedIndexes = append(d.droppedIndexes, dropIndexClausePattern.FindStringSubmatch(clause)[1])
+	case dropColumnPattern.MatchString(clause):
+		d.droppedColumns = append(d.droppedColumns, dropColumnPattern.FindStringSubmatch(clause)[1])
+	case changeColumnPattern.MatchString(clause):
+		d.renameColumn(changeColumnPattern.FindStringSubmatch(clause))
+	case renameColumnPattern.MatchString(clause):
+		d.renameColumn(renameColumnPattern.FindStringSubmatch(clause))
+	case renameIndexPattern.MatchString(clause):
+		match := renameIndexPattern.FindStringSubmatch(clause)
+		d.droppedIndexes = append(d.droppedIndexes, match[1])
+		d.addedIndexes = append(d.addedIndexes, match[2])
+	}
+}
+
+// renameColumn records the rename of match[1] to match[2], which keeps the
+// data: the former name is gone and the new one is there.
+func (d *ddl) renameColumn(match []string) {
+	if strings.EqualFold(match[1], match[2]) {
+		return
+	}
+	d.renamedColumns = append(d.renamedColumns, match[1])
+	d.addedColumns = append(d.addedColumns, match[2])
+}
+
+// parseChecks returns the CHECK constraints of sql of the form column IN
+// (values), the only ones compared with the enums.
+func parseChecks(sql string) []check {
+	var checks []check
+	for _, loc := range checkPattern.FindAllStringIndex(sql, -1) {
+		expr, ok := parenthesized(sql[loc[1]-1:])
+		if !ok {
+			continue
+		}
+		// The expression may have parentheses of its own.
+		for {
+			inner, ok := parenthesized(strings.TrimSpace(expr))
+			if !ok || len(inner) != len(strings.TrimSpace(expr))-2 {
+				break
+			}
+			expr = inner
+		}
+
+		match := inPattern.FindStringSubmatch(expr)
+		if match == nil {
+			continue
+		}
+		list, ok := parenthesized(match[2])
+		if !ok {
+			continue
+		}
+		c := check{column: match[1]}
+		for _, value := range splitTopLevel(list) {
+			c.values = append(c.values, unquote(value))
+		}
+		checks = append(checks, c)
+	}
+	return checks
+}
+
+// lastName returns the name of a possibly quoted and qualified identifier.
+func lastName(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.LastIndexByte(s, '.'); i >= 0 {
+		s = s[i+1:]
+	}
+	return unquote(s)
+}
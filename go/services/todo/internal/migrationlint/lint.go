@@ -0,0 +1,380 @@
+// Package migrationlint flags the migrations that may lose data, lock a large
+// table or leave the schema out of step with the Go code, before they run.
+package migrationlint
+
+import (
+	"fmt"
+	"io/fs"
+	"slices"
+	"strings"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/database/migrations"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+// The rules of the linter, named by the ignore directives.
+const (
+	RuleMissingDown      = "missing-down"
+	RuleMissingUp        = "missing-up"
+	RuleIrreversibleDown = "irreversible-down"
+	RuleDropTable        = "drop-table"
+	RuleDropColumn       = "drop-column"
+	RuleLockingAlter     = "locking-alter"
+	RuleCheckEnum        = "check-enum"
+)
+
+// Config is what the linter knows of the database beyond the migrations.
+type Config struct {
+	// LargeTables are the tables an ALTER TABLE, CREATE INDEX or DROP INDEX
+	// must not copy or lock: the statement has to ask for ALGORITHM=INPLACE
+	// or ALGORITHM=INSTANT.
+	LargeTables []string
+	// Enums are the values of the Go enums stored in a column, by
+	// table.column, written as in SQL without quotes. The last CHECK
+	// constraint of the column must allow exactly them.
+	Enums map[string][]string
+	// Baseline is the last migration applied before the linter. The findings
+	// of the migrations up to it are still reported, as baselined: they
+	// cannot be fixed by editing an applied migration, so they do not fail
+	// the lint.
+	Baseline uint
+}
+
+// DefaultConfig is the configuration of the todo database.
+func DefaultConfig() Config {
+	statuses := make([]string, 0, len(todo.TodoStatuses()))
+	for _, status := range todo.TodoStatuses() {
+		statuses = append(statuses, fmt.Sprint(int32(status)))
+	}
+
+	return Config{
+		LargeTables: []string{"users", "todos", "outbox"},
+		Enums: map[string][]string{
+			"todos.status": statuses,
+		},
+		// 000003 replaces the status column of todos, which cannot be done
+		// online.
+		Baseline: 3,
+	}
+}
+
+// Finding is a statement, or a file when Line is 0, breaking a rule.
+type Finding struct {
+	File    string
+	Line    int
+	Rule    string
+	Message string
+	// Baselined is set for the findings of the migrations up to
+	// Config.Baseline.
+	Baselined bool
+}
+
+func (f Finding) String() string {
+	s := fmt.Sprintf("%s:%d: %s: %s", f.File, f.Line, f.Rule, f.Message)
+	if f.Line == 0 {
+		s = fmt.Sprintf("%s: %s: %s", f.File, f.Rule, f.Message)
+	}
+	if f.Baselined {
+		s += " (baselined)"
+	}
+	return s
+}
+
+// Failures returns the findings which are not baselined, the ones failing the
+// lint.
+func Failures(findings []Finding) []Finding {
+	var failures []Finding
+	for _, f := range findings {
+		if !f.Baselined {
+			failures = append(failures, f)
+		}
+	}
+	return failures
+}
+
+// Lint checks the migrations of fsys and returns the findings sorted by file
+// and line. Directives in SQL comments turn rules
+// off, "-- lint:ignore RULE[,RULE] reason" for the next statement and
+// "-- lint:ignore-file RULE[,RULE] reason" for the whole file.
+func Lint(fsys fs.FS, cfg Config) ([]Finding, error) {
+	list, err := migrations.Scan(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &linter{cfg: cfg}
+	for _, m := range list {
+		if err := l.lintMigration(fsys, m); err != nil {
+			return nil, err
+		}
+	}
+	l.lintEnums()
+
+	slices.SortStableFunc(l.findings, func(a, b Finding) int {
+		if c := strings.Compare(a.File, b.File); c != 0 {
+			return c
+		}
+		return a.Line - b.Line
+	})
+	return l.findings, nil
+}
+
+type linter struct {
+	cfg      Config
+	findings []Finding
+
+	// lastChecks are the last CHECK constraints of the up files on the
+	// columns of the enums, by table.column.
+	lastChecks map[string]enumCheck
+}
+
+type enumCheck struct {
+	file *sqlFile
+	stmt statement
+	check
+}
+
+// located is a statement and what it changes.
+type located struct {
+	stmt statement
+	ddl  ddl
+}
+
+func (l *linter) lintMigration(fsys fs.FS, m *migrations.Migration) error {
+	name := fmt.Sprintf("%06d_%s", m.Version, m.Name)
+	if m.Up == "" {
+		l.report(&sqlFile{name: m.Down, version: m.Version}, statement{}, RuleMissingUp, fmt.Sprintf("migration %s has no up file", name))
+		return nil
+	}
+
+	up, upDDL, err := readSQL(fsys, m.Up)
+	if err != nil {
+		return err
+	}
+	up.version = m.Version
+	created := l.lintUp(up, upDDL)
+
+	if m.Down == "" {
+		l.report(up, statement{}, RuleMissingDown, fmt.Sprintf("migration %s has no down file", name))
+		return nil
+	}
+
+	down, downDDL, err := readSQL(fsys, m.Down)
+	if err != nil {
+		return err
+	}
+	down.version = m.Version
+	l.lintDown(down, downDDL, created)
+	return nil
+}
+
+func readSQL(fsys fs.FS, name string) (*sqlFile, []located, error) {
+	content, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	f := parseSQL(name, string(content))
+	statements := make([]located, 0, len(f.statements))
+	for _, stmt := range f.statements {
+		statements = append(statements, located{stmt: stmt, ddl: parseDDL(stmt.sql)})
+	}
+	return f, statements, nil
+}
+
+// lintUp lints the up file f and returns the objects it creates, which the
+// down file has to remove.
+func (l *linter) lintUp(f *sqlFile, statements []located) *objects {
+	created := newObjects()
+	for _, s := range statements {
+		l.lintLocking(f, s, created)
+
+		for _, table := range s.ddl.droppedTables {
+			l.report(f, s.stmt, RuleDropTable, fmt.Sprintf("DROP TABLE %s loses its rows", table))
+		}
+		for _, column := range s.ddl.droppedColumns {
+			l.report(f, s.stmt, RuleDropColumn, fmt.Sprintf("DROP COLUMN %s.%s loses its values", s.ddl.table, column))
+		}
+
+		for _, c := range s.ddl.checks {
+			column := s.ddl.table + "." + c.column
+			if _, ok := l.cfg.Enums[column]; !ok {
+				continue
+			}
+			if l.lastChecks == nil {
+				l.lastChecks = map[string]enumCheck{}
+			}
+			l.lastChecks[column] = enumCheck{file: f, stmt: s.stmt, check: c}
+		}
+
+		created.apply(s.ddl)
+	}
+	return created
+}
+
+// lintDown lints the down file f of the up file which creates created.
+func (l *linter) lintDown(f *sqlFile, statements []located, created *objects) {
+	if len(statements) == 0 {
+		l.report(f, statement{}, RuleIrreversibleDown, "down file has no statement")
+		return
+	}
+
+	createdHere := newObjects()
+	removed := newObjects()
+	for _, s := range statements {
+		l.lintLocking(f, s, createdHere)
+
+		// Dropping what the up file created is what a down file is for.
+		for _, table := range s.ddl.droppedTables {
+			if !created.tables[table] {
+				l.report(f, s.stmt, RuleDropTable, fmt.Sprintf("DROP TABLE %s drops a table the up file does not create", table))
+			}
+		}
+		for _, column := range s.ddl.droppedColumns {
+			key := s.ddl.table + "." + column
+			if !created.columns[key] && !created.tables[s.ddl.table] {
+				l.report(f, s.stmt, RuleDropColumn, fmt.Sprintf("DROP COLUMN %s drops a column the up file does not add", key))
+			}
+		}
+
+		createdHere.apply(s.ddl)
+		removed.remove(s.ddl)
+	}
+
+	for _, missing := range created.notIn(removed) {
+		l.report(f, statement{}, RuleIrreversibleDown, fmt.Sprintf("down file does not remove %s, created by the up file", missing))
+	}
+}
+
+var lockingStatementNames = map[ddlKind]string{
+	ddlAlterTable:  "ALTER TABLE",
+	ddlCreateIndex: "CREATE INDEX",
+	ddlDropIndex:   "DROP INDEX",
+}
+
+// lintLocking reports the statements altering a large table without an
+// online algorithm. Tables created earlier in the file are new and empty.
+func (l *linter) lintLocking(f *sqlFile, s located, created *objects) {
+	if _, ok := lockingStatementNames[s.ddl.kind]; !ok {
+		return
+	}
+	if !slices.Contains(l.cfg.LargeTables, s.ddl.table) || created.tables[s.ddl.table] {
+		return
+	}
+	if s.ddl.algorithm == "INPLACE" || s.ddl.algorithm == "INSTANT" {
+		return
+	}
+
+	l.report(f, s.stmt, RuleLockingAlter, fmt.Sprintf(
+		"%s on the large table %s without ALGORITHM=INPLACE or ALGORITHM=INSTANT may copy it and block its writes",
+		lockingStatementNames[s.ddl.kind], s.ddl.table,
+	))
+}
+
+// lintEnums compares the last CHECK constraint of every enum column with its
+// Go enum.
+func (l *linter) lintEnums() {
+	columns := make([]string, 0, len(l.lastChecks))
+	for column := range l.lastChecks {
+		columns = append(columns, column)
+	}
+	slices.Sort(columns)
+
+	for _, column := range columns {
+		last := l.lastChecks[column]
+		allowed := slices.Sorted(slices.Values(last.values))
+		expected := slices.Sorted(slices.Values(l.cfg.Enums[column]))
+		if slices.Equal(allowed, expected) {
+			continue
+		}
+
+		l.report(last.file, last.stmt, RuleCheckEnum, fmt.Sprintf(
+			"CHECK on %s allows %s, the Go enum has %s",
+			column, strings.Join(allowed, ", "), strings.Join(expected, ", "),
+		))
+	}
+}
+
+func (l *linter) report(f *sqlFile, stmt statement, rule string, message string) {
+	if slices.Contains(f.ignored, rule) || slices.Contains(stmt.ignored, rule) {
+		return
+	}
+	l.findings = append(l.findings, Finding{
+		File:      f.name,
+		Line:      stmt.line,
+		Rule:      rule,
+		Message:   message,
+		Baselined: f.version <= l.cfg.Baseline,
+	})
+}
+
+// objects are tables, columns and indexes, the last two by table.name.
+type objects struct {
+	tables  map[string]bool
+	columns map[string]bool
+	indexes map[string]bool
+}
+
+func newObjects() *objects {
+	return &objects{tables: map[string]bool{}, columns: map[string]bool{}, indexes: map[string]bool{}}
+}
+
+// apply adds the objects d creates.
+func (o *objects) apply(d ddl) {
+	if d.kind == ddlCreateTable {
+		o.tables[d.table] = true
+	}
+	for _, column := range d.addedColumns {
+		o.columns[d.table+"."+column] = true
+	}
+	for _, column := range append(slices.Clone(d.droppedColumns), d.renamedColumns...) {
+		delete(o.columns, d.table+"."+column)
+	}
+	for _, index := range d.addedIndexes {
+		o.indexes[d.table+"."+index] = true
+	}
+	for _, index := range d.droppedIndexes {
+		delete(o.indexes, d.table+"."+index)
+	}
+}
+
+// remove adds the objects d removes, renamed columns included.
+func (o *objects) remove(d ddl) {
+	for _, table := range d.droppedTables {
+		o.tables[table] = true
+	}
+	for _, column := range append(slices.Clone(d.droppedColumns), d.renamedColumns...) {
+		o.columns[d.table+"."+column] = true
+	}
+	for _, index := range d.droppedIndexes {
+		o.indexes[d.table+"."+index] = true
+	}
+}
+
+// notIn returns the objects of o which removed does not remove, a dropped
+// table removing its columns and indexes.
+func (o *objects) notIn(removed *objects) []string {
+	var missing []string
+	for table := range o.tables {
+		if !removed.tables[table] {
+			missing = append(missing, "table "+table)
+		}
+	}
+	for column := range o.columns {
+		if !removed.columns[column] && !removed.tables[tableOf(column)] {
+			missing = append(missing, "column "+column)
+		}
+	}
+	for index := range o.indexes {
+		if !removed.indexes[index] && !removed.tables[tableOf(index)] {
+			missing = append(missing, "index "+index)
+		}
+	}
+	slices.Sort(missing)
+	return missing
+}
+
+func tableOf(name string) string {
+	table, _, _ := strings.Cut(name, ".")
+	return table
+}
@@ -0,0 +1,198 @@
+package migrationlint_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/database/migrations"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/migrationlint"
+)
+
+func TestLint(t *testing.T) {
+	type testcase struct {
+		files    map[string]string
+		baseline uint
+		expected []string
+	}
+
+	t.Parallel()
+
+	cfg := migrationlint.Config{
+		LargeTables: []string{"todos"},
+		Enums:       map[string][]string{"todos.status": {"0", "1", "2"}},
+	}
+
+	testTables := map[string]testcase{
+		"Reversible online migrations": {
+			files: map[string]string{
+				"000001_create_todos.up.sql": `CREATE TABLE todos (
+    id BIGINT PRIMARY KEY,
+    status TINYINT NOT NULL,
+    CONSTRAINT check_todos_status CHECK (status IN (0, 1, 2))
+);`,
+				"000001_create_todos.down.sql": "DROP TABLE IF EXISTS todos;",
+				"000002_add_due_at.up.sql": `-- A comment; with a semicolon.
+ALTER TABLE todos ADD COLUMN due_at DATETIME NULL, ALGORITHM=INSTANT;
+CREATE INDEX idx_todos_due_at ON todos (due_at) ALGORITHM=INPLACE;`,
+				"000002_add_due_at.down.sql": `DROP INDEX idx_todos_due_at ON todos ALGORITHM=INPLACE;
+ALTER TABLE todos DROP COLUMN due_at, ALGORITHM=INPLACE;`,
+			},
+			expected: nil,
+		},
+		"Missing files": {
+			files: map[string]string{
+				"000001_create_todos.up.sql":   "CREATE TABLE todos (id BIGINT PRIMARY KEY);",
+				"000002_create_users.down.sql": "DROP TABLE users;",
+			},
+			expected: []string{
+				"000001_create_todos.up.sql: missing-down: migration 000001_create_todos has no down file",
+				"000002_create_users.down.sql: missing-up: migration 000002_create_users has no up file",
+			},
+		},
+		"Destructive statements": {
+			files: map[string]string{
+				"000001_drop.up.sql": `DROP TABLE legacy_todos, ` + "`archive`.`todos_2020`" + `;
+ALTER TABLE users DROP COLUMN nickname, DROP INDEX idx_users_nickname;`,
+				"000001_drop.down.sql": `CREATE TABLE legacy_todos (id BIGINT PRIMARY KEY);
+ALTER TABLE users DROP email;`,
+			},
+			expected: []string{
+				"000001_drop.down.sql:2: drop-column: DROP COLUMN users.email drops a column the up file does not add",
+				"000001_drop.up.sql:1: drop-table: DROP TABLE legacy_todos loses its rows",
+				"000001_drop.up.sql:1: drop-table: DROP TABLE todos_2020 loses its rows",
+				"000001_drop.up.sql:2: drop-column: DROP COLUMN users.nickname loses its values",
+			},
+		},
+		"Down files not removing what the up file creates": {
+			files: map[string]string{
+				"000001_empty_down.up.sql":   "ALTER TABLE users ADD COLUMN nickname VARCHAR(255) NULL;",
+				"000001_empty_down.down.sql": "-- Nothing to undo.",
+				"000002_partial_down.up.sql": `CREATE TABLE tags (id BIGINT PRIMARY KEY);
+ALTER TABLE users ADD COLUMN bio TEXT NULL, ADD INDEX idx_users_bio (bio(16)), ADD UNIQUE (nickname);
+ALTER TABLE users RENAME COLUMN bio TO about;`,
+				"000002_partial_down.down.sql": "ALTER TABLE users DROP INDEX idx_users_bio;",
+			},
+			expected: []string{
+				"000001_empty_down.down.sql: irreversible-down: down file has no statement",
+				"000002_partial_down.down.sql: irreversible-down: down file does not remove column users.about, created by the up file",
+				"000002_partial_down.down.sql: irreversible-down: down file does not remove table tags, created by the up file",
+			},
+		},
+		"Locking statements on large tables": {
+			files: map[string]string{
+				"000001_todos.up.sql": `ALTER TABLE todos ADD COLUMN a INT NULL;
+ALTER TABLE todos ADD COLUMN b INT NULL, ALGORITHM=COPY;
+ALTER TABLE users ADD COLUMN c INT NULL;
+CREATE UNIQUE INDEX ui_todos_a ON ` + "`todos`" + ` (a);
+ALTER TABLE todos
+    CHANGE b d INT NULL,
+    ALGORITHM = INPLACE;`,
+				"000001_todos.down.sql": `DROP INDEX ui_todos_a ON todos;
+ALTER TABLE todos DROP COLUMN a, DROP COLUMN d, ALGORITHM=INSTANT;
+ALTER TABLE users DROP COLUMN c;`,
+			},
+			expected: []string{
+				"000001_todos.down.sql:1: locking-alter: DROP INDEX on the large table todos without ALGORITHM=INPLACE or ALGORITHM=INSTANT may copy it and block its writes",
+				"000001_todos.up.sql:1: locking-alter: ALTER TABLE on the large table todos without ALGORITHM=INPLACE or ALGORITHM=INSTANT may copy it and block its writes",
+				"000001_todos.up.sql:2: locking-alter: ALTER TABLE on the large table todos without ALGORITHM=INPLACE or ALGORITHM=INSTANT may copy it and block its writes",
+				"000001_todos.up.sql:4: locking-alter: CREATE INDEX on the large table todos without ALGORITHM=INPLACE or ALGORITHM=INSTANT may copy it and block its writes",
+			},
+		},
+		"Last CHECK of an enum column disagreeing with the enum": {
+			files: map[string]string{
+				"000001_create_todos.up.sql": `CREATE TABLE todos (
+    status VARCHAR(20) NOT NULL,
+    CONSTRAINT check_todos_status CHECK (status IN ('pending', 'in_progress', 'done'))
+);`,
+				"000001_create_todos.down.sql": "DROP TABLE todos;",
+				"000002_add_archived.up.sql": `ALTER TABLE todos DROP CHECK check_todos_status, ALGORITHM=INPLACE;
+ALTER TABLE todos ADD CONSTRAINT check_todos_status CHECK ((` + "`status`" + ` IN (0, 1, 2, 3))), ALGORITHM=INPLACE;`,
+				"000002_add_archived.down.sql": `ALTER TABLE todos DROP CHECK check_todos_status, ALGORITHM=INPLACE;
+ALTER TABLE todos ADD CONSTRAINT check_todos_status CHECK (status IN ('pending', 'in_progress', 'done')), ALGORITHM=INPLACE;`,
+			},
+			expected: []string{
+				"000002_add_archived.up.sql:2: check-enum: CHECK on todos.status allows 0, 1, 2, 3, the Go enum has 0, 1, 2",
+			},
+		},
+		"Ignore directives": {
+			files: map[string]string{
+				"000001_legacy.up.sql": `-- lint:ignore-file locking-alter applied before the linter.
+ALTER TABLE todos ADD COLUMN a INT NULL;
+-- lint:ignore drop-column, drop-table the column is unused.
+ALTER TABLE todos DROP COLUMN b;
+ALTER TABLE todos DROP COLUMN c;`,
+				"000001_legacy.down.sql": "ALTER TABLE todos DROP COLUMN a, ALGORITHM=INSTANT;",
+			},
+			expected: []string{
+				"000001_legacy.up.sql:5: drop-column: DROP COLUMN todos.c loses its values",
+			},
+		},
+		"Findings up to the baseline": {
+			files: map[string]string{
+				"000001_legacy.up.sql":   "ALTER TABLE todos ADD COLUMN a INT NULL;",
+				"000001_legacy.down.sql": "ALTER TABLE todos DROP COLUMN a, ALGORITHM=INSTANT;",
+				"000002_new.up.sql":      "ALTER TABLE todos ADD COLUMN b INT NULL;",
+				"000002_new.down.sql":    "ALTER TABLE todos DROP COLUMN b, ALGORITHM=INSTANT;",
+			},
+			baseline: 1,
+			expected: []string{
+				"000001_legacy.up.sql:1: locking-alter: ALTER TABLE on the large table todos without ALGORITHM=INPLACE or ALGORITHM=INSTANT may copy it and block its writes (baselined)",
+				"000002_new.up.sql:1: locking-alter: ALTER TABLE on the large table todos without ALGORITHM=INPLACE or ALGORITHM=INSTANT may copy it and block its writes",
+			},
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			fsys := fstest.MapFS{}
+			for file, content := range tt.files {
+				fsys[file] = &fstest.MapFile{Data: []byte(content)}
+			}
+
+			cfg := cfg
+			cfg.Baseline = tt.baseline
+			findings, err := migrationlint.Lint(fsys, cfg)
+			if err != nil {
+				t.Fatalf("Lint() error = %v", err)
+			}
+
+			var actual []string
+			for _, finding := range findings {
+				actual = append(actual, finding.String())
+			}
+			if diff := cmp.Diff(actual, tt.expected); diff != "" {
+				t.Fatalf("Lint() mismatch (-actual +expected):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLint_Migrations(t *testing.T) {
+	t.Parallel()
+
+	findings, err := migrationlint.Lint(migrations.FS, migrationlint.DefaultConfig())
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	for _, finding := range migrationlint.Failures(findings) {
+		t.Errorf("%s", finding)
+	}
+}
+
+func TestFailures(t *testing.T) {
+	t.Parallel()
+
+	findings := []migrationlint.Finding{
+		{File: "000001_legacy.up.sql", Line: 1, Rule: migrationlint.RuleLockingAlter, Baselined: true},
+		{File: "000002_new.up.sql", Line: 1, Rule: migrationlint.RuleLockingAlter},
+	}
+
+	if diff := cmp.Diff(migrationlint.Failures(findings), findings[1:]); diff != "" {
+		t.Fatalf("Failures() mismatch (-actual +expected):\n%s", diff)
+	}
+}
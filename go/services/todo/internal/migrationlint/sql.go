@@ -0,0 +1,192 @@
+package migrationlint
+
+import (
+	"regexp"
+	"strings"
+)
+
+// statement is a statement of a migration file without its comments, its
+// whitespace collapsed.
+type statement struct {
+	line int
+	sql  string
+	// ignored are the rules turned off by a directive right before it.
+	ignored []string
+}
+
+// sqlFile is a parsed migration file.
+type sqlFile struct {
+	name string
+	// version is the version of the migration of the file.
+	version    uint
+	statements []statement
+	// ignored are the rules turned off for the whole file.
+	ignored []string
+}
+
+// directivePattern matches the comments turning rules off, for the next
+// statement or for the whole file:
+//
+//	-- lint:ignore drop-column the column has been unused since v1.4.
+//	-- lint:ignore-file locking-alter,drop-column applied before the linter.
+var directivePattern = regexp.MustCompile(`^lint:(ignore|ignore-file)\s+([a-z-]+(?:\s*,\s*[a-z-]+)*)`)
+
+// parseSQL splits src into statements at the semicolons outside of quotes and
+// comments, and reads the directives of its comments.
+func parseSQL(name string, src string) *sqlFile {
+	f := &sqlFile{name: name}
+
+	var (
+		sb      strings.Builder
+		line    = 1
+		start   = 0 // line of the statement being read, 0 before its first token.
+		ignored []string
+	)
+	flush := func() {
+		sql := strings.Join(strings.Fields(sb.String()), " ")
+		if sql != "" {
+			f.statements = append(f.statements, statement{line: start, sql: sql, ignored: ignored})
+			ignored = nil
+		}
+		sb.Reset()
+		start = 0
+	}
+	comment := func(text string) {
+		match := directivePattern.FindStringSubmatch(strings.TrimSpace(text))
+		if match == nil {
+			return
+		}
+		var rules []string
+		for _, rule := range strings.Split(match[2], ",") {
+			rules = append(rules, strings.TrimSpace(rule))
+		}
+		if match[1] == "ignore-file" {
+			f.ignored = append(f.ignored, rules...)
+		} else {
+			ignored = append(ignored, rules...)
+		}
+	}
+
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+		switch {
+		case c == '\n':
+			line++
+			sb.WriteByte(' ')
+		case c == '#' || strings.HasPrefix(src[i:], "--"):
+			end := strings.IndexByte(src[i:], '\n')
+			if end < 0 {
+				end = len(src) - i
+			}
+			comment(strings.TrimLeft(src[i:i+end], "-#"))
+			// The newline is read next.
+			i += end - 1
+			sb.WriteByte(' ')
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				end = len(src) - i - 2
+			} else {
+				end += 2
+			}
+			block := src[i : i+2+end]
+			line += strings.Count(block, "\n")
+			i += len(block) - 1
+			sb.WriteByte(' ')
+		case c == '\'' || c == '"' || c == '`':
+			if start == 0 {
+				start = line
+			}
+			j := quoteEnd(src, i)
+			quoted := src[i:j]
+			line += strings.Count(quoted, "\n")
+			sb.WriteString(quoted)
+			i = j - 1
+		case c == ';':
+			flush()
+		default:
+			if start == 0 && c != ' ' && c != '\t' && c != '\r' {
+				start = line
+			}
+			sb.WriteByte(c)
+		}
+	}
+	flush()
+
+	return f
+}
+
+// quoteEnd returns the index after the end of the quoted string starting at
+// i, or len(src) when it is not closed.
+func quoteEnd(src string, i int) int {
+	quote := src[i]
+	for j := i + 1; j < len(src); j++ {
+		switch {
+		case src[j] == '\\' && quote != '`':
+			j++
+		case src[j] == quote && j+1 < len(src) && src[j+1] == quote:
+			j++
+		case src[j] == quote:
+			return j + 1
+		}
+	}
+	return len(src)
+}
+
+// splitTopLevel splits s at the commas outside of parentheses and quotes.
+func splitTopLevel(s string) []string {
+	var (
+		parts []string
+		depth int
+		from  int
+	)
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\'', '"', '`':
+			i = quoteEnd(s, i) - 1
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, strings.TrimSpace(s[from:i]))
+				from = i + 1
+			}
+		}
+	}
+	return append(parts, strings.TrimSpace(s[from:]))
+}
+
+// parenthesized returns the text between the parenthesis opening s and the
+// one closing it, and false when s does not start with a parenthesis.
+func parenthesized(s string) (string, bool) {
+	if !strings.HasPrefix(s, "(") {
+		return "", false
+	}
+
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\'', '"', '`':
+			i = quoteEnd(s, i) - 1
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return s[1:i], true
+			}
+		}
+	}
+	return "", false
+}
+
+// unquote removes the quotes around a literal or an identifier.
+func unquote(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && strings.ContainsRune("'\"`", rune(s[0])) && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
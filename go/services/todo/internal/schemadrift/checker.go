@@ -0,0 +1,156 @@
+package schemadrift
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+
+	"github.com/google/uuid"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/database/migrations"
+)
+
+// Checker builds schemas in scratch databases of a MySQL server.
+type Checker struct {
+	db           *sql.DB
+	dbNamePrefix string
+}
+
+// NewChecker creates its scratch databases through db, a connection to the
+// server that runs several statements at once (multiStatements=true). Their
+// names start with dbNamePrefix.
+func NewChecker(db *sql.DB, dbNamePrefix string) *Checker {
+	return &Checker{db: db, dbNamePrefix: dbNamePrefix}
+}
+
+// Check applies every migration to a scratch database and each schema file,
+// keyed by name, to another one, and returns the drifts of each file from the
+// migrations. Files without drift are left out.
+func (c *Checker) Check(ctx context.Context, files map[string]string) (map[string][]Drift, error) {
+	upFiles, err := migrationUpFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	migrated, err := c.build(ctx, false, upFiles...)
+	if err != nil {
+		return nil, fmt.Errorf("apply migrations: %w", err)
+	}
+
+	drifts := map[string][]Drift{}
+	for name, content := range files {
+		schema, err := c.build(ctx, false, content)
+		if err != nil {
+			return nil, fmt.Errorf("apply %s: %w", name, err)
+		}
+		if diff := Diff(migrated, schema); len(diff) > 0 {
+			drifts[name] = diff
+		}
+	}
+
+	return drifts, nil
+}
+
+// CheckSQLite is Check for SQLite schema files, each applied to an in-memory
+// SQLite database. Only the part of the schemas both dialects have is
+// compared, see LoadPortable.
+func (c *Checker) CheckSQLite(ctx context.Context, files map[string]string) (map[string][]Drift, error) {
+	upFiles, err := migrationUpFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	migrated, err := c.build(ctx, true, upFiles...)
+	if err != nil {
+		return nil, fmt.Errorf("apply migrations: %w", err)
+	}
+
+	drifts := map[string][]Drift{}
+	for name, content := range files {
+		schema, err := buildSQLite(ctx, content)
+		if err != nil {
+			return nil, fmt.Errorf("apply %s: %w", name, err)
+		}
+		if diff := Diff(migrated, schema); len(diff) > 0 {
+			drifts[name] = diff
+		}
+	}
+
+	return drifts, nil
+}
+
+// build runs the scripts in a new database and returns its schema, the
+// portable one if portable is set. The database is dropped afterwards.
+func (c *Checker) build(ctx context.Context, portable bool, scripts ...string) (Schema, error) {
+	conn, err := c.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	id := uuid.New()
+	dbName := fmt.Sprintf("%s_drift_%x", c.dbNamePrefix, id[:])
+	if _, err := conn.ExecContext(
+		ctx,
+		fmt.Sprintf("CREATE DATABASE `%s` DEFAULT CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci;", dbName),
+	); err != nil {
+		return nil, fmt.Errorf("create db: %w", err)
+	}
+	defer func() {
+		_, _ = conn.ExecContext(context.WithoutCancel(ctx), fmt.Sprintf("DROP DATABASE `%s`;", dbName))
+	}()
+
+	if _, err := conn.ExecContext(ctx, fmt.Sprintf("USE `%s`;", dbName)); err != nil {
+		return nil, fmt.Errorf("switch to db, dbName=%s: %w", dbName, err)
+	}
+	for _, script := range scripts {
+		if _, err := conn.ExecContext(ctx, script); err != nil {
+			return nil, err
+		}
+	}
+
+	if portable {
+		return LoadPortable(ctx, conn, dbName)
+	}
+	return Load(ctx, conn, dbName)
+}
+
+// buildSQLite runs the script in an in-memory SQLite database and returns its
+// schema.
+func buildSQLite(ctx context.Context, script string) (Schema, error) {
+	db, err := openSQLiteMemory()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	// Every connection to :memory: has its own database.
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, script); err != nil {
+		return nil, err
+	}
+	return LoadSQLite(ctx, conn)
+}
+
+func migrationUpFiles() ([]string, error) {
+	list, err := migrations.List(migrations.FS)
+	if err != nil {
+		return nil, err
+	}
+
+	upFiles := make([]string, 0, len(list))
+	for _, m := range list {
+		content, err := fs.ReadFile(migrations.FS, m.Up)
+		if err != nil {
+			return nil, err
+		}
+		upFiles = append(upFiles, string(content))
+	}
+	return upFiles, nil
+}
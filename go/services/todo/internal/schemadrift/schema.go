@@ -0,0 +1,302 @@
+// Package schemadrift compares the schema the migrations build with the
+// schema files kept apart from them, such as the test and docker ones.
+package schemadrift
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// migrationsTable is the version table of the migrate command, which the
+// schema files do not create.
+const migrationsTable = "schema_migrations"
+
+// Schema maps every object of a database, "column todos.status" say, to its
+// definition. Columns are compared by name, not by position.
+type Schema map[string]string
+
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// Load reads the tables, columns, indexes and constraints of the MySQL
+// database dbName.
+func Load(ctx context.Context, db queryer, dbName string) (Schema, error) {
+	return load(ctx, db, dbName, false)
+}
+
+// LoadPortable reads the part of the schema of the MySQL database dbName that
+// a SQLite schema has too, in the form of LoadSQLite: the tables, the
+// nullability and default of the columns, the indexes and the foreign keys,
+// named by their columns. Types and checks are left out, as the SQLite schema
+// translates them.
+func LoadPortable(ctx context.Context, db queryer, dbName string) (Schema, error) {
+	return load(ctx, db, dbName, true)
+}
+
+func load(ctx context.Context, db queryer, dbName string, portable bool) (Schema, error) {
+	loaders := []func(context.Context, queryer, string, bool, Schema) error{
+		loadTables,
+		loadColumns,
+		loadIndexes,
+		loadForeignKeys,
+	}
+	if !portable {
+		loaders = append(loaders, loadChecks)
+	}
+
+	schema := Schema{}
+	for _, load := range loaders {
+		if err := load(ctx, db, dbName, portable, schema); err != nil {
+			return nil, err
+		}
+	}
+
+	for object := range schema {
+		if strings.Contains(object, " "+migrationsTable) {
+			delete(schema, object)
+		}
+	}
+
+	return schema, nil
+}
+
+func loadTables(ctx context.Context, db queryer, dbName string, portable bool, schema Schema) error {
+	return scanRows(ctx, db, `
+        SELECT TABLE_NAME, ENGINE
+        FROM information_schema.TABLES
+        WHERE TABLE_SCHEMA = ? AND TABLE_TYPE = 'BASE TABLE'
+    `, dbName, func(rows *sql.Rows) error {
+		var table, engine sql.NullString
+		if err := rows.Scan(&table, &engine); err != nil {
+			return err
+		}
+		if portable {
+			schema["table "+table.String] = portableTable
+			return nil
+		}
+		schema["table "+table.String] = engine.String
+		return nil
+	})
+}
+
+func loadColumns(ctx context.Context, db queryer, dbName string, portable bool, schema Schema) error {
+	return scanRows(ctx, db, `
+        SELECT TABLE_NAME, COLUMN_NAME, COLUMN_TYPE, IS_NULLABLE, COLUMN_DEFAULT, EXTRA, CHARACTER_SET_NAME, COLLATION_NAME
+        FROM information_schema.COLUMNS
+        WHERE TABLE_SCHEMA = ?
+    `, dbName, func(rows *sql.Rows) error {
+		var table, column, columnType, nullable, extra string
+		var columnDefault, charset, collation sql.NullString
+		if err := rows.Scan(&table, &column, &columnType, &nullable, &columnDefault, &extra, &charset, &collation); err != nil {
+			return err
+		}
+
+		object := fmt.Sprintf("column %s.%s", table, column)
+		if portable {
+			schema[object] = portableColumn(nullable == "NO", columnDefault)
+			return nil
+		}
+
+		definition := []string{columnType}
+		if charset.Valid {
+			definition = append(definition, "CHARACTER SET "+charset.String, "COLLATE "+collation.String)
+		}
+		if nullable == "YES" {
+			definition = append(definition, "NULL")
+		} else {
+			definition = append(definition, "NOT NULL")
+		}
+		if columnDefault.Valid {
+			definition = append(definition, "DEFAULT "+columnDefault.String)
+		}
+		if extra != "" {
+			definition = append(definition, extra)
+		}
+
+		schema[object] = strings.Join(definition, " ")
+		return nil
+	})
+}
+
+func loadIndexes(ctx context.Context, db queryer, dbName string, _ bool, schema Schema) error {
+	type index struct {
+		unique  bool
+		columns []string
+	}
+	indexes := map[string]*index{}
+
+	err := scanRows(ctx, db, `
+        SELECT TABLE_NAME, INDEX_NAME, NON_UNIQUE, COLUMN_NAME
+        FROM information_schema.STATISTICS
+        WHERE TABLE_SCHEMA = ?
+        ORDER BY TABLE_NAME, INDEX_NAME, SEQ_IN_INDEX
+    `, dbName, func(rows *sql.Rows) error {
+		var table, name, column string
+		var nonUnique int
+		if err := rows.Scan(&table, &name, &nonUnique, &column); err != nil {
+			return err
+		}
+
+		object := fmt.Sprintf("index %s.%s", table, name)
+		if indexes[object] == nil {
+			indexes[object] = &index{unique: nonUnique == 0}
+		}
+		indexes[object].columns = append(indexes[object].columns, column)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for object, index := range indexes {
+		schema[object] = indexDefinition(index.unique, index.columns)
+	}
+	return nil
+}
+
+func loadChecks(ctx context.Context, db queryer, dbName string, _ bool, schema Schema) error {
+	return scanRows(ctx, db, `
+        SELECT tc.TABLE_NAME, cc.CONSTRAINT_NAME, cc.CHECK_CLAUSE
+        FROM information_schema.TABLE_CONSTRAINTS tc
+        JOIN information_schema.CHECK_CONSTRAINTS cc
+            ON cc.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA AND cc.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+        WHERE tc.TABLE_SCHEMA = ? AND tc.CONSTRAINT_TYPE = 'CHECK'
+    `, dbName, func(rows *sql.Rows) error {
+		var table, name, clause string
+		if err := rows.Scan(&table, &name, &clause); err != nil {
+			return err
+		}
+		schema[fmt.Sprintf("check %s.%s", table, name)] = clause
+		return nil
+	})
+}
+
+func loadForeignKeys(ctx context.Context, db queryer, dbName string, portable bool, schema Schema) error {
+	type foreignKey struct {
+		table             string
+		columns           []string
+		referencedTable   string
+		referencedColumns []string
+		updateRule        string
+		deleteRule        string
+	}
+	foreignKeys := map[string]*foreignKey{}
+
+	err := scanRows(ctx, db, `
+        SELECT kcu.TABLE_NAME, kcu.CONSTRAINT_NAME, kcu.COLUMN_NAME, kcu.REFERENCED_TABLE_NAME, kcu.REFERENCED_COLUMN_NAME, rc.UPDATE_RULE, rc.DELETE_RULE
+        FROM information_schema.KEY_COLUMN_USAGE kcu
+        JOIN information_schema.REFERENTIAL_CONSTRAINTS rc
+            ON rc.CONSTRAINT_SCHEMA = kcu.CONSTRAINT_SCHEMA AND rc.CONSTRAINT_NAME = kcu.CONSTRAINT_NAME
+        WHERE kcu.TABLE_SCHEMA = ?
+        ORDER BY kcu.TABLE_NAME, kcu.CONSTRAINT_NAME, kcu.ORDINAL_POSITION
+    `, dbName, func(rows *sql.Rows) error {
+		var table, name, column, referencedTable, referencedColumn, updateRule, deleteRule string
+		if err := rows.Scan(&table, &name, &column, &referencedTable, &referencedColumn, &updateRule, &deleteRule); err != nil {
+			return err
+		}
+
+		object := fmt.Sprintf("foreign key %s.%s", table, name)
+		if foreignKeys[object] == nil {
+			foreignKeys[object] = &foreignKey{
+				table:           table,
+				referencedTable: referencedTable,
+				updateRule:      updateRule,
+				deleteRule:      deleteRule,
+			}
+		}
+		foreignKeys[object].columns = append(foreignKeys[object].columns, column)
+		foreignKeys[object].referencedColumns = append(foreignKeys[object].referencedColumns, referencedColumn)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for object, fk := range foreignKeys {
+		if portable {
+			schema[portableForeignKeyObject(fk.table, fk.columns)] = foreignKeyDefinition(
+				fk.columns, fk.referencedTable, fk.referencedColumns, portableRule(fk.updateRule), portableRule(fk.deleteRule),
+			)
+			continue
+		}
+		schema[object] = foreignKeyDefinition(fk.columns, fk.referencedTable, fk.referencedColumns, fk.updateRule, fk.deleteRule)
+	}
+	return nil
+}
+
+func indexDefinition(unique bool, columns []string) string {
+	definition := "(" + strings.Join(columns, ", ") + ")"
+	if unique {
+		definition = "UNIQUE " + definition
+	}
+	return definition
+}
+
+func foreignKeyDefinition(columns []string, referencedTable string, referencedColumns []string, updateRule, deleteRule string) string {
+	return fmt.Sprintf(
+		"(%s) REFERENCES %s (%s) ON UPDATE %s ON DELETE %s",
+		strings.Join(columns, ", "),
+		referencedTable,
+		strings.Join(referencedColumns, ", "),
+		updateRule,
+		deleteRule,
+	)
+}
+
+func scanRows(ctx context.Context, db queryer, query string, arg string, scan func(rows *sql.Rows) error) error {
+	rows, err := db.QueryContext(ctx, query, arg)
+	if err != nil {
+		return fmt.Errorf("query schema: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return fmt.Errorf("scan schema: %w", err)
+		}
+	}
+	return rows.Err()
+}
+
+// Drift is an object defined differently by the migrations and a schema file.
+// An empty definition is a missing object: every object has a definition,
+// the engine of a table say.
+type Drift struct {
+	Object     string
+	Migrations string
+	File       string
+}
+
+func (d Drift) String() string {
+	switch {
+	case d.Migrations == "":
+		return fmt.Sprintf("%s: not created by the migrations", d.Object)
+	case d.File == "":
+		return fmt.Sprintf("%s: missing from the file", d.Object)
+	}
+	return fmt.Sprintf("%s: %s by the migrations, %s in the file", d.Object, d.Migrations, d.File)
+}
+
+// Diff returns the objects of migrations and file that differ, sorted.
+func Diff(migrations, file Schema) []Drift {
+	var drifts []Drift
+	for object, definition := range migrations {
+		if other, ok := file[object]; !ok || other != definition {
+			drifts = append(drifts, Drift{Object: object, Migrations: definition, File: other})
+		}
+	}
+	for object, definition := range file {
+		if _, ok := migrations[object]; !ok {
+			drifts = append(drifts, Drift{Object: object, File: definition})
+		}
+	}
+
+	sort.Slice(drifts, func(i, j int) bool {
+		return drifts[i].Object < drifts[j].Object
+	})
+	return drifts
+}
@@ -0,0 +1,69 @@
+package schemadrift_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/schemadrift"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/testutil"
+)
+
+func TestDiff(t *testing.T) {
+	type testcase struct {
+		file     schemadrift.Schema
+		expected []string
+	}
+
+	t.Parallel()
+
+	migrations := schemadrift.Schema{
+		"table todos":                     "InnoDB",
+		"column todos.status":             "tinyint unsigned NOT NULL",
+		"index todos.PRIMARY":             "UNIQUE (id)",
+		"check todos.check_status":        "(`status` in (0,1,2))",
+		"foreign key todos.fk_todos_user": "(user_id) REFERENCES users (id) ON UPDATE NO ACTION ON DELETE CASCADE",
+	}
+
+	testTables := map[string]testcase{
+		"No drift": {
+			file:     migrations,
+			expected: nil,
+		},
+		"Drifted, missing and extra objects": {
+			file: schemadrift.Schema{
+				"table todos":                     "InnoDB",
+				"column todos.status":             "tinyint unsigned NOT NULL DEFAULT 0",
+				"index todos.PRIMARY":             "UNIQUE (id)",
+				"index todos.idx_status":          "(status)",
+				"foreign key todos.fk_todos_user": "(user_id) REFERENCES users (id) ON UPDATE NO ACTION ON DELETE CASCADE",
+			},
+			expected: []string{
+				"check todos.check_status: missing from the file",
+				"column todos.status: tinyint unsigned NOT NULL by the migrations, tinyint unsigned NOT NULL DEFAULT 0 in the file",
+				"index todos.idx_status: not created by the migrations",
+			},
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var actual []string
+			for _, drift := range schemadrift.Diff(migrations, tt.file) {
+				actual = append(actual, drift.String())
+			}
+			if diff := cmp.Diff(actual, tt.expected); diff != "" {
+				t.Fatalf("mismatch (-actual +expected):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSchemaFilesMatchTheMigrations(t *testing.T) {
+	t.Parallel()
+
+	testutil.CheckSchemaDrift(t)
+}
@@ -0,0 +1,230 @@
+package schemadrift
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// portableTable is the definition of every table of a portable schema, whose
+// engine is left out.
+const portableTable = "table"
+
+// LoadSQLite reads the schema of the SQLite database of db in the form of
+// LoadPortable, to compare it with the schema the migrations build in MySQL.
+// The primary key of a table is its index PRIMARY, as in MySQL.
+func LoadSQLite(ctx context.Context, db queryer) (Schema, error) {
+	var tables []string
+	err := scanRows(ctx, db, `
+        SELECT name
+        FROM sqlite_master
+        WHERE type = 'table' AND name NOT LIKE ?
+    `, "sqlite_%", func(rows *sql.Rows) error {
+		var table string
+		if err := rows.Scan(&table); err != nil {
+			return err
+		}
+		tables = append(tables, table)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	schema := Schema{}
+	for _, table := range tables {
+		schema["table "+table] = portableTable
+		for _, load := range []func(context.Context, queryer, string, Schema) error{
+			loadSQLiteColumns,
+			loadSQLiteIndexes,
+			loadSQLiteForeignKeys,
+		} {
+			if err := load(ctx, db, table, schema); err != nil {
+				return nil, fmt.Errorf("load table %s: %w", table, err)
+			}
+		}
+	}
+
+	return schema, nil
+}
+
+func loadSQLiteColumns(ctx context.Context, db queryer, table string, schema Schema) error {
+	type primaryKeyColumn struct {
+		name     string
+		position int
+	}
+	var primaryKey []primaryKeyColumn
+
+	err := scanRows(ctx, db, `
+        SELECT name, "notnull", dflt_value, pk
+        FROM pragma_table_info(?)
+    `, table, func(rows *sql.Rows) error {
+		var (
+			column        string
+			notNull       bool
+			columnDefault sql.NullString
+			pk            int
+		)
+		if err := rows.Scan(&column, &notNull, &columnDefault, &pk); err != nil {
+			return err
+		}
+
+		// SQLite lets primary key columns be NULL, MySQL does not.
+		schema[fmt.Sprintf("column %s.%s", table, column)] = portableColumn(notNull || pk > 0, columnDefault)
+		if pk > 0 {
+			primaryKey = append(primaryKey, primaryKeyColumn{name: column, position: pk})
+		}
+		return nil
+	})
+	if err != nil || len(primaryKey) == 0 {
+		return err
+	}
+
+	sort.Slice(primaryKey, func(i, j int) bool {
+		return primaryKey[i].position < primaryKey[j].position
+	})
+	columns := make([]string, 0, len(primaryKey))
+	for _, column := range primaryKey {
+		columns = append(columns, column.name)
+	}
+	schema[fmt.Sprintf("index %s.PRIMARY", table)] = indexDefinition(true, columns)
+	return nil
+}
+
+func loadSQLiteIndexes(ctx context.Context, db queryer, table string, schema Schema) error {
+	type index struct {
+		name   string
+		unique bool
+	}
+	var indexes []index
+
+	// The index of the primary key is loaded with the columns.
+	err := scanRows(ctx, db, `
+        SELECT name, "unique"
+        FROM pragma_index_list(?)
+        WHERE origin <> 'pk'
+    `, table, func(rows *sql.Rows) error {
+		var i index
+		if err := rows.Scan(&i.name, &i.unique); err != nil {
+			return err
+		}
+		indexes = append(indexes, i)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, i := range indexes {
+		var columns []string
+		err := scanRows(ctx, db, `
+            SELECT name
+            FROM pragma_index_info(?)
+            ORDER BY seqno
+        `, i.name, func(rows *sql.Rows) error {
+			var column string
+			if err := rows.Scan(&column); err != nil {
+				return err
+			}
+			columns = append(columns, column)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		schema[fmt.Sprintf("index %s.%s", table, i.name)] = indexDefinition(i.unique, columns)
+	}
+	return nil
+}
+
+func loadSQLiteForeignKeys(ctx context.Context, db queryer, table string, schema Schema) error {
+	type foreignKey struct {
+		columns           []string
+		referencedTable   string
+		referencedColumns []string
+		updateRule        string
+		deleteRule        string
+	}
+	var foreignKeys []*foreignKey
+
+	// SQLite does not keep the names of the foreign keys, only their ids.
+	err := scanRows(ctx, db, `
+        SELECT id, "table", "from", "to", on_update, on_delete
+        FROM pragma_foreign_key_list(?)
+        ORDER BY id, seq
+    `, table, func(rows *sql.Rows) error {
+		var (
+			id                                  int
+			referencedTable, column, referenced string
+			updateRule, deleteRule              string
+		)
+		if err := rows.Scan(&id, &referencedTable, &column, &referenced, &updateRule, &deleteRule); err != nil {
+			return err
+		}
+
+		if id >= len(foreignKeys) {
+			foreignKeys = append(foreignKeys, &foreignKey{
+				referencedTable: referencedTable,
+				updateRule:      updateRule,
+				deleteRule:      deleteRule,
+			})
+		}
+		fk := foreignKeys[len(foreignKeys)-1]
+		fk.columns = append(fk.columns, column)
+		fk.referencedColumns = append(fk.referencedColumns, referenced)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, fk := range foreignKeys {
+		schema[portableForeignKeyObject(table, fk.columns)] = foreignKeyDefinition(
+			fk.columns, fk.referencedTable, fk.referencedColumns, portableRule(fk.updateRule), portableRule(fk.deleteRule),
+		)
+	}
+	return nil
+}
+
+// portableColumn is the definition of a column in a portable schema: its
+// nullability and default.
+func portableColumn(notNull bool, columnDefault sql.NullString) string {
+	definition := "NULL"
+	if notNull {
+		definition = "NOT NULL"
+	}
+	if columnDefault.Valid {
+		definition += " DEFAULT " + portableDefault(columnDefault.String)
+	}
+	return definition
+}
+
+// portableDefault writes a default as MySQL reports it: string literals
+// without their quotes, and booleans as numbers.
+func portableDefault(value string) string {
+	if len(value) >= 2 && strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'") {
+		return strings.ReplaceAll(value[1:len(value)-1], "''", "'")
+	}
+	switch strings.ToUpper(value) {
+	case "FALSE":
+		return "0"
+	case "TRUE":
+		return "1"
+	}
+	return value
+}
+
+// portableRule names the default referential action of both dialects the
+// same: MySQL reports RESTRICT or NO ACTION, and they behave the same there.
+func portableRule(rule string) string {
+	if strings.EqualFold(rule, "RESTRICT") {
+		return "NO ACTION"
+	}
+	return strings.ToUpper(rule)
+}
+
+func portableForeignKeyObject(table string, columns []string) string {
+	return fmt.Sprintf("foreign key %s(%s)", table, strings.Join(columns, ", "))
+}
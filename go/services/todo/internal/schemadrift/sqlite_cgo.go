@@ -0,0 +1,14 @@
+//go:build cgo
+
+package schemadrift
+
+import (
+	"database/sql"
+
+	"gorm.io/driver/sqlite"
+)
+
+// openSQLiteMemory opens an in-memory SQLite database.
+func openSQLiteMemory() (*sql.DB, error) {
+	return sql.Open(sqlite.DriverName, ":memory:")
+}
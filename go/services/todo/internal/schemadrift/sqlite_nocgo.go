@@ -0,0 +1,14 @@
+//go:build !cgo
+
+package schemadrift
+
+import (
+	"database/sql"
+	"errors"
+)
+
+// openSQLiteMemory fails: the sqlite driver needs cgo, and this binary was
+// built without it.
+func openSQLiteMemory() (*sql.DB, error) {
+	return nil, errors.New("sqlite is not supported by this build: build with CGO_ENABLED=1")
+}
@@ -0,0 +1,76 @@
+//go:build cgo
+
+package schemadrift_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gorm.io/driver/sqlite"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/schemadrift"
+)
+
+func TestLoadSQLite(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open(sqlite.DriverName, ":memory:")
+	if err != nil {
+		t.Fatalf("open db: %s", err)
+	}
+	defer db.Close()
+
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		t.Fatalf("open db conn: %s", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(context.Background(), `
+CREATE TABLE users (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    email VARCHAR(255) NULL,
+    read_only BOOLEAN NOT NULL DEFAULT FALSE
+);
+CREATE UNIQUE INDEX ui_users_email ON users (email);
+
+CREATE TABLE todos (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    position VARCHAR(255) NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT check_todos_position CHECK (position <> 'x'),
+    CONSTRAINT fk_todos_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX idx_todos_user_id_position ON todos (user_id, position);
+`); err != nil {
+		t.Fatalf("create tables: %s", err)
+	}
+
+	schema, err := schemadrift.LoadSQLite(context.Background(), conn)
+	if err != nil {
+		t.Fatalf("LoadSQLite() error = %v", err)
+	}
+
+	expected := schemadrift.Schema{
+		"table users":                            "table",
+		"column users.id":                        "NOT NULL",
+		"column users.email":                     "NULL",
+		"column users.read_only":                 "NOT NULL DEFAULT 0",
+		"index users.PRIMARY":                    "UNIQUE (id)",
+		"index users.ui_users_email":             "UNIQUE (email)",
+		"table todos":                            "table",
+		"column todos.id":                        "NOT NULL",
+		"column todos.user_id":                   "NOT NULL",
+		"column todos.position":                  "NOT NULL DEFAULT ",
+		"column todos.created_at":                "NOT NULL DEFAULT CURRENT_TIMESTAMP",
+		"index todos.PRIMARY":                    "UNIQUE (id)",
+		"index todos.idx_todos_user_id_position": "(user_id, position)",
+		"foreign key todos(user_id)":             "(user_id) REFERENCES users (id) ON UPDATE NO ACTION ON DELETE CASCADE",
+	}
+	if diff := cmp.Diff(schema, expected); diff != "" {
+		t.Fatalf("LoadSQLite() mismatch (-actual +expected):\n%s", diff)
+	}
+}
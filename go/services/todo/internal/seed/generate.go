@@ -0,0 +1,128 @@
+// Package seed generates demo data for development databases.
+package seed
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+// Config is the shape of the generated data. The same config always generates
+// the same data.
+type Config struct {
+	Users        int
+	TodosPerUser int
+	Seed         int64
+	// Now is the time the data is generated at: every time is before it,
+	// within History.
+	Now     time.Time
+	History time.Duration
+}
+
+var (
+	firstNames = []string{
+		"alice", "bao", "carlos", "dung", "emma", "farid", "giang", "hana",
+		"ivan", "julia", "kenji", "linh", "minh", "nora", "omar", "phuong",
+	}
+	verbs = []string{
+		"Write", "Review", "Fix", "Plan", "Book", "Clean", "Call", "Buy",
+		"Prepare", "Send", "Update", "Read",
+	}
+	objects = []string{
+		"the quarterly report", "the pull request", "the flaky test", "the team offsite",
+		"a dentist appointment", "the garage", "the bank", "groceries",
+		"the slides", "the invoice", "the README", "the design doc",
+	}
+	descriptions = []string{
+		"Before the end of the week.",
+		"Ask for feedback first.",
+		"Check the notes from the last meeting.",
+		"Low priority.",
+	}
+)
+
+// Generate returns cfg.Users users with cfg.TodosPerUser todos each, listed
+// in the order of their positions. IDs are left to the database: the todos
+// are inserted with the ID of their user once it is known.
+//
+// About half the todos are pending, a fifth in progress and the rest done,
+// some have a due date or a description and a few are deleted. Users are
+// created within cfg.History before cfg.Now, their todos after them.
+func Generate(cfg Config) []*todo.UserData {
+	rng := rand.New(rand.NewSource(cfg.Seed))
+	now := cfg.Now.Truncate(time.Second)
+
+	users := make([]*todo.UserData, 0, cfg.Users)
+	for i := 0; i < cfg.Users; i++ {
+		createdAt := between(rng, now.Add(-cfg.History), now)
+		username := fmt.Sprintf("%s%d", firstNames[rng.Intn(len(firstNames))], i+1)
+		email := username + "@example.com"
+
+		positions := todo.EvenPositions(cfg.TodosPerUser)
+		todos := make([]*todo.Todo, 0, cfg.TodosPerUser)
+		for j := 0; j < cfg.TodosPerUser; j++ {
+			todos = append(todos, generateTodo(rng, createdAt, now, positions[j]))
+		}
+
+		users = append(users, &todo.UserData{
+			User: &todo.User{
+				Username:  username,
+				Email:     &email,
+				CreatedAt: createdAt,
+				UpdatedAt: createdAt,
+			},
+			Todos: todos,
+		})
+	}
+
+	return users
+}
+
+func generateTodo(rng *rand.Rand, userCreatedAt time.Time, now time.Time, position string) *todo.Todo {
+	createdAt := between(rng, userCreatedAt, now)
+	t := &todo.Todo{
+		Task:      fmt.Sprintf("%s %s", verbs[rng.Intn(len(verbs))], objects[rng.Intn(len(objects))]),
+		Position:  position,
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+	}
+
+	if rng.Intn(3) == 0 {
+		description := descriptions[rng.Intn(len(descriptions))]
+		t.Description = &description
+	}
+	if rng.Intn(5) < 2 {
+		dueAt := createdAt.Add(time.Duration(1+rng.Intn(30)) * 24 * time.Hour).Truncate(time.Hour)
+		t.DueAt = &dueAt
+	}
+
+	switch n := rng.Intn(10); {
+	case n < 5:
+		t.Status = todo.Pending
+	case n < 7:
+		t.UpdatedAt = between(rng, createdAt, now)
+		t.Status = todo.InProcess
+	default:
+		t.UpdatedAt = between(rng, createdAt, now)
+		t.SetStatus(todo.Done, t.UpdatedAt)
+	}
+
+	if rng.Intn(20) == 0 {
+		t.UpdatedAt = between(rng, t.UpdatedAt, now)
+		deletedAt := t.UpdatedAt
+		t.DeletedAt = &deletedAt
+	}
+
+	return t
+}
+
+// between returns a random time in [from, to], to the second.
+func between(rng *rand.Rand, from time.Time, to time.Time) time.Time {
+	seconds := int64(to.Sub(from) / time.Second)
+	if seconds <= 0 {
+		return from
+	}
+	return from.Add(time.Duration(rng.Int63n(seconds+1)) * time.Second)
+}
@@ -0,0 +1,77 @@
+package seed_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/seed"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	cfg := seed.Config{
+		Users:        20,
+		TodosPerUser: 15,
+		Seed:         42,
+		Now:          time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		History:      90 * 24 * time.Hour,
+	}
+	data := seed.Generate(cfg)
+
+	if diff := cmp.Diff(data, seed.Generate(cfg)); diff != "" {
+		t.Fatalf("Generate() is not deterministic (-first +second):\n%s", diff)
+	}
+
+	other := cfg
+	other.Seed = 43
+	if cmp.Equal(data, seed.Generate(other)) {
+		t.Fatal("Generate() returned the same data for another seed")
+	}
+
+	if len(data) != cfg.Users {
+		t.Fatalf("Generate() returned %d users, want %d", len(data), cfg.Users)
+	}
+
+	statuses := map[todo.TodoStatus]int{}
+	emails := map[string]struct{}{}
+	for _, d := range data {
+		u := d.User
+		if u.CreatedAt.Before(cfg.Now.Add(-cfg.History)) || u.CreatedAt.After(cfg.Now) {
+			t.Fatalf("user %s created at %s, out of the history", u.Username, u.CreatedAt)
+		}
+		if _, ok := emails[*u.Email]; ok {
+			t.Fatalf("email %s is generated twice", *u.Email)
+		}
+		emails[*u.Email] = struct{}{}
+
+		if len(d.Todos) != cfg.TodosPerUser {
+			t.Fatalf("user %s has %d todos, want %d", u.Username, len(d.Todos), cfg.TodosPerUser)
+		}
+		for i, td := range d.Todos {
+			statuses[td.Status]++
+
+			if !td.Status.IsValid() {
+				t.Fatalf("todo %d of %s has status %d", i, u.Username, td.Status)
+			}
+			if i > 0 && d.Todos[i-1].Position >= td.Position {
+				t.Fatalf("todos of %s are not in the order of their positions", u.Username)
+			}
+			if td.CreatedAt.Before(u.CreatedAt) || td.UpdatedAt.Before(td.CreatedAt) || td.UpdatedAt.After(cfg.Now) {
+				t.Fatalf("todo %d of %s has times out of order: %+v", i, u.Username, td)
+			}
+			if (td.Status == todo.Done) != (td.CompletedAt != nil) {
+				t.Fatalf("todo %d of %s has status %s and completed at %v", i, u.Username, td.Status, td.CompletedAt)
+			}
+		}
+	}
+
+	for _, status := range []todo.TodoStatus{todo.Pending, todo.InProcess, todo.Done} {
+		if statuses[status] == 0 {
+			t.Fatalf("Generate() generated no %s todo", status)
+		}
+	}
+}
@@ -30,6 +30,7 @@ var getTemplateDB = sync.OnceValues(func() (*TemplateDB, error) {
 	env := LoadEnv()
 	db := NewTemplateDB(
 		&TemplateDBConfig{
+			Dialect:      env.DBDialect,
 			DBHost:       env.DBHost,
 			DBPort:       env.DBPort,
 			DBUser:       env.DBUser,
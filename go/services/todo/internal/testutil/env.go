@@ -5,6 +5,10 @@ import (
 )
 
 type TestEnv struct {
+	// DBDialect is mysql or sqlite. The DB_HOST, DB_PORT, DB_USER and DB_PASS
+	// settings are not used by sqlite.
+	DBDialect string `envconfig:"DB_DIALECT" default:"mysql"`
+
 	DBHost string `envconfig:"DB_HOST"`
 	DBPort int    `envconfig:"DB_PORT"`
 	DBUser string `envconfig:"DB_USER"`
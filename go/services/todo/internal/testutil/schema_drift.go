@@ -0,0 +1,93 @@
+package testutil
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"testing"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/schemadrift"
+)
+
+// schemaFiles are the schema files kept apart from the migrations, relative
+// to the database directory.
+var schemaFiles = []string{
+	"test/sqls/import/create_tables.sql",
+	"docker/sqls/import/create_tables.sql",
+}
+
+// sqliteSchemaFiles are the SQLite translations of the migrations.
+var sqliteSchemaFiles = []string{
+	"sqlite/create_tables.sql",
+}
+
+// CheckSchemaDrift fails the test for every table, column, index or
+// constraint of the schema files that differs from the schema the migrations
+// build. The SQLite schema files are compared without types and checks. It
+// needs MySQL, and is skipped with sqlite.
+func CheckSchemaDrift(t *testing.T) {
+	t.Helper()
+
+	env := LoadEnv()
+	if env.DBDialect == DialectSQLite {
+		t.Skip("schema drift is checked against MySQL")
+	}
+
+	db, err := sql.Open("mysql", fmt.Sprintf(
+		"%s:%s@tcp(%s:%d)/?parseTime=true&multiStatements=true",
+		env.DBUser,
+		env.DBPass,
+		env.DBHost,
+		env.DBPort,
+	))
+	if err != nil {
+		t.Fatalf("open db conn: %s", err)
+	}
+	defer db.Close()
+
+	_, currentFilename, _, ok := runtime.Caller(0)
+	if !ok {
+		panic("runtime.Caller error")
+	}
+	databaseDir := filepath.Join(filepath.Dir(currentFilename), "../../database")
+
+	readFiles := func(names []string) map[string]string {
+		files := map[string]string{}
+		for _, name := range names {
+			content, err := os.ReadFile(filepath.Join(databaseDir, name))
+			if err != nil {
+				t.Fatalf("read schema file: %s", err)
+			}
+			files[name] = string(content)
+		}
+		return files
+	}
+
+	checker := schemadrift.NewChecker(db, env.DBName)
+	drifts, err := checker.Check(context.Background(), readFiles(schemaFiles))
+	if err != nil {
+		t.Fatalf("check schema drift: %s", err)
+	}
+	sqliteDrifts, err := checker.CheckSQLite(context.Background(), readFiles(sqliteSchemaFiles))
+	if err != nil {
+		t.Fatalf("check sqlite schema drift: %s", err)
+	}
+	for name, diff := range sqliteDrifts {
+		drifts[name] = diff
+	}
+
+	names := make([]string, 0, len(drifts))
+	for name := range drifts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, drift := range drifts[name] {
+			t.Errorf("%s: %s", name, drift)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-testfixtures/testfixtures/v3"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/datastore"
+)
+
+// DialectSQLite runs the tests against sqlite database files instead of
+// MySQL, so that they need no database server.
+const DialectSQLite = "sqlite"
+
+// testLocation is the location test databases read times in.
+const testLocation = "Asia/Tokyo"
+
+// initSQLite creates the template database file in a new temporary directory
+// and loads the fixtures into it.
+func (db *TemplateDB) initSQLite() error {
+	loc, err := time.LoadLocation(testLocation)
+	if err != nil {
+		return fmt.Errorf("load location: %w", err)
+	}
+
+	db.sqliteDir, err = os.MkdirTemp("", db.cfg.DBNamePrefix+"_sqlite_")
+	if err != nil {
+		return fmt.Errorf("create temp dir: %w", err)
+	}
+
+	id := uuid.New()
+	db.dbName = filepath.Join(db.sqliteDir, fmt.Sprintf("%s_template_%x.db", db.cfg.DBNamePrefix, id[:]))
+
+	gormDB, err := datastore.OpenSQLite(db.dbName, loc)
+	if err != nil {
+		return fmt.Errorf("open db: %w", err)
+	}
+
+	db.db, err = gormDB.DB()
+	if err != nil {
+		return fmt.Errorf("get gorm underlying db: %w", err)
+	}
+
+	fixtures, err := testfixtures.New(
+		testfixtures.Database(db.db),
+		testfixtures.Dialect("sqlite"),
+		testfixtures.Directory(db.cfg.FixturesDir),
+		// The file has just been created in a temporary directory.
+		testfixtures.DangerousSkipTestDatabaseCheck(),
+	)
+	if err != nil {
+		return fmt.Errorf("create fixtures loader: %w", err)
+	}
+
+	if err := fixtures.Load(); err != nil {
+		return fmt.Errorf("load fixtures: %w", err)
+	}
+
+	if err := db.loadTableNames(); err != nil {
+		return fmt.Errorf("load table names: %w", err)
+	}
+
+	if err := db.convertFixtureValues(loc); err != nil {
+		return fmt.Errorf("convert fixture values: %w", err)
+	}
+
+	return nil
+}
+
+// convertFixtureValues stores the fixture values the way the service writes
+// them. Times keep their wall clock and get the offset of loc, the way MySQL,
+// which stores no offset, reads them back in the location of the connection.
+// Blobs, which are loaded as text, are cast back to blobs.
+func (db *TemplateDB) convertFixtureValues(loc *time.Location) error {
+	offset := time.Now().In(loc).Format("-07:00")
+
+	for _, tableName := range db.tableNames {
+		for columnType, set := range map[string]string{
+			"DATETIME": "SUBSTR(`%s`, 1, 19) || '" + offset + "'",
+			"BLOB":     "CAST(`%s` AS BLOB)",
+		} {
+			columns, err := db.columnsOfType(tableName, columnType)
+			if err != nil {
+				return fmt.Errorf("get columns, table = %s: %w", tableName, err)
+			}
+
+			for _, column := range columns {
+				if _, err := db.db.ExecContext(
+					context.TODO(),
+					fmt.Sprintf(
+						"UPDATE `%s` SET `%s` = %s WHERE `%s` IS NOT NULL",
+						tableName, column, fmt.Sprintf(set, column), column,
+					),
+				); err != nil {
+					return fmt.Errorf("update %s.%s: %w", tableName, column, err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (db *TemplateDB) columnsOfType(tableName string, columnType string) ([]string, error) {
+	rows, err := db.db.QueryContext(
+		context.TODO(),
+		"SELECT name FROM pragma_table_info(?) WHERE type = ?",
+		tableName,
+		columnType,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("table info: %w", err)
+	}
+	defer rows.Close()
+
+	var columns []string
+	for rows.Next() {
+		var column string
+		if err := rows.Scan(&column); err != nil {
+			return nil, fmt.Errorf("scan row: %w", err)
+		}
+		columns = append(columns, column)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sql rows: %w", err)
+	}
+
+	return columns, nil
+}
+
+// newSQLiteTestDB copies the template database file into a new file.
+func (db *TemplateDB) newSQLiteTestDB() (*TestDB, error) {
+	id := uuid.New()
+	path := filepath.Join(db.sqliteDir, fmt.Sprintf("%s_%x.db", db.cfg.DBNamePrefix, id[:]))
+
+	if _, err := db.db.ExecContext(context.TODO(), "VACUUM INTO ?", path); err != nil {
+		return nil, fmt.Errorf("clone db: %w", err)
+	}
+
+	return &TestDB{
+		dialect: DialectSQLite,
+		dsn:     path,
+		dbName:  path,
+	}, nil
+}
+
+// cleanupSQLite removes the temporary directory of the template database and
+// closes the underlying database connection.
+func (db *TemplateDB) cleanupSQLite() error {
+	if err := db.db.Close(); err != nil {
+		return fmt.Errorf("db conn close: %w", err)
+	}
+
+	if err := os.RemoveAll(db.sqliteDir); err != nil {
+		return fmt.Errorf("remove db dir: %w", err)
+	}
+
+	return nil
+}
+
+func (db *TestDB) openSQLite() (*gorm.DB, error) {
+	loc, err := time.LoadLocation(testLocation)
+	if err != nil {
+		return nil, fmt.Errorf("load location: %w", err)
+	}
+
+	return datastore.OpenSQLite(db.dsn, loc)
+}
+
+// cleanupSQLite removes the test database file with its journal files.
+func (db *TestDB) cleanupSQLite() error {
+	for _, suffix := range []string{"", "-wal", "-shm"} {
+		if err := os.Remove(db.dsn + suffix); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("remove db file: %w", err)
+		}
+	}
+
+	return nil
+}
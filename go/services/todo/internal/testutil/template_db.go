@@ -13,10 +13,13 @@ import (
 
 	"github.com/go-testfixtures/testfixtures/v3"
 	"github.com/google/uuid"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/datastore"
 )
 
 // TemplateDBConfig represents a config for creating TemplateDB.
 type TemplateDBConfig struct {
+	Dialect      string
 	DBHost       string
 	DBPort       int
 	DBUser       string
@@ -32,7 +35,8 @@ type TemplateDB struct {
 	cfg *TemplateDBConfig
 
 	dsn         string
-	dbName      string       // name of db.
+	dbName      string       // name of db, the path of the file for sqlite.
+	sqliteDir   string       // directory of the sqlite db files.
 	db          *sql.DB      // connection to db.
 	tableNames  []string     // list of table names of db.
 	foreignKeys []ForeignKey // list of foreign keys of db.
@@ -56,6 +60,14 @@ func (db *TemplateDB) Init() error {
 		return nil
 	}
 
+	if db.cfg.Dialect == DialectSQLite {
+		if err := db.initSQLite(); err != nil {
+			return fmt.Errorf("init sqlite db: %w", err)
+		}
+		db.initialized = true
+		return nil
+	}
+
 	db.dsn = fmt.Sprintf(
 		"%s:%s@tcp(%s:%d)/?parseTime=true&multiStatements=true",
 		db.cfg.DBUser,
@@ -161,109 +173,32 @@ func (db *TemplateDB) loadFixtures() error {
 
 // loadTableNames loads all table names in database.
 func (db *TemplateDB) loadTableNames() error {
-	rows, err := db.db.QueryContext(
-		context.TODO(),
-		fmt.Sprintf(`SHOW TABLES FROM %s`, db.dbName),
-	)
+	tableNames, err := datastore.LoadTableNames(context.TODO(), db.db, db.cfg.Dialect, db.dbName)
 	if err != nil {
-		return fmt.Errorf("show tables: %w", err)
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var tableName string
-		if err := rows.Scan(&tableName); err != nil {
-			return fmt.Errorf("scan row: %w", err)
-		}
-		db.tableNames = append(db.tableNames, tableName)
-	}
-
-	if err := rows.Err(); err != nil {
-		return fmt.Errorf("sql rows: %w", err)
+		return err
 	}
+	db.tableNames = tableNames
 
 	return nil
 }
 
-type ForeignKey struct {
-	TableName            string
-	ConstraintName       string
-	ColumnName           string
-	ReferencedTableName  string
-	ReferencedColumnName string
-	UpdateRule           string
-	DeleteRule           string
-}
+type ForeignKey = datastore.ForeignKey
 
 // loadForeignKeys gets all foreign keys from database.
 func (db *TemplateDB) loadForeignKeys() error {
-	for _, tableName := range db.tableNames {
-		foreignKeys, err := db.getForeignKeysFromTable(tableName)
-		if err != nil {
-			return fmt.Errorf("get foreign key, table = %s: %w", tableName, err)
-		}
-		db.foreignKeys = append(db.foreignKeys, foreignKeys...)
-	}
-
-	return nil
-}
-
-// getForeignKeysFromTable gets all foreign keys of a table from database.
-func (db *TemplateDB) getForeignKeysFromTable(tableName string) ([]ForeignKey, error) {
-	query := `
-        SELECT
-            kcu.constraint_name,
-            kcu.column_name,
-            kcu.referenced_table_name,
-            kcu.referenced_column_name,
-            rc.update_rule,
-            rc.delete_rule
-        FROM
-            information_schema.TABLE_CONSTRAINTS tc
-        JOIN
-            information_schema.KEY_COLUMN_USAGE kcu ON tc.constraint_name = kcu.constraint_name AND tc.table_schema = kcu.table_schema AND tc.table_name = kcu.table_name
-        JOIN
-            information_schema.REFERENTIAL_CONSTRAINTS rc ON tc.constraint_name = rc.constraint_name AND tc.table_schema = rc.constraint_schema
-        WHERE
-            tc.constraint_type = 'FOREIGN KEY'
-            AND tc.table_schema = ?
-            AND tc.table_name = ?
-    `
-	rows, err := db.db.QueryContext(
+	foreignKeys, err := datastore.LoadForeignKeys(
 		context.TODO(),
-		query,
+		db.db,
+		db.cfg.Dialect,
 		db.dbName,
-		tableName,
+		db.tableNames,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("get foreign keys: %w", err)
-	}
-
-	defer rows.Close()
-
-	var foreignKeys []ForeignKey
-	for rows.Next() {
-		fk := ForeignKey{
-			TableName: tableName,
-		}
-		if err := rows.Scan(
-			&fk.ConstraintName,
-			&fk.ColumnName,
-			&fk.ReferencedTableName,
-			&fk.ReferencedColumnName,
-			&fk.UpdateRule,
-			&fk.DeleteRule,
-		); err != nil {
-			return nil, err
-		}
-		foreignKeys = append(foreignKeys, fk)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("sql rows: %w", err)
+		return err
 	}
+	db.foreignKeys = foreignKeys
 
-	return foreignKeys, nil
+	return nil
 }
 
 // NewTestDB creates a new test database by cloning the schema and data from the
@@ -277,6 +212,10 @@ func (db *TemplateDB) NewTestDB() (*TestDB, error) {
 		return nil, errors.New("db is not initialized")
 	}
 
+	if db.cfg.Dialect == DialectSQLite {
+		return db.newSQLiteTestDB()
+	}
+
 	id := uuid.New()
 	dbName := fmt.Sprintf("%s_%x", db.cfg.DBNamePrefix, id[:])
 
@@ -358,6 +297,10 @@ func (db *TemplateDB) cleanup() error {
 	db.tableNames = nil
 	db.foreignKeys = nil
 
+	if db.cfg.Dialect == DialectSQLite {
+		return db.cleanupSQLite()
+	}
+
 	if _, err := db.db.ExecContext(
 		context.TODO(),
 		fmt.Sprintf("DROP DATABASE `%s`;", db.dbName),
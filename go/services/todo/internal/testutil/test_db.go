@@ -14,9 +14,10 @@ import (
 // TestDB represents a database for testing, it's designed for running with
 // a specific testcase.
 type TestDB struct {
-	dsn    string
-	dbName string
-	db     *sql.DB
+	dialect string
+	dsn     string // path of the file for sqlite.
+	dbName  string
+	db      *sql.DB
 
 	cleanedUp bool
 	mu        sync.RWMutex
@@ -50,10 +51,15 @@ func (db *TestDB) Open() (*gorm.DB, error) {
 		return nil, errors.New("db already cleaned up")
 	}
 
+	if db.dialect == DialectSQLite {
+		return db.openSQLite()
+	}
+
 	return gorm.Open(
 		mysql.Open(db.dsn),
 		&gorm.Config{
 			SkipDefaultTransaction: true,
+			TranslateError:         true,
 		},
 	)
 }
@@ -69,6 +75,10 @@ func (db *TestDB) Cleanup() error {
 
 	db.cleanedUp = true
 
+	if db.dialect == DialectSQLite {
+		return db.cleanupSQLite()
+	}
+
 	if _, err := db.db.ExecContext(
 		context.TODO(),
 		fmt.Sprintf("DROP DATABASE `%s`;", db.dbName),
@@ -83,7 +93,8 @@ func (db *TestDB) Cleanup() error {
 	return nil
 }
 
-// DBName returns the name of the test database.
+// DBName returns the name of the test database, the path of its file for
+// sqlite.
 func (db *TestDB) DBName() string {
 	return db.dbName
 }
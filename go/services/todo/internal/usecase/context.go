@@ -0,0 +1,17 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+// withUser makes userID the user of ctx, so that the binder picks the shard
+// that keeps the data of userID, also when the request is made by a tool or
+// on behalf of another user.
+func withUser(ctx context.Context, userID todo.UserID) context.Context {
+	if user := todo.ExtractUser(ctx); user != nil && user.ID == userID {
+		return ctx
+	}
+	return todo.WithUser(ctx, &todo.User{ID: userID})
+}
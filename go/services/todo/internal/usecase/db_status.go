@@ -0,0 +1,28 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/errors"
+)
+
+type dbStatusInteractor struct {
+	statusReader gateway.DBStatusQueriesGateway
+}
+
+func NewDBStatusUsecase(statusReader gateway.DBStatusQueriesGateway) DBStatusUsecase {
+	return &dbStatusInteractor{
+		statusReader: statusReader,
+	}
+}
+
+func (u *dbStatusInteractor) GetDBStatus(ctx context.Context) ([]*todo.DBPoolStatus, error) {
+	statuses, err := u.statusReader.ListDBPoolStatuses(ctx)
+	if err != nil {
+		return nil, errors.NewInternalError("GetDBStatus: failed to list pool statuses", err)
+	}
+
+	return statuses, nil
+}
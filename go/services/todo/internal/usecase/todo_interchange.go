@@ -0,0 +1,273 @@
+package usecase
+
+import (
+	"context"
+	stderrors "errors"
+	"io"
+	"sort"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/errors"
+)
+
+type todoInterchangeInteractor struct {
+	binder     gateway.Binder
+	todoReader gateway.TodoQueriesGateway
+	todoWriter gateway.TodoCommandsGateway
+	codecs     map[todo.FileFormat]gateway.TodoCodec
+}
+
+func NewTodoInterchangeUsecase(
+	binder gateway.Binder,
+	todoReader gateway.TodoQueriesGateway,
+	todoWriter gateway.TodoCommandsGateway,
+	codecs map[todo.FileFormat]gateway.TodoCodec,
+) TodoInterchangeUsecase {
+	return &todoInterchangeInteractor{
+		binder:     binder,
+		todoReader: todoReader,
+		todoWriter: todoWriter,
+		codecs:     codecs,
+	}
+}
+
+// ExportTodos writes the todos of the user in their manual order.
+func (u *todoInterchangeInteractor) ExportTodos(
+	ctx context.Context,
+	userID todo.UserID,
+	format todo.FileFormat,
+	w io.Writer,
+) error {
+	codec, err := u.codec(format)
+	if err != nil {
+		return err
+	}
+
+	ctx = u.binder.Bind(withUser(ctx, userID))
+	todos, _, err := u.todoReader.ListTodos(ctx, userID, todo.SortingTypes.Position)
+	if err != nil {
+		return errors.NewInternalError("ExportTodos: failed to list todos", err)
+	}
+
+	if err := codec.Encode(w, todos); err != nil {
+		return errors.NewInternalError("ExportTodos: failed to encode todos", err)
+	}
+
+	return nil
+}
+
+// ImportTodos creates a todo for every valid row. Invalid rows, and rows that
+// fail to be written, are reported in the returned report instead of failing
+// the whole file. A row whose
+// external ID appears earlier in the file is skipped, and so is a row whose
+// external ID is already used by one of the user's todos, unless
+// opts.UpdateExisting is set, in which case that todo is updated. With
+// opts.DryRun nothing is written.
+func (u *todoInterchangeInteractor) ImportTodos(
+	ctx context.Context,
+	userID todo.UserID,
+	format todo.FileFormat,
+	r io.Reader,
+	opts todo.ImportOptions,
+) (*todo.ImportReport, error) {
+	codec, err := u.codec(format)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, rowErrors, err := codec.Decode(r)
+	if err != nil {
+		return nil, errors.NewParameterError(
+			"ImportTodos: failed to read file",
+			err,
+			nil,
+			errors.ToMetadata("format", string(format)),
+		)
+	}
+
+	report := &todo.ImportReport{
+		DryRun: opts.DryRun,
+		Errors: rowErrors,
+	}
+
+	validRows := make([]*todo.ImportRow, 0, len(rows))
+	for _, row := range rows {
+		if errs := row.Validate(); len(errs) > 0 {
+			report.Errors = append(report.Errors, errs...)
+			continue
+		}
+		validRows = append(validRows, row)
+	}
+
+	// A replica lagging behind a previous import would let rows be created
+	// twice.
+	ctx = gateway.WithPrimaryReads(u.binder.Bind(withUser(ctx, userID)))
+	existing, err := u.listExisting(ctx, userID, validRows)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{}, len(validRows))
+	for _, row := range validRows {
+		var current *todo.Todo
+		if row.ExternalID != nil {
+			if _, ok := seen[*row.ExternalID]; ok {
+				report.Skipped++
+				continue
+			}
+			seen[*row.ExternalID] = struct{}{}
+			current = existing[*row.ExternalID]
+		}
+
+		switch {
+		case current == nil:
+			created, err := u.create(ctx, userID, row, opts.DryRun)
+			if err != nil {
+				// The rows left would fail the same way.
+				if ctx.Err() != nil {
+					return nil, err
+				}
+				report.Errors = append(report.Errors, todo.ImportRowError{
+					Line:    row.Line,
+					Field:   "todo",
+					Message: "failed to create todo",
+				})
+				continue
+			}
+			if created {
+				report.Created++
+			} else {
+				report.Skipped++
+			}
+		case opts.UpdateExisting && !current.IsDeleted():
+			if err := u.update(ctx, current, row, opts.DryRun); err != nil {
+				if ctx.Err() != nil {
+					return nil, err
+				}
+				report.Errors = append(report.Errors, todo.ImportRowError{
+					Line:    row.Line,
+					Field:   "todo",
+					Message: "failed to update todo",
+				})
+				continue
+			}
+			report.Updated++
+		default:
+			report.Skipped++
+		}
+	}
+
+	sort.SliceStable(report.Errors, func(i, j int) bool {
+		return report.Errors[i].Line < report.Errors[j].Line
+	})
+
+	return report, nil
+}
+
+// listExisting returns the user's todos, soft-deleted ones included, that use
+// the external IDs of the rows, by external ID. ExportTodos writes the todo ID
+// of a todo without an external ID in its place, marked by todo.ExportedID, so
+// such an ID finds that todo too. Other external IDs are only matched with
+// external IDs, even when they are numbers.
+func (u *todoInterchangeInteractor) listExisting(
+	ctx context.Context,
+	userID todo.UserID,
+	rows []*todo.ImportRow,
+) (map[string]*todo.Todo, error) {
+	externalIDs := make([]string, 0, len(rows))
+	todoIDs := make([]todo.TodoID, 0, len(rows))
+	for _, row := range rows {
+		if row.ExternalID == nil {
+			continue
+		}
+		externalIDs = append(externalIDs, *row.ExternalID)
+		if id, ok := todo.ParseExportedID(*row.ExternalID); ok {
+			todoIDs = append(todoIDs, id)
+		}
+	}
+
+	todos, err := u.todoReader.ListTodosByExternalIDs(ctx, userID, externalIDs)
+	if err != nil {
+		return nil, errors.NewInternalError("ImportTodos: failed to list existing todos", err)
+	}
+	todosByID, err := u.todoReader.ListTodosByIDs(ctx, userID, todoIDs)
+	if err != nil {
+		return nil, errors.NewInternalError("ImportTodos: failed to list existing todos", err)
+	}
+
+	existing := make(map[string]*todo.Todo, len(todos)+len(todosByID))
+	for _, t := range todosByID {
+		if t.ExternalID == nil {
+			existing[todo.ExportedID(t.ID)] = t
+		}
+	}
+	// A todo using the external ID wins over the todo of the same ID.
+	for _, t := range todos {
+		if t.ExternalID != nil {
+			existing[*t.ExternalID] = t
+		}
+	}
+
+	return existing, nil
+}
+
+// create returns false when another import created the same external ID in
+// the meantime.
+func (u *todoInterchangeInteractor) create(
+	ctx context.Context,
+	userID todo.UserID,
+	row *todo.ImportRow,
+	dryRun bool,
+) (bool, error) {
+	if dryRun {
+		return true, nil
+	}
+
+	if _, err := u.todoWriter.CreateTodo(ctx, row.NewTodo(userID)); err != nil {
+		if stderrors.Is(err, todo.ErrDuplicateKey) {
+			return false, nil
+		}
+		return false, errors.NewInternalError(
+			"ImportTodos: failed to create todo",
+			err,
+			errors.ToMetadataInt("line", row.Line),
+		)
+	}
+
+	return true, nil
+}
+
+func (u *todoInterchangeInteractor) update(
+	ctx context.Context,
+	current *todo.Todo,
+	row *todo.ImportRow,
+	dryRun bool,
+) error {
+	if dryRun {
+		return nil
+	}
+
+	if _, err := u.todoWriter.UpdateTodo(ctx, current.ID, current.UserID, row.UpdateTodo()); err != nil {
+		return errors.NewInternalError(
+			"ImportTodos: failed to update todo",
+			err,
+			errors.ToMetadataInt("line", row.Line),
+		)
+	}
+
+	return nil
+}
+
+func (u *todoInterchangeInteractor) codec(format todo.FileFormat) (gateway.TodoCodec, error) {
+	codec, ok := u.codecs[format]
+	if !ok {
+		return nil, errors.NewParameterError(
+			"unsupported file format",
+			nil,
+			nil,
+			errors.ToMetadata("format", string(format)),
+		)
+	}
+	return codec, nil
+}
@@ -0,0 +1,296 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/phamquanandpad/training-project/go/pkg/cast"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/interchange"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/usecase"
+)
+
+type fakeBinder struct{}
+
+func (fakeBinder) Bind(ctx context.Context) context.Context { return ctx }
+
+type fakeTodoStore struct {
+	todos   []*todo.Todo
+	created []todo.NewTodo
+	updated []todo.UpdateTodo
+	// failTasks are the tasks of the todos that fail to be written.
+	failTasks map[string]bool
+}
+
+func (s *fakeTodoStore) GetTodo(_ context.Context, _ todo.TodoID, _ todo.UserID) (*todo.Todo, error) {
+	return nil, nil
+}
+
+func (s *fakeTodoStore) ListTodos(_ context.Context, _ todo.UserID, _ todo.SortingType) ([]*todo.Todo, int, error) {
+	return s.todos, len(s.todos), nil
+}
+
+func (s *fakeTodoStore) ListTodosByExternalIDs(_ context.Context, userID todo.UserID, externalIDs []string) ([]*todo.Todo, error) {
+	var todos []*todo.Todo
+	for _, t := range s.todos {
+		for _, id := range externalIDs {
+			if t.UserID == userID && t.ExternalID != nil && *t.ExternalID == id {
+				todos = append(todos, t)
+			}
+		}
+	}
+	return todos, nil
+}
+
+func (s *fakeTodoStore) ListTodosByIDs(_ context.Context, userID todo.UserID, todoIDs []todo.TodoID) ([]*todo.Todo, error) {
+	var todos []*todo.Todo
+	for _, t := range s.todos {
+		for _, id := range todoIDs {
+			if t.UserID == userID && t.ID == id {
+				todos = append(todos, t)
+			}
+		}
+	}
+	return todos, nil
+}
+
+func (s *fakeTodoStore) CreateTodo(_ context.Context, newTodo todo.NewTodo) (*todo.Todo, error) {
+	if s.failTasks[newTodo.Task] {
+		return nil, errors.New("write failed")
+	}
+	s.created = append(s.created, newTodo)
+	return &todo.Todo{UserID: newTodo.UserID, ExternalID: newTodo.ExternalID, Task: newTodo.Task}, nil
+}
+
+func (s *fakeTodoStore) UpdateTodo(_ context.Context, id todo.TodoID, userID todo.UserID, updateTodo todo.UpdateTodo) (*todo.Todo, error) {
+	if s.failTasks[*updateTodo.Task] {
+		return nil, errors.New("write failed")
+	}
+	s.updated = append(s.updated, updateTodo)
+	return &todo.Todo{ID: id, UserID: userID}, nil
+}
+
+func (s *fakeTodoStore) SoftDeleteTodo(_ context.Context, _ todo.TodoID, _ todo.UserID) error {
+	return nil
+}
+
+func (s *fakeTodoStore) MoveTodo(_ context.Context, _ todo.TodoID, _ todo.UserID, _ todo.MoveTodo) (*todo.Todo, error) {
+	return nil, nil
+}
+
+func Test_todoInterchangeInteractor_ImportTodos(t *testing.T) {
+	type args struct {
+		format todo.FileFormat
+		input  string
+		opts   todo.ImportOptions
+	}
+
+	type expected struct {
+		report  *todo.ImportReport
+		created []string
+		updated []string
+	}
+
+	type testcase struct {
+		args      args
+		failTasks map[string]bool
+		expected  expected
+		wantErr   bool
+	}
+
+	t.Parallel()
+
+	input := "external_id,task,status\n" +
+		"ext-1,already imported,pending\n" +
+		"ext-2,new todo,done\n" +
+		"ext-2,duplicated in file,done\n" +
+		",,pending\n" +
+		"ext-3,bad status,7\n" +
+		",no external id,in_progress\n"
+
+	testTables := map[string]testcase{
+		"Import valid rows and report the others": {
+			args: args{format: todo.FileFormats.CSV, input: input},
+			expected: expected{
+				report: &todo.ImportReport{
+					Created: 2,
+					Skipped: 2,
+					Errors: []todo.ImportRowError{
+						{Line: 5, Field: "task", Message: "must not be empty"},
+						{Line: 6, Field: "status", Message: "invalid status 7"},
+					},
+				},
+				created: []string{"new todo", "no external id"},
+			},
+			wantErr: false,
+		},
+		"Dry run writes nothing": {
+			args: args{format: todo.FileFormats.CSV, input: input, opts: todo.ImportOptions{DryRun: true}},
+			expected: expected{
+				report: &todo.ImportReport{
+					DryRun:  true,
+					Created: 2,
+					Skipped: 2,
+					Errors: []todo.ImportRowError{
+						{Line: 5, Field: "task", Message: "must not be empty"},
+						{Line: 6, Field: "status", Message: "invalid status 7"},
+					},
+				},
+				created: nil,
+			},
+			wantErr: false,
+		},
+		"Update todos that already use the external ID": {
+			args: args{format: todo.FileFormats.CSV, input: input, opts: todo.ImportOptions{UpdateExisting: true}},
+			expected: expected{
+				report: &todo.ImportReport{
+					Created: 2,
+					Updated: 1,
+					Skipped: 1,
+					Errors: []todo.ImportRowError{
+						{Line: 5, Field: "task", Message: "must not be empty"},
+						{Line: 6, Field: "status", Message: "invalid status 7"},
+					},
+				},
+				created: []string{"new todo", "no external id"},
+				updated: []string{"already imported"},
+			},
+			wantErr: false,
+		},
+		"Report the rows that fail to be written and go on": {
+			args:      args{format: todo.FileFormats.CSV, input: input, opts: todo.ImportOptions{UpdateExisting: true}},
+			failTasks: map[string]bool{"new todo": true, "already imported": true},
+			expected: expected{
+				report: &todo.ImportReport{
+					Created: 1,
+					Skipped: 1,
+					Errors: []todo.ImportRowError{
+						{Line: 2, Field: "todo", Message: "failed to update todo"},
+						{Line: 3, Field: "todo", Message: "failed to create todo"},
+						{Line: 5, Field: "task", Message: "must not be empty"},
+						{Line: 6, Field: "status", Message: "invalid status 7"},
+					},
+				},
+				created: []string{"no external id"},
+			},
+			wantErr: false,
+		},
+		"Match numeric external IDs with external IDs only": {
+			args: args{format: todo.FileFormats.CSV, input: "external_id,task\n2,from another app\ntodo:2,exported\n"},
+			expected: expected{
+				report: &todo.ImportReport{
+					Created: 1,
+					Skipped: 1,
+				},
+				created: []string{"from another app"},
+			},
+			wantErr: false,
+		},
+		"Unsupported format": {
+			args:    args{format: "xlsx", input: input},
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			store := &fakeTodoStore{
+				todos: []*todo.Todo{
+					{ID: 1, UserID: 1, ExternalID: cast.Ptr("ext-1"), Task: "already imported"},
+					{ID: 2, UserID: 1, Task: "created in the app"},
+				},
+				failTasks: tt.failTasks,
+			}
+			u := usecase.NewTodoInterchangeUsecase(fakeBinder{}, store, store, interchange.NewCodecs())
+
+			report, err := u.ImportTodos(context.Background(), 1, tt.args.format, strings.NewReader(tt.args.input), tt.args.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(report, tt.expected.report); diff != "" {
+				t.Fatalf("report mismatch (-actual +expected):\n%s", diff)
+			}
+
+			var created []string
+			for _, c := range store.created {
+				created = append(created, c.Task)
+			}
+			if diff := cmp.Diff(created, tt.expected.created); diff != "" {
+				t.Fatalf("created todos mismatch (-actual +expected):\n%s", diff)
+			}
+
+			var updated []string
+			for _, u := range store.updated {
+				updated = append(updated, *u.Task)
+			}
+			if diff := cmp.Diff(updated, tt.expected.updated); diff != "" {
+				t.Fatalf("updated todos mismatch (-actual +expected):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_todoInterchangeInteractor_ExportThenImportTodos(t *testing.T) {
+	type testcase struct {
+		format todo.FileFormat
+	}
+
+	t.Parallel()
+
+	testTables := map[string]testcase{
+		"CSV":                {format: todo.FileFormats.CSV},
+		"JSON Lines":         {format: todo.FileFormats.JSONLines},
+		"iCalendar":          {format: todo.FileFormats.ICalendar},
+		"todo.txt":           {format: todo.FileFormats.TodoTxt},
+		"Markdown checklist": {format: todo.FileFormats.Markdown},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			store := &fakeTodoStore{
+				todos: []*todo.Todo{
+					{ID: 1, UserID: 1, ExternalID: cast.Ptr("ext-1"), Task: "imported", Status: todo.Pending},
+					{ID: 2, UserID: 1, Task: "created in the app", Status: todo.InProcess},
+					{ID: 3, UserID: 1, Task: "done in the app", Status: todo.Done},
+				},
+			}
+			u := usecase.NewTodoInterchangeUsecase(fakeBinder{}, store, store, interchange.NewCodecs())
+
+			var file strings.Builder
+			if err := u.ExportTodos(context.Background(), 1, tt.format, &file); err != nil {
+				t.Fatalf("ExportTodos() error = %v", err)
+			}
+
+			report, err := u.ImportTodos(
+				context.Background(),
+				1,
+				tt.format,
+				strings.NewReader(file.String()),
+				todo.ImportOptions{UpdateExisting: true},
+			)
+			if err != nil {
+				t.Fatalf("ImportTodos() error = %v", err)
+			}
+
+			// Every todo is found again, those created in the app by their ID.
+			expected := &todo.ImportReport{Updated: 3}
+			if diff := cmp.Diff(report, expected); diff != "" {
+				t.Fatalf("report mismatch (-actual +expected):\n%s", diff)
+			}
+			if len(store.created) != 0 {
+				t.Fatalf("created %d todos, want none", len(store.created))
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/errors"
+)
+
+type todoOrderingInteractor struct {
+	binder     gateway.Binder
+	todoWriter gateway.TodoCommandsGateway
+}
+
+func NewTodoOrderingUsecase(
+	binder gateway.Binder,
+	todoWriter gateway.TodoCommandsGateway,
+) TodoOrderingUsecase {
+	return &todoOrderingInteractor{
+		binder:     binder,
+		todoWriter: todoWriter,
+	}
+}
+
+// MoveTodo places the todo right before or right after another todo of the
+// same user.
+func (u *todoOrderingInteractor) MoveTodo(
+	ctx context.Context,
+	todoID todo.TodoID,
+	userID todo.UserID,
+	move todo.MoveTodo,
+) (*todo.Todo, error) {
+	if !move.IsValid() {
+		return nil, errors.NewParameterError(
+			"MoveTodo: exactly one of before and after must be set",
+			nil,
+			nil,
+			errors.ToMetadata("todo_id", todoID.String()),
+		)
+	}
+	targetID := move.TargetID()
+	if targetID == todoID {
+		return nil, errors.NewParameterError(
+			"MoveTodo: a todo cannot be moved next to itself",
+			nil,
+			nil,
+			errors.ToMetadata("todo_id", todoID.String()),
+		)
+	}
+
+	ctx = u.binder.Bind(withUser(ctx, userID))
+
+	moved, err := u.todoWriter.MoveTodo(ctx, todoID, userID, move)
+	if err != nil {
+		return nil, errors.NewInternalError("MoveTodo: failed to move todo", err)
+	}
+	if moved == nil {
+		return nil, errors.NewNotFoundError(
+			"MoveTodo: todo not found",
+			nil,
+			nil,
+			errors.ToMetadata("todo_id", todoID.String()),
+			errors.ToMetadata("target_id", targetID.String()),
+		)
+	}
+
+	return moved, nil
+}
@@ -0,0 +1,99 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	apperrors "github.com/phamquanandpad/training-project/go/services/todo/internal/errors"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/usecase"
+)
+
+type fakeTodoMover struct {
+	fakeTodoStore
+	moved   *todo.Todo
+	moveErr error
+}
+
+func (m *fakeTodoMover) MoveTodo(_ context.Context, _ todo.TodoID, _ todo.UserID, _ todo.MoveTodo) (*todo.Todo, error) {
+	return m.moved, m.moveErr
+}
+
+func Test_todoOrderingInteractor_MoveTodo(t *testing.T) {
+	type args struct {
+		todoID todo.TodoID
+		move   todo.MoveTodo
+	}
+
+	type testcase struct {
+		args     args
+		moved    *todo.Todo
+		moveErr  error
+		expected *todo.Todo
+		checkErr func(error) bool
+	}
+
+	t.Parallel()
+
+	moved := &todo.Todo{ID: 1, UserID: 1, Position: "m"}
+
+	testTables := map[string]testcase{
+		"Move a todo after another": {
+			args:     args{todoID: 1, move: todo.MoveTodo{AfterID: todo.NewTodoID(2)}},
+			moved:    moved,
+			expected: moved,
+		},
+		"Return parameter error when no target is set": {
+			args:     args{todoID: 1, move: todo.MoveTodo{}},
+			checkErr: apperrors.IsParameterError,
+		},
+		"Return parameter error when both targets are set": {
+			args:     args{todoID: 1, move: todo.MoveTodo{BeforeID: todo.NewTodoID(2), AfterID: todo.NewTodoID(3)}},
+			checkErr: apperrors.IsParameterError,
+		},
+		"Return parameter error when the target is the todo itself": {
+			args:     args{todoID: 1, move: todo.MoveTodo{BeforeID: todo.NewTodoID(1)}},
+			checkErr: apperrors.IsParameterError,
+		},
+		"Return not found error when a todo does not exist": {
+			args:     args{todoID: 1, move: todo.MoveTodo{BeforeID: todo.NewTodoID(2)}},
+			checkErr: apperrors.IsNotFoundErr,
+		},
+		"Return internal error when the move fails": {
+			args:    args{todoID: 1, move: todo.MoveTodo{BeforeID: todo.NewTodoID(2)}},
+			moveErr: errors.New("connection lost"),
+			checkErr: func(err error) bool {
+				var appErr apperrors.AppError
+				return errors.As(err, &appErr) && appErr.Elem.Type == apperrors.ErrorTypes.InternalError
+			},
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			mover := &fakeTodoMover{moved: tt.moved, moveErr: tt.moveErr}
+			u := usecase.NewTodoOrderingUsecase(fakeBinder{}, mover)
+
+			actual, err := u.MoveTodo(context.Background(), tt.args.todoID, 1, tt.args.move)
+			if (err != nil) != (tt.checkErr != nil) {
+				t.Fatalf("error = %v", err)
+			}
+			if err != nil {
+				if !tt.checkErr(err) {
+					t.Fatalf("unexpected error type: %v", err)
+				}
+				return
+			}
+
+			if diff := cmp.Diff(actual, tt.expected); diff != "" {
+				t.Fatalf("mismatch (-actual +expected):\n%s", diff)
+			}
+		})
+	}
+}
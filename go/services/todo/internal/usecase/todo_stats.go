@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/errors"
+)
+
+type todoStatsInteractor struct {
+	binder      gateway.Binder
+	statsReader gateway.TodoStatsQueriesGateway
+}
+
+func NewTodoStatsUsecase(
+	binder gateway.Binder,
+	statsReader gateway.TodoStatsQueriesGateway,
+) TodoStatsUsecase {
+	return &todoStatsInteractor{
+		binder:      binder,
+		statsReader: statsReader,
+	}
+}
+
+// GetTodoStats summarizes the todos the user created in [param.From,
+// param.To) and pages through their activity series, oldest bucket first
+// unless param.Paging.SortingOrder says otherwise.
+func (u *todoStatsInteractor) GetTodoStats(
+	ctx context.Context,
+	param todo.TodoStatsParam,
+) (*todo.TodoStats, error) {
+	if !param.From.Before(param.To) {
+		return nil, errors.NewParameterError(
+			"GetTodoStats: from must be before to",
+			nil,
+			nil,
+			errors.ToMetadata("from", param.From.String()),
+			errors.ToMetadata("to", param.To.String()),
+		)
+	}
+	if !param.BucketSize.IsValid() {
+		return nil, errors.NewParameterError(
+			"GetTodoStats: unsupported bucket size",
+			nil,
+			nil,
+			errors.ToMetadata("bucket_size", string(param.BucketSize)),
+		)
+	}
+	if param.Paging.Size == 0 {
+		param.Paging.Size = todo.DefaultStatsPageSize
+	}
+	if param.Paging.Size < 0 || param.Paging.Size > todo.MaxStatsPageSize {
+		return nil, errors.NewParameterError(
+			"GetTodoStats: invalid page size",
+			nil,
+			nil,
+			errors.ToMetadataInt("page_size", param.Paging.Size),
+		)
+	}
+	if param.Paging.SortingOrder == "" {
+		param.Paging.SortingOrder = todo.SortingOrders.Asc
+	}
+	if !param.Paging.SortingOrder.IsValid() {
+		return nil, errors.NewParameterError(
+			"GetTodoStats: invalid sorting order",
+			nil,
+			nil,
+			errors.ToMetadata("sorting_order", string(param.Paging.SortingOrder)),
+		)
+	}
+
+	ctx = u.binder.Bind(withUser(ctx, param.UserID))
+
+	counts, err := u.statsReader.CountTodosByStatus(ctx, param.UserID, param.From, param.To)
+	if err != nil {
+		return nil, errors.NewInternalError("GetTodoStats: failed to count todos", err)
+	}
+
+	averageCompletionTime, err := u.statsReader.GetAverageCompletionTime(ctx, param.UserID, param.From, param.To)
+	if err != nil {
+		return nil, errors.NewInternalError("GetTodoStats: failed to get average completion time", err)
+	}
+
+	activity, err := u.statsReader.ListTodoActivity(ctx, param)
+	if err != nil {
+		if errors.IsParameterError(err) {
+			return nil, err
+		}
+		return nil, errors.NewInternalError("GetTodoStats: failed to list activity", err)
+	}
+
+	stats := todo.NewTodoStats(counts, averageCompletionTime)
+	stats.Activity = activity
+
+	return stats, nil
+}
@@ -0,0 +1,154 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/phamquanandpad/training-project/go/pkg/cast"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/errors"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/usecase"
+)
+
+type fakeStatsReader struct {
+	counts      map[todo.TodoStatus]int
+	average     *time.Duration
+	activity    []*todo.TodoActivityBucket
+	activityErr error
+	param       todo.TodoStatsParam
+}
+
+func (r *fakeStatsReader) CountTodosByStatus(_ context.Context, _ todo.UserID, _, _ time.Time) (map[todo.TodoStatus]int, error) {
+	return r.counts, nil
+}
+
+func (r *fakeStatsReader) GetAverageCompletionTime(_ context.Context, _ todo.UserID, _, _ time.Time) (*time.Duration, error) {
+	return r.average, nil
+}
+
+func (r *fakeStatsReader) ListTodoActivity(_ context.Context, param todo.TodoStatsParam) (*todo.Page[*todo.TodoActivityBucket], error) {
+	r.param = param
+	if r.activityErr != nil {
+		return nil, r.activityErr
+	}
+	return &todo.Page[*todo.TodoActivityBucket]{Items: r.activity}, nil
+}
+
+func Test_todoStatsInteractor_GetTodoStats(t *testing.T) {
+	type expected struct {
+		stats  *todo.TodoStats
+		paging todo.CursorPagingParam
+	}
+
+	type testcase struct {
+		args        todo.TodoStatsParam
+		activityErr error
+		expected    expected
+		wantErr     bool
+	}
+
+	t.Parallel()
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	activity := []*todo.TodoActivityBucket{
+		{StartAt: from, Created: 3, Completed: 1},
+	}
+
+	testTables := map[string]testcase{
+		"Summarize counts with the default paging": {
+			args: todo.TodoStatsParam{UserID: 1, From: from, To: to, BucketSize: todo.StatsBucketSizes.Day},
+			expected: expected{
+				stats: &todo.TodoStats{
+					CountsByStatus: map[todo.TodoStatus]int{
+						todo.Pending:   1,
+						todo.InProcess: 0,
+						todo.Done:      3,
+					},
+					Total:                 4,
+					CompletionRate:        0.75,
+					AverageCompletionTime: cast.Ptr(90 * time.Minute),
+					Activity:              &todo.Page[*todo.TodoActivityBucket]{Items: activity},
+				},
+				paging: todo.CursorPagingParam{Size: todo.DefaultStatsPageSize, SortingOrder: todo.SortingOrders.Asc},
+			},
+			wantErr: false,
+		},
+		"Return error when the range is empty": {
+			args:    todo.TodoStatsParam{UserID: 1, From: to, To: from, BucketSize: todo.StatsBucketSizes.Day},
+			wantErr: true,
+		},
+		"Return error for an unsupported bucket size": {
+			args:    todo.TodoStatsParam{UserID: 1, From: from, To: to, BucketSize: "year"},
+			wantErr: true,
+		},
+		"Return error for a page size over the maximum": {
+			args: todo.TodoStatsParam{
+				UserID:     1,
+				From:       from,
+				To:         to,
+				BucketSize: todo.StatsBucketSizes.Day,
+				Paging:     todo.CursorPagingParam{Size: todo.MaxStatsPageSize + 1},
+			},
+			wantErr: true,
+		},
+		"Return error for a sorting order other than asc or desc": {
+			args: todo.TodoStatsParam{
+				UserID:     1,
+				From:       from,
+				To:         to,
+				BucketSize: todo.StatsBucketSizes.Day,
+				Paging:     todo.CursorPagingParam{SortingOrder: "ASC, (SELECT 1)"},
+			},
+			wantErr: true,
+		},
+		"Return the parameter error of an invalid page token": {
+			args: todo.TodoStatsParam{
+				UserID:     1,
+				From:       from,
+				To:         to,
+				BucketSize: todo.StatsBucketSizes.Day,
+				Paging:     todo.CursorPagingParam{Token: cast.Ptr("invalid")},
+			},
+			activityErr: errors.NewParameterError("invalid page token", nil, nil),
+			wantErr:     true,
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			reader := &fakeStatsReader{
+				counts:      map[todo.TodoStatus]int{todo.Pending: 1, todo.Done: 3},
+				average:     cast.Ptr(90 * time.Minute),
+				activity:    activity,
+				activityErr: tt.activityErr,
+			}
+			u := usecase.NewTodoStatsUsecase(fakeBinder{}, reader)
+
+			stats, err := u.GetTodoStats(context.Background(), tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !errors.IsParameterError(err) {
+					t.Fatalf("error = %v, want a parameter error", err)
+				}
+				return
+			}
+
+			if diff := cmp.Diff(stats, tt.expected.stats); diff != "" {
+				t.Fatalf("stats mismatch (-actual +expected):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(reader.param.Paging, tt.expected.paging); diff != "" {
+				t.Fatalf("paging mismatch (-actual +expected):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+	"io"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+type TodoInterchangeUsecase interface {
+	ExportTodos(ctx context.Context, userID todo.UserID, format todo.FileFormat, w io.Writer) error
+	ImportTodos(
+		ctx context.Context,
+		userID todo.UserID,
+		format todo.FileFormat,
+		r io.Reader,
+		opts todo.ImportOptions,
+	) (*todo.ImportReport, error)
+}
+
+type TodoStatsUsecase interface {
+	GetTodoStats(ctx context.Context, param todo.TodoStatsParam) (*todo.TodoStats, error)
+}
+
+type TodoOrderingUsecase interface {
+	MoveTodo(ctx context.Context, todoID todo.TodoID, userID todo.UserID, move todo.MoveTodo) (*todo.Todo, error)
+}
+
+type UserUsecase interface {
+	GetUser(ctx context.Context, userID todo.UserID) (*todo.User, error)
+	GetUserWithTodos(ctx context.Context, userID todo.UserID, paging todo.CursorPagingParam) (*todo.UserWithTodos, error)
+	CreateUser(ctx context.Context, newUser todo.NewUser) (*todo.User, error)
+	UpdateUser(ctx context.Context, userID todo.UserID, updateUser todo.UpdateUser) (*todo.User, error)
+	DeleteUser(ctx context.Context, userID todo.UserID) error
+}
+
+type UserDataUsecase interface {
+	ExportUserData(ctx context.Context, userID todo.UserID, w io.Writer) error
+	EraseUser(ctx context.Context, userID todo.UserID) error
+}
+
+type DBStatusUsecase interface {
+	GetDBStatus(ctx context.Context) ([]*todo.DBPoolStatus, error)
+}
@@ -0,0 +1,204 @@
+package usecase
+
+import (
+	"context"
+	stderrors "errors"
+	"strings"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/errors"
+)
+
+type userInteractor struct {
+	binder              gateway.Binder
+	userReader          gateway.UserQueriesGateway
+	userWithTodosReader gateway.UserWithTodosQueriesGateway
+	userWriter          gateway.UserCommandsGateway
+}
+
+func NewUserUsecase(
+	binder gateway.Binder,
+	userReader gateway.UserQueriesGateway,
+	userWithTodosReader gateway.UserWithTodosQueriesGateway,
+	userWriter gateway.UserCommandsGateway,
+) UserUsecase {
+	return &userInteractor{
+		binder:              binder,
+		userReader:          userReader,
+		userWithTodosReader: userWithTodosReader,
+		userWriter:          userWriter,
+	}
+}
+
+func (u *userInteractor) GetUser(
+	ctx context.Context,
+	userID todo.UserID,
+) (*todo.User, error) {
+	ctx = u.binder.Bind(withUser(ctx, userID))
+
+	user, err := u.userReader.GetUser(ctx, userID.Int64())
+	if err != nil {
+		return nil, errors.NewInternalError("GetUser: failed to get user", err)
+	}
+	if user == nil {
+		return nil, userNotFoundError("GetUser", userID)
+	}
+
+	return user, nil
+}
+
+// GetUserWithTodos returns the user with a page of their todos, newest first
+// unless paging.SortingOrder says otherwise.
+func (u *userInteractor) GetUserWithTodos(
+	ctx context.Context,
+	userID todo.UserID,
+	paging todo.CursorPagingParam,
+) (*todo.UserWithTodos, error) {
+	if paging.Size == 0 {
+		paging.Size = todo.DefaultUserTodosPageSize
+	}
+	if paging.Size < 0 || paging.Size > todo.MaxUserTodosPageSize {
+		return nil, errors.NewParameterError(
+			"GetUserWithTodos: invalid page size",
+			nil,
+			nil,
+			errors.ToMetadataInt("page_size", paging.Size),
+		)
+	}
+	if paging.SortingOrder == "" {
+		paging.SortingOrder = todo.SortingOrders.Desc
+	}
+	if !paging.SortingOrder.IsValid() {
+		return nil, errors.NewParameterError(
+			"GetUserWithTodos: invalid sorting order",
+			nil,
+			nil,
+			errors.ToMetadata("sorting_order", string(paging.SortingOrder)),
+		)
+	}
+
+	ctx = u.binder.Bind(withUser(ctx, userID))
+
+	user, err := u.userWithTodosReader.GetUserWithTodos(ctx, userID, paging)
+	if err != nil {
+		if errors.IsParameterError(err) {
+			return nil, err
+		}
+		return nil, errors.NewInternalError("GetUserWithTodos: failed to get user", err)
+	}
+	if user == nil {
+		return nil, userNotFoundError("GetUserWithTodos", userID)
+	}
+
+	return user, nil
+}
+
+// CreateUser fails with an AlreadyExistsError when the ID or the email is
+// used, by a deleted user too, and with a ParameterError when the ID is left
+// to a sharded database.
+func (u *userInteractor) CreateUser(
+	ctx context.Context,
+	newUser todo.NewUser,
+) (*todo.User, error) {
+	if strings.TrimSpace(newUser.Username) == "" {
+		return nil, errors.NewParameterError("CreateUser: username is required", nil, nil)
+	}
+
+	ctx = u.binder.Bind(withUser(ctx, newUser.ID))
+
+	created, err := u.userWriter.CreateUser(ctx, newUser)
+	if err != nil {
+		if stderrors.Is(err, todo.ErrDuplicateKey) {
+			return nil, errors.NewAlreadyExistsError(
+				"CreateUser: user ID or email already used",
+				err,
+				nil,
+				errors.ToMetadata("user_id", newUser.ID.String()),
+			)
+		}
+		if stderrors.Is(err, todo.ErrUserIDRequired) {
+			return nil, errors.NewParameterError("CreateUser: user ID is required", err, nil)
+		}
+		if stderrors.Is(err, todo.ErrUserErased) {
+			return nil, errors.NewPreconditionFailedError(
+				"CreateUser: user was erased",
+				err,
+				nil,
+				errors.ToMetadata("user_id", newUser.ID.String()),
+			)
+		}
+		return nil, errors.NewInternalError("CreateUser: failed to create user", err)
+	}
+
+	return created, nil
+}
+
+// UpdateUser fails with an AlreadyExistsError when the email is used by
+// another user.
+func (u *userInteractor) UpdateUser(
+	ctx context.Context,
+	userID todo.UserID,
+	updateUser todo.UpdateUser,
+) (*todo.User, error) {
+	if updateUser.Username != nil && strings.TrimSpace(*updateUser.Username) == "" {
+		return nil, errors.NewParameterError(
+			"UpdateUser: username cannot be empty",
+			nil,
+			nil,
+			errors.ToMetadata("user_id", userID.String()),
+		)
+	}
+
+	ctx = u.binder.Bind(withUser(ctx, userID))
+
+	updated, err := u.userWriter.UpdateUser(ctx, userID, updateUser)
+	if err != nil {
+		if stderrors.Is(err, todo.ErrDuplicateKey) {
+			return nil, errors.NewAlreadyExistsError(
+				"UpdateUser: email already used",
+				err,
+				nil,
+				errors.ToMetadata("user_id", userID.String()),
+			)
+		}
+		return nil, errors.NewInternalError("UpdateUser: failed to update user", err)
+	}
+	if updated == nil {
+		return nil, userNotFoundError("UpdateUser", userID)
+	}
+
+	return updated, nil
+}
+
+// DeleteUser soft-deletes the user, keeping their todos.
+func (u *userInteractor) DeleteUser(
+	ctx context.Context,
+	userID todo.UserID,
+) error {
+	// The user must be read from the primary, it is deleted right after.
+	ctx = gateway.WithPrimaryReads(u.binder.Bind(withUser(ctx, userID)))
+
+	user, err := u.userReader.GetUser(ctx, userID.Int64())
+	if err != nil {
+		return errors.NewInternalError("DeleteUser: failed to get user", err)
+	}
+	if user == nil {
+		return userNotFoundError("DeleteUser", userID)
+	}
+
+	if err := u.userWriter.SoftDeleteUser(ctx, userID); err != nil {
+		return errors.NewInternalError("DeleteUser: failed to delete user", err)
+	}
+
+	return nil
+}
+
+func userNotFoundError(method string, userID todo.UserID) error {
+	return errors.NewNotFoundError(
+		method+": user not found",
+		nil,
+		nil,
+		errors.ToMetadata("user_id", userID.String()),
+	)
+}
@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"context"
+	"io"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/errors"
+)
+
+type userDataInteractor struct {
+	binder     gateway.Binder
+	dataReader gateway.UserDataQueriesGateway
+	eraser     gateway.UserErasureCommandsGateway
+	encoder    gateway.UserDataEncoder
+}
+
+func NewUserDataUsecase(
+	binder gateway.Binder,
+	dataReader gateway.UserDataQueriesGateway,
+	eraser gateway.UserErasureCommandsGateway,
+	encoder gateway.UserDataEncoder,
+) UserDataUsecase {
+	return &userDataInteractor{
+		binder:     binder,
+		dataReader: dataReader,
+		eraser:     eraser,
+		encoder:    encoder,
+	}
+}
+
+// userDataPageSize is how many todos or events of a user are read and
+// written to the export at once.
+const userDataPageSize = 500
+
+// ExportUserData writes everything kept about the user, deleted user and
+// todos included. The data is written to w page by page as it is read, so
+// nothing is written when the user does not exist, but an export failing
+// halfway leaves w with a truncated bundle.
+func (u *userDataInteractor) ExportUserData(
+	ctx context.Context,
+	userID todo.UserID,
+	w io.Writer,
+) error {
+	ctx = u.binder.Bind(withUser(ctx, userID))
+
+	bundle := u.encoder.NewWriter(w)
+	found, err := u.dataReader.ReadUserData(ctx, userID, userDataPageSize, bundle)
+	if err != nil {
+		return errors.NewInternalError("ExportUserData: failed to export user data", err)
+	}
+	if !found {
+		return userNotFoundError("ExportUserData", userID)
+	}
+
+	if err := bundle.Close(); err != nil {
+		return errors.NewInternalError("ExportUserData: failed to encode user data", err)
+	}
+
+	return nil
+}
+
+// EraseUser hard-deletes the user and their todos. The user ID is kept so
+// that a replayed CreateUser cannot bring the user back.
+func (u *userDataInteractor) EraseUser(
+	ctx context.Context,
+	userID todo.UserID,
+) error {
+	ctx = u.binder.Bind(withUser(ctx, userID))
+
+	erased, err := u.eraser.EraseUser(ctx, userID)
+	if err != nil {
+		return errors.NewInternalError("EraseUser: failed to erase user", err)
+	}
+	if erased == nil {
+		return userNotFoundError("EraseUser", userID)
+	}
+
+	return nil
+}
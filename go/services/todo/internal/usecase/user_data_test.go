@@ -0,0 +1,129 @@
+package usecase_test
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	apperrors "github.com/phamquanandpad/training-project/go/services/todo/internal/errors"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/memory"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/usecase"
+)
+
+// fakeUserDataEncoder collects the data written to it, once closed.
+type fakeUserDataEncoder struct {
+	data *todo.UserData
+}
+
+func (e *fakeUserDataEncoder) NewWriter(_ io.Writer) gateway.UserDataWriter {
+	return &fakeUserDataWriter{encoder: e, UserData: &todo.UserData{}}
+}
+
+type fakeUserDataWriter struct {
+	*todo.UserData
+	encoder *fakeUserDataEncoder
+}
+
+func (w *fakeUserDataWriter) Close() error {
+	w.encoder.data = w.UserData
+	return nil
+}
+
+func Test_userDataInteractor(t *testing.T) {
+	type testcase struct {
+		run      func(ctx context.Context, u usecase.UserDataUsecase, users usecase.UserUsecase) error
+		expected *todo.UserData
+		checkErr func(error) bool
+	}
+
+	t.Parallel()
+
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	deletedAt := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	user := &todo.User{ID: 1, Username: "user1", CreatedAt: createdAt, UpdatedAt: createdAt}
+	todos := []*todo.Todo{
+		{ID: 1, UserID: 1, Task: "todo task 1", CreatedAt: createdAt, UpdatedAt: createdAt},
+		{ID: 2, UserID: 1, Task: "todo task 2", CreatedAt: createdAt, UpdatedAt: deletedAt, DeletedAt: &deletedAt},
+	}
+
+	testTables := map[string]testcase{
+		"Export the user with their deleted todos": {
+			run: func(ctx context.Context, u usecase.UserDataUsecase, _ usecase.UserUsecase) error {
+				return u.ExportUserData(ctx, 1, io.Discard)
+			},
+			expected: &todo.UserData{User: user, Todos: todos},
+		},
+		"Return not found error when exporting an unknown user": {
+			run: func(ctx context.Context, u usecase.UserDataUsecase, _ usecase.UserUsecase) error {
+				return u.ExportUserData(ctx, 999, io.Discard)
+			},
+			checkErr: apperrors.IsNotFoundErr,
+		},
+		"Return not found error when exporting an erased user": {
+			run: func(ctx context.Context, u usecase.UserDataUsecase, _ usecase.UserUsecase) error {
+				if err := u.EraseUser(ctx, 1); err != nil {
+					return err
+				}
+				return u.ExportUserData(ctx, 1, io.Discard)
+			},
+			checkErr: apperrors.IsNotFoundErr,
+		},
+		"Return not found error when erasing an unknown user": {
+			run: func(ctx context.Context, u usecase.UserDataUsecase, _ usecase.UserUsecase) error {
+				return u.EraseUser(ctx, 999)
+			},
+			checkErr: apperrors.IsNotFoundErr,
+		},
+		"Return precondition failed error when creating an erased user again": {
+			run: func(ctx context.Context, u usecase.UserDataUsecase, users usecase.UserUsecase) error {
+				if err := u.EraseUser(ctx, 1); err != nil {
+					return err
+				}
+				_, err := users.CreateUser(ctx, todo.NewUser{ID: 1, Username: "user1"})
+				return err
+			},
+			checkErr: func(err error) bool {
+				return apperrors.ToGRPCCode(err) == codes.FailedPrecondition
+			},
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			store := memory.NewStore()
+			store.SeedUsers(&todo.User{ID: 1, Username: "user1", CreatedAt: createdAt, UpdatedAt: createdAt})
+			store.SeedTodos(
+				&todo.Todo{ID: 1, UserID: 1, Task: "todo task 1", CreatedAt: createdAt, UpdatedAt: createdAt},
+				&todo.Todo{ID: 2, UserID: 1, Task: "todo task 2", CreatedAt: createdAt, UpdatedAt: deletedAt, DeletedAt: &deletedAt},
+			)
+			encoder := &fakeUserDataEncoder{}
+			binder := memory.NewConnectionBinder()
+			u := usecase.NewUserDataUsecase(binder, memory.NewUserDataReader(store), memory.NewUserEraser(store), encoder)
+			users := usecase.NewUserUsecase(binder, memory.NewUserReader(store), &fakeUserWithTodosReader{}, memory.NewUserWriter(store))
+
+			err := tt.run(context.Background(), u, users)
+			if (err != nil) != (tt.checkErr != nil) {
+				t.Fatalf("error = %v", err)
+			}
+			if err != nil {
+				if !tt.checkErr(err) {
+					t.Fatalf("unexpected error type: %v", err)
+				}
+				return
+			}
+
+			if diff := cmp.Diff(encoder.data, tt.expected); diff != "" {
+				t.Fatalf("mismatch (-actual +expected):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/phamquanandpad/training-project/go/pkg/cast"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	apperrors "github.com/phamquanandpad/training-project/go/services/todo/internal/errors"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/memory"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/usecase"
+)
+
+type fakeUserWithTodosReader struct {
+	paging todo.CursorPagingParam
+}
+
+func (r *fakeUserWithTodosReader) GetUserWithTodos(
+	_ context.Context,
+	userID todo.UserID,
+	paging todo.CursorPagingParam,
+) (*todo.UserWithTodos, error) {
+	r.paging = paging
+	if userID != 1 {
+		return nil, nil
+	}
+	return &todo.UserWithTodos{ID: userID, Username: "user1", Todos: &todo.Page[*todo.Todo]{}}, nil
+}
+
+func newUserUsecase() (usecase.UserUsecase, *fakeUserWithTodosReader) {
+	store := memory.NewStore()
+	store.SeedUsers(
+		&todo.User{ID: 1, Username: "user1", Email: cast.Ptr("user1@example.com")},
+		&todo.User{ID: 2, Username: "user2", Email: cast.Ptr("user2@example.com")},
+	)
+	withTodos := &fakeUserWithTodosReader{}
+
+	return usecase.NewUserUsecase(
+		memory.NewConnectionBinder(),
+		memory.NewUserReader(store),
+		withTodos,
+		memory.NewUserWriter(store),
+	), withTodos
+}
+
+func Test_userInteractor(t *testing.T) {
+	type testcase struct {
+		run      func(ctx context.Context, u usecase.UserUsecase) (*todo.User, error)
+		expected *todo.User
+		checkErr func(error) bool
+	}
+
+	t.Parallel()
+
+	ignoreTimes := cmpopts.IgnoreFields(todo.User{}, "CreatedAt", "UpdatedAt", "DeletedAt")
+
+	testTables := map[string]testcase{
+		"Create a user": {
+			run: func(ctx context.Context, u usecase.UserUsecase) (*todo.User, error) {
+				return u.CreateUser(ctx, todo.NewUser{ID: 3, Username: "user3"})
+			},
+			expected: &todo.User{ID: 3, Username: "user3"},
+		},
+		"Return parameter error when the username is empty": {
+			run: func(ctx context.Context, u usecase.UserUsecase) (*todo.User, error) {
+				return u.CreateUser(ctx, todo.NewUser{ID: 3, Username: " "})
+			},
+			checkErr: apperrors.IsParameterError,
+		},
+		"Return already exists error when the email is used": {
+			run: func(ctx context.Context, u usecase.UserUsecase) (*todo.User, error) {
+				return u.CreateUser(ctx, todo.NewUser{ID: 3, Username: "user3", Email: cast.Ptr("user1@example.com")})
+			},
+			checkErr: apperrors.IsAlreadyExistsError,
+		},
+		"Update a user": {
+			run: func(ctx context.Context, u usecase.UserUsecase) (*todo.User, error) {
+				return u.UpdateUser(ctx, 1, todo.UpdateUser{Username: cast.Ptr("renamed")})
+			},
+			expected: &todo.User{ID: 1, Username: "renamed", Email: cast.Ptr("user1@example.com")},
+		},
+		"Return already exists error when updating to a used email": {
+			run: func(ctx context.Context, u usecase.UserUsecase) (*todo.User, error) {
+				return u.UpdateUser(ctx, 1, todo.UpdateUser{Email: cast.Ptr("user2@example.com")})
+			},
+			checkErr: apperrors.IsAlreadyExistsError,
+		},
+		"Return not found error when updating an unknown user": {
+			run: func(ctx context.Context, u usecase.UserUsecase) (*todo.User, error) {
+				return u.UpdateUser(ctx, 999, todo.UpdateUser{Username: cast.Ptr("renamed")})
+			},
+			checkErr: apperrors.IsNotFoundErr,
+		},
+		"Return not found error when getting a deleted user": {
+			run: func(ctx context.Context, u usecase.UserUsecase) (*todo.User, error) {
+				if err := u.DeleteUser(ctx, 2); err != nil {
+					return nil, err
+				}
+				return u.GetUser(ctx, 2)
+			},
+			checkErr: apperrors.IsNotFoundErr,
+		},
+		"Return not found error when deleting a user twice": {
+			run: func(ctx context.Context, u usecase.UserUsecase) (*todo.User, error) {
+				if err := u.DeleteUser(ctx, 2); err != nil {
+					return nil, err
+				}
+				return nil, u.DeleteUser(ctx, 2)
+			},
+			checkErr: apperrors.IsNotFoundErr,
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			u, _ := newUserUsecase()
+
+			actual, err := tt.run(context.Background(), u)
+			if (err != nil) != (tt.checkErr != nil) {
+				t.Fatalf("error = %v", err)
+			}
+			if err != nil {
+				if !tt.checkErr(err) {
+					t.Fatalf("unexpected error type: %v", err)
+				}
+				return
+			}
+
+			if diff := cmp.Diff(actual, tt.expected, ignoreTimes); diff != "" {
+				t.Fatalf("mismatch (-actual +expected):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_userInteractor_GetUserWithTodos(t *testing.T) {
+	type args struct {
+		userID todo.UserID
+		paging todo.CursorPagingParam
+	}
+
+	type testcase struct {
+		args     args
+		expected todo.CursorPagingParam
+		checkErr func(error) bool
+	}
+
+	t.Parallel()
+
+	testTables := map[string]testcase{
+		"Get the newest todos by default": {
+			args:     args{userID: 1},
+			expected: todo.CursorPagingParam{Size: todo.DefaultUserTodosPageSize, SortingOrder: todo.SortingOrders.Desc},
+		},
+		"Return parameter error for a page size over the maximum": {
+			args:     args{userID: 1, paging: todo.CursorPagingParam{Size: todo.MaxUserTodosPageSize + 1}},
+			checkErr: apperrors.IsParameterError,
+		},
+		"Return parameter error for a sorting order other than asc or desc": {
+			args:     args{userID: 1, paging: todo.CursorPagingParam{SortingOrder: "DESC, (SELECT 1)"}},
+			checkErr: apperrors.IsParameterError,
+		},
+		"Return not found error for an unknown user": {
+			args:     args{userID: 999},
+			checkErr: apperrors.IsNotFoundErr,
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			u, reader := newUserUsecase()
+
+			_, err := u.GetUserWithTodos(context.Background(), tt.args.userID, tt.args.paging)
+			if (err != nil) != (tt.checkErr != nil) {
+				t.Fatalf("error = %v", err)
+			}
+			if err != nil {
+				if !tt.checkErr(err) {
+					t.Fatalf("unexpected error type: %v", err)
+				}
+				return
+			}
+
+			if diff := cmp.Diff(reader.paging, tt.expected); diff != "" {
+				t.Fatalf("paging mismatch (-actual +expected):\n%s", diff)
+			}
+		})
+	}
+}
@@ -51,11 +51,15 @@ func WithMethodName(ctx context.Context, methodName string) context.Context {
 }
 
 func ExtractMethodName(ctx context.Context) (string, error) {
-	if v := ctx.Value(&methodNameKey{}); v != nil {
-		s, ok := v.(string)
-		if ok {
-			return s, nil
-		}
+	if s, ok := MethodName(ctx); ok {
+		return s, nil
 	}
 	return "", errors.NewInternalError("ExtractMethodName: failed to method Name", nil)
 }
+
+// MethodName is ExtractMethodName without the error, for the hot paths that
+// run without a method name, such as the query hooks.
+func MethodName(ctx context.Context) (string, bool) {
+	s, ok := ctx.Value(&methodNameKey{}).(string)
+	return s, ok
+}
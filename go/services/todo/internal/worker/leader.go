@@ -0,0 +1,161 @@
+package worker
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+// releaseTimeout bounds the release of a lease once the job stopped, which
+// runs after ctx was canceled.
+const releaseTimeout = 5 * time.Second
+
+type LeaderConfig struct {
+	// Name is the name of the lease, shared by the processes running the job.
+	Name   string
+	Holder string
+	// TTL is how long the lease lasts without renewal. The lease is renewed
+	// every third of it.
+	TTL           time.Duration
+	RetryInterval time.Duration
+}
+
+// Leader runs a job on one process at a time: the one holding the lease of the
+// job. The other processes try to acquire the lease every RetryInterval and
+// take over once it was released or expired.
+type Leader struct {
+	binder gateway.Binder
+	leases gateway.LeaseCommandsGateway
+	cfg    LeaderConfig
+}
+
+func NewLeader(
+	binder gateway.Binder,
+	leases gateway.LeaseCommandsGateway,
+	cfg LeaderConfig,
+) *Leader {
+	if cfg.Holder == "" {
+		cfg.Holder = NewLeaseHolder()
+	}
+
+	return &Leader{
+		binder: binder,
+		leases: leases,
+		cfg:    cfg,
+	}
+}
+
+// NewLeaseHolder names the process: its host name and ID, and a random suffix
+// so that a restarted process is not mistaken for the former one.
+func NewLeaseHolder() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+
+	suffix := make([]byte, 4)
+	_, _ = rand.Read(suffix)
+
+	return fmt.Sprintf("%s-%d-%s", host, os.Getpid(), hex.EncodeToString(suffix))
+}
+
+// Run runs job while this process holds the lease, until ctx is canceled. The
+// context of the job is canceled when the lease is lost, and the job must
+// then return. A job stalled past the expiry of the lease only notices later,
+// so a job whose writes must not interleave with the next holder's fences
+// them with the token of the lease, as OutboxRelay does. The lease is
+// released once the job returned, including when ctx was canceled.
+func (l *Leader) Run(ctx context.Context, job func(ctx context.Context, lease *todo.Lease) error) error {
+	ticker := time.NewTicker(l.cfg.RetryInterval)
+	defer ticker.Stop()
+
+	for ctx.Err() == nil {
+		lease, err := l.leases.AcquireLease(l.binder.Bind(ctx), l.cfg.Name, l.cfg.Holder, l.cfg.TTL)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("leader %s: acquire lease: %v", l.cfg.Name, err)
+		}
+		if lease != nil {
+			log.Printf("leader %s: %s leads with token %d", l.cfg.Name, l.cfg.Holder, lease.Token)
+			l.lead(ctx, lease, job)
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-ticker.C:
+		}
+	}
+
+	return nil
+}
+
+// lead runs the job, renewing the lease until the job returns.
+func (l *Leader) lead(ctx context.Context, lease *todo.Lease, job func(ctx context.Context, lease *todo.Lease) error) {
+	jobCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	renewed := make(chan *todo.Lease, 1)
+	go func() {
+		renewed <- l.renew(jobCtx, cancel, lease)
+	}()
+
+	if err := job(jobCtx, lease); err != nil && jobCtx.Err() == nil {
+		log.Printf("leader %s: job: %v", l.cfg.Name, err)
+	}
+	cancel()
+
+	last := <-renewed
+	if last == nil {
+		return
+	}
+
+	releaseCtx, cancelRelease := context.WithTimeout(context.WithoutCancel(ctx), releaseTimeout)
+	defer cancelRelease()
+	if err := l.leases.ReleaseLease(l.binder.Bind(releaseCtx), last); err != nil {
+		log.Printf("leader %s: release lease: %v", l.cfg.Name, err)
+	}
+}
+
+// renew renews the lease until ctx is canceled and returns the last renewed
+// lease. It cancels the job and returns nil once the lease is lost, or once it
+// would expire before the next renewal.
+func (l *Leader) renew(ctx context.Context, cancel context.CancelFunc, lease *todo.Lease) *todo.Lease {
+	interval := l.cfg.TTL / 3
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return lease
+		case <-ticker.C:
+		}
+
+		next, err := l.leases.RenewLease(l.binder.Bind(ctx), lease, l.cfg.TTL)
+		if err == nil {
+			lease = next
+			continue
+		}
+		if ctx.Err() != nil {
+			return lease
+		}
+
+		if errors.Is(err, todo.ErrLeaseLost) {
+			log.Printf("leader %s: lease lost", l.cfg.Name)
+			cancel()
+			return nil
+		}
+		log.Printf("leader %s: renew lease: %v", l.cfg.Name, err)
+		if time.Now().Add(interval).After(lease.ExpiresAt) {
+			cancel()
+			return nil
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package worker_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/infrastructure/memory"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/worker"
+)
+
+func newTestLeader(leases *memory.Store, holder string) *worker.Leader {
+	return worker.NewLeader(fakeBinder{}, memory.NewLeaseWriter(leases), worker.LeaderConfig{
+		Name:          "job",
+		Holder:        holder,
+		TTL:           300 * time.Millisecond,
+		RetryInterval: 10 * time.Millisecond,
+	})
+}
+
+func TestLeader_Run_TakesOverOnRelease(t *testing.T) {
+	t.Parallel()
+
+	store := memory.NewStore()
+	var running atomic.Int32
+	started := make(chan string, 2)
+	job := func(holder string) func(ctx context.Context, _ *todo.Lease) error {
+		return func(ctx context.Context, _ *todo.Lease) error {
+			if running.Add(1) > 1 {
+				t.Errorf("job of %s runs along with another", holder)
+			}
+			started <- holder
+			<-ctx.Done()
+			running.Add(-1)
+			return nil
+		}
+	}
+
+	ctxA, cancelA := context.WithCancel(context.Background())
+	doneA := make(chan struct{})
+	go func() {
+		defer close(doneA)
+		_ = newTestLeader(store, "a").Run(ctxA, job("a"))
+	}()
+	if holder := <-started; holder != "a" {
+		t.Fatalf("job of %s started first, want a", holder)
+	}
+
+	ctxB, cancelB := context.WithCancel(context.Background())
+	defer cancelB()
+	go func() {
+		_ = newTestLeader(store, "b").Run(ctxB, job("b"))
+	}()
+
+	select {
+	case holder := <-started:
+		t.Fatalf("job of %s started while a holds the lease", holder)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// a releases the lease on shutdown, well before it expires.
+	cancelA()
+	<-doneA
+	select {
+	case holder := <-started:
+		if holder != "b" {
+			t.Fatalf("job of %s started, want b", holder)
+		}
+	case <-time.After(200 * time.Millisecond):
+		t.Fatal("b did not take over the released lease")
+	}
+}
+
+func TestLeader_Run_StopsTheJobWhenTheLeaseIsLost(t *testing.T) {
+	t.Parallel()
+
+	store := memory.NewStore()
+	leases := memory.NewLeaseWriter(store)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	leased := make(chan *todo.Lease, 1)
+	stopped := make(chan struct{}, 1)
+	go func() {
+		_ = newTestLeader(store, "a").Run(ctx, func(ctx context.Context, lease *todo.Lease) error {
+			leased <- lease
+			<-ctx.Done()
+			stopped <- struct{}{}
+			return nil
+		})
+	}()
+
+	// Another holder takes the lease over, as if a had stalled past its expiry.
+	lease := <-leased
+	if err := leases.ReleaseLease(ctx, lease); err != nil {
+		t.Fatalf("ReleaseLease() error = %v", err)
+	}
+	if taken, err := leases.AcquireLease(ctx, "job", "b", time.Minute); err != nil || taken == nil {
+		t.Fatalf("AcquireLease() = %v, %v, want the lease", taken, err)
+	}
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("the job kept running after its lease was lost")
+	}
+}
@@ -0,0 +1,166 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+type OutboxRelayConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	// MaxAttempts is how many times an event is attempted before it is
+	// dead-lettered. Zero retries it forever.
+	MaxAttempts int
+}
+
+// OutboxRelay publishes events recorded in the outbox table. Delivery is
+// at-least-once: an event is marked as published only after the publisher
+// accepted it, so a crash in between publishes it again on the next run.
+// Events of the same aggregate are published in order; once one of them fails
+// the following ones wait until it has been delivered, or until it is
+// dead-lettered after MaxAttempts attempts. Every shard has its
+// outbox, written in the transactions of its users: the relay goes through
+// each of them.
+type OutboxRelay struct {
+	binder       gateway.ShardBinder
+	outboxReader gateway.OutboxQueriesGateway
+	outboxWriter gateway.OutboxCommandsGateway
+	publisher    gateway.Publisher
+	cfg          OutboxRelayConfig
+	now          func() time.Time
+}
+
+func NewOutboxRelay(
+	binder gateway.ShardBinder,
+	outboxReader gateway.OutboxQueriesGateway,
+	outboxWriter gateway.OutboxCommandsGateway,
+	publisher gateway.Publisher,
+	cfg OutboxRelayConfig,
+) *OutboxRelay {
+	return &OutboxRelay{
+		binder:       binder,
+		outboxReader: outboxReader,
+		outboxWriter: outboxWriter,
+		publisher:    publisher,
+		cfg:          cfg,
+		now:          time.Now,
+	}
+}
+
+// Run polls the outbox until ctx is canceled, or until lease, the lease of
+// the relay which fences its writes, was acquired by another process.
+func (r *OutboxRelay) Run(ctx context.Context, lease *todo.Lease) error {
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := r.RelayOnce(ctx, lease); err != nil {
+			if errors.Is(err, todo.ErrLeaseLost) {
+				return err
+			}
+			log.Printf("outbox relay: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayOnce publishes one batch of pending events of each shard and returns
+// how many of them were published. A shard that fails does not hold back the
+// others. It fails with todo.ErrLeaseLost once lease was acquired by another
+// process.
+func (r *OutboxRelay) RelayOnce(ctx context.Context, lease *todo.Lease) (int, error) {
+	published := 0
+	var errs []error
+	for _, shard := range r.binder.Shards() {
+		n, err := r.relayShard(r.binder.BindShard(ctx, shard), lease)
+		published += n
+		if err == nil {
+			continue
+		}
+
+		err = fmt.Errorf("shard %s: %w", shard, err)
+		if errors.Is(err, todo.ErrLeaseLost) || ctx.Err() != nil {
+			return published, err
+		}
+		errs = append(errs, err)
+	}
+
+	return published, errors.Join(errs...)
+}
+
+// relayShard publishes one batch of pending events of the shard bound to ctx.
+func (r *OutboxRelay) relayShard(ctx context.Context, lease *todo.Lease) (int, error) {
+	now := r.now()
+	events, err := r.outboxReader.ListPendingEvents(ctx, now, r.cfg.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("list pending events: %w", err)
+	}
+
+	// blocked are the aggregates with an event that failed in this batch.
+	blocked := map[string]bool{}
+	published := 0
+	for _, event := range events {
+		key := event.AggregateKey()
+		if blocked[key] {
+			continue
+		}
+
+		if err := r.publisher.Publish(ctx, event); err != nil {
+			if ctx.Err() != nil {
+				return published, ctx.Err()
+			}
+
+			if r.cfg.MaxAttempts > 0 && event.Attempts+1 >= r.cfg.MaxAttempts {
+				if err := r.outboxWriter.MarkEventDeadLettered(ctx, lease, event.ID, err); err != nil {
+					return published, fmt.Errorf("mark event %d dead-lettered: %w", event.ID, err)
+				}
+				log.Printf("outbox relay: dead-lettered event %d of %s after %d attempts: %v", event.ID, key, event.Attempts+1, err)
+				continue
+			}
+
+			blocked[key] = true
+			nextAttemptAt := now.Add(r.backoff(event.Attempts))
+			if err := r.outboxWriter.MarkEventFailed(ctx, lease, event.ID, nextAttemptAt, err); err != nil {
+				return published, fmt.Errorf("mark event %d failed: %w", event.ID, err)
+			}
+			continue
+		}
+
+		if err := r.outboxWriter.MarkEventPublished(ctx, lease, event.ID); err != nil {
+			return published, fmt.Errorf("mark event %d published: %w", event.ID, err)
+		}
+		published++
+	}
+
+	return published, nil
+}
+
+// backoff returns the delay before the next attempt, doubling with every
+// failed attempt up to MaxBackoff.
+func (r *OutboxRelay) backoff(attempts int) time.Duration {
+	d := r.cfg.BaseBackoff
+	for i := 0; i < attempts; i++ {
+		d *= 2
+		if d >= r.cfg.MaxBackoff {
+			return r.cfg.MaxBackoff
+		}
+	}
+
+	if d > r.cfg.MaxBackoff {
+		return r.cfg.MaxBackoff
+	}
+	return d
+}
@@ -0,0 +1,413 @@
+package worker_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/worker"
+)
+
+// fakeBinder binds the shards it is given, a single one when there are none.
+type fakeBinder struct {
+	shards []string
+}
+
+type shardKey struct{}
+
+func (fakeBinder) Bind(ctx context.Context) context.Context { return ctx }
+
+func (b fakeBinder) Shards() []string {
+	if len(b.shards) == 0 {
+		return []string{"primary"}
+	}
+	return b.shards
+}
+
+func (fakeBinder) BindShard(ctx context.Context, shard string) context.Context {
+	return context.WithValue(ctx, shardKey{}, shard)
+}
+
+// shardedOutbox is an outbox of each shard, picked by the shard bound to the
+// context.
+type shardedOutbox map[string]*fakeOutbox
+
+func (o shardedOutbox) of(ctx context.Context) *fakeOutbox {
+	shard, _ := ctx.Value(shardKey{}).(string)
+	return o[shard]
+}
+
+func (o shardedOutbox) ListPendingEvents(ctx context.Context, now time.Time, limit int) ([]*todo.Event, error) {
+	return o.of(ctx).ListPendingEvents(ctx, now, limit)
+}
+
+func (o shardedOutbox) ListUserEventsAfter(
+	ctx context.Context,
+	userID todo.UserID,
+	aggregateType todo.AggregateType,
+	after todo.EventID,
+	limit int,
+) ([]*todo.Event, error) {
+	return o.of(ctx).ListUserEventsAfter(ctx, userID, aggregateType, after, limit)
+}
+
+func (o shardedOutbox) ListEventsAfter(ctx context.Context, afterID todo.EventID, limit int) ([]*todo.Event, error) {
+	return o.of(ctx).ListEventsAfter(ctx, afterID, limit)
+}
+
+func (o shardedOutbox) GetLastEventID(ctx context.Context) (todo.EventID, error) {
+	return o.of(ctx).GetLastEventID(ctx)
+}
+
+func (o shardedOutbox) MarkEventPublished(ctx context.Context, lease *todo.Lease, eventID todo.EventID) error {
+	return o.of(ctx).MarkEventPublished(ctx, lease, eventID)
+}
+
+func (o shardedOutbox) MarkEventFailed(
+	ctx context.Context,
+	lease *todo.Lease,
+	eventID todo.EventID,
+	nextAttemptAt time.Time,
+	cause error,
+) error {
+	return o.of(ctx).MarkEventFailed(ctx, lease, eventID, nextAttemptAt, cause)
+}
+
+func (o shardedOutbox) MarkEventDeadLettered(
+	ctx context.Context,
+	lease *todo.Lease,
+	eventID todo.EventID,
+	cause error,
+) error {
+	return o.of(ctx).MarkEventDeadLettered(ctx, lease, eventID, cause)
+}
+
+type fakeOutbox struct {
+	mu     sync.Mutex
+	events []*todo.Event
+	// token is the token of the current holder of the lease of the relay.
+	token int64
+}
+
+func (o *fakeOutbox) ListPendingEvents(_ context.Context, now time.Time, limit int) ([]*todo.Event, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	waiting := map[string]bool{}
+	var pending []*todo.Event
+	for _, e := range o.events {
+		if e.IsPublished() || e.IsDeadLettered() {
+			continue
+		}
+		if e.NextAttemptAt.After(now) {
+			waiting[e.AggregateKey()] = true
+			continue
+		}
+		if !waiting[e.AggregateKey()] && len(pending) < limit {
+			copied := *e
+			pending = append(pending, &copied)
+		}
+	}
+	return pending, nil
+}
+
+func (o *fakeOutbox) ListUserEventsAfter(
+	_ context.Context,
+	_ todo.UserID,
+	_ todo.AggregateType,
+	_ todo.EventID,
+	_ int,
+) ([]*todo.Event, error) {
+	return nil, nil
+}
+
+func (o *fakeOutbox) ListEventsAfter(_ context.Context, afterID todo.EventID, limit int) ([]*todo.Event, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var events []*todo.Event
+	for _, e := range o.events {
+		if e.ID > afterID {
+			copied := *e
+			events = append(events, &copied)
+		}
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].ID < events[j].ID })
+	if len(events) > limit {
+		events = events[:limit]
+	}
+	return events, nil
+}
+
+func (o *fakeOutbox) GetLastEventID(_ context.Context) (todo.EventID, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var lastID todo.EventID
+	for _, e := range o.events {
+		if e.ID > lastID {
+			lastID = e.ID
+		}
+	}
+	return lastID, nil
+}
+
+func (o *fakeOutbox) MarkEventPublished(_ context.Context, lease *todo.Lease, eventID todo.EventID) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if lease.Token != o.token {
+		return todo.ErrLeaseLost
+	}
+
+	for _, e := range o.events {
+		if e.ID == eventID {
+			now := time.Now()
+			e.PublishedAt = &now
+		}
+	}
+	return nil
+}
+
+func (o *fakeOutbox) MarkEventFailed(
+	_ context.Context,
+	lease *todo.Lease,
+	eventID todo.EventID,
+	nextAttemptAt time.Time,
+	cause error,
+) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if lease.Token != o.token {
+		return todo.ErrLeaseLost
+	}
+
+	for _, e := range o.events {
+		if e.ID == eventID {
+			msg := cause.Error()
+			e.Attempts++
+			e.LastError = &msg
+			e.NextAttemptAt = nextAttemptAt
+		}
+	}
+	return nil
+}
+
+func (o *fakeOutbox) MarkEventDeadLettered(
+	_ context.Context,
+	lease *todo.Lease,
+	eventID todo.EventID,
+	cause error,
+) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if lease.Token != o.token {
+		return todo.ErrLeaseLost
+	}
+
+	for _, e := range o.events {
+		if e.ID == eventID {
+			msg := cause.Error()
+			now := time.Now()
+			e.Attempts++
+			e.LastError = &msg
+			e.DeadLetteredAt = &now
+		}
+	}
+	return nil
+}
+
+type fakePublisher struct {
+	failFor   map[todo.EventID]bool
+	published []todo.EventID
+}
+
+func (p *fakePublisher) Publish(_ context.Context, event *todo.Event) error {
+	if p.failFor[event.ID] {
+		return errors.New("publish failed")
+	}
+	p.published = append(p.published, event.ID)
+	return nil
+}
+
+func Test_OutboxRelay_RelayOnce(t *testing.T) {
+	now := time.Now()
+
+	newEvent := func(id todo.EventID, aggregateID int64, nextAttemptAt time.Time) *todo.Event {
+		return &todo.Event{
+			ID:            id,
+			AggregateType: todo.AggregateTypes.Todo,
+			AggregateID:   aggregateID,
+			EventType:     todo.EventTypes.TodoUpdated,
+			NextAttemptAt: nextAttemptAt,
+		}
+	}
+	withAttempts := func(e *todo.Event, attempts int) *todo.Event {
+		e.Attempts = attempts
+		return e
+	}
+
+	type testcase struct {
+		events               []*todo.Event
+		failFor              map[todo.EventID]bool
+		expectedPublished    []todo.EventID
+		expectedAttempts     map[todo.EventID]int
+		expectedDeadLettered []todo.EventID
+	}
+
+	t.Parallel()
+
+	testTables := map[string]testcase{
+		"Publish every pending event in order": {
+			events: []*todo.Event{
+				newEvent(1, 1, now),
+				newEvent(2, 2, now),
+				newEvent(3, 1, now),
+			},
+			expectedPublished: []todo.EventID{1, 2, 3},
+			expectedAttempts:  map[todo.EventID]int{},
+		},
+		"Failed event holds back later events of the same aggregate only": {
+			events: []*todo.Event{
+				newEvent(1, 1, now),
+				newEvent(2, 2, now),
+				newEvent(3, 1, now),
+			},
+			failFor:           map[todo.EventID]bool{1: true},
+			expectedPublished: []todo.EventID{2},
+			expectedAttempts:  map[todo.EventID]int{1: 1},
+		},
+		"Event waiting for retry holds back later events of the same aggregate": {
+			events: []*todo.Event{
+				newEvent(1, 1, now.Add(time.Minute)),
+				newEvent(2, 1, now),
+				newEvent(3, 2, now),
+			},
+			expectedPublished: []todo.EventID{3},
+			expectedAttempts:  map[todo.EventID]int{},
+		},
+		"Event failing its last attempt is dead-lettered and no longer holds back its aggregate": {
+			events: []*todo.Event{
+				withAttempts(newEvent(1, 1, now), 2),
+				newEvent(2, 1, now),
+			},
+			failFor:              map[todo.EventID]bool{1: true},
+			expectedPublished:    []todo.EventID{2},
+			expectedAttempts:     map[todo.EventID]int{1: 3},
+			expectedDeadLettered: []todo.EventID{1},
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			outbox := &fakeOutbox{events: tt.events, token: 1}
+			pub := &fakePublisher{failFor: tt.failFor}
+			relay := worker.NewOutboxRelay(fakeBinder{}, outbox, outbox, pub, worker.OutboxRelayConfig{
+				PollInterval: time.Second,
+				BatchSize:    10,
+				BaseBackoff:  time.Second,
+				MaxBackoff:   time.Minute,
+				MaxAttempts:  3,
+			})
+
+			published, err := relay.RelayOnce(context.Background(), &todo.Lease{Name: "outbox-relay", Token: 1})
+			if err != nil {
+				t.Fatalf("RelayOnce() error = %v", err)
+			}
+
+			if published != len(tt.expectedPublished) {
+				t.Errorf("published = %d want %d", published, len(tt.expectedPublished))
+			}
+
+			if diff := cmp.Diff(pub.published, tt.expectedPublished); diff != "" {
+				t.Errorf("published events mismatch (-actual +expected):\n%s", diff)
+			}
+
+			for _, e := range outbox.events {
+				if e.Attempts != tt.expectedAttempts[e.ID] {
+					t.Errorf("event %d attempts = %d want %d", e.ID, e.Attempts, tt.expectedAttempts[e.ID])
+				}
+				if e.Attempts > 0 && !e.IsDeadLettered() && !e.NextAttemptAt.After(now) {
+					t.Errorf("event %d next attempt at = %v, want it to be delayed", e.ID, e.NextAttemptAt)
+				}
+			}
+
+			var deadLettered []todo.EventID
+			for _, e := range outbox.events {
+				if e.IsDeadLettered() {
+					deadLettered = append(deadLettered, e.ID)
+				}
+			}
+			if diff := cmp.Diff(deadLettered, tt.expectedDeadLettered); diff != "" {
+				t.Errorf("dead-lettered events mismatch (-actual +expected):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_OutboxRelay_RelayOnce_EveryShard(t *testing.T) {
+	t.Parallel()
+
+	outbox := shardedOutbox{
+		"primary": {events: []*todo.Event{{ID: 1, AggregateType: todo.AggregateTypes.Todo, AggregateID: 1}}, token: 1},
+		"b":       {events: []*todo.Event{{ID: 1, AggregateType: todo.AggregateTypes.Todo, AggregateID: 2}}, token: 1},
+	}
+	pub := &fakePublisher{}
+	relay := worker.NewOutboxRelay(fakeBinder{shards: []string{"primary", "b"}}, outbox, outbox, pub, worker.OutboxRelayConfig{
+		PollInterval: time.Second,
+		BatchSize:    10,
+		BaseBackoff:  time.Second,
+		MaxBackoff:   time.Minute,
+	})
+
+	published, err := relay.RelayOnce(context.Background(), &todo.Lease{Name: "outbox-relay", Token: 1})
+	if err != nil {
+		t.Fatalf("RelayOnce() error = %v", err)
+	}
+	if published != 2 {
+		t.Errorf("published = %d want 2", published)
+	}
+	for shard, o := range outbox {
+		if !o.events[0].IsPublished() {
+			t.Errorf("event of shard %s was not published", shard)
+		}
+	}
+}
+
+func Test_OutboxRelay_Run_LeaseLost(t *testing.T) {
+	t.Parallel()
+
+	// The lease was acquired again by another relay since token 1 was issued.
+	outbox := &fakeOutbox{
+		events: []*todo.Event{{ID: 1, AggregateType: todo.AggregateTypes.Todo, AggregateID: 1}},
+		token:  2,
+	}
+	relay := worker.NewOutboxRelay(fakeBinder{}, outbox, outbox, &fakePublisher{}, worker.OutboxRelayConfig{
+		PollInterval: time.Hour,
+		BatchSize:    10,
+		BaseBackoff:  time.Second,
+		MaxBackoff:   time.Minute,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	err := relay.Run(ctx, &todo.Lease{Name: "outbox-relay", Token: 1})
+	if !errors.Is(err, todo.ErrLeaseLost) {
+		t.Fatalf("Run() error = %v, want %v", err, todo.ErrLeaseLost)
+	}
+	if outbox.events[0].IsPublished() {
+		t.Errorf("event 1 was marked as published with a stale lease")
+	}
+}
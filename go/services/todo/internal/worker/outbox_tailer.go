@@ -0,0 +1,164 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+type OutboxTailerConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	// Overlap is how many event IDs are read again behind the last event seen
+	// on a shard. An event is given its ID when it is written but is only seen
+	// once its transaction commits, possibly after events with higher IDs.
+	Overlap int
+}
+
+// OutboxTailer feeds the events recorded in the outbox of every shard to a
+// publisher of this process, such as the hub of its WatchTodos streams. Unlike
+// the relay, every process runs one and nothing is written back: the events
+// are published whether the relay published them yet or not. It starts from
+// the end of the outbox; the streams replay what they missed themselves.
+type OutboxTailer struct {
+	binder       gateway.ShardBinder
+	outboxReader gateway.OutboxQueriesGateway
+	publisher    gateway.Publisher
+	cfg          OutboxTailerConfig
+
+	shards map[string]*tailedShard
+}
+
+// tailedShard is how far the tailer read the outbox of a shard.
+type tailedShard struct {
+	lastID todo.EventID
+	// seen are the events of the overlap published already.
+	seen map[todo.EventID]struct{}
+}
+
+func NewOutboxTailer(
+	binder gateway.ShardBinder,
+	outboxReader gateway.OutboxQueriesGateway,
+	publisher gateway.Publisher,
+	cfg OutboxTailerConfig,
+) *OutboxTailer {
+	return &OutboxTailer{
+		binder:       binder,
+		outboxReader: outboxReader,
+		publisher:    publisher,
+		cfg:          cfg,
+		shards:       map[string]*tailedShard{},
+	}
+}
+
+// Run tails the outbox until ctx is canceled.
+func (t *OutboxTailer) Run(ctx context.Context) error {
+	ticker := time.NewTicker(t.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := t.TailOnce(ctx); err != nil {
+			log.Printf("outbox tailer: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// TailOnce publishes the events recorded on each shard since the last call and
+// returns how many of them were published. It is not safe for concurrent use.
+func (t *OutboxTailer) TailOnce(ctx context.Context) (int, error) {
+	published := 0
+	var errs []error
+	for _, shard := range t.binder.Shards() {
+		n, err := t.tailShard(t.binder.BindShard(ctx, shard), shard)
+		published += n
+		if err != nil {
+			if ctx.Err() != nil {
+				return published, err
+			}
+			errs = append(errs, fmt.Errorf("shard %s: %w", shard, err))
+		}
+	}
+
+	return published, errors.Join(errs...)
+}
+
+func (t *OutboxTailer) tailShard(ctx context.Context, shard string) (int, error) {
+	state, ok := t.shards[shard]
+	if !ok {
+		lastID, err := t.outboxReader.GetLastEventID(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("get last event ID: %w", err)
+		}
+		// The events of the overlap committed before the start are not
+		// published: the streams that need them replay them.
+		state = &tailedShard{lastID: lastID, seen: map[todo.EventID]struct{}{}}
+		if t.cfg.Overlap > 0 {
+			events, err := t.outboxReader.ListEventsAfter(ctx, t.overlapStart(lastID), t.cfg.Overlap)
+			if err != nil {
+				return 0, fmt.Errorf("list events: %w", err)
+			}
+			for _, event := range events {
+				state.seen[event.ID] = struct{}{}
+			}
+		}
+		t.shards[shard] = state
+	}
+
+	published := 0
+	afterID := t.overlapStart(state.lastID)
+	for {
+		events, err := t.outboxReader.ListEventsAfter(ctx, afterID, t.cfg.BatchSize)
+		if err != nil {
+			return published, fmt.Errorf("list events: %w", err)
+		}
+
+		for _, event := range events {
+			afterID = event.ID
+			if _, ok := state.seen[event.ID]; ok {
+				continue
+			}
+			if err := t.publisher.Publish(ctx, event); err != nil {
+				return published, fmt.Errorf("publish event %d: %w", event.ID, err)
+			}
+			state.seen[event.ID] = struct{}{}
+			if event.ID > state.lastID {
+				state.lastID = event.ID
+			}
+			published++
+		}
+
+		if len(events) < t.cfg.BatchSize {
+			break
+		}
+	}
+
+	// Events behind the overlap are not read again.
+	start := t.overlapStart(state.lastID)
+	for id := range state.seen {
+		if id <= start {
+			delete(state.seen, id)
+		}
+	}
+
+	return published, nil
+}
+
+// overlapStart is the ID the outbox is read after to go through the overlap
+// behind lastID.
+func (t *OutboxTailer) overlapStart(lastID todo.EventID) todo.EventID {
+	if lastID <= todo.EventID(t.cfg.Overlap) {
+		return 0
+	}
+	return lastID - todo.EventID(t.cfg.Overlap)
+}
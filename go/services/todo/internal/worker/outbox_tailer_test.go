@@ -0,0 +1,57 @@
+package worker_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/worker"
+)
+
+func Test_OutboxTailer_TailOnce(t *testing.T) {
+	t.Parallel()
+
+	newEvent := func(id todo.EventID) *todo.Event {
+		return &todo.Event{ID: id, AggregateType: todo.AggregateTypes.Todo, AggregateID: int64(id)}
+	}
+
+	outbox := &fakeOutbox{events: []*todo.Event{newEvent(1), newEvent(2)}}
+	pub := &fakePublisher{}
+	tailer := worker.NewOutboxTailer(fakeBinder{}, outbox, pub, worker.OutboxTailerConfig{
+		PollInterval: time.Second,
+		BatchSize:    2,
+		Overlap:      5,
+	})
+
+	tail := func(add ...*todo.Event) []todo.EventID {
+		t.Helper()
+
+		outbox.mu.Lock()
+		outbox.events = append(outbox.events, add...)
+		outbox.mu.Unlock()
+
+		pub.published = nil
+		if _, err := tailer.TailOnce(context.Background()); err != nil {
+			t.Fatalf("TailOnce() error = %v", err)
+		}
+		return pub.published
+	}
+
+	// The tailer starts from the end of the outbox.
+	if published := tail(); len(published) != 0 {
+		t.Fatalf("published %v on start, want none", published)
+	}
+	if diff := cmp.Diff(tail(newEvent(4), newEvent(5), newEvent(6)), []todo.EventID{4, 5, 6}); diff != "" {
+		t.Fatalf("published events mismatch (-actual +expected):\n%s", diff)
+	}
+	// Event 3 was committed after the events recorded after it.
+	if diff := cmp.Diff(tail(newEvent(3)), []todo.EventID{3}); diff != "" {
+		t.Fatalf("published events mismatch (-actual +expected):\n%s", diff)
+	}
+	if published := tail(); len(published) != 0 {
+		t.Fatalf("published %v again, want none", published)
+	}
+}
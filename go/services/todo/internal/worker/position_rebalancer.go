@@ -0,0 +1,100 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/gateway"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+)
+
+type PositionRebalancerConfig struct {
+	Interval  time.Duration
+	BatchSize int
+}
+
+// PositionRebalancer shortens the positions of the lists that grew long from
+// repeated moves to the same spot, before a move has to rebalance the list
+// itself.
+type PositionRebalancer struct {
+	binder         gateway.ShardBinder
+	positionReader gateway.TodoPositionQueriesGateway
+	positionWriter gateway.TodoPositionCommandsGateway
+	cfg            PositionRebalancerConfig
+}
+
+func NewPositionRebalancer(
+	binder gateway.ShardBinder,
+	positionReader gateway.TodoPositionQueriesGateway,
+	positionWriter gateway.TodoPositionCommandsGateway,
+	cfg PositionRebalancerConfig,
+) *PositionRebalancer {
+	return &PositionRebalancer{
+		binder:         binder,
+		positionReader: positionReader,
+		positionWriter: positionWriter,
+		cfg:            cfg,
+	}
+}
+
+// Run rebalances until ctx is canceled.
+func (r *PositionRebalancer) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := r.RebalanceOnce(ctx); err != nil {
+			log.Printf("position rebalancer: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// RebalanceOnce rebalances one batch of lists of each shard and returns how
+// many were rebalanced. A shard that fails does not hold back the others.
+func (r *PositionRebalancer) RebalanceOnce(ctx context.Context) (int, error) {
+	rebalanced := 0
+	var errs []error
+	for _, shard := range r.binder.Shards() {
+		n, err := r.rebalanceShard(ctx, shard)
+		rebalanced += n
+		if err != nil {
+			if ctx.Err() != nil {
+				return rebalanced, err
+			}
+			errs = append(errs, fmt.Errorf("shard %s: %w", shard, err))
+		}
+	}
+
+	return rebalanced, errors.Join(errs...)
+}
+
+// rebalanceShard rebalances one batch of lists found on the shard. Each list
+// is rebalanced on the shard of its user, which a move may have changed since.
+func (r *PositionRebalancer) rebalanceShard(ctx context.Context, shard string) (int, error) {
+	userIDs, err := r.positionReader.ListUserIDsWithLongPositions(
+		r.binder.BindShard(ctx, shard),
+		todo.PositionRebalanceLength,
+		r.cfg.BatchSize,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("list users with long positions: %w", err)
+	}
+
+	for i, userID := range userIDs {
+		userCtx := r.binder.Bind(todo.WithUser(ctx, &todo.User{ID: userID}))
+		if err := r.positionWriter.RebalancePositions(userCtx, userID); err != nil {
+			return i, fmt.Errorf("rebalance positions of user %d: %w", userID, err)
+		}
+	}
+
+	return len(userIDs), nil
+}
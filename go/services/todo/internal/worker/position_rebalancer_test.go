@@ -0,0 +1,101 @@
+package worker_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/domain/model/todo"
+	"github.com/phamquanandpad/training-project/go/services/todo/internal/worker"
+)
+
+type fakePositionStore struct {
+	positions  map[todo.UserID][]string
+	rebalanced []todo.UserID
+}
+
+func (s *fakePositionStore) ListUserIDsWithLongPositions(_ context.Context, length int, limit int) ([]todo.UserID, error) {
+	var userIDs []todo.UserID
+	for userID := todo.UserID(1); userID <= todo.UserID(len(s.positions)); userID++ {
+		for _, position := range s.positions[userID] {
+			if len(position) >= length && len(userIDs) < limit {
+				userIDs = append(userIDs, userID)
+				break
+			}
+		}
+	}
+	return userIDs, nil
+}
+
+func (s *fakePositionStore) RebalancePositions(_ context.Context, userID todo.UserID) error {
+	s.rebalanced = append(s.rebalanced, userID)
+	s.positions[userID] = todo.EvenPositions(len(s.positions[userID]))
+	return nil
+}
+
+func Test_PositionRebalancer_RebalanceOnce(t *testing.T) {
+	type testcase struct {
+		positions          map[todo.UserID][]string
+		batchSize          int
+		expectedRebalanced []todo.UserID
+	}
+
+	t.Parallel()
+
+	long := "i" + strings.Repeat("z", todo.PositionRebalanceLength-1)
+
+	testTables := map[string]testcase{
+		"Rebalance only the lists with long positions": {
+			positions: map[todo.UserID][]string{
+				1: {"i", "r"},
+				2: {"i", long},
+				3: {long},
+			},
+			batchSize:          10,
+			expectedRebalanced: []todo.UserID{2, 3},
+		},
+		"Rebalance at most one batch": {
+			positions: map[todo.UserID][]string{
+				1: {long},
+				2: {long},
+			},
+			batchSize:          1,
+			expectedRebalanced: []todo.UserID{1},
+		},
+		"Do nothing when every position is short": {
+			positions: map[todo.UserID][]string{
+				1: {"i", "r"},
+			},
+			batchSize: 10,
+		},
+	}
+
+	for name, tt := range testTables {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			store := &fakePositionStore{positions: tt.positions}
+			rebalancer := worker.NewPositionRebalancer(fakeBinder{}, store, store, worker.PositionRebalancerConfig{
+				Interval:  time.Minute,
+				BatchSize: tt.batchSize,
+			})
+
+			rebalanced, err := rebalancer.RebalanceOnce(context.Background())
+			if err != nil {
+				t.Fatalf("RebalanceOnce() error = %v", err)
+			}
+
+			if rebalanced != len(tt.expectedRebalanced) {
+				t.Errorf("rebalanced = %d want %d", rebalanced, len(tt.expectedRebalanced))
+			}
+
+			if diff := cmp.Diff(store.rebalanced, tt.expectedRebalanced); diff != "" {
+				t.Errorf("rebalanced users mismatch (-actual +expected):\n%s", diff)
+			}
+		})
+	}
+}
@@ -4,12 +4,12 @@ go 1.25.6
 
 require (
 	connectrpc.com/connect v1.19.1
+	go.uber.org/mock v0.6.0
 	google.golang.org/grpc v1.78.0
 	google.golang.org/protobuf v1.36.10
 )
 
 require (
-	go.uber.org/mock v0.6.0 // indirect
 	golang.org/x/net v0.47.0 // indirect
 	golang.org/x/sys v0.38.0 // indirect
 	golang.org/x/text v0.31.0 // indirect
@@ -80,6 +80,8 @@ type Todo struct {
 	Status        TodoStatus             `protobuf:"varint,5,opt,name=status,proto3,enum=todo.common.v1.TodoStatus" json:"status,omitempty"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	DueAt         *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=due_at,json=dueAt,proto3" json:"due_at,omitempty"`
+	Position      string                 `protobuf:"bytes,9,opt,name=position,proto3" json:"position,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -163,6 +165,20 @@ func (x *Todo) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Todo) GetDueAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueAt
+	}
+	return nil
+}
+
+func (x *Todo) GetPosition() string {
+	if x != nil {
+		return x.Position
+	}
+	return ""
+}
+
 type User struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -243,7 +259,7 @@ var File_todo_common_v1_todo_model_proto protoreflect.FileDescriptor
 
 const file_todo_common_v1_todo_model_proto_rawDesc = "" +
 	"\n" +
-	"\x1ftodo/common/v1/todo_model.proto\x12\x0etodo.common.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x8f\x02\n" +
+	"\x1ftodo/common/v1/todo_model.proto\x12\x0etodo.common.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xde\x02\n" +
 	"\x04Todo\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12\x12\n" +
@@ -253,7 +269,9 @@ const file_todo_common_v1_todo_model_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xbe\x01\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x121\n" +
+	"\x06due_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x05dueAt\x12\x1a\n" +
+	"\bposition\x18\t \x01(\tR\bposition\"\xbe\x01\n" +
 	"\x04User\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12\x14\n" +
@@ -292,13 +310,14 @@ var file_todo_common_v1_todo_model_proto_depIdxs = []int32{
 	0, // 0: todo.common.v1.Todo.status:type_name -> todo.common.v1.TodoStatus
 	3, // 1: todo.common.v1.Todo.created_at:type_name -> google.protobuf.Timestamp
 	3, // 2: todo.common.v1.Todo.updated_at:type_name -> google.protobuf.Timestamp
-	3, // 3: todo.common.v1.User.created_at:type_name -> google.protobuf.Timestamp
-	3, // 4: todo.common.v1.User.updated_at:type_name -> google.protobuf.Timestamp
-	5, // [5:5] is the sub-list for method output_type
-	5, // [5:5] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	3, // 3: todo.common.v1.Todo.due_at:type_name -> google.protobuf.Timestamp
+	3, // 4: todo.common.v1.User.created_at:type_name -> google.protobuf.Timestamp
+	3, // 5: todo.common.v1.User.updated_at:type_name -> google.protobuf.Timestamp
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_todo_common_v1_todo_model_proto_init() }
@@ -62,6 +62,126 @@ func (mr *MockTodoServiceClientMockRecorder) DeleteTodo(ctx, in any, opts ...any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTodo", reflect.TypeOf((*MockTodoServiceClient)(nil).DeleteTodo), varargs...)
 }
 
+// DeleteUser mocks base method.
+func (m *MockTodoServiceClient) DeleteUser(ctx context.Context, in *v1.DeleteUserRequest, opts ...grpc.CallOption) (*v1.DeleteUserResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteUser", varargs...)
+	ret0, _ := ret[0].(*v1.DeleteUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockTodoServiceClientMockRecorder) DeleteUser(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockTodoServiceClient)(nil).DeleteUser), varargs...)
+}
+
+// EraseUser mocks base method.
+func (m *MockTodoServiceClient) EraseUser(ctx context.Context, in *v1.EraseUserRequest, opts ...grpc.CallOption) (*v1.EraseUserResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "EraseUser", varargs...)
+	ret0, _ := ret[0].(*v1.EraseUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EraseUser indicates an expected call of EraseUser.
+func (mr *MockTodoServiceClientMockRecorder) EraseUser(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EraseUser", reflect.TypeOf((*MockTodoServiceClient)(nil).EraseUser), varargs...)
+}
+
+// ExportTodos mocks base method.
+func (m *MockTodoServiceClient) ExportTodos(ctx context.Context, in *v1.ExportTodosRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[v1.ExportTodosResponse], error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ExportTodos", varargs...)
+	ret0, _ := ret[0].(grpc.ServerStreamingClient[v1.ExportTodosResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportTodos indicates an expected call of ExportTodos.
+func (mr *MockTodoServiceClientMockRecorder) ExportTodos(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportTodos", reflect.TypeOf((*MockTodoServiceClient)(nil).ExportTodos), varargs...)
+}
+
+// ExportUserData mocks base method.
+func (m *MockTodoServiceClient) ExportUserData(ctx context.Context, in *v1.ExportUserDataRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[v1.ExportUserDataResponse], error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ExportUserData", varargs...)
+	ret0, _ := ret[0].(grpc.ServerStreamingClient[v1.ExportUserDataResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportUserData indicates an expected call of ExportUserData.
+func (mr *MockTodoServiceClientMockRecorder) ExportUserData(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportUserData", reflect.TypeOf((*MockTodoServiceClient)(nil).ExportUserData), varargs...)
+}
+
+// GetCalendarFeedURL mocks base method.
+func (m *MockTodoServiceClient) GetCalendarFeedURL(ctx context.Context, in *v1.GetCalendarFeedURLRequest, opts ...grpc.CallOption) (*v1.GetCalendarFeedURLResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetCalendarFeedURL", varargs...)
+	ret0, _ := ret[0].(*v1.GetCalendarFeedURLResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCalendarFeedURL indicates an expected call of GetCalendarFeedURL.
+func (mr *MockTodoServiceClientMockRecorder) GetCalendarFeedURL(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCalendarFeedURL", reflect.TypeOf((*MockTodoServiceClient)(nil).GetCalendarFeedURL), varargs...)
+}
+
+// GetDatabaseStatus mocks base method.
+func (m *MockTodoServiceClient) GetDatabaseStatus(ctx context.Context, in *v1.GetDatabaseStatusRequest, opts ...grpc.CallOption) (*v1.GetDatabaseStatusResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetDatabaseStatus", varargs...)
+	ret0, _ := ret[0].(*v1.GetDatabaseStatusResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDatabaseStatus indicates an expected call of GetDatabaseStatus.
+func (mr *MockTodoServiceClientMockRecorder) GetDatabaseStatus(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDatabaseStatus", reflect.TypeOf((*MockTodoServiceClient)(nil).GetDatabaseStatus), varargs...)
+}
+
 // GetTodo mocks base method.
 func (m *MockTodoServiceClient) GetTodo(ctx context.Context, in *v1.GetTodoRequest, opts ...grpc.CallOption) (*v1.GetTodoResponse, error) {
 	m.ctrl.T.Helper()
@@ -82,6 +202,26 @@ func (mr *MockTodoServiceClientMockRecorder) GetTodo(ctx, in any, opts ...any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTodo", reflect.TypeOf((*MockTodoServiceClient)(nil).GetTodo), varargs...)
 }
 
+// GetTodoStats mocks base method.
+func (m *MockTodoServiceClient) GetTodoStats(ctx context.Context, in *v1.GetTodoStatsRequest, opts ...grpc.CallOption) (*v1.GetTodoStatsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetTodoStats", varargs...)
+	ret0, _ := ret[0].(*v1.GetTodoStatsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTodoStats indicates an expected call of GetTodoStats.
+func (mr *MockTodoServiceClientMockRecorder) GetTodoStats(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTodoStats", reflect.TypeOf((*MockTodoServiceClient)(nil).GetTodoStats), varargs...)
+}
+
 // GetUser mocks base method.
 func (m *MockTodoServiceClient) GetUser(ctx context.Context, in *v1.GetUserRequest, opts ...grpc.CallOption) (*v1.GetUserResponse, error) {
 	m.ctrl.T.Helper()
@@ -102,6 +242,26 @@ func (mr *MockTodoServiceClientMockRecorder) GetUser(ctx, in any, opts ...any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockTodoServiceClient)(nil).GetUser), varargs...)
 }
 
+// ImportTodos mocks base method.
+func (m *MockTodoServiceClient) ImportTodos(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[v1.ImportTodosRequest, v1.ImportTodosResponse], error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ImportTodos", varargs...)
+	ret0, _ := ret[0].(grpc.ClientStreamingClient[v1.ImportTodosRequest, v1.ImportTodosResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportTodos indicates an expected call of ImportTodos.
+func (mr *MockTodoServiceClientMockRecorder) ImportTodos(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportTodos", reflect.TypeOf((*MockTodoServiceClient)(nil).ImportTodos), varargs...)
+}
+
 // ListTodos mocks base method.
 func (m *MockTodoServiceClient) ListTodos(ctx context.Context, in *v1.ListTodosRequest, opts ...grpc.CallOption) (*v1.ListTodosResponse, error) {
 	m.ctrl.T.Helper()
@@ -122,6 +282,26 @@ func (mr *MockTodoServiceClientMockRecorder) ListTodos(ctx, in any, opts ...any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTodos", reflect.TypeOf((*MockTodoServiceClient)(nil).ListTodos), varargs...)
 }
 
+// MoveTodo mocks base method.
+func (m *MockTodoServiceClient) MoveTodo(ctx context.Context, in *v1.MoveTodoRequest, opts ...grpc.CallOption) (*v1.MoveTodoResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "MoveTodo", varargs...)
+	ret0, _ := ret[0].(*v1.MoveTodoResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveTodo indicates an expected call of MoveTodo.
+func (mr *MockTodoServiceClientMockRecorder) MoveTodo(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveTodo", reflect.TypeOf((*MockTodoServiceClient)(nil).MoveTodo), varargs...)
+}
+
 // PostTodo mocks base method.
 func (m *MockTodoServiceClient) PostTodo(ctx context.Context, in *v1.PostTodoRequest, opts ...grpc.CallOption) (*v1.PostTodoResponse, error) {
 	m.ctrl.T.Helper()
@@ -182,6 +362,46 @@ func (mr *MockTodoServiceClientMockRecorder) PutTodo(ctx, in any, opts ...any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutTodo", reflect.TypeOf((*MockTodoServiceClient)(nil).PutTodo), varargs...)
 }
 
+// UpdateUser mocks base method.
+func (m *MockTodoServiceClient) UpdateUser(ctx context.Context, in *v1.UpdateUserRequest, opts ...grpc.CallOption) (*v1.UpdateUserResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateUser", varargs...)
+	ret0, _ := ret[0].(*v1.UpdateUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *MockTodoServiceClientMockRecorder) UpdateUser(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockTodoServiceClient)(nil).UpdateUser), varargs...)
+}
+
+// WatchTodos mocks base method.
+func (m *MockTodoServiceClient) WatchTodos(ctx context.Context, in *v1.WatchTodosRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[v1.WatchTodosResponse], error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WatchTodos", varargs...)
+	ret0, _ := ret[0].(grpc.ServerStreamingClient[v1.WatchTodosResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchTodos indicates an expected call of WatchTodos.
+func (mr *MockTodoServiceClientMockRecorder) WatchTodos(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchTodos", reflect.TypeOf((*MockTodoServiceClient)(nil).WatchTodos), varargs...)
+}
+
 // MockTodoServiceServer is a mock of TodoServiceServer interface.
 type MockTodoServiceServer struct {
 	ctrl     *gomock.Controller
@@ -221,6 +441,94 @@ func (mr *MockTodoServiceServerMockRecorder) DeleteTodo(arg0, arg1 any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTodo", reflect.TypeOf((*MockTodoServiceServer)(nil).DeleteTodo), arg0, arg1)
 }
 
+// DeleteUser mocks base method.
+func (m *MockTodoServiceServer) DeleteUser(arg0 context.Context, arg1 *v1.DeleteUserRequest) (*v1.DeleteUserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", arg0, arg1)
+	ret0, _ := ret[0].(*v1.DeleteUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockTodoServiceServerMockRecorder) DeleteUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockTodoServiceServer)(nil).DeleteUser), arg0, arg1)
+}
+
+// EraseUser mocks base method.
+func (m *MockTodoServiceServer) EraseUser(arg0 context.Context, arg1 *v1.EraseUserRequest) (*v1.EraseUserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EraseUser", arg0, arg1)
+	ret0, _ := ret[0].(*v1.EraseUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EraseUser indicates an expected call of EraseUser.
+func (mr *MockTodoServiceServerMockRecorder) EraseUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EraseUser", reflect.TypeOf((*MockTodoServiceServer)(nil).EraseUser), arg0, arg1)
+}
+
+// ExportTodos mocks base method.
+func (m *MockTodoServiceServer) ExportTodos(arg0 *v1.ExportTodosRequest, arg1 grpc.ServerStreamingServer[v1.ExportTodosResponse]) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportTodos", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportTodos indicates an expected call of ExportTodos.
+func (mr *MockTodoServiceServerMockRecorder) ExportTodos(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportTodos", reflect.TypeOf((*MockTodoServiceServer)(nil).ExportTodos), arg0, arg1)
+}
+
+// ExportUserData mocks base method.
+func (m *MockTodoServiceServer) ExportUserData(arg0 *v1.ExportUserDataRequest, arg1 grpc.ServerStreamingServer[v1.ExportUserDataResponse]) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportUserData", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportUserData indicates an expected call of ExportUserData.
+func (mr *MockTodoServiceServerMockRecorder) ExportUserData(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportUserData", reflect.TypeOf((*MockTodoServiceServer)(nil).ExportUserData), arg0, arg1)
+}
+
+// GetCalendarFeedURL mocks base method.
+func (m *MockTodoServiceServer) GetCalendarFeedURL(arg0 context.Context, arg1 *v1.GetCalendarFeedURLRequest) (*v1.GetCalendarFeedURLResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCalendarFeedURL", arg0, arg1)
+	ret0, _ := ret[0].(*v1.GetCalendarFeedURLResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCalendarFeedURL indicates an expected call of GetCalendarFeedURL.
+func (mr *MockTodoServiceServerMockRecorder) GetCalendarFeedURL(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCalendarFeedURL", reflect.TypeOf((*MockTodoServiceServer)(nil).GetCalendarFeedURL), arg0, arg1)
+}
+
+// GetDatabaseStatus mocks base method.
+func (m *MockTodoServiceServer) GetDatabaseStatus(arg0 context.Context, arg1 *v1.GetDatabaseStatusRequest) (*v1.GetDatabaseStatusResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDatabaseStatus", arg0, arg1)
+	ret0, _ := ret[0].(*v1.GetDatabaseStatusResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDatabaseStatus indicates an expected call of GetDatabaseStatus.
+func (mr *MockTodoServiceServerMockRecorder) GetDatabaseStatus(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDatabaseStatus", reflect.TypeOf((*MockTodoServiceServer)(nil).GetDatabaseStatus), arg0, arg1)
+}
+
 // GetTodo mocks base method.
 func (m *MockTodoServiceServer) GetTodo(arg0 context.Context, arg1 *v1.GetTodoRequest) (*v1.GetTodoResponse, error) {
 	m.ctrl.T.Helper()
@@ -236,6 +544,21 @@ func (mr *MockTodoServiceServerMockRecorder) GetTodo(arg0, arg1 any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTodo", reflect.TypeOf((*MockTodoServiceServer)(nil).GetTodo), arg0, arg1)
 }
 
+// GetTodoStats mocks base method.
+func (m *MockTodoServiceServer) GetTodoStats(arg0 context.Context, arg1 *v1.GetTodoStatsRequest) (*v1.GetTodoStatsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTodoStats", arg0, arg1)
+	ret0, _ := ret[0].(*v1.GetTodoStatsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTodoStats indicates an expected call of GetTodoStats.
+func (mr *MockTodoServiceServerMockRecorder) GetTodoStats(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTodoStats", reflect.TypeOf((*MockTodoServiceServer)(nil).GetTodoStats), arg0, arg1)
+}
+
 // GetUser mocks base method.
 func (m *MockTodoServiceServer) GetUser(arg0 context.Context, arg1 *v1.GetUserRequest) (*v1.GetUserResponse, error) {
 	m.ctrl.T.Helper()
@@ -251,6 +574,20 @@ func (mr *MockTodoServiceServerMockRecorder) GetUser(arg0, arg1 any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockTodoServiceServer)(nil).GetUser), arg0, arg1)
 }
 
+// ImportTodos mocks base method.
+func (m *MockTodoServiceServer) ImportTodos(arg0 grpc.ClientStreamingServer[v1.ImportTodosRequest, v1.ImportTodosResponse]) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportTodos", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportTodos indicates an expected call of ImportTodos.
+func (mr *MockTodoServiceServerMockRecorder) ImportTodos(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportTodos", reflect.TypeOf((*MockTodoServiceServer)(nil).ImportTodos), arg0)
+}
+
 // ListTodos mocks base method.
 func (m *MockTodoServiceServer) ListTodos(arg0 context.Context, arg1 *v1.ListTodosRequest) (*v1.ListTodosResponse, error) {
 	m.ctrl.T.Helper()
@@ -266,6 +603,21 @@ func (mr *MockTodoServiceServerMockRecorder) ListTodos(arg0, arg1 any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTodos", reflect.TypeOf((*MockTodoServiceServer)(nil).ListTodos), arg0, arg1)
 }
 
+// MoveTodo mocks base method.
+func (m *MockTodoServiceServer) MoveTodo(arg0 context.Context, arg1 *v1.MoveTodoRequest) (*v1.MoveTodoResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveTodo", arg0, arg1)
+	ret0, _ := ret[0].(*v1.MoveTodoResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveTodo indicates an expected call of MoveTodo.
+func (mr *MockTodoServiceServerMockRecorder) MoveTodo(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveTodo", reflect.TypeOf((*MockTodoServiceServer)(nil).MoveTodo), arg0, arg1)
+}
+
 // PostTodo mocks base method.
 func (m *MockTodoServiceServer) PostTodo(arg0 context.Context, arg1 *v1.PostTodoRequest) (*v1.PostTodoResponse, error) {
 	m.ctrl.T.Helper()
@@ -311,6 +663,35 @@ func (mr *MockTodoServiceServerMockRecorder) PutTodo(arg0, arg1 any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutTodo", reflect.TypeOf((*MockTodoServiceServer)(nil).PutTodo), arg0, arg1)
 }
 
+// UpdateUser mocks base method.
+func (m *MockTodoServiceServer) UpdateUser(arg0 context.Context, arg1 *v1.UpdateUserRequest) (*v1.UpdateUserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUser", arg0, arg1)
+	ret0, _ := ret[0].(*v1.UpdateUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *MockTodoServiceServerMockRecorder) UpdateUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockTodoServiceServer)(nil).UpdateUser), arg0, arg1)
+}
+
+// WatchTodos mocks base method.
+func (m *MockTodoServiceServer) WatchTodos(arg0 *v1.WatchTodosRequest, arg1 grpc.ServerStreamingServer[v1.WatchTodosResponse]) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchTodos", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WatchTodos indicates an expected call of WatchTodos.
+func (mr *MockTodoServiceServerMockRecorder) WatchTodos(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchTodos", reflect.TypeOf((*MockTodoServiceServer)(nil).WatchTodos), arg0, arg1)
+}
+
 // mustEmbedUnimplementedTodoServiceServer mocks base method.
 func (m *MockTodoServiceServer) mustEmbedUnimplementedTodoServiceServer() {
 	m.ctrl.T.Helper()
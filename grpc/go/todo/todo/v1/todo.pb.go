@@ -10,6 +10,8 @@ import (
 	v1 "github.com/phamquanandpad/training-project/grpc/go/todo/common/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -22,6 +24,220 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type TodoSortingType int32
+
+const (
+	TodoSortingType_TODO_SORTING_TYPE_UNSPECIFIED TodoSortingType = 0
+	TodoSortingType_TODO_SORTING_TYPE_CREATED_AT  TodoSortingType = 1
+	TodoSortingType_TODO_SORTING_TYPE_UPDATED_AT  TodoSortingType = 2
+	TodoSortingType_TODO_SORTING_TYPE_POSITION    TodoSortingType = 3
+)
+
+// Enum value maps for TodoSortingType.
+var (
+	TodoSortingType_name = map[int32]string{
+		0: "TODO_SORTING_TYPE_UNSPECIFIED",
+		1: "TODO_SORTING_TYPE_CREATED_AT",
+		2: "TODO_SORTING_TYPE_UPDATED_AT",
+		3: "TODO_SORTING_TYPE_POSITION",
+	}
+	TodoSortingType_value = map[string]int32{
+		"TODO_SORTING_TYPE_UNSPECIFIED": 0,
+		"TODO_SORTING_TYPE_CREATED_AT":  1,
+		"TODO_SORTING_TYPE_UPDATED_AT":  2,
+		"TODO_SORTING_TYPE_POSITION":    3,
+	}
+)
+
+func (x TodoSortingType) Enum() *TodoSortingType {
+	p := new(TodoSortingType)
+	*p = x
+	return p
+}
+
+func (x TodoSortingType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TodoSortingType) Descriptor() protoreflect.EnumDescriptor {
+	return file_todo_todo_v1_todo_proto_enumTypes[0].Descriptor()
+}
+
+func (TodoSortingType) Type() protoreflect.EnumType {
+	return &file_todo_todo_v1_todo_proto_enumTypes[0]
+}
+
+func (x TodoSortingType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TodoSortingType.Descriptor instead.
+func (TodoSortingType) EnumDescriptor() ([]byte, []int) {
+	return file_todo_todo_v1_todo_proto_rawDescGZIP(), []int{0}
+}
+
+type TodoEventType int32
+
+const (
+	TodoEventType_TODO_EVENT_TYPE_UNSPECIFIED TodoEventType = 0
+	TodoEventType_TODO_EVENT_TYPE_CREATED     TodoEventType = 1
+	TodoEventType_TODO_EVENT_TYPE_UPDATED     TodoEventType = 2
+	TodoEventType_TODO_EVENT_TYPE_DELETED     TodoEventType = 3
+)
+
+// Enum value maps for TodoEventType.
+var (
+	TodoEventType_name = map[int32]string{
+		0: "TODO_EVENT_TYPE_UNSPECIFIED",
+		1: "TODO_EVENT_TYPE_CREATED",
+		2: "TODO_EVENT_TYPE_UPDATED",
+		3: "TODO_EVENT_TYPE_DELETED",
+	}
+	TodoEventType_value = map[string]int32{
+		"TODO_EVENT_TYPE_UNSPECIFIED": 0,
+		"TODO_EVENT_TYPE_CREATED":     1,
+		"TODO_EVENT_TYPE_UPDATED":     2,
+		"TODO_EVENT_TYPE_DELETED":     3,
+	}
+)
+
+func (x TodoEventType) Enum() *TodoEventType {
+	p := new(TodoEventType)
+	*p = x
+	return p
+}
+
+func (x TodoEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TodoEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_todo_todo_v1_todo_proto_enumTypes[1].Descriptor()
+}
+
+func (TodoEventType) Type() protoreflect.EnumType {
+	return &file_todo_todo_v1_todo_proto_enumTypes[1]
+}
+
+func (x TodoEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TodoEventType.Descriptor instead.
+func (TodoEventType) EnumDescriptor() ([]byte, []int) {
+	return file_todo_todo_v1_todo_proto_rawDescGZIP(), []int{1}
+}
+
+type TodoFileFormat int32
+
+const (
+	TodoFileFormat_TODO_FILE_FORMAT_UNSPECIFIED TodoFileFormat = 0
+	TodoFileFormat_TODO_FILE_FORMAT_CSV         TodoFileFormat = 1
+	TodoFileFormat_TODO_FILE_FORMAT_JSON_LINES  TodoFileFormat = 2
+	TodoFileFormat_TODO_FILE_FORMAT_ICALENDAR   TodoFileFormat = 3
+	TodoFileFormat_TODO_FILE_FORMAT_TODO_TXT    TodoFileFormat = 4
+	TodoFileFormat_TODO_FILE_FORMAT_MARKDOWN    TodoFileFormat = 5
+)
+
+// Enum value maps for TodoFileFormat.
+var (
+	TodoFileFormat_name = map[int32]string{
+		0: "TODO_FILE_FORMAT_UNSPECIFIED",
+		1: "TODO_FILE_FORMAT_CSV",
+		2: "TODO_FILE_FORMAT_JSON_LINES",
+		3: "TODO_FILE_FORMAT_ICALENDAR",
+		4: "TODO_FILE_FORMAT_TODO_TXT",
+		5: "TODO_FILE_FORMAT_MARKDOWN",
+	}
+	TodoFileFormat_value = map[string]int32{
+		"TODO_FILE_FORMAT_UNSPECIFIED": 0,
+		"TODO_FILE_FORMAT_CSV":         1,
+		"TODO_FILE_FORMAT_JSON_LINES":  2,
+		"TODO_FILE_FORMAT_ICALENDAR":   3,
+		"TODO_FILE_FORMAT_TODO_TXT":    4,
+		"TODO_FILE_FORMAT_MARKDOWN":    5,
+	}
+)
+
+func (x TodoFileFormat) Enum() *TodoFileFormat {
+	p := new(TodoFileFormat)
+	*p = x
+	return p
+}
+
+func (x TodoFileFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TodoFileFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_todo_todo_v1_todo_proto_enumTypes[2].Descriptor()
+}
+
+func (TodoFileFormat) Type() protoreflect.EnumType {
+	return &file_todo_todo_v1_todo_proto_enumTypes[2]
+}
+
+func (x TodoFileFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TodoFileFormat.Descriptor instead.
+func (TodoFileFormat) EnumDescriptor() ([]byte, []int) {
+	return file_todo_todo_v1_todo_proto_rawDescGZIP(), []int{2}
+}
+
+type StatsBucketSize int32
+
+const (
+	StatsBucketSize_STATS_BUCKET_SIZE_UNSPECIFIED StatsBucketSize = 0
+	StatsBucketSize_STATS_BUCKET_SIZE_DAY         StatsBucketSize = 1
+	StatsBucketSize_STATS_BUCKET_SIZE_WEEK        StatsBucketSize = 2
+	StatsBucketSize_STATS_BUCKET_SIZE_MONTH       StatsBucketSize = 3
+)
+
+// Enum value maps for StatsBucketSize.
+var (
+	StatsBucketSize_name = map[int32]string{
+		0: "STATS_BUCKET_SIZE_UNSPECIFIED",
+		1: "STATS_BUCKET_SIZE_DAY",
+		2: "STATS_BUCKET_SIZE_WEEK",
+		3: "STATS_BUCKET_SIZE_MONTH",
+	}
+	StatsBucketSize_value = map[string]int32{
+		"STATS_BUCKET_SIZE_UNSPECIFIED": 0,
+		"STATS_BUCKET_SIZE_DAY":         1,
+		"STATS_BUCKET_SIZE_WEEK":        2,
+		"STATS_BUCKET_SIZE_MONTH":       3,
+	}
+)
+
+func (x StatsBucketSize) Enum() *StatsBucketSize {
+	p := new(StatsBucketSize)
+	*p = x
+	return p
+}
+
+func (x StatsBucketSize) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StatsBucketSize) Descriptor() protoreflect.EnumDescriptor {
+	return file_todo_todo_v1_todo_proto_enumTypes[3].Descriptor()
+}
+
+func (StatsBucketSize) Type() protoreflect.EnumType {
+	return &file_todo_todo_v1_todo_proto_enumTypes[3]
+}
+
+func (x StatsBucketSize) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StatsBucketSize.Descriptor instead.
+func (StatsBucketSize) EnumDescriptor() ([]byte, []int) {
+	return file_todo_todo_v1_todo_proto_rawDescGZIP(), []int{3}
+}
+
 type UserAttributes struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -71,6 +287,7 @@ type ListTodosRequest struct {
 	UserAttributes *UserAttributes        `protobuf:"bytes,1,opt,name=user_attributes,json=userAttributes,proto3" json:"user_attributes,omitempty"`
 	Offset         *int64                 `protobuf:"varint,2,opt,name=offset,proto3,oneof" json:"offset,omitempty"`
 	Limit          *int64                 `protobuf:"varint,3,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	SortingType    TodoSortingType        `protobuf:"varint,4,opt,name=sorting_type,json=sortingType,proto3,enum=todo.todo.v1.TodoSortingType" json:"sorting_type,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -126,6 +343,13 @@ func (x *ListTodosRequest) GetLimit() int64 {
 	return 0
 }
 
+func (x *ListTodosRequest) GetSortingType() TodoSortingType {
+	if x != nil {
+		return x.SortingType
+	}
+	return TodoSortingType_TODO_SORTING_TYPE_UNSPECIFIED
+}
+
 type ListTodosResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Todos         []*v1.Todo             `protobuf:"bytes,1,rep,name=todos,proto3" json:"todos,omitempty"`
@@ -594,27 +818,33 @@ func (*DeleteTodoResponse) Descriptor() ([]byte, []int) {
 	return file_todo_todo_v1_todo_proto_rawDescGZIP(), []int{10}
 }
 
-type GetUserRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+type MoveTodoRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserAttributes *UserAttributes        `protobuf:"bytes,1,opt,name=user_attributes,json=userAttributes,proto3" json:"user_attributes,omitempty"`
+	TodoId         int64                  `protobuf:"varint,2,opt,name=todo_id,json=todoId,proto3" json:"todo_id,omitempty"`
+	// Types that are valid to be assigned to Target:
+	//
+	//	*MoveTodoRequest_BeforeTodoId
+	//	*MoveTodoRequest_AfterTodoId
+	Target        isMoveTodoRequest_Target `protobuf_oneof:"target"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetUserRequest) Reset() {
-	*x = GetUserRequest{}
+func (x *MoveTodoRequest) Reset() {
+	*x = MoveTodoRequest{}
 	mi := &file_todo_todo_v1_todo_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetUserRequest) String() string {
+func (x *MoveTodoRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetUserRequest) ProtoMessage() {}
+func (*MoveTodoRequest) ProtoMessage() {}
 
-func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
+func (x *MoveTodoRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_todo_todo_v1_todo_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -626,39 +856,87 @@ func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
-func (*GetUserRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use MoveTodoRequest.ProtoReflect.Descriptor instead.
+func (*MoveTodoRequest) Descriptor() ([]byte, []int) {
 	return file_todo_todo_v1_todo_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *GetUserRequest) GetUserId() int64 {
+func (x *MoveTodoRequest) GetUserAttributes() *UserAttributes {
 	if x != nil {
-		return x.UserId
+		return x.UserAttributes
+	}
+	return nil
+}
+
+func (x *MoveTodoRequest) GetTodoId() int64 {
+	if x != nil {
+		return x.TodoId
 	}
 	return 0
 }
 
-type GetUserResponse struct {
+func (x *MoveTodoRequest) GetTarget() isMoveTodoRequest_Target {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *MoveTodoRequest) GetBeforeTodoId() int64 {
+	if x != nil {
+		if x, ok := x.Target.(*MoveTodoRequest_BeforeTodoId); ok {
+			return x.BeforeTodoId
+		}
+	}
+	return 0
+}
+
+func (x *MoveTodoRequest) GetAfterTodoId() int64 {
+	if x != nil {
+		if x, ok := x.Target.(*MoveTodoRequest_AfterTodoId); ok {
+			return x.AfterTodoId
+		}
+	}
+	return 0
+}
+
+type isMoveTodoRequest_Target interface {
+	isMoveTodoRequest_Target()
+}
+
+type MoveTodoRequest_BeforeTodoId struct {
+	BeforeTodoId int64 `protobuf:"varint,3,opt,name=before_todo_id,json=beforeTodoId,proto3,oneof"`
+}
+
+type MoveTodoRequest_AfterTodoId struct {
+	AfterTodoId int64 `protobuf:"varint,4,opt,name=after_todo_id,json=afterTodoId,proto3,oneof"`
+}
+
+func (*MoveTodoRequest_BeforeTodoId) isMoveTodoRequest_Target() {}
+
+func (*MoveTodoRequest_AfterTodoId) isMoveTodoRequest_Target() {}
+
+type MoveTodoResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	User          *v1.User               `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Todo          *v1.Todo               `protobuf:"bytes,1,opt,name=todo,proto3" json:"todo,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetUserResponse) Reset() {
-	*x = GetUserResponse{}
+func (x *MoveTodoResponse) Reset() {
+	*x = MoveTodoResponse{}
 	mi := &file_todo_todo_v1_todo_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetUserResponse) String() string {
+func (x *MoveTodoResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetUserResponse) ProtoMessage() {}
+func (*MoveTodoResponse) ProtoMessage() {}
 
-func (x *GetUserResponse) ProtoReflect() protoreflect.Message {
+func (x *MoveTodoResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_todo_todo_v1_todo_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -670,39 +948,41 @@ func (x *GetUserResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetUserResponse.ProtoReflect.Descriptor instead.
-func (*GetUserResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use MoveTodoResponse.ProtoReflect.Descriptor instead.
+func (*MoveTodoResponse) Descriptor() ([]byte, []int) {
 	return file_todo_todo_v1_todo_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *GetUserResponse) GetUser() *v1.User {
+func (x *MoveTodoResponse) GetTodo() *v1.Todo {
 	if x != nil {
-		return x.User
+		return x.Todo
 	}
 	return nil
 }
 
-type PostUserRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	User          *v1.User               `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+type WatchTodosRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserAttributes *UserAttributes        `protobuf:"bytes,1,opt,name=user_attributes,json=userAttributes,proto3" json:"user_attributes,omitempty"`
+	// Resume after this event ID, e.g. the last one received before a reconnect.
+	LastEventId   *int64 `protobuf:"varint,2,opt,name=last_event_id,json=lastEventId,proto3,oneof" json:"last_event_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PostUserRequest) Reset() {
-	*x = PostUserRequest{}
+func (x *WatchTodosRequest) Reset() {
+	*x = WatchTodosRequest{}
 	mi := &file_todo_todo_v1_todo_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PostUserRequest) String() string {
+func (x *WatchTodosRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PostUserRequest) ProtoMessage() {}
+func (*WatchTodosRequest) ProtoMessage() {}
 
-func (x *PostUserRequest) ProtoReflect() protoreflect.Message {
+func (x *WatchTodosRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_todo_todo_v1_todo_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -714,38 +994,50 @@ func (x *PostUserRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PostUserRequest.ProtoReflect.Descriptor instead.
-func (*PostUserRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use WatchTodosRequest.ProtoReflect.Descriptor instead.
+func (*WatchTodosRequest) Descriptor() ([]byte, []int) {
 	return file_todo_todo_v1_todo_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *PostUserRequest) GetUser() *v1.User {
+func (x *WatchTodosRequest) GetUserAttributes() *UserAttributes {
 	if x != nil {
-		return x.User
+		return x.UserAttributes
 	}
 	return nil
 }
 
-type PostUserResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+func (x *WatchTodosRequest) GetLastEventId() int64 {
+	if x != nil && x.LastEventId != nil {
+		return *x.LastEventId
+	}
+	return 0
+}
+
+type WatchTodosResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*WatchTodosResponse_Event
+	//	*WatchTodosResponse_Heartbeat
+	Payload       isWatchTodosResponse_Payload `protobuf_oneof:"payload"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PostUserResponse) Reset() {
-	*x = PostUserResponse{}
+func (x *WatchTodosResponse) Reset() {
+	*x = WatchTodosResponse{}
 	mi := &file_todo_todo_v1_todo_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PostUserResponse) String() string {
+func (x *WatchTodosResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PostUserResponse) ProtoMessage() {}
+func (*WatchTodosResponse) ProtoMessage() {}
 
-func (x *PostUserResponse) ProtoReflect() protoreflect.Message {
+func (x *WatchTodosResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_todo_todo_v1_todo_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -757,154 +1049,1958 @@ func (x *PostUserResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PostUserResponse.ProtoReflect.Descriptor instead.
-func (*PostUserResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use WatchTodosResponse.ProtoReflect.Descriptor instead.
+func (*WatchTodosResponse) Descriptor() ([]byte, []int) {
 	return file_todo_todo_v1_todo_proto_rawDescGZIP(), []int{14}
 }
 
-var File_todo_todo_v1_todo_proto protoreflect.FileDescriptor
+func (x *WatchTodosResponse) GetPayload() isWatchTodosResponse_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
 
-const file_todo_todo_v1_todo_proto_rawDesc = "" +
-	"\n" +
-	"\x17todo/todo/v1/todo.proto\x12\ftodo.todo.v1\x1a\x1ftodo/common/v1/todo_model.proto\")\n" +
-	"\x0eUserAttributes\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\x03R\x06userId\"\xa6\x01\n" +
-	"\x10ListTodosRequest\x12E\n" +
-	"\x0fuser_attributes\x18\x01 \x01(\v2\x1c.todo.todo.v1.UserAttributesR\x0euserAttributes\x12\x1b\n" +
-	"\x06offset\x18\x02 \x01(\x03H\x00R\x06offset\x88\x01\x01\x12\x19\n" +
-	"\x05limit\x18\x03 \x01(\x03H\x01R\x05limit\x88\x01\x01B\t\n" +
-	"\a_offsetB\b\n" +
-	"\x06_limit\"U\n" +
-	"\x11ListTodosResponse\x12*\n" +
-	"\x05todos\x18\x01 \x03(\v2\x14.todo.common.v1.TodoR\x05todos\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x03R\x05total\"p\n" +
-	"\x0eGetTodoRequest\x12E\n" +
-	"\x0fuser_attributes\x18\x01 \x01(\v2\x1c.todo.todo.v1.UserAttributesR\x0euserAttributes\x12\x17\n" +
-	"\atodo_id\x18\x02 \x01(\x03R\x06todoId\";\n" +
-	"\x0fGetTodoResponse\x12(\n" +
-	"\x04todo\x18\x01 \x01(\v2\x14.todo.common.v1.TodoR\x04todo\"\xc2\x01\n" +
-	"\x0fPostTodoRequest\x12E\n" +
-	"\x0fuser_attributes\x18\x01 \x01(\v2\x1c.todo.todo.v1.UserAttributesR\x0euserAttributes\x12\x12\n" +
-	"\x04task\x18\x02 \x01(\tR\x04task\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x122\n" +
-	"\x06status\x18\x04 \x01(\x0e2\x1a.todo.common.v1.TodoStatusR\x06status\"<\n" +
-	"\x10PostTodoResponse\x12(\n" +
-	"\x04todo\x18\x01 \x01(\v2\x14.todo.common.v1.TodoR\x04todo\"\xda\x01\n" +
-	"\x0ePutTodoRequest\x12E\n" +
-	"\x0fuser_attributes\x18\x01 \x01(\v2\x1c.todo.todo.v1.UserAttributesR\x0euserAttributes\x12\x17\n" +
-	"\atodo_id\x18\x02 \x01(\x03R\x06todoId\x12\x12\n" +
-	"\x04task\x18\x03 \x01(\tR\x04task\x12 \n" +
-	"\vdescription\x18\x04 \x01(\tR\vdescription\x122\n" +
-	"\x06status\x18\x05 \x01(\x0e2\x1a.todo.common.v1.TodoStatusR\x06status\";\n" +
-	"\x0fPutTodoResponse\x12(\n" +
-	"\x04todo\x18\x01 \x01(\v2\x14.todo.common.v1.TodoR\x04todo\"s\n" +
-	"\x11DeleteTodoRequest\x12E\n" +
-	"\x0fuser_attributes\x18\x01 \x01(\v2\x1c.todo.todo.v1.UserAttributesR\x0euserAttributes\x12\x17\n" +
-	"\atodo_id\x18\x02 \x01(\x03R\x06todoId\"\x14\n" +
-	"\x12DeleteTodoResponse\")\n" +
-	"\x0eGetUserRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\x03R\x06userId\";\n" +
-	"\x0fGetUserResponse\x12(\n" +
-	"\x04user\x18\x01 \x01(\v2\x14.todo.common.v1.UserR\x04user\";\n" +
-	"\x0fPostUserRequest\x12(\n" +
-	"\x04user\x18\x01 \x01(\v2\x14.todo.common.v1.UserR\x04user\"\x12\n" +
-	"\x10PostUserResponse2\xa8\x04\n" +
-	"\vTodoService\x12N\n" +
-	"\tListTodos\x12\x1e.todo.todo.v1.ListTodosRequest\x1a\x1f.todo.todo.v1.ListTodosResponse\"\x00\x12H\n" +
-	"\aGetTodo\x12\x1c.todo.todo.v1.GetTodoRequest\x1a\x1d.todo.todo.v1.GetTodoResponse\"\x00\x12K\n" +
-	"\bPostTodo\x12\x1d.todo.todo.v1.PostTodoRequest\x1a\x1e.todo.todo.v1.PostTodoResponse\"\x00\x12H\n" +
-	"\aPutTodo\x12\x1c.todo.todo.v1.PutTodoRequest\x1a\x1d.todo.todo.v1.PutTodoResponse\"\x00\x12Q\n" +
-	"\n" +
-	"DeleteTodo\x12\x1f.todo.todo.v1.DeleteTodoRequest\x1a .todo.todo.v1.DeleteTodoResponse\"\x00\x12H\n" +
-	"\aGetUser\x12\x1c.todo.todo.v1.GetUserRequest\x1a\x1d.todo.todo.v1.GetUserResponse\"\x00\x12K\n" +
-	"\bPostUser\x12\x1d.todo.todo.v1.PostUserRequest\x1a\x1e.todo.todo.v1.PostUserResponse\"\x00BNZLgithub.com/phamquanandpad/training-project/grpc/go/todo/todo/v1;todo_todo_v1b\x06proto3"
+func (x *WatchTodosResponse) GetEvent() *TodoEvent {
+	if x != nil {
+		if x, ok := x.Payload.(*WatchTodosResponse_Event); ok {
+			return x.Event
+		}
+	}
+	return nil
+}
 
-var (
-	file_todo_todo_v1_todo_proto_rawDescOnce sync.Once
-	file_todo_todo_v1_todo_proto_rawDescData []byte
-)
+func (x *WatchTodosResponse) GetHeartbeat() *Heartbeat {
+	if x != nil {
+		if x, ok := x.Payload.(*WatchTodosResponse_Heartbeat); ok {
+			return x.Heartbeat
+		}
+	}
+	return nil
+}
 
-func file_todo_todo_v1_todo_proto_rawDescGZIP() []byte {
-	file_todo_todo_v1_todo_proto_rawDescOnce.Do(func() {
-		file_todo_todo_v1_todo_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_todo_todo_v1_todo_proto_rawDesc), len(file_todo_todo_v1_todo_proto_rawDesc)))
-	})
-	return file_todo_todo_v1_todo_proto_rawDescData
+type isWatchTodosResponse_Payload interface {
+	isWatchTodosResponse_Payload()
 }
 
-var file_todo_todo_v1_todo_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
-var file_todo_todo_v1_todo_proto_goTypes = []any{
-	(*UserAttributes)(nil),     // 0: todo.todo.v1.UserAttributes
-	(*ListTodosRequest)(nil),   // 1: todo.todo.v1.ListTodosRequest
-	(*ListTodosResponse)(nil),  // 2: todo.todo.v1.ListTodosResponse
-	(*GetTodoRequest)(nil),     // 3: todo.todo.v1.GetTodoRequest
-	(*GetTodoResponse)(nil),    // 4: todo.todo.v1.GetTodoResponse
-	(*PostTodoRequest)(nil),    // 5: todo.todo.v1.PostTodoRequest
-	(*PostTodoResponse)(nil),   // 6: todo.todo.v1.PostTodoResponse
-	(*PutTodoRequest)(nil),     // 7: todo.todo.v1.PutTodoRequest
-	(*PutTodoResponse)(nil),    // 8: todo.todo.v1.PutTodoResponse
-	(*DeleteTodoRequest)(nil),  // 9: todo.todo.v1.DeleteTodoRequest
-	(*DeleteTodoResponse)(nil), // 10: todo.todo.v1.DeleteTodoResponse
-	(*GetUserRequest)(nil),     // 11: todo.todo.v1.GetUserRequest
-	(*GetUserResponse)(nil),    // 12: todo.todo.v1.GetUserResponse
-	(*PostUserRequest)(nil),    // 13: todo.todo.v1.PostUserRequest
-	(*PostUserResponse)(nil),   // 14: todo.todo.v1.PostUserResponse
-	(*v1.Todo)(nil),            // 15: todo.common.v1.Todo
-	(v1.TodoStatus)(0),         // 16: todo.common.v1.TodoStatus
-	(*v1.User)(nil),            // 17: todo.common.v1.User
+type WatchTodosResponse_Event struct {
+	Event *TodoEvent `protobuf:"bytes,1,opt,name=event,proto3,oneof"`
 }
-var file_todo_todo_v1_todo_proto_depIdxs = []int32{
-	0,  // 0: todo.todo.v1.ListTodosRequest.user_attributes:type_name -> todo.todo.v1.UserAttributes
-	15, // 1: todo.todo.v1.ListTodosResponse.todos:type_name -> todo.common.v1.Todo
-	0,  // 2: todo.todo.v1.GetTodoRequest.user_attributes:type_name -> todo.todo.v1.UserAttributes
-	15, // 3: todo.todo.v1.GetTodoResponse.todo:type_name -> todo.common.v1.Todo
-	0,  // 4: todo.todo.v1.PostTodoRequest.user_attributes:type_name -> todo.todo.v1.UserAttributes
-	16, // 5: todo.todo.v1.PostTodoRequest.status:type_name -> todo.common.v1.TodoStatus
-	15, // 6: todo.todo.v1.PostTodoResponse.todo:type_name -> todo.common.v1.Todo
-	0,  // 7: todo.todo.v1.PutTodoRequest.user_attributes:type_name -> todo.todo.v1.UserAttributes
-	16, // 8: todo.todo.v1.PutTodoRequest.status:type_name -> todo.common.v1.TodoStatus
-	15, // 9: todo.todo.v1.PutTodoResponse.todo:type_name -> todo.common.v1.Todo
-	0,  // 10: todo.todo.v1.DeleteTodoRequest.user_attributes:type_name -> todo.todo.v1.UserAttributes
-	17, // 11: todo.todo.v1.GetUserResponse.user:type_name -> todo.common.v1.User
-	17, // 12: todo.todo.v1.PostUserRequest.user:type_name -> todo.common.v1.User
-	1,  // 13: todo.todo.v1.TodoService.ListTodos:input_type -> todo.todo.v1.ListTodosRequest
-	3,  // 14: todo.todo.v1.TodoService.GetTodo:input_type -> todo.todo.v1.GetTodoRequest
-	5,  // 15: todo.todo.v1.TodoService.PostTodo:input_type -> todo.todo.v1.PostTodoRequest
-	7,  // 16: todo.todo.v1.TodoService.PutTodo:input_type -> todo.todo.v1.PutTodoRequest
-	9,  // 17: todo.todo.v1.TodoService.DeleteTodo:input_type -> todo.todo.v1.DeleteTodoRequest
-	11, // 18: todo.todo.v1.TodoService.GetUser:input_type -> todo.todo.v1.GetUserRequest
-	13, // 19: todo.todo.v1.TodoService.PostUser:input_type -> todo.todo.v1.PostUserRequest
-	2,  // 20: todo.todo.v1.TodoService.ListTodos:output_type -> todo.todo.v1.ListTodosResponse
-	4,  // 21: todo.todo.v1.TodoService.GetTodo:output_type -> todo.todo.v1.GetTodoResponse
-	6,  // 22: todo.todo.v1.TodoService.PostTodo:output_type -> todo.todo.v1.PostTodoResponse
-	8,  // 23: todo.todo.v1.TodoService.PutTodo:output_type -> todo.todo.v1.PutTodoResponse
-	10, // 24: todo.todo.v1.TodoService.DeleteTodo:output_type -> todo.todo.v1.DeleteTodoResponse
-	12, // 25: todo.todo.v1.TodoService.GetUser:output_type -> todo.todo.v1.GetUserResponse
-	14, // 26: todo.todo.v1.TodoService.PostUser:output_type -> todo.todo.v1.PostUserResponse
-	20, // [20:27] is the sub-list for method output_type
-	13, // [13:20] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+
+type WatchTodosResponse_Heartbeat struct {
+	Heartbeat *Heartbeat `protobuf:"bytes,2,opt,name=heartbeat,proto3,oneof"`
 }
 
-func init() { file_todo_todo_v1_todo_proto_init() }
-func file_todo_todo_v1_todo_proto_init() {
+func (*WatchTodosResponse_Event) isWatchTodosResponse_Payload() {}
+
+func (*WatchTodosResponse_Heartbeat) isWatchTodosResponse_Payload() {}
+
+type TodoEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Type          TodoEventType          `protobuf:"varint,2,opt,name=type,proto3,enum=todo.todo.v1.TodoEventType" json:"type,omitempty"`
+	Todo          *v1.Todo               `protobuf:"bytes,3,opt,name=todo,proto3" json:"todo,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TodoEvent) Reset() {
+	*x = TodoEvent{}
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TodoEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TodoEvent) ProtoMessage() {}
+
+func (x *TodoEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TodoEvent.ProtoReflect.Descriptor instead.
+func (*TodoEvent) Descriptor() ([]byte, []int) {
+	return file_todo_todo_v1_todo_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *TodoEvent) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *TodoEvent) GetType() TodoEventType {
+	if x != nil {
+		return x.Type
+	}
+	return TodoEventType_TODO_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *TodoEvent) GetTodo() *v1.Todo {
+	if x != nil {
+		return x.Todo
+	}
+	return nil
+}
+
+func (x *TodoEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+type Heartbeat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SentAt        *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=sent_at,json=sentAt,proto3" json:"sent_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Heartbeat) Reset() {
+	*x = Heartbeat{}
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Heartbeat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Heartbeat) ProtoMessage() {}
+
+func (x *Heartbeat) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Heartbeat.ProtoReflect.Descriptor instead.
+func (*Heartbeat) Descriptor() ([]byte, []int) {
+	return file_todo_todo_v1_todo_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Heartbeat) GetSentAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SentAt
+	}
+	return nil
+}
+
+type ExportTodosRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserAttributes *UserAttributes        `protobuf:"bytes,1,opt,name=user_attributes,json=userAttributes,proto3" json:"user_attributes,omitempty"`
+	Format         TodoFileFormat         `protobuf:"varint,2,opt,name=format,proto3,enum=todo.todo.v1.TodoFileFormat" json:"format,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ExportTodosRequest) Reset() {
+	*x = ExportTodosRequest{}
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportTodosRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportTodosRequest) ProtoMessage() {}
+
+func (x *ExportTodosRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportTodosRequest.ProtoReflect.Descriptor instead.
+func (*ExportTodosRequest) Descriptor() ([]byte, []int) {
+	return file_todo_todo_v1_todo_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ExportTodosRequest) GetUserAttributes() *UserAttributes {
+	if x != nil {
+		return x.UserAttributes
+	}
+	return nil
+}
+
+func (x *ExportTodosRequest) GetFormat() TodoFileFormat {
+	if x != nil {
+		return x.Format
+	}
+	return TodoFileFormat_TODO_FILE_FORMAT_UNSPECIFIED
+}
+
+type ExportTodosResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chunk         []byte                 `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportTodosResponse) Reset() {
+	*x = ExportTodosResponse{}
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportTodosResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportTodosResponse) ProtoMessage() {}
+
+func (x *ExportTodosResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportTodosResponse.ProtoReflect.Descriptor instead.
+func (*ExportTodosResponse) Descriptor() ([]byte, []int) {
+	return file_todo_todo_v1_todo_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ExportTodosResponse) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+// The first message carries user_attributes, format, dry_run and
+// update_existing; every message may carry the next chunk of the file.
+type ImportTodosRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserAttributes *UserAttributes        `protobuf:"bytes,1,opt,name=user_attributes,json=userAttributes,proto3" json:"user_attributes,omitempty"`
+	Format         TodoFileFormat         `protobuf:"varint,2,opt,name=format,proto3,enum=todo.todo.v1.TodoFileFormat" json:"format,omitempty"`
+	DryRun         bool                   `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	Chunk          []byte                 `protobuf:"bytes,4,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	UpdateExisting bool                   `protobuf:"varint,5,opt,name=update_existing,json=updateExisting,proto3" json:"update_existing,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ImportTodosRequest) Reset() {
+	*x = ImportTodosRequest{}
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportTodosRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportTodosRequest) ProtoMessage() {}
+
+func (x *ImportTodosRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportTodosRequest.ProtoReflect.Descriptor instead.
+func (*ImportTodosRequest) Descriptor() ([]byte, []int) {
+	return file_todo_todo_v1_todo_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ImportTodosRequest) GetUserAttributes() *UserAttributes {
+	if x != nil {
+		return x.UserAttributes
+	}
+	return nil
+}
+
+func (x *ImportTodosRequest) GetFormat() TodoFileFormat {
+	if x != nil {
+		return x.Format
+	}
+	return TodoFileFormat_TODO_FILE_FORMAT_UNSPECIFIED
+}
+
+func (x *ImportTodosRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportTodosRequest) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+func (x *ImportTodosRequest) GetUpdateExisting() bool {
+	if x != nil {
+		return x.UpdateExisting
+	}
+	return false
+}
+
+type ImportTodosResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Created       int64                  `protobuf:"varint,1,opt,name=created,proto3" json:"created,omitempty"`
+	Skipped       int64                  `protobuf:"varint,2,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	Errors        []*ImportRowError      `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"`
+	DryRun        bool                   `protobuf:"varint,4,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	Updated       int64                  `protobuf:"varint,5,opt,name=updated,proto3" json:"updated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportTodosResponse) Reset() {
+	*x = ImportTodosResponse{}
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportTodosResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportTodosResponse) ProtoMessage() {}
+
+func (x *ImportTodosResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportTodosResponse.ProtoReflect.Descriptor instead.
+func (*ImportTodosResponse) Descriptor() ([]byte, []int) {
+	return file_todo_todo_v1_todo_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ImportTodosResponse) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *ImportTodosResponse) GetSkipped() int64 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+func (x *ImportTodosResponse) GetErrors() []*ImportRowError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *ImportTodosResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportTodosResponse) GetUpdated() int64 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+type ImportRowError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Line          int64                  `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	Field         string                 `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportRowError) Reset() {
+	*x = ImportRowError{}
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportRowError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportRowError) ProtoMessage() {}
+
+func (x *ImportRowError) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportRowError.ProtoReflect.Descriptor instead.
+func (*ImportRowError) Descriptor() ([]byte, []int) {
+	return file_todo_todo_v1_todo_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ImportRowError) GetLine() int64 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *ImportRowError) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *ImportRowError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type GetCalendarFeedURLRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserAttributes *UserAttributes        `protobuf:"bytes,1,opt,name=user_attributes,json=userAttributes,proto3" json:"user_attributes,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetCalendarFeedURLRequest) Reset() {
+	*x = GetCalendarFeedURLRequest{}
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCalendarFeedURLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCalendarFeedURLRequest) ProtoMessage() {}
+
+func (x *GetCalendarFeedURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCalendarFeedURLRequest.ProtoReflect.Descriptor instead.
+func (*GetCalendarFeedURLRequest) Descriptor() ([]byte, []int) {
+	return file_todo_todo_v1_todo_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetCalendarFeedURLRequest) GetUserAttributes() *UserAttributes {
+	if x != nil {
+		return x.UserAttributes
+	}
+	return nil
+}
+
+type GetCalendarFeedURLResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCalendarFeedURLResponse) Reset() {
+	*x = GetCalendarFeedURLResponse{}
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCalendarFeedURLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCalendarFeedURLResponse) ProtoMessage() {}
+
+func (x *GetCalendarFeedURLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCalendarFeedURLResponse.ProtoReflect.Descriptor instead.
+func (*GetCalendarFeedURLResponse) Descriptor() ([]byte, []int) {
+	return file_todo_todo_v1_todo_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetCalendarFeedURLResponse) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+// Statistics cover the todos created in [from, to). The activity series is
+// paged by bucket, oldest first. page_token may be the next or the previous
+// page token of a response.
+type GetTodoStatsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserAttributes *UserAttributes        `protobuf:"bytes,1,opt,name=user_attributes,json=userAttributes,proto3" json:"user_attributes,omitempty"`
+	From           *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To             *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	BucketSize     StatsBucketSize        `protobuf:"varint,4,opt,name=bucket_size,json=bucketSize,proto3,enum=todo.todo.v1.StatsBucketSize" json:"bucket_size,omitempty"`
+	PageSize       *int32                 `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`
+	PageToken      *string                `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3,oneof" json:"page_token,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetTodoStatsRequest) Reset() {
+	*x = GetTodoStatsRequest{}
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTodoStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTodoStatsRequest) ProtoMessage() {}
+
+func (x *GetTodoStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTodoStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetTodoStatsRequest) Descriptor() ([]byte, []int) {
+	return file_todo_todo_v1_todo_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetTodoStatsRequest) GetUserAttributes() *UserAttributes {
+	if x != nil {
+		return x.UserAttributes
+	}
+	return nil
+}
+
+func (x *GetTodoStatsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetTodoStatsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *GetTodoStatsRequest) GetBucketSize() StatsBucketSize {
+	if x != nil {
+		return x.BucketSize
+	}
+	return StatsBucketSize_STATS_BUCKET_SIZE_UNSPECIFIED
+}
+
+func (x *GetTodoStatsRequest) GetPageSize() int32 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return 0
+}
+
+func (x *GetTodoStatsRequest) GetPageToken() string {
+	if x != nil && x.PageToken != nil {
+		return *x.PageToken
+	}
+	return ""
+}
+
+type GetTodoStatsResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PendingCount    int64                  `protobuf:"varint,1,opt,name=pending_count,json=pendingCount,proto3" json:"pending_count,omitempty"`
+	InProgressCount int64                  `protobuf:"varint,2,opt,name=in_progress_count,json=inProgressCount,proto3" json:"in_progress_count,omitempty"`
+	DoneCount       int64                  `protobuf:"varint,3,opt,name=done_count,json=doneCount,proto3" json:"done_count,omitempty"`
+	TotalCount      int64                  `protobuf:"varint,4,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	// Share of the todos that are done, between 0 and 1.
+	CompletionRate float64 `protobuf:"fixed64,5,opt,name=completion_rate,json=completionRate,proto3" json:"completion_rate,omitempty"`
+	// Average time from creation to done of the todos completed in the range.
+	// Unset when none were.
+	AverageCompletionTime *durationpb.Duration  `protobuf:"bytes,6,opt,name=average_completion_time,json=averageCompletionTime,proto3" json:"average_completion_time,omitempty"`
+	Activity              []*TodoActivityBucket `protobuf:"bytes,7,rep,name=activity,proto3" json:"activity,omitempty"`
+	NextPageToken         *string               `protobuf:"bytes,8,opt,name=next_page_token,json=nextPageToken,proto3,oneof" json:"next_page_token,omitempty"`
+	PreviousPageToken     *string               `protobuf:"bytes,9,opt,name=previous_page_token,json=previousPageToken,proto3,oneof" json:"previous_page_token,omitempty"`
+	HasNext               bool                  `protobuf:"varint,10,opt,name=has_next,json=hasNext,proto3" json:"has_next,omitempty"`
+	HasPrevious           bool                  `protobuf:"varint,11,opt,name=has_previous,json=hasPrevious,proto3" json:"has_previous,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *GetTodoStatsResponse) Reset() {
+	*x = GetTodoStatsResponse{}
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTodoStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTodoStatsResponse) ProtoMessage() {}
+
+func (x *GetTodoStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTodoStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetTodoStatsResponse) Descriptor() ([]byte, []int) {
+	return file_todo_todo_v1_todo_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetTodoStatsResponse) GetPendingCount() int64 {
+	if x != nil {
+		return x.PendingCount
+	}
+	return 0
+}
+
+func (x *GetTodoStatsResponse) GetInProgressCount() int64 {
+	if x != nil {
+		return x.InProgressCount
+	}
+	return 0
+}
+
+func (x *GetTodoStatsResponse) GetDoneCount() int64 {
+	if x != nil {
+		return x.DoneCount
+	}
+	return 0
+}
+
+func (x *GetTodoStatsResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *GetTodoStatsResponse) GetCompletionRate() float64 {
+	if x != nil {
+		return x.CompletionRate
+	}
+	return 0
+}
+
+func (x *GetTodoStatsResponse) GetAverageCompletionTime() *durationpb.Duration {
+	if x != nil {
+		return x.AverageCompletionTime
+	}
+	return nil
+}
+
+func (x *GetTodoStatsResponse) GetActivity() []*TodoActivityBucket {
+	if x != nil {
+		return x.Activity
+	}
+	return nil
+}
+
+func (x *GetTodoStatsResponse) GetNextPageToken() string {
+	if x != nil && x.NextPageToken != nil {
+		return *x.NextPageToken
+	}
+	return ""
+}
+
+func (x *GetTodoStatsResponse) GetPreviousPageToken() string {
+	if x != nil && x.PreviousPageToken != nil {
+		return *x.PreviousPageToken
+	}
+	return ""
+}
+
+func (x *GetTodoStatsResponse) GetHasNext() bool {
+	if x != nil {
+		return x.HasNext
+	}
+	return false
+}
+
+func (x *GetTodoStatsResponse) GetHasPrevious() bool {
+	if x != nil {
+		return x.HasPrevious
+	}
+	return false
+}
+
+type TodoActivityBucket struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartAt       *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_at,json=startAt,proto3" json:"start_at,omitempty"`
+	Created       int64                  `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	Completed     int64                  `protobuf:"varint,3,opt,name=completed,proto3" json:"completed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TodoActivityBucket) Reset() {
+	*x = TodoActivityBucket{}
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TodoActivityBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TodoActivityBucket) ProtoMessage() {}
+
+func (x *TodoActivityBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TodoActivityBucket.ProtoReflect.Descriptor instead.
+func (*TodoActivityBucket) Descriptor() ([]byte, []int) {
+	return file_todo_todo_v1_todo_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *TodoActivityBucket) GetStartAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartAt
+	}
+	return nil
+}
+
+func (x *TodoActivityBucket) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *TodoActivityBucket) GetCompleted() int64 {
+	if x != nil {
+		return x.Completed
+	}
+	return 0
+}
+
+type GetDatabaseStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDatabaseStatusRequest) Reset() {
+	*x = GetDatabaseStatusRequest{}
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDatabaseStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDatabaseStatusRequest) ProtoMessage() {}
+
+func (x *GetDatabaseStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDatabaseStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetDatabaseStatusRequest) Descriptor() ([]byte, []int) {
+	return file_todo_todo_v1_todo_proto_rawDescGZIP(), []int{27}
+}
+
+type GetDatabaseStatusResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The primary first, then the read replicas.
+	Pools         []*DatabasePoolStatus `protobuf:"bytes,1,rep,name=pools,proto3" json:"pools,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDatabaseStatusResponse) Reset() {
+	*x = GetDatabaseStatusResponse{}
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDatabaseStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDatabaseStatusResponse) ProtoMessage() {}
+
+func (x *GetDatabaseStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDatabaseStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetDatabaseStatusResponse) Descriptor() ([]byte, []int) {
+	return file_todo_todo_v1_todo_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetDatabaseStatusResponse) GetPools() []*DatabasePoolStatus {
+	if x != nil {
+		return x.Pools
+	}
+	return nil
+}
+
+type DatabasePoolStatus struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Name               string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Healthy            bool                   `protobuf:"varint,2,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	MaxOpenConnections int64                  `protobuf:"varint,3,opt,name=max_open_connections,json=maxOpenConnections,proto3" json:"max_open_connections,omitempty"`
+	OpenConnections    int64                  `protobuf:"varint,4,opt,name=open_connections,json=openConnections,proto3" json:"open_connections,omitempty"`
+	InUse              int64                  `protobuf:"varint,5,opt,name=in_use,json=inUse,proto3" json:"in_use,omitempty"`
+	Idle               int64                  `protobuf:"varint,6,opt,name=idle,proto3" json:"idle,omitempty"`
+	// Waits for a free connection since the pool was opened.
+	WaitCount         int64                `protobuf:"varint,7,opt,name=wait_count,json=waitCount,proto3" json:"wait_count,omitempty"`
+	WaitDuration      *durationpb.Duration `protobuf:"bytes,8,opt,name=wait_duration,json=waitDuration,proto3" json:"wait_duration,omitempty"`
+	MaxIdleClosed     int64                `protobuf:"varint,9,opt,name=max_idle_closed,json=maxIdleClosed,proto3" json:"max_idle_closed,omitempty"`
+	MaxIdleTimeClosed int64                `protobuf:"varint,10,opt,name=max_idle_time_closed,json=maxIdleTimeClosed,proto3" json:"max_idle_time_closed,omitempty"`
+	MaxLifetimeClosed int64                `protobuf:"varint,11,opt,name=max_lifetime_closed,json=maxLifetimeClosed,proto3" json:"max_lifetime_closed,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *DatabasePoolStatus) Reset() {
+	*x = DatabasePoolStatus{}
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DatabasePoolStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DatabasePoolStatus) ProtoMessage() {}
+
+func (x *DatabasePoolStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DatabasePoolStatus.ProtoReflect.Descriptor instead.
+func (*DatabasePoolStatus) Descriptor() ([]byte, []int) {
+	return file_todo_todo_v1_todo_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *DatabasePoolStatus) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DatabasePoolStatus) GetHealthy() bool {
+	if x != nil {
+		return x.Healthy
+	}
+	return false
+}
+
+func (x *DatabasePoolStatus) GetMaxOpenConnections() int64 {
+	if x != nil {
+		return x.MaxOpenConnections
+	}
+	return 0
+}
+
+func (x *DatabasePoolStatus) GetOpenConnections() int64 {
+	if x != nil {
+		return x.OpenConnections
+	}
+	return 0
+}
+
+func (x *DatabasePoolStatus) GetInUse() int64 {
+	if x != nil {
+		return x.InUse
+	}
+	return 0
+}
+
+func (x *DatabasePoolStatus) GetIdle() int64 {
+	if x != nil {
+		return x.Idle
+	}
+	return 0
+}
+
+func (x *DatabasePoolStatus) GetWaitCount() int64 {
+	if x != nil {
+		return x.WaitCount
+	}
+	return 0
+}
+
+func (x *DatabasePoolStatus) GetWaitDuration() *durationpb.Duration {
+	if x != nil {
+		return x.WaitDuration
+	}
+	return nil
+}
+
+func (x *DatabasePoolStatus) GetMaxIdleClosed() int64 {
+	if x != nil {
+		return x.MaxIdleClosed
+	}
+	return 0
+}
+
+func (x *DatabasePoolStatus) GetMaxIdleTimeClosed() int64 {
+	if x != nil {
+		return x.MaxIdleTimeClosed
+	}
+	return 0
+}
+
+func (x *DatabasePoolStatus) GetMaxLifetimeClosed() int64 {
+	if x != nil {
+		return x.MaxLifetimeClosed
+	}
+	return 0
+}
+
+type GetUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserRequest) Reset() {
+	*x = GetUserRequest{}
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserRequest) ProtoMessage() {}
+
+func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
+func (*GetUserRequest) Descriptor() ([]byte, []int) {
+	return file_todo_todo_v1_todo_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetUserRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type GetUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *v1.User               `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserResponse) Reset() {
+	*x = GetUserResponse{}
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserResponse) ProtoMessage() {}
+
+func (x *GetUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserResponse.ProtoReflect.Descriptor instead.
+func (*GetUserResponse) Descriptor() ([]byte, []int) {
+	return file_todo_todo_v1_todo_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetUserResponse) GetUser() *v1.User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type PostUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *v1.User               `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostUserRequest) Reset() {
+	*x = PostUserRequest{}
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostUserRequest) ProtoMessage() {}
+
+func (x *PostUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostUserRequest.ProtoReflect.Descriptor instead.
+func (*PostUserRequest) Descriptor() ([]byte, []int) {
+	return file_todo_todo_v1_todo_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *PostUserRequest) GetUser() *v1.User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type PostUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostUserResponse) Reset() {
+	*x = PostUserResponse{}
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostUserResponse) ProtoMessage() {}
+
+func (x *PostUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostUserResponse.ProtoReflect.Descriptor instead.
+func (*PostUserResponse) Descriptor() ([]byte, []int) {
+	return file_todo_todo_v1_todo_proto_rawDescGZIP(), []int{33}
+}
+
+type UpdateUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      *string                `protobuf:"bytes,2,opt,name=username,proto3,oneof" json:"username,omitempty"`
+	Email         *string                `protobuf:"bytes,3,opt,name=email,proto3,oneof" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserRequest) Reset() {
+	*x = UpdateUserRequest{}
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserRequest) ProtoMessage() {}
+
+func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserRequest.ProtoReflect.Descriptor instead.
+func (*UpdateUserRequest) Descriptor() ([]byte, []int) {
+	return file_todo_todo_v1_todo_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *UpdateUserRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *UpdateUserRequest) GetUsername() string {
+	if x != nil && x.Username != nil {
+		return *x.Username
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetEmail() string {
+	if x != nil && x.Email != nil {
+		return *x.Email
+	}
+	return ""
+}
+
+type UpdateUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *v1.User               `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserResponse) Reset() {
+	*x = UpdateUserResponse{}
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserResponse) ProtoMessage() {}
+
+func (x *UpdateUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserResponse.ProtoReflect.Descriptor instead.
+func (*UpdateUserResponse) Descriptor() ([]byte, []int) {
+	return file_todo_todo_v1_todo_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *UpdateUserResponse) GetUser() *v1.User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type DeleteUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserRequest) Reset() {
+	*x = DeleteUserRequest{}
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserRequest) ProtoMessage() {}
+
+func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserRequest.ProtoReflect.Descriptor instead.
+func (*DeleteUserRequest) Descriptor() ([]byte, []int) {
+	return file_todo_todo_v1_todo_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *DeleteUserRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type DeleteUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserResponse) Reset() {
+	*x = DeleteUserResponse{}
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserResponse) ProtoMessage() {}
+
+func (x *DeleteUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserResponse.ProtoReflect.Descriptor instead.
+func (*DeleteUserResponse) Descriptor() ([]byte, []int) {
+	return file_todo_todo_v1_todo_proto_rawDescGZIP(), []int{37}
+}
+
+// The chunks make up a zip archive of JSON files: manifest.json, user.json,
+// todos.json and events.json.
+type ExportUserDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataRequest) Reset() {
+	*x = ExportUserDataRequest{}
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataRequest) ProtoMessage() {}
+
+func (x *ExportUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_todo_todo_v1_todo_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ExportUserDataRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type ExportUserDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chunk         []byte                 `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataResponse) Reset() {
+	*x = ExportUserDataResponse{}
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataResponse) ProtoMessage() {}
+
+func (x *ExportUserDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataResponse.ProtoReflect.Descriptor instead.
+func (*ExportUserDataResponse) Descriptor() ([]byte, []int) {
+	return file_todo_todo_v1_todo_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ExportUserDataResponse) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+// EraseUser hard-deletes the user and their todos. Creating a user with the
+// same ID fails afterwards.
+type EraseUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EraseUserRequest) Reset() {
+	*x = EraseUserRequest{}
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EraseUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EraseUserRequest) ProtoMessage() {}
+
+func (x *EraseUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EraseUserRequest.ProtoReflect.Descriptor instead.
+func (*EraseUserRequest) Descriptor() ([]byte, []int) {
+	return file_todo_todo_v1_todo_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *EraseUserRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type EraseUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EraseUserResponse) Reset() {
+	*x = EraseUserResponse{}
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EraseUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EraseUserResponse) ProtoMessage() {}
+
+func (x *EraseUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_todo_v1_todo_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EraseUserResponse.ProtoReflect.Descriptor instead.
+func (*EraseUserResponse) Descriptor() ([]byte, []int) {
+	return file_todo_todo_v1_todo_proto_rawDescGZIP(), []int{41}
+}
+
+var File_todo_todo_v1_todo_proto protoreflect.FileDescriptor
+
+const file_todo_todo_v1_todo_proto_rawDesc = "" +
+	"\n" +
+	"\x17todo/todo/v1/todo.proto\x12\ftodo.todo.v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1ftodo/common/v1/todo_model.proto\")\n" +
+	"\x0eUserAttributes\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\"\xe8\x01\n" +
+	"\x10ListTodosRequest\x12E\n" +
+	"\x0fuser_attributes\x18\x01 \x01(\v2\x1c.todo.todo.v1.UserAttributesR\x0euserAttributes\x12\x1b\n" +
+	"\x06offset\x18\x02 \x01(\x03H\x00R\x06offset\x88\x01\x01\x12\x19\n" +
+	"\x05limit\x18\x03 \x01(\x03H\x01R\x05limit\x88\x01\x01\x12@\n" +
+	"\fsorting_type\x18\x04 \x01(\x0e2\x1d.todo.todo.v1.TodoSortingTypeR\vsortingTypeB\t\n" +
+	"\a_offsetB\b\n" +
+	"\x06_limit\"U\n" +
+	"\x11ListTodosResponse\x12*\n" +
+	"\x05todos\x18\x01 \x03(\v2\x14.todo.common.v1.TodoR\x05todos\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"p\n" +
+	"\x0eGetTodoRequest\x12E\n" +
+	"\x0fuser_attributes\x18\x01 \x01(\v2\x1c.todo.todo.v1.UserAttributesR\x0euserAttributes\x12\x17\n" +
+	"\atodo_id\x18\x02 \x01(\x03R\x06todoId\";\n" +
+	"\x0fGetTodoResponse\x12(\n" +
+	"\x04todo\x18\x01 \x01(\v2\x14.todo.common.v1.TodoR\x04todo\"\xc2\x01\n" +
+	"\x0fPostTodoRequest\x12E\n" +
+	"\x0fuser_attributes\x18\x01 \x01(\v2\x1c.todo.todo.v1.UserAttributesR\x0euserAttributes\x12\x12\n" +
+	"\x04task\x18\x02 \x01(\tR\x04task\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x122\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x1a.todo.common.v1.TodoStatusR\x06status\"<\n" +
+	"\x10PostTodoResponse\x12(\n" +
+	"\x04todo\x18\x01 \x01(\v2\x14.todo.common.v1.TodoR\x04todo\"\xda\x01\n" +
+	"\x0ePutTodoRequest\x12E\n" +
+	"\x0fuser_attributes\x18\x01 \x01(\v2\x1c.todo.todo.v1.UserAttributesR\x0euserAttributes\x12\x17\n" +
+	"\atodo_id\x18\x02 \x01(\x03R\x06todoId\x12\x12\n" +
+	"\x04task\x18\x03 \x01(\tR\x04task\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x122\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x1a.todo.common.v1.TodoStatusR\x06status\";\n" +
+	"\x0fPutTodoResponse\x12(\n" +
+	"\x04todo\x18\x01 \x01(\v2\x14.todo.common.v1.TodoR\x04todo\"s\n" +
+	"\x11DeleteTodoRequest\x12E\n" +
+	"\x0fuser_attributes\x18\x01 \x01(\v2\x1c.todo.todo.v1.UserAttributesR\x0euserAttributes\x12\x17\n" +
+	"\atodo_id\x18\x02 \x01(\x03R\x06todoId\"\x14\n" +
+	"\x12DeleteTodoResponse\"\xc9\x01\n" +
+	"\x0fMoveTodoRequest\x12E\n" +
+	"\x0fuser_attributes\x18\x01 \x01(\v2\x1c.todo.todo.v1.UserAttributesR\x0euserAttributes\x12\x17\n" +
+	"\atodo_id\x18\x02 \x01(\x03R\x06todoId\x12&\n" +
+	"\x0ebefore_todo_id\x18\x03 \x01(\x03H\x00R\fbeforeTodoId\x12$\n" +
+	"\rafter_todo_id\x18\x04 \x01(\x03H\x00R\vafterTodoIdB\b\n" +
+	"\x06target\"<\n" +
+	"\x10MoveTodoResponse\x12(\n" +
+	"\x04todo\x18\x01 \x01(\v2\x14.todo.common.v1.TodoR\x04todo\"\x95\x01\n" +
+	"\x11WatchTodosRequest\x12E\n" +
+	"\x0fuser_attributes\x18\x01 \x01(\v2\x1c.todo.todo.v1.UserAttributesR\x0euserAttributes\x12'\n" +
+	"\rlast_event_id\x18\x02 \x01(\x03H\x00R\vlastEventId\x88\x01\x01B\x10\n" +
+	"\x0e_last_event_id\"\x89\x01\n" +
+	"\x12WatchTodosResponse\x12/\n" +
+	"\x05event\x18\x01 \x01(\v2\x17.todo.todo.v1.TodoEventH\x00R\x05event\x127\n" +
+	"\theartbeat\x18\x02 \x01(\v2\x17.todo.todo.v1.HeartbeatH\x00R\theartbeatB\t\n" +
+	"\apayload\"\xbe\x01\n" +
+	"\tTodoEvent\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\x12/\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x1b.todo.todo.v1.TodoEventTypeR\x04type\x12(\n" +
+	"\x04todo\x18\x03 \x01(\v2\x14.todo.common.v1.TodoR\x04todo\x12;\n" +
+	"\voccurred_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\"@\n" +
+	"\tHeartbeat\x123\n" +
+	"\asent_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x06sentAt\"\x91\x01\n" +
+	"\x12ExportTodosRequest\x12E\n" +
+	"\x0fuser_attributes\x18\x01 \x01(\v2\x1c.todo.todo.v1.UserAttributesR\x0euserAttributes\x124\n" +
+	"\x06format\x18\x02 \x01(\x0e2\x1c.todo.todo.v1.TodoFileFormatR\x06format\"+\n" +
+	"\x13ExportTodosResponse\x12\x14\n" +
+	"\x05chunk\x18\x01 \x01(\fR\x05chunk\"\xe9\x01\n" +
+	"\x12ImportTodosRequest\x12E\n" +
+	"\x0fuser_attributes\x18\x01 \x01(\v2\x1c.todo.todo.v1.UserAttributesR\x0euserAttributes\x124\n" +
+	"\x06format\x18\x02 \x01(\x0e2\x1c.todo.todo.v1.TodoFileFormatR\x06format\x12\x17\n" +
+	"\adry_run\x18\x03 \x01(\bR\x06dryRun\x12\x14\n" +
+	"\x05chunk\x18\x04 \x01(\fR\x05chunk\x12'\n" +
+	"\x0fupdate_existing\x18\x05 \x01(\bR\x0eupdateExisting\"\xb2\x01\n" +
+	"\x13ImportTodosResponse\x12\x18\n" +
+	"\acreated\x18\x01 \x01(\x03R\acreated\x12\x18\n" +
+	"\askipped\x18\x02 \x01(\x03R\askipped\x124\n" +
+	"\x06errors\x18\x03 \x03(\v2\x1c.todo.todo.v1.ImportRowErrorR\x06errors\x12\x17\n" +
+	"\adry_run\x18\x04 \x01(\bR\x06dryRun\x12\x18\n" +
+	"\aupdated\x18\x05 \x01(\x03R\aupdated\"T\n" +
+	"\x0eImportRowError\x12\x12\n" +
+	"\x04line\x18\x01 \x01(\x03R\x04line\x12\x14\n" +
+	"\x05field\x18\x02 \x01(\tR\x05field\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"b\n" +
+	"\x19GetCalendarFeedURLRequest\x12E\n" +
+	"\x0fuser_attributes\x18\x01 \x01(\v2\x1c.todo.todo.v1.UserAttributesR\x0euserAttributes\".\n" +
+	"\x1aGetCalendarFeedURLResponse\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\"\xdb\x02\n" +
+	"\x13GetTodoStatsRequest\x12E\n" +
+	"\x0fuser_attributes\x18\x01 \x01(\v2\x1c.todo.todo.v1.UserAttributesR\x0euserAttributes\x12.\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12>\n" +
+	"\vbucket_size\x18\x04 \x01(\x0e2\x1d.todo.todo.v1.StatsBucketSizeR\n" +
+	"bucketSize\x12 \n" +
+	"\tpage_size\x18\x05 \x01(\x05H\x00R\bpageSize\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"page_token\x18\x06 \x01(\tH\x01R\tpageToken\x88\x01\x01B\f\n" +
+	"\n" +
+	"_page_sizeB\r\n" +
+	"\v_page_token\"\xad\x04\n" +
+	"\x14GetTodoStatsResponse\x12#\n" +
+	"\rpending_count\x18\x01 \x01(\x03R\fpendingCount\x12*\n" +
+	"\x11in_progress_count\x18\x02 \x01(\x03R\x0finProgressCount\x12\x1d\n" +
+	"\n" +
+	"done_count\x18\x03 \x01(\x03R\tdoneCount\x12\x1f\n" +
+	"\vtotal_count\x18\x04 \x01(\x03R\n" +
+	"totalCount\x12'\n" +
+	"\x0fcompletion_rate\x18\x05 \x01(\x01R\x0ecompletionRate\x12Q\n" +
+	"\x17average_completion_time\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\x15averageCompletionTime\x12<\n" +
+	"\bactivity\x18\a \x03(\v2 .todo.todo.v1.TodoActivityBucketR\bactivity\x12+\n" +
+	"\x0fnext_page_token\x18\b \x01(\tH\x00R\rnextPageToken\x88\x01\x01\x123\n" +
+	"\x13previous_page_token\x18\t \x01(\tH\x01R\x11previousPageToken\x88\x01\x01\x12\x19\n" +
+	"\bhas_next\x18\n" +
+	" \x01(\bR\ahasNext\x12!\n" +
+	"\fhas_previous\x18\v \x01(\bR\vhasPreviousB\x12\n" +
+	"\x10_next_page_tokenB\x16\n" +
+	"\x14_previous_page_token\"\x83\x01\n" +
+	"\x12TodoActivityBucket\x125\n" +
+	"\bstart_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\astartAt\x12\x18\n" +
+	"\acreated\x18\x02 \x01(\x03R\acreated\x12\x1c\n" +
+	"\tcompleted\x18\x03 \x01(\x03R\tcompleted\"\x1a\n" +
+	"\x18GetDatabaseStatusRequest\"S\n" +
+	"\x19GetDatabaseStatusResponse\x126\n" +
+	"\x05pools\x18\x01 \x03(\v2 .todo.todo.v1.DatabasePoolStatusR\x05pools\"\xb2\x03\n" +
+	"\x12DatabasePoolStatus\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\ahealthy\x18\x02 \x01(\bR\ahealthy\x120\n" +
+	"\x14max_open_connections\x18\x03 \x01(\x03R\x12maxOpenConnections\x12)\n" +
+	"\x10open_connections\x18\x04 \x01(\x03R\x0fopenConnections\x12\x15\n" +
+	"\x06in_use\x18\x05 \x01(\x03R\x05inUse\x12\x12\n" +
+	"\x04idle\x18\x06 \x01(\x03R\x04idle\x12\x1d\n" +
+	"\n" +
+	"wait_count\x18\a \x01(\x03R\twaitCount\x12>\n" +
+	"\rwait_duration\x18\b \x01(\v2\x19.google.protobuf.DurationR\fwaitDuration\x12&\n" +
+	"\x0fmax_idle_closed\x18\t \x01(\x03R\rmaxIdleClosed\x12/\n" +
+	"\x14max_idle_time_closed\x18\n" +
+	" \x01(\x03R\x11maxIdleTimeClosed\x12.\n" +
+	"\x13max_lifetime_closed\x18\v \x01(\x03R\x11maxLifetimeClosed\")\n" +
+	"\x0eGetUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\";\n" +
+	"\x0fGetUserResponse\x12(\n" +
+	"\x04user\x18\x01 \x01(\v2\x14.todo.common.v1.UserR\x04user\";\n" +
+	"\x0fPostUserRequest\x12(\n" +
+	"\x04user\x18\x01 \x01(\v2\x14.todo.common.v1.UserR\x04user\"\x12\n" +
+	"\x10PostUserResponse\"\x7f\n" +
+	"\x11UpdateUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x1f\n" +
+	"\busername\x18\x02 \x01(\tH\x00R\busername\x88\x01\x01\x12\x19\n" +
+	"\x05email\x18\x03 \x01(\tH\x01R\x05email\x88\x01\x01B\v\n" +
+	"\t_usernameB\b\n" +
+	"\x06_email\">\n" +
+	"\x12UpdateUserResponse\x12(\n" +
+	"\x04user\x18\x01 \x01(\v2\x14.todo.common.v1.UserR\x04user\",\n" +
+	"\x11DeleteUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\"\x14\n" +
+	"\x12DeleteUserResponse\"0\n" +
+	"\x15ExportUserDataRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\".\n" +
+	"\x16ExportUserDataResponse\x12\x14\n" +
+	"\x05chunk\x18\x01 \x01(\fR\x05chunk\"+\n" +
+	"\x10EraseUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\"\x13\n" +
+	"\x11EraseUserResponse*\x98\x01\n" +
+	"\x0fTodoSortingType\x12!\n" +
+	"\x1dTODO_SORTING_TYPE_UNSPECIFIED\x10\x00\x12 \n" +
+	"\x1cTODO_SORTING_TYPE_CREATED_AT\x10\x01\x12 \n" +
+	"\x1cTODO_SORTING_TYPE_UPDATED_AT\x10\x02\x12\x1e\n" +
+	"\x1aTODO_SORTING_TYPE_POSITION\x10\x03*\x87\x01\n" +
+	"\rTodoEventType\x12\x1f\n" +
+	"\x1bTODO_EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17TODO_EVENT_TYPE_CREATED\x10\x01\x12\x1b\n" +
+	"\x17TODO_EVENT_TYPE_UPDATED\x10\x02\x12\x1b\n" +
+	"\x17TODO_EVENT_TYPE_DELETED\x10\x03*\xcb\x01\n" +
+	"\x0eTodoFileFormat\x12 \n" +
+	"\x1cTODO_FILE_FORMAT_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14TODO_FILE_FORMAT_CSV\x10\x01\x12\x1f\n" +
+	"\x1bTODO_FILE_FORMAT_JSON_LINES\x10\x02\x12\x1e\n" +
+	"\x1aTODO_FILE_FORMAT_ICALENDAR\x10\x03\x12\x1d\n" +
+	"\x19TODO_FILE_FORMAT_TODO_TXT\x10\x04\x12\x1d\n" +
+	"\x19TODO_FILE_FORMAT_MARKDOWN\x10\x05*\x88\x01\n" +
+	"\x0fStatsBucketSize\x12!\n" +
+	"\x1dSTATS_BUCKET_SIZE_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15STATS_BUCKET_SIZE_DAY\x10\x01\x12\x1a\n" +
+	"\x16STATS_BUCKET_SIZE_WEEK\x10\x02\x12\x1b\n" +
+	"\x17STATS_BUCKET_SIZE_MONTH\x10\x032\xfd\v\n" +
+	"\vTodoService\x12N\n" +
+	"\tListTodos\x12\x1e.todo.todo.v1.ListTodosRequest\x1a\x1f.todo.todo.v1.ListTodosResponse\"\x00\x12H\n" +
+	"\aGetTodo\x12\x1c.todo.todo.v1.GetTodoRequest\x1a\x1d.todo.todo.v1.GetTodoResponse\"\x00\x12K\n" +
+	"\bPostTodo\x12\x1d.todo.todo.v1.PostTodoRequest\x1a\x1e.todo.todo.v1.PostTodoResponse\"\x00\x12H\n" +
+	"\aPutTodo\x12\x1c.todo.todo.v1.PutTodoRequest\x1a\x1d.todo.todo.v1.PutTodoResponse\"\x00\x12Q\n" +
+	"\n" +
+	"DeleteTodo\x12\x1f.todo.todo.v1.DeleteTodoRequest\x1a .todo.todo.v1.DeleteTodoResponse\"\x00\x12S\n" +
+	"\n" +
+	"WatchTodos\x12\x1f.todo.todo.v1.WatchTodosRequest\x1a .todo.todo.v1.WatchTodosResponse\"\x000\x01\x12V\n" +
+	"\vExportTodos\x12 .todo.todo.v1.ExportTodosRequest\x1a!.todo.todo.v1.ExportTodosResponse\"\x000\x01\x12V\n" +
+	"\vImportTodos\x12 .todo.todo.v1.ImportTodosRequest\x1a!.todo.todo.v1.ImportTodosResponse\"\x00(\x01\x12i\n" +
+	"\x12GetCalendarFeedURL\x12'.todo.todo.v1.GetCalendarFeedURLRequest\x1a(.todo.todo.v1.GetCalendarFeedURLResponse\"\x00\x12W\n" +
+	"\fGetTodoStats\x12!.todo.todo.v1.GetTodoStatsRequest\x1a\".todo.todo.v1.GetTodoStatsResponse\"\x00\x12K\n" +
+	"\bMoveTodo\x12\x1d.todo.todo.v1.MoveTodoRequest\x1a\x1e.todo.todo.v1.MoveTodoResponse\"\x00\x12f\n" +
+	"\x11GetDatabaseStatus\x12&.todo.todo.v1.GetDatabaseStatusRequest\x1a'.todo.todo.v1.GetDatabaseStatusResponse\"\x00\x12H\n" +
+	"\aGetUser\x12\x1c.todo.todo.v1.GetUserRequest\x1a\x1d.todo.todo.v1.GetUserResponse\"\x00\x12K\n" +
+	"\bPostUser\x12\x1d.todo.todo.v1.PostUserRequest\x1a\x1e.todo.todo.v1.PostUserResponse\"\x00\x12Q\n" +
+	"\n" +
+	"UpdateUser\x12\x1f.todo.todo.v1.UpdateUserRequest\x1a .todo.todo.v1.UpdateUserResponse\"\x00\x12Q\n" +
+	"\n" +
+	"DeleteUser\x12\x1f.todo.todo.v1.DeleteUserRequest\x1a .todo.todo.v1.DeleteUserResponse\"\x00\x12_\n" +
+	"\x0eExportUserData\x12#.todo.todo.v1.ExportUserDataRequest\x1a$.todo.todo.v1.ExportUserDataResponse\"\x000\x01\x12N\n" +
+	"\tEraseUser\x12\x1e.todo.todo.v1.EraseUserRequest\x1a\x1f.todo.todo.v1.EraseUserResponse\"\x00BNZLgithub.com/phamquanandpad/training-project/grpc/go/todo/todo/v1;todo_todo_v1b\x06proto3"
+
+var (
+	file_todo_todo_v1_todo_proto_rawDescOnce sync.Once
+	file_todo_todo_v1_todo_proto_rawDescData []byte
+)
+
+func file_todo_todo_v1_todo_proto_rawDescGZIP() []byte {
+	file_todo_todo_v1_todo_proto_rawDescOnce.Do(func() {
+		file_todo_todo_v1_todo_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_todo_todo_v1_todo_proto_rawDesc), len(file_todo_todo_v1_todo_proto_rawDesc)))
+	})
+	return file_todo_todo_v1_todo_proto_rawDescData
+}
+
+var file_todo_todo_v1_todo_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_todo_todo_v1_todo_proto_msgTypes = make([]protoimpl.MessageInfo, 42)
+var file_todo_todo_v1_todo_proto_goTypes = []any{
+	(TodoSortingType)(0),               // 0: todo.todo.v1.TodoSortingType
+	(TodoEventType)(0),                 // 1: todo.todo.v1.TodoEventType
+	(TodoFileFormat)(0),                // 2: todo.todo.v1.TodoFileFormat
+	(StatsBucketSize)(0),               // 3: todo.todo.v1.StatsBucketSize
+	(*UserAttributes)(nil),             // 4: todo.todo.v1.UserAttributes
+	(*ListTodosRequest)(nil),           // 5: todo.todo.v1.ListTodosRequest
+	(*ListTodosResponse)(nil),          // 6: todo.todo.v1.ListTodosResponse
+	(*GetTodoRequest)(nil),             // 7: todo.todo.v1.GetTodoRequest
+	(*GetTodoResponse)(nil),            // 8: todo.todo.v1.GetTodoResponse
+	(*PostTodoRequest)(nil),            // 9: todo.todo.v1.PostTodoRequest
+	(*PostTodoResponse)(nil),           // 10: todo.todo.v1.PostTodoResponse
+	(*PutTodoRequest)(nil),             // 11: todo.todo.v1.PutTodoRequest
+	(*PutTodoResponse)(nil),            // 12: todo.todo.v1.PutTodoResponse
+	(*DeleteTodoRequest)(nil),          // 13: todo.todo.v1.DeleteTodoRequest
+	(*DeleteTodoResponse)(nil),         // 14: todo.todo.v1.DeleteTodoResponse
+	(*MoveTodoRequest)(nil),            // 15: todo.todo.v1.MoveTodoRequest
+	(*MoveTodoResponse)(nil),           // 16: todo.todo.v1.MoveTodoResponse
+	(*WatchTodosRequest)(nil),          // 17: todo.todo.v1.WatchTodosRequest
+	(*WatchTodosResponse)(nil),         // 18: todo.todo.v1.WatchTodosResponse
+	(*TodoEvent)(nil),                  // 19: todo.todo.v1.TodoEvent
+	(*Heartbeat)(nil),                  // 20: todo.todo.v1.Heartbeat
+	(*ExportTodosRequest)(nil),         // 21: todo.todo.v1.ExportTodosRequest
+	(*ExportTodosResponse)(nil),        // 22: todo.todo.v1.ExportTodosResponse
+	(*ImportTodosRequest)(nil),         // 23: todo.todo.v1.ImportTodosRequest
+	(*ImportTodosResponse)(nil),        // 24: todo.todo.v1.ImportTodosResponse
+	(*ImportRowError)(nil),             // 25: todo.todo.v1.ImportRowError
+	(*GetCalendarFeedURLRequest)(nil),  // 26: todo.todo.v1.GetCalendarFeedURLRequest
+	(*GetCalendarFeedURLResponse)(nil), // 27: todo.todo.v1.GetCalendarFeedURLResponse
+	(*GetTodoStatsRequest)(nil),        // 28: todo.todo.v1.GetTodoStatsRequest
+	(*GetTodoStatsResponse)(nil),       // 29: todo.todo.v1.GetTodoStatsResponse
+	(*TodoActivityBucket)(nil),         // 30: todo.todo.v1.TodoActivityBucket
+	(*GetDatabaseStatusRequest)(nil),   // 31: todo.todo.v1.GetDatabaseStatusRequest
+	(*GetDatabaseStatusResponse)(nil),  // 32: todo.todo.v1.GetDatabaseStatusResponse
+	(*DatabasePoolStatus)(nil),         // 33: todo.todo.v1.DatabasePoolStatus
+	(*GetUserRequest)(nil),             // 34: todo.todo.v1.GetUserRequest
+	(*GetUserResponse)(nil),            // 35: todo.todo.v1.GetUserResponse
+	(*PostUserRequest)(nil),            // 36: todo.todo.v1.PostUserRequest
+	(*PostUserResponse)(nil),           // 37: todo.todo.v1.PostUserResponse
+	(*UpdateUserRequest)(nil),          // 38: todo.todo.v1.UpdateUserRequest
+	(*UpdateUserResponse)(nil),         // 39: todo.todo.v1.UpdateUserResponse
+	(*DeleteUserRequest)(nil),          // 40: todo.todo.v1.DeleteUserRequest
+	(*DeleteUserResponse)(nil),         // 41: todo.todo.v1.DeleteUserResponse
+	(*ExportUserDataRequest)(nil),      // 42: todo.todo.v1.ExportUserDataRequest
+	(*ExportUserDataResponse)(nil),     // 43: todo.todo.v1.ExportUserDataResponse
+	(*EraseUserRequest)(nil),           // 44: todo.todo.v1.EraseUserRequest
+	(*EraseUserResponse)(nil),          // 45: todo.todo.v1.EraseUserResponse
+	(*v1.Todo)(nil),                    // 46: todo.common.v1.Todo
+	(v1.TodoStatus)(0),                 // 47: todo.common.v1.TodoStatus
+	(*timestamppb.Timestamp)(nil),      // 48: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),        // 49: google.protobuf.Duration
+	(*v1.User)(nil),                    // 50: todo.common.v1.User
+}
+var file_todo_todo_v1_todo_proto_depIdxs = []int32{
+	4,  // 0: todo.todo.v1.ListTodosRequest.user_attributes:type_name -> todo.todo.v1.UserAttributes
+	0,  // 1: todo.todo.v1.ListTodosRequest.sorting_type:type_name -> todo.todo.v1.TodoSortingType
+	46, // 2: todo.todo.v1.ListTodosResponse.todos:type_name -> todo.common.v1.Todo
+	4,  // 3: todo.todo.v1.GetTodoRequest.user_attributes:type_name -> todo.todo.v1.UserAttributes
+	46, // 4: todo.todo.v1.GetTodoResponse.todo:type_name -> todo.common.v1.Todo
+	4,  // 5: todo.todo.v1.PostTodoRequest.user_attributes:type_name -> todo.todo.v1.UserAttributes
+	47, // 6: todo.todo.v1.PostTodoRequest.status:type_name -> todo.common.v1.TodoStatus
+	46, // 7: todo.todo.v1.PostTodoResponse.todo:type_name -> todo.common.v1.Todo
+	4,  // 8: todo.todo.v1.PutTodoRequest.user_attributes:type_name -> todo.todo.v1.UserAttributes
+	47, // 9: todo.todo.v1.PutTodoRequest.status:type_name -> todo.common.v1.TodoStatus
+	46, // 10: todo.todo.v1.PutTodoResponse.todo:type_name -> todo.common.v1.Todo
+	4,  // 11: todo.todo.v1.DeleteTodoRequest.user_attributes:type_name -> todo.todo.v1.UserAttributes
+	4,  // 12: todo.todo.v1.MoveTodoRequest.user_attributes:type_name -> todo.todo.v1.UserAttributes
+	46, // 13: todo.todo.v1.MoveTodoResponse.todo:type_name -> todo.common.v1.Todo
+	4,  // 14: todo.todo.v1.WatchTodosRequest.user_attributes:type_name -> todo.todo.v1.UserAttributes
+	19, // 15: todo.todo.v1.WatchTodosResponse.event:type_name -> todo.todo.v1.TodoEvent
+	20, // 16: todo.todo.v1.WatchTodosResponse.heartbeat:type_name -> todo.todo.v1.Heartbeat
+	1,  // 17: todo.todo.v1.TodoEvent.type:type_name -> todo.todo.v1.TodoEventType
+	46, // 18: todo.todo.v1.TodoEvent.todo:type_name -> todo.common.v1.Todo
+	48, // 19: todo.todo.v1.TodoEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	48, // 20: todo.todo.v1.Heartbeat.sent_at:type_name -> google.protobuf.Timestamp
+	4,  // 21: todo.todo.v1.ExportTodosRequest.user_attributes:type_name -> todo.todo.v1.UserAttributes
+	2,  // 22: todo.todo.v1.ExportTodosRequest.format:type_name -> todo.todo.v1.TodoFileFormat
+	4,  // 23: todo.todo.v1.ImportTodosRequest.user_attributes:type_name -> todo.todo.v1.UserAttributes
+	2,  // 24: todo.todo.v1.ImportTodosRequest.format:type_name -> todo.todo.v1.TodoFileFormat
+	25, // 25: todo.todo.v1.ImportTodosResponse.errors:type_name -> todo.todo.v1.ImportRowError
+	4,  // 26: todo.todo.v1.GetCalendarFeedURLRequest.user_attributes:type_name -> todo.todo.v1.UserAttributes
+	4,  // 27: todo.todo.v1.GetTodoStatsRequest.user_attributes:type_name -> todo.todo.v1.UserAttributes
+	48, // 28: todo.todo.v1.GetTodoStatsRequest.from:type_name -> google.protobuf.Timestamp
+	48, // 29: todo.todo.v1.GetTodoStatsRequest.to:type_name -> google.protobuf.Timestamp
+	3,  // 30: todo.todo.v1.GetTodoStatsRequest.bucket_size:type_name -> todo.todo.v1.StatsBucketSize
+	49, // 31: todo.todo.v1.GetTodoStatsResponse.average_completion_time:type_name -> google.protobuf.Duration
+	30, // 32: todo.todo.v1.GetTodoStatsResponse.activity:type_name -> todo.todo.v1.TodoActivityBucket
+	48, // 33: todo.todo.v1.TodoActivityBucket.start_at:type_name -> google.protobuf.Timestamp
+	33, // 34: todo.todo.v1.GetDatabaseStatusResponse.pools:type_name -> todo.todo.v1.DatabasePoolStatus
+	49, // 35: todo.todo.v1.DatabasePoolStatus.wait_duration:type_name -> google.protobuf.Duration
+	50, // 36: todo.todo.v1.GetUserResponse.user:type_name -> todo.common.v1.User
+	50, // 37: todo.todo.v1.PostUserRequest.user:type_name -> todo.common.v1.User
+	50, // 38: todo.todo.v1.UpdateUserResponse.user:type_name -> todo.common.v1.User
+	5,  // 39: todo.todo.v1.TodoService.ListTodos:input_type -> todo.todo.v1.ListTodosRequest
+	7,  // 40: todo.todo.v1.TodoService.GetTodo:input_type -> todo.todo.v1.GetTodoRequest
+	9,  // 41: todo.todo.v1.TodoService.PostTodo:input_type -> todo.todo.v1.PostTodoRequest
+	11, // 42: todo.todo.v1.TodoService.PutTodo:input_type -> todo.todo.v1.PutTodoRequest
+	13, // 43: todo.todo.v1.TodoService.DeleteTodo:input_type -> todo.todo.v1.DeleteTodoRequest
+	17, // 44: todo.todo.v1.TodoService.WatchTodos:input_type -> todo.todo.v1.WatchTodosRequest
+	21, // 45: todo.todo.v1.TodoService.ExportTodos:input_type -> todo.todo.v1.ExportTodosRequest
+	23, // 46: todo.todo.v1.TodoService.ImportTodos:input_type -> todo.todo.v1.ImportTodosRequest
+	26, // 47: todo.todo.v1.TodoService.GetCalendarFeedURL:input_type -> todo.todo.v1.GetCalendarFeedURLRequest
+	28, // 48: todo.todo.v1.TodoService.GetTodoStats:input_type -> todo.todo.v1.GetTodoStatsRequest
+	15, // 49: todo.todo.v1.TodoService.MoveTodo:input_type -> todo.todo.v1.MoveTodoRequest
+	31, // 50: todo.todo.v1.TodoService.GetDatabaseStatus:input_type -> todo.todo.v1.GetDatabaseStatusRequest
+	34, // 51: todo.todo.v1.TodoService.GetUser:input_type -> todo.todo.v1.GetUserRequest
+	36, // 52: todo.todo.v1.TodoService.PostUser:input_type -> todo.todo.v1.PostUserRequest
+	38, // 53: todo.todo.v1.TodoService.UpdateUser:input_type -> todo.todo.v1.UpdateUserRequest
+	40, // 54: todo.todo.v1.TodoService.DeleteUser:input_type -> todo.todo.v1.DeleteUserRequest
+	42, // 55: todo.todo.v1.TodoService.ExportUserData:input_type -> todo.todo.v1.ExportUserDataRequest
+	44, // 56: todo.todo.v1.TodoService.EraseUser:input_type -> todo.todo.v1.EraseUserRequest
+	6,  // 57: todo.todo.v1.TodoService.ListTodos:output_type -> todo.todo.v1.ListTodosResponse
+	8,  // 58: todo.todo.v1.TodoService.GetTodo:output_type -> todo.todo.v1.GetTodoResponse
+	10, // 59: todo.todo.v1.TodoService.PostTodo:output_type -> todo.todo.v1.PostTodoResponse
+	12, // 60: todo.todo.v1.TodoService.PutTodo:output_type -> todo.todo.v1.PutTodoResponse
+	14, // 61: todo.todo.v1.TodoService.DeleteTodo:output_type -> todo.todo.v1.DeleteTodoResponse
+	18, // 62: todo.todo.v1.TodoService.WatchTodos:output_type -> todo.todo.v1.WatchTodosResponse
+	22, // 63: todo.todo.v1.TodoService.ExportTodos:output_type -> todo.todo.v1.ExportTodosResponse
+	24, // 64: todo.todo.v1.TodoService.ImportTodos:output_type -> todo.todo.v1.ImportTodosResponse
+	27, // 65: todo.todo.v1.TodoService.GetCalendarFeedURL:output_type -> todo.todo.v1.GetCalendarFeedURLResponse
+	29, // 66: todo.todo.v1.TodoService.GetTodoStats:output_type -> todo.todo.v1.GetTodoStatsResponse
+	16, // 67: todo.todo.v1.TodoService.MoveTodo:output_type -> todo.todo.v1.MoveTodoResponse
+	32, // 68: todo.todo.v1.TodoService.GetDatabaseStatus:output_type -> todo.todo.v1.GetDatabaseStatusResponse
+	35, // 69: todo.todo.v1.TodoService.GetUser:output_type -> todo.todo.v1.GetUserResponse
+	37, // 70: todo.todo.v1.TodoService.PostUser:output_type -> todo.todo.v1.PostUserResponse
+	39, // 71: todo.todo.v1.TodoService.UpdateUser:output_type -> todo.todo.v1.UpdateUserResponse
+	41, // 72: todo.todo.v1.TodoService.DeleteUser:output_type -> todo.todo.v1.DeleteUserResponse
+	43, // 73: todo.todo.v1.TodoService.ExportUserData:output_type -> todo.todo.v1.ExportUserDataResponse
+	45, // 74: todo.todo.v1.TodoService.EraseUser:output_type -> todo.todo.v1.EraseUserResponse
+	57, // [57:75] is the sub-list for method output_type
+	39, // [39:57] is the sub-list for method input_type
+	39, // [39:39] is the sub-list for extension type_name
+	39, // [39:39] is the sub-list for extension extendee
+	0,  // [0:39] is the sub-list for field type_name
+}
+
+func init() { file_todo_todo_v1_todo_proto_init() }
+func file_todo_todo_v1_todo_proto_init() {
 	if File_todo_todo_v1_todo_proto != nil {
 		return
 	}
 	file_todo_todo_v1_todo_proto_msgTypes[1].OneofWrappers = []any{}
+	file_todo_todo_v1_todo_proto_msgTypes[11].OneofWrappers = []any{
+		(*MoveTodoRequest_BeforeTodoId)(nil),
+		(*MoveTodoRequest_AfterTodoId)(nil),
+	}
+	file_todo_todo_v1_todo_proto_msgTypes[13].OneofWrappers = []any{}
+	file_todo_todo_v1_todo_proto_msgTypes[14].OneofWrappers = []any{
+		(*WatchTodosResponse_Event)(nil),
+		(*WatchTodosResponse_Heartbeat)(nil),
+	}
+	file_todo_todo_v1_todo_proto_msgTypes[24].OneofWrappers = []any{}
+	file_todo_todo_v1_todo_proto_msgTypes[25].OneofWrappers = []any{}
+	file_todo_todo_v1_todo_proto_msgTypes[34].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_todo_todo_v1_todo_proto_rawDesc), len(file_todo_todo_v1_todo_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   15,
+			NumEnums:      4,
+			NumMessages:   42,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_todo_todo_v1_todo_proto_goTypes,
 		DependencyIndexes: file_todo_todo_v1_todo_proto_depIdxs,
+		EnumInfos:         file_todo_todo_v1_todo_proto_enumTypes,
 		MessageInfos:      file_todo_todo_v1_todo_proto_msgTypes,
 	}.Build()
 	File_todo_todo_v1_todo_proto = out.File
@@ -43,10 +43,36 @@ const (
 	TodoServicePutTodoProcedure = "/todo.todo.v1.TodoService/PutTodo"
 	// TodoServiceDeleteTodoProcedure is the fully-qualified name of the TodoService's DeleteTodo RPC.
 	TodoServiceDeleteTodoProcedure = "/todo.todo.v1.TodoService/DeleteTodo"
+	// TodoServiceWatchTodosProcedure is the fully-qualified name of the TodoService's WatchTodos RPC.
+	TodoServiceWatchTodosProcedure = "/todo.todo.v1.TodoService/WatchTodos"
+	// TodoServiceExportTodosProcedure is the fully-qualified name of the TodoService's ExportTodos RPC.
+	TodoServiceExportTodosProcedure = "/todo.todo.v1.TodoService/ExportTodos"
+	// TodoServiceImportTodosProcedure is the fully-qualified name of the TodoService's ImportTodos RPC.
+	TodoServiceImportTodosProcedure = "/todo.todo.v1.TodoService/ImportTodos"
+	// TodoServiceGetCalendarFeedURLProcedure is the fully-qualified name of the TodoService's
+	// GetCalendarFeedURL RPC.
+	TodoServiceGetCalendarFeedURLProcedure = "/todo.todo.v1.TodoService/GetCalendarFeedURL"
+	// TodoServiceGetTodoStatsProcedure is the fully-qualified name of the TodoService's GetTodoStats
+	// RPC.
+	TodoServiceGetTodoStatsProcedure = "/todo.todo.v1.TodoService/GetTodoStats"
+	// TodoServiceMoveTodoProcedure is the fully-qualified name of the TodoService's MoveTodo RPC.
+	TodoServiceMoveTodoProcedure = "/todo.todo.v1.TodoService/MoveTodo"
+	// TodoServiceGetDatabaseStatusProcedure is the fully-qualified name of the TodoService's
+	// GetDatabaseStatus RPC.
+	TodoServiceGetDatabaseStatusProcedure = "/todo.todo.v1.TodoService/GetDatabaseStatus"
 	// TodoServiceGetUserProcedure is the fully-qualified name of the TodoService's GetUser RPC.
 	TodoServiceGetUserProcedure = "/todo.todo.v1.TodoService/GetUser"
 	// TodoServicePostUserProcedure is the fully-qualified name of the TodoService's PostUser RPC.
 	TodoServicePostUserProcedure = "/todo.todo.v1.TodoService/PostUser"
+	// TodoServiceUpdateUserProcedure is the fully-qualified name of the TodoService's UpdateUser RPC.
+	TodoServiceUpdateUserProcedure = "/todo.todo.v1.TodoService/UpdateUser"
+	// TodoServiceDeleteUserProcedure is the fully-qualified name of the TodoService's DeleteUser RPC.
+	TodoServiceDeleteUserProcedure = "/todo.todo.v1.TodoService/DeleteUser"
+	// TodoServiceExportUserDataProcedure is the fully-qualified name of the TodoService's
+	// ExportUserData RPC.
+	TodoServiceExportUserDataProcedure = "/todo.todo.v1.TodoService/ExportUserData"
+	// TodoServiceEraseUserProcedure is the fully-qualified name of the TodoService's EraseUser RPC.
+	TodoServiceEraseUserProcedure = "/todo.todo.v1.TodoService/EraseUser"
 )
 
 // TodoServiceClient is a client for the todo.todo.v1.TodoService service.
@@ -56,8 +82,19 @@ type TodoServiceClient interface {
 	PostTodo(context.Context, *connect.Request[v1.PostTodoRequest]) (*connect.Response[v1.PostTodoResponse], error)
 	PutTodo(context.Context, *connect.Request[v1.PutTodoRequest]) (*connect.Response[v1.PutTodoResponse], error)
 	DeleteTodo(context.Context, *connect.Request[v1.DeleteTodoRequest]) (*connect.Response[v1.DeleteTodoResponse], error)
+	WatchTodos(context.Context, *connect.Request[v1.WatchTodosRequest]) (*connect.ServerStreamForClient[v1.WatchTodosResponse], error)
+	ExportTodos(context.Context, *connect.Request[v1.ExportTodosRequest]) (*connect.ServerStreamForClient[v1.ExportTodosResponse], error)
+	ImportTodos(context.Context) *connect.ClientStreamForClient[v1.ImportTodosRequest, v1.ImportTodosResponse]
+	GetCalendarFeedURL(context.Context, *connect.Request[v1.GetCalendarFeedURLRequest]) (*connect.Response[v1.GetCalendarFeedURLResponse], error)
+	GetTodoStats(context.Context, *connect.Request[v1.GetTodoStatsRequest]) (*connect.Response[v1.GetTodoStatsResponse], error)
+	MoveTodo(context.Context, *connect.Request[v1.MoveTodoRequest]) (*connect.Response[v1.MoveTodoResponse], error)
+	GetDatabaseStatus(context.Context, *connect.Request[v1.GetDatabaseStatusRequest]) (*connect.Response[v1.GetDatabaseStatusResponse], error)
 	GetUser(context.Context, *connect.Request[v1.GetUserRequest]) (*connect.Response[v1.GetUserResponse], error)
 	PostUser(context.Context, *connect.Request[v1.PostUserRequest]) (*connect.Response[v1.PostUserResponse], error)
+	UpdateUser(context.Context, *connect.Request[v1.UpdateUserRequest]) (*connect.Response[v1.UpdateUserResponse], error)
+	DeleteUser(context.Context, *connect.Request[v1.DeleteUserRequest]) (*connect.Response[v1.DeleteUserResponse], error)
+	ExportUserData(context.Context, *connect.Request[v1.ExportUserDataRequest]) (*connect.ServerStreamForClient[v1.ExportUserDataResponse], error)
+	EraseUser(context.Context, *connect.Request[v1.EraseUserRequest]) (*connect.Response[v1.EraseUserResponse], error)
 }
 
 // NewTodoServiceClient constructs a client for the todo.todo.v1.TodoService service. By default, it
@@ -101,6 +138,48 @@ func NewTodoServiceClient(httpClient connect.HTTPClient, baseURL string, opts ..
 			connect.WithSchema(todoServiceMethods.ByName("DeleteTodo")),
 			connect.WithClientOptions(opts...),
 		),
+		watchTodos: connect.NewClient[v1.WatchTodosRequest, v1.WatchTodosResponse](
+			httpClient,
+			baseURL+TodoServiceWatchTodosProcedure,
+			connect.WithSchema(todoServiceMethods.ByName("WatchTodos")),
+			connect.WithClientOptions(opts...),
+		),
+		exportTodos: connect.NewClient[v1.ExportTodosRequest, v1.ExportTodosResponse](
+			httpClient,
+			baseURL+TodoServiceExportTodosProcedure,
+			connect.WithSchema(todoServiceMethods.ByName("ExportTodos")),
+			connect.WithClientOptions(opts...),
+		),
+		importTodos: connect.NewClient[v1.ImportTodosRequest, v1.ImportTodosResponse](
+			httpClient,
+			baseURL+TodoServiceImportTodosProcedure,
+			connect.WithSchema(todoServiceMethods.ByName("ImportTodos")),
+			connect.WithClientOptions(opts...),
+		),
+		getCalendarFeedURL: connect.NewClient[v1.GetCalendarFeedURLRequest, v1.GetCalendarFeedURLResponse](
+			httpClient,
+			baseURL+TodoServiceGetCalendarFeedURLProcedure,
+			connect.WithSchema(todoServiceMethods.ByName("GetCalendarFeedURL")),
+			connect.WithClientOptions(opts...),
+		),
+		getTodoStats: connect.NewClient[v1.GetTodoStatsRequest, v1.GetTodoStatsResponse](
+			httpClient,
+			baseURL+TodoServiceGetTodoStatsProcedure,
+			connect.WithSchema(todoServiceMethods.ByName("GetTodoStats")),
+			connect.WithClientOptions(opts...),
+		),
+		moveTodo: connect.NewClient[v1.MoveTodoRequest, v1.MoveTodoResponse](
+			httpClient,
+			baseURL+TodoServiceMoveTodoProcedure,
+			connect.WithSchema(todoServiceMethods.ByName("MoveTodo")),
+			connect.WithClientOptions(opts...),
+		),
+		getDatabaseStatus: connect.NewClient[v1.GetDatabaseStatusRequest, v1.GetDatabaseStatusResponse](
+			httpClient,
+			baseURL+TodoServiceGetDatabaseStatusProcedure,
+			connect.WithSchema(todoServiceMethods.ByName("GetDatabaseStatus")),
+			connect.WithClientOptions(opts...),
+		),
 		getUser: connect.NewClient[v1.GetUserRequest, v1.GetUserResponse](
 			httpClient,
 			baseURL+TodoServiceGetUserProcedure,
@@ -113,18 +192,53 @@ func NewTodoServiceClient(httpClient connect.HTTPClient, baseURL string, opts ..
 			connect.WithSchema(todoServiceMethods.ByName("PostUser")),
 			connect.WithClientOptions(opts...),
 		),
+		updateUser: connect.NewClient[v1.UpdateUserRequest, v1.UpdateUserResponse](
+			httpClient,
+			baseURL+TodoServiceUpdateUserProcedure,
+			connect.WithSchema(todoServiceMethods.ByName("UpdateUser")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteUser: connect.NewClient[v1.DeleteUserRequest, v1.DeleteUserResponse](
+			httpClient,
+			baseURL+TodoServiceDeleteUserProcedure,
+			connect.WithSchema(todoServiceMethods.ByName("DeleteUser")),
+			connect.WithClientOptions(opts...),
+		),
+		exportUserData: connect.NewClient[v1.ExportUserDataRequest, v1.ExportUserDataResponse](
+			httpClient,
+			baseURL+TodoServiceExportUserDataProcedure,
+			connect.WithSchema(todoServiceMethods.ByName("ExportUserData")),
+			connect.WithClientOptions(opts...),
+		),
+		eraseUser: connect.NewClient[v1.EraseUserRequest, v1.EraseUserResponse](
+			httpClient,
+			baseURL+TodoServiceEraseUserProcedure,
+			connect.WithSchema(todoServiceMethods.ByName("EraseUser")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // todoServiceClient implements TodoServiceClient.
 type todoServiceClient struct {
-	listTodos  *connect.Client[v1.ListTodosRequest, v1.ListTodosResponse]
-	getTodo    *connect.Client[v1.GetTodoRequest, v1.GetTodoResponse]
-	postTodo   *connect.Client[v1.PostTodoRequest, v1.PostTodoResponse]
-	putTodo    *connect.Client[v1.PutTodoRequest, v1.PutTodoResponse]
-	deleteTodo *connect.Client[v1.DeleteTodoRequest, v1.DeleteTodoResponse]
-	getUser    *connect.Client[v1.GetUserRequest, v1.GetUserResponse]
-	postUser   *connect.Client[v1.PostUserRequest, v1.PostUserResponse]
+	listTodos          *connect.Client[v1.ListTodosRequest, v1.ListTodosResponse]
+	getTodo            *connect.Client[v1.GetTodoRequest, v1.GetTodoResponse]
+	postTodo           *connect.Client[v1.PostTodoRequest, v1.PostTodoResponse]
+	putTodo            *connect.Client[v1.PutTodoRequest, v1.PutTodoResponse]
+	deleteTodo         *connect.Client[v1.DeleteTodoRequest, v1.DeleteTodoResponse]
+	watchTodos         *connect.Client[v1.WatchTodosRequest, v1.WatchTodosResponse]
+	exportTodos        *connect.Client[v1.ExportTodosRequest, v1.ExportTodosResponse]
+	importTodos        *connect.Client[v1.ImportTodosRequest, v1.ImportTodosResponse]
+	getCalendarFeedURL *connect.Client[v1.GetCalendarFeedURLRequest, v1.GetCalendarFeedURLResponse]
+	getTodoStats       *connect.Client[v1.GetTodoStatsRequest, v1.GetTodoStatsResponse]
+	moveTodo           *connect.Client[v1.MoveTodoRequest, v1.MoveTodoResponse]
+	getDatabaseStatus  *connect.Client[v1.GetDatabaseStatusRequest, v1.GetDatabaseStatusResponse]
+	getUser            *connect.Client[v1.GetUserRequest, v1.GetUserResponse]
+	postUser           *connect.Client[v1.PostUserRequest, v1.PostUserResponse]
+	updateUser         *connect.Client[v1.UpdateUserRequest, v1.UpdateUserResponse]
+	deleteUser         *connect.Client[v1.DeleteUserRequest, v1.DeleteUserResponse]
+	exportUserData     *connect.Client[v1.ExportUserDataRequest, v1.ExportUserDataResponse]
+	eraseUser          *connect.Client[v1.EraseUserRequest, v1.EraseUserResponse]
 }
 
 // ListTodos calls todo.todo.v1.TodoService.ListTodos.
@@ -152,6 +266,41 @@ func (c *todoServiceClient) DeleteTodo(ctx context.Context, req *connect.Request
 	return c.deleteTodo.CallUnary(ctx, req)
 }
 
+// WatchTodos calls todo.todo.v1.TodoService.WatchTodos.
+func (c *todoServiceClient) WatchTodos(ctx context.Context, req *connect.Request[v1.WatchTodosRequest]) (*connect.ServerStreamForClient[v1.WatchTodosResponse], error) {
+	return c.watchTodos.CallServerStream(ctx, req)
+}
+
+// ExportTodos calls todo.todo.v1.TodoService.ExportTodos.
+func (c *todoServiceClient) ExportTodos(ctx context.Context, req *connect.Request[v1.ExportTodosRequest]) (*connect.ServerStreamForClient[v1.ExportTodosResponse], error) {
+	return c.exportTodos.CallServerStream(ctx, req)
+}
+
+// ImportTodos calls todo.todo.v1.TodoService.ImportTodos.
+func (c *todoServiceClient) ImportTodos(ctx context.Context) *connect.ClientStreamForClient[v1.ImportTodosRequest, v1.ImportTodosResponse] {
+	return c.importTodos.CallClientStream(ctx)
+}
+
+// GetCalendarFeedURL calls todo.todo.v1.TodoService.GetCalendarFeedURL.
+func (c *todoServiceClient) GetCalendarFeedURL(ctx context.Context, req *connect.Request[v1.GetCalendarFeedURLRequest]) (*connect.Response[v1.GetCalendarFeedURLResponse], error) {
+	return c.getCalendarFeedURL.CallUnary(ctx, req)
+}
+
+// GetTodoStats calls todo.todo.v1.TodoService.GetTodoStats.
+func (c *todoServiceClient) GetTodoStats(ctx context.Context, req *connect.Request[v1.GetTodoStatsRequest]) (*connect.Response[v1.GetTodoStatsResponse], error) {
+	return c.getTodoStats.CallUnary(ctx, req)
+}
+
+// MoveTodo calls todo.todo.v1.TodoService.MoveTodo.
+func (c *todoServiceClient) MoveTodo(ctx context.Context, req *connect.Request[v1.MoveTodoRequest]) (*connect.Response[v1.MoveTodoResponse], error) {
+	return c.moveTodo.CallUnary(ctx, req)
+}
+
+// GetDatabaseStatus calls todo.todo.v1.TodoService.GetDatabaseStatus.
+func (c *todoServiceClient) GetDatabaseStatus(ctx context.Context, req *connect.Request[v1.GetDatabaseStatusRequest]) (*connect.Response[v1.GetDatabaseStatusResponse], error) {
+	return c.getDatabaseStatus.CallUnary(ctx, req)
+}
+
 // GetUser calls todo.todo.v1.TodoService.GetUser.
 func (c *todoServiceClient) GetUser(ctx context.Context, req *connect.Request[v1.GetUserRequest]) (*connect.Response[v1.GetUserResponse], error) {
 	return c.getUser.CallUnary(ctx, req)
@@ -162,6 +311,26 @@ func (c *todoServiceClient) PostUser(ctx context.Context, req *connect.Request[v
 	return c.postUser.CallUnary(ctx, req)
 }
 
+// UpdateUser calls todo.todo.v1.TodoService.UpdateUser.
+func (c *todoServiceClient) UpdateUser(ctx context.Context, req *connect.Request[v1.UpdateUserRequest]) (*connect.Response[v1.UpdateUserResponse], error) {
+	return c.updateUser.CallUnary(ctx, req)
+}
+
+// DeleteUser calls todo.todo.v1.TodoService.DeleteUser.
+func (c *todoServiceClient) DeleteUser(ctx context.Context, req *connect.Request[v1.DeleteUserRequest]) (*connect.Response[v1.DeleteUserResponse], error) {
+	return c.deleteUser.CallUnary(ctx, req)
+}
+
+// ExportUserData calls todo.todo.v1.TodoService.ExportUserData.
+func (c *todoServiceClient) ExportUserData(ctx context.Context, req *connect.Request[v1.ExportUserDataRequest]) (*connect.ServerStreamForClient[v1.ExportUserDataResponse], error) {
+	return c.exportUserData.CallServerStream(ctx, req)
+}
+
+// EraseUser calls todo.todo.v1.TodoService.EraseUser.
+func (c *todoServiceClient) EraseUser(ctx context.Context, req *connect.Request[v1.EraseUserRequest]) (*connect.Response[v1.EraseUserResponse], error) {
+	return c.eraseUser.CallUnary(ctx, req)
+}
+
 // TodoServiceHandler is an implementation of the todo.todo.v1.TodoService service.
 type TodoServiceHandler interface {
 	ListTodos(context.Context, *connect.Request[v1.ListTodosRequest]) (*connect.Response[v1.ListTodosResponse], error)
@@ -169,8 +338,19 @@ type TodoServiceHandler interface {
 	PostTodo(context.Context, *connect.Request[v1.PostTodoRequest]) (*connect.Response[v1.PostTodoResponse], error)
 	PutTodo(context.Context, *connect.Request[v1.PutTodoRequest]) (*connect.Response[v1.PutTodoResponse], error)
 	DeleteTodo(context.Context, *connect.Request[v1.DeleteTodoRequest]) (*connect.Response[v1.DeleteTodoResponse], error)
+	WatchTodos(context.Context, *connect.Request[v1.WatchTodosRequest], *connect.ServerStream[v1.WatchTodosResponse]) error
+	ExportTodos(context.Context, *connect.Request[v1.ExportTodosRequest], *connect.ServerStream[v1.ExportTodosResponse]) error
+	ImportTodos(context.Context, *connect.ClientStream[v1.ImportTodosRequest]) (*connect.Response[v1.ImportTodosResponse], error)
+	GetCalendarFeedURL(context.Context, *connect.Request[v1.GetCalendarFeedURLRequest]) (*connect.Response[v1.GetCalendarFeedURLResponse], error)
+	GetTodoStats(context.Context, *connect.Request[v1.GetTodoStatsRequest]) (*connect.Response[v1.GetTodoStatsResponse], error)
+	MoveTodo(context.Context, *connect.Request[v1.MoveTodoRequest]) (*connect.Response[v1.MoveTodoResponse], error)
+	GetDatabaseStatus(context.Context, *connect.Request[v1.GetDatabaseStatusRequest]) (*connect.Response[v1.GetDatabaseStatusResponse], error)
 	GetUser(context.Context, *connect.Request[v1.GetUserRequest]) (*connect.Response[v1.GetUserResponse], error)
 	PostUser(context.Context, *connect.Request[v1.PostUserRequest]) (*connect.Response[v1.PostUserResponse], error)
+	UpdateUser(context.Context, *connect.Request[v1.UpdateUserRequest]) (*connect.Response[v1.UpdateUserResponse], error)
+	DeleteUser(context.Context, *connect.Request[v1.DeleteUserRequest]) (*connect.Response[v1.DeleteUserResponse], error)
+	ExportUserData(context.Context, *connect.Request[v1.ExportUserDataRequest], *connect.ServerStream[v1.ExportUserDataResponse]) error
+	EraseUser(context.Context, *connect.Request[v1.EraseUserRequest]) (*connect.Response[v1.EraseUserResponse], error)
 }
 
 // NewTodoServiceHandler builds an HTTP handler from the service implementation. It returns the path
@@ -210,6 +390,48 @@ func NewTodoServiceHandler(svc TodoServiceHandler, opts ...connect.HandlerOption
 		connect.WithSchema(todoServiceMethods.ByName("DeleteTodo")),
 		connect.WithHandlerOptions(opts...),
 	)
+	todoServiceWatchTodosHandler := connect.NewServerStreamHandler(
+		TodoServiceWatchTodosProcedure,
+		svc.WatchTodos,
+		connect.WithSchema(todoServiceMethods.ByName("WatchTodos")),
+		connect.WithHandlerOptions(opts...),
+	)
+	todoServiceExportTodosHandler := connect.NewServerStreamHandler(
+		TodoServiceExportTodosProcedure,
+		svc.ExportTodos,
+		connect.WithSchema(todoServiceMethods.ByName("ExportTodos")),
+		connect.WithHandlerOptions(opts...),
+	)
+	todoServiceImportTodosHandler := connect.NewClientStreamHandler(
+		TodoServiceImportTodosProcedure,
+		svc.ImportTodos,
+		connect.WithSchema(todoServiceMethods.ByName("ImportTodos")),
+		connect.WithHandlerOptions(opts...),
+	)
+	todoServiceGetCalendarFeedURLHandler := connect.NewUnaryHandler(
+		TodoServiceGetCalendarFeedURLProcedure,
+		svc.GetCalendarFeedURL,
+		connect.WithSchema(todoServiceMethods.ByName("GetCalendarFeedURL")),
+		connect.WithHandlerOptions(opts...),
+	)
+	todoServiceGetTodoStatsHandler := connect.NewUnaryHandler(
+		TodoServiceGetTodoStatsProcedure,
+		svc.GetTodoStats,
+		connect.WithSchema(todoServiceMethods.ByName("GetTodoStats")),
+		connect.WithHandlerOptions(opts...),
+	)
+	todoServiceMoveTodoHandler := connect.NewUnaryHandler(
+		TodoServiceMoveTodoProcedure,
+		svc.MoveTodo,
+		connect.WithSchema(todoServiceMethods.ByName("MoveTodo")),
+		connect.WithHandlerOptions(opts...),
+	)
+	todoServiceGetDatabaseStatusHandler := connect.NewUnaryHandler(
+		TodoServiceGetDatabaseStatusProcedure,
+		svc.GetDatabaseStatus,
+		connect.WithSchema(todoServiceMethods.ByName("GetDatabaseStatus")),
+		connect.WithHandlerOptions(opts...),
+	)
 	todoServiceGetUserHandler := connect.NewUnaryHandler(
 		TodoServiceGetUserProcedure,
 		svc.GetUser,
@@ -222,6 +444,30 @@ func NewTodoServiceHandler(svc TodoServiceHandler, opts ...connect.HandlerOption
 		connect.WithSchema(todoServiceMethods.ByName("PostUser")),
 		connect.WithHandlerOptions(opts...),
 	)
+	todoServiceUpdateUserHandler := connect.NewUnaryHandler(
+		TodoServiceUpdateUserProcedure,
+		svc.UpdateUser,
+		connect.WithSchema(todoServiceMethods.ByName("UpdateUser")),
+		connect.WithHandlerOptions(opts...),
+	)
+	todoServiceDeleteUserHandler := connect.NewUnaryHandler(
+		TodoServiceDeleteUserProcedure,
+		svc.DeleteUser,
+		connect.WithSchema(todoServiceMethods.ByName("DeleteUser")),
+		connect.WithHandlerOptions(opts...),
+	)
+	todoServiceExportUserDataHandler := connect.NewServerStreamHandler(
+		TodoServiceExportUserDataProcedure,
+		svc.ExportUserData,
+		connect.WithSchema(todoServiceMethods.ByName("ExportUserData")),
+		connect.WithHandlerOptions(opts...),
+	)
+	todoServiceEraseUserHandler := connect.NewUnaryHandler(
+		TodoServiceEraseUserProcedure,
+		svc.EraseUser,
+		connect.WithSchema(todoServiceMethods.ByName("EraseUser")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/todo.todo.v1.TodoService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case TodoServiceListTodosProcedure:
@@ -234,10 +480,32 @@ func NewTodoServiceHandler(svc TodoServiceHandler, opts ...connect.HandlerOption
 			todoServicePutTodoHandler.ServeHTTP(w, r)
 		case TodoServiceDeleteTodoProcedure:
 			todoServiceDeleteTodoHandler.ServeHTTP(w, r)
+		case TodoServiceWatchTodosProcedure:
+			todoServiceWatchTodosHandler.ServeHTTP(w, r)
+		case TodoServiceExportTodosProcedure:
+			todoServiceExportTodosHandler.ServeHTTP(w, r)
+		case TodoServiceImportTodosProcedure:
+			todoServiceImportTodosHandler.ServeHTTP(w, r)
+		case TodoServiceGetCalendarFeedURLProcedure:
+			todoServiceGetCalendarFeedURLHandler.ServeHTTP(w, r)
+		case TodoServiceGetTodoStatsProcedure:
+			todoServiceGetTodoStatsHandler.ServeHTTP(w, r)
+		case TodoServiceMoveTodoProcedure:
+			todoServiceMoveTodoHandler.ServeHTTP(w, r)
+		case TodoServiceGetDatabaseStatusProcedure:
+			todoServiceGetDatabaseStatusHandler.ServeHTTP(w, r)
 		case TodoServiceGetUserProcedure:
 			todoServiceGetUserHandler.ServeHTTP(w, r)
 		case TodoServicePostUserProcedure:
 			todoServicePostUserHandler.ServeHTTP(w, r)
+		case TodoServiceUpdateUserProcedure:
+			todoServiceUpdateUserHandler.ServeHTTP(w, r)
+		case TodoServiceDeleteUserProcedure:
+			todoServiceDeleteUserHandler.ServeHTTP(w, r)
+		case TodoServiceExportUserDataProcedure:
+			todoServiceExportUserDataHandler.ServeHTTP(w, r)
+		case TodoServiceEraseUserProcedure:
+			todoServiceEraseUserHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -267,6 +535,34 @@ func (UnimplementedTodoServiceHandler) DeleteTodo(context.Context, *connect.Requ
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("todo.todo.v1.TodoService.DeleteTodo is not implemented"))
 }
 
+func (UnimplementedTodoServiceHandler) WatchTodos(context.Context, *connect.Request[v1.WatchTodosRequest], *connect.ServerStream[v1.WatchTodosResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("todo.todo.v1.TodoService.WatchTodos is not implemented"))
+}
+
+func (UnimplementedTodoServiceHandler) ExportTodos(context.Context, *connect.Request[v1.ExportTodosRequest], *connect.ServerStream[v1.ExportTodosResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("todo.todo.v1.TodoService.ExportTodos is not implemented"))
+}
+
+func (UnimplementedTodoServiceHandler) ImportTodos(context.Context, *connect.ClientStream[v1.ImportTodosRequest]) (*connect.Response[v1.ImportTodosResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("todo.todo.v1.TodoService.ImportTodos is not implemented"))
+}
+
+func (UnimplementedTodoServiceHandler) GetCalendarFeedURL(context.Context, *connect.Request[v1.GetCalendarFeedURLRequest]) (*connect.Response[v1.GetCalendarFeedURLResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("todo.todo.v1.TodoService.GetCalendarFeedURL is not implemented"))
+}
+
+func (UnimplementedTodoServiceHandler) GetTodoStats(context.Context, *connect.Request[v1.GetTodoStatsRequest]) (*connect.Response[v1.GetTodoStatsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("todo.todo.v1.TodoService.GetTodoStats is not implemented"))
+}
+
+func (UnimplementedTodoServiceHandler) MoveTodo(context.Context, *connect.Request[v1.MoveTodoRequest]) (*connect.Response[v1.MoveTodoResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("todo.todo.v1.TodoService.MoveTodo is not implemented"))
+}
+
+func (UnimplementedTodoServiceHandler) GetDatabaseStatus(context.Context, *connect.Request[v1.GetDatabaseStatusRequest]) (*connect.Response[v1.GetDatabaseStatusResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("todo.todo.v1.TodoService.GetDatabaseStatus is not implemented"))
+}
+
 func (UnimplementedTodoServiceHandler) GetUser(context.Context, *connect.Request[v1.GetUserRequest]) (*connect.Response[v1.GetUserResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("todo.todo.v1.TodoService.GetUser is not implemented"))
 }
@@ -274,3 +570,19 @@ func (UnimplementedTodoServiceHandler) GetUser(context.Context, *connect.Request
 func (UnimplementedTodoServiceHandler) PostUser(context.Context, *connect.Request[v1.PostUserRequest]) (*connect.Response[v1.PostUserResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("todo.todo.v1.TodoService.PostUser is not implemented"))
 }
+
+func (UnimplementedTodoServiceHandler) UpdateUser(context.Context, *connect.Request[v1.UpdateUserRequest]) (*connect.Response[v1.UpdateUserResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("todo.todo.v1.TodoService.UpdateUser is not implemented"))
+}
+
+func (UnimplementedTodoServiceHandler) DeleteUser(context.Context, *connect.Request[v1.DeleteUserRequest]) (*connect.Response[v1.DeleteUserResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("todo.todo.v1.TodoService.DeleteUser is not implemented"))
+}
+
+func (UnimplementedTodoServiceHandler) ExportUserData(context.Context, *connect.Request[v1.ExportUserDataRequest], *connect.ServerStream[v1.ExportUserDataResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("todo.todo.v1.TodoService.ExportUserData is not implemented"))
+}
+
+func (UnimplementedTodoServiceHandler) EraseUser(context.Context, *connect.Request[v1.EraseUserRequest]) (*connect.Response[v1.EraseUserResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("todo.todo.v1.TodoService.EraseUser is not implemented"))
+}